@@ -443,7 +443,8 @@ func (c *DefaultDomainCache) refreshDomainsLocked() error {
 	}
 
 	var token []byte
-	request := &persistence.ListDomainsRequest{PageSize: domainCacheRefreshPageSize}
+	// soft-deleted domains are still cached so that their pending tasks can be dropped
+	request := &persistence.ListDomainsRequest{PageSize: domainCacheRefreshPageSize, IncludeDeleted: true}
 	var domains DomainCacheEntries
 	continuePage := true
 
@@ -167,16 +167,18 @@ func (s *domainCacheSuite) TestListDomain() {
 
 	s.metadataMgr.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{NotificationVersion: domainNotificationVersion}, nil)
 	s.metadataMgr.On("ListDomains", mock.Anything, &persistence.ListDomainsRequest{
-		PageSize:      domainCacheRefreshPageSize,
-		NextPageToken: nil,
+		PageSize:       domainCacheRefreshPageSize,
+		NextPageToken:  nil,
+		IncludeDeleted: true,
 	}).Return(&persistence.ListDomainsResponse{
 		Domains:       []*persistence.GetDomainResponse{domainRecord1},
 		NextPageToken: pageToken,
 	}, nil).Once()
 
 	s.metadataMgr.On("ListDomains", mock.Anything, &persistence.ListDomainsRequest{
-		PageSize:      domainCacheRefreshPageSize,
-		NextPageToken: pageToken,
+		PageSize:       domainCacheRefreshPageSize,
+		NextPageToken:  pageToken,
+		IncludeDeleted: true,
 	}).Return(&persistence.ListDomainsResponse{
 		Domains:       []*persistence.GetDomainResponse{domainRecord2, domainRecord3},
 		NextPageToken: nil,
@@ -235,8 +237,9 @@ func (s *domainCacheSuite) TestGetDomain_NonLoaded_GetByName() {
 
 	s.metadataMgr.On("GetDomain", mock.Anything, &persistence.GetDomainRequest{Name: entry.info.Name}).Return(domainRecord, nil).Once()
 	s.metadataMgr.On("ListDomains", mock.Anything, &persistence.ListDomainsRequest{
-		PageSize:      domainCacheRefreshPageSize,
-		NextPageToken: nil,
+		PageSize:       domainCacheRefreshPageSize,
+		NextPageToken:  nil,
+		IncludeDeleted: true,
 	}).Return(&persistence.ListDomainsResponse{
 		Domains:       []*persistence.GetDomainResponse{domainRecord},
 		NextPageToken: nil,
@@ -273,8 +276,9 @@ func (s *domainCacheSuite) TestGetDomain_NonLoaded_GetByID() {
 
 	s.metadataMgr.On("GetDomain", mock.Anything, &persistence.GetDomainRequest{ID: entry.info.ID}).Return(domainRecord, nil).Once()
 	s.metadataMgr.On("ListDomains", mock.Anything, &persistence.ListDomainsRequest{
-		PageSize:      domainCacheRefreshPageSize,
-		NextPageToken: nil,
+		PageSize:       domainCacheRefreshPageSize,
+		NextPageToken:  nil,
+		IncludeDeleted: true,
 	}).Return(&persistence.ListDomainsResponse{
 		Domains:       []*persistence.GetDomainResponse{domainRecord},
 		NextPageToken: nil,
@@ -391,8 +395,9 @@ func (s *domainCacheSuite) TestRegisterCallback_CatchUp() {
 
 	s.metadataMgr.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{NotificationVersion: domainNotificationVersion}, nil).Once()
 	s.metadataMgr.On("ListDomains", mock.Anything, &persistence.ListDomainsRequest{
-		PageSize:      domainCacheRefreshPageSize,
-		NextPageToken: nil,
+		PageSize:       domainCacheRefreshPageSize,
+		NextPageToken:  nil,
+		IncludeDeleted: true,
 	}).Return(&persistence.ListDomainsResponse{
 		Domains:       []*persistence.GetDomainResponse{domainRecord1, domainRecord2},
 		NextPageToken: nil,
@@ -468,8 +473,9 @@ func (s *domainCacheSuite) TestUpdateCache_TriggerCallBack() {
 
 	s.metadataMgr.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{NotificationVersion: domainNotificationVersion}, nil).Once()
 	s.metadataMgr.On("ListDomains", mock.Anything, &persistence.ListDomainsRequest{
-		PageSize:      domainCacheRefreshPageSize,
-		NextPageToken: nil,
+		PageSize:       domainCacheRefreshPageSize,
+		NextPageToken:  nil,
+		IncludeDeleted: true,
 	}).Return(&persistence.ListDomainsResponse{
 		Domains:       []*persistence.GetDomainResponse{domainRecord1Old, domainRecord2Old},
 		NextPageToken: nil,
@@ -533,8 +539,9 @@ func (s *domainCacheSuite) TestUpdateCache_TriggerCallBack() {
 
 	s.metadataMgr.On("GetMetadata", mock.Anything).Return(&persistence.GetMetadataResponse{NotificationVersion: domainNotificationVersion}, nil).Once()
 	s.metadataMgr.On("ListDomains", mock.Anything, &persistence.ListDomainsRequest{
-		PageSize:      domainCacheRefreshPageSize,
-		NextPageToken: nil,
+		PageSize:       domainCacheRefreshPageSize,
+		NextPageToken:  nil,
+		IncludeDeleted: true,
 	}).Return(&persistence.ListDomainsResponse{
 		Domains:       []*persistence.GetDomainResponse{domainRecord1New, domainRecord2New},
 		NextPageToken: nil,
@@ -576,8 +583,9 @@ func (s *domainCacheSuite) TestGetTriggerListAndUpdateCache_ConcurrentAccess() {
 
 	s.metadataMgr.On("GetDomain", mock.Anything, &persistence.GetDomainRequest{ID: id}).Return(domainRecordOld, nil).Maybe()
 	s.metadataMgr.On("ListDomains", mock.Anything, &persistence.ListDomainsRequest{
-		PageSize:      domainCacheRefreshPageSize,
-		NextPageToken: nil,
+		PageSize:       domainCacheRefreshPageSize,
+		NextPageToken:  nil,
+		IncludeDeleted: true,
 	}).Return(&persistence.ListDomainsResponse{
 		Domains:       []*persistence.GetDomainResponse{domainRecordOld},
 		NextPageToken: nil,
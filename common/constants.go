@@ -66,6 +66,8 @@ const (
 	EncodingTypeUnknown  EncodingType = "unknow"
 	EncodingTypeEmpty    EncodingType = ""
 	EncodingTypeProto    EncodingType = "proto3"
+	// EncodingTypeThriftRWSnappy is thriftrw encoded data compressed with snappy
+	EncodingTypeThriftRWSnappy EncodingType = "thriftrw-snappy"
 )
 
 type (
@@ -168,6 +170,12 @@ const (
 	DomainDataKeyForPreferredCluster = "PreferredCluster"
 	// DomainDataKeyForFailoverHistory is the key of DomainData for failover history
 	DomainDataKeyForFailoverHistory = "FailoverHistory"
+	// DomainDataKeyForLastFailoverOperator is the key of DomainData for the operator of the last managed failover
+	DomainDataKeyForLastFailoverOperator = "LastFailoverOperator"
+	// DomainDataKeyForLastFailoverReason is the key of DomainData for the reason of the last managed failover
+	DomainDataKeyForLastFailoverReason = "LastFailoverReason"
+	// DomainDataKeyForSoftDeletedTime is the key of DomainData for the time a domain was soft-deleted in persistence
+	DomainDataKeyForSoftDeletedTime = "SoftDeletedTime"
 	// DomainDataKeyForReadGroups stores which groups have read permission of the domain API
 	DomainDataKeyForReadGroups = "READ_GROUPS"
 	// DomainDataKeyForWriteGroups stores which groups have write permission of the domain API
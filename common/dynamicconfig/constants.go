@@ -670,6 +670,13 @@ const (
 	// Default value: 10 (see domain.MaxBadBinaries)
 	// Allowed filters: DomainName
 	FrontendMaxBadBinaries
+	// FrontendRedirectionCircuitBreakerFailureThreshold is the number of consecutive failures of calls forwarded to a cluster
+	// after which forwarding to that cluster is short-circuited. 0 disables the circuit breaker
+	// KeyName: frontend.redirectionCircuitBreakerFailureThreshold
+	// Value type: Int
+	// Default value: 0
+	// Allowed filters: N/A
+	FrontendRedirectionCircuitBreakerFailureThreshold
 	// SearchAttributesNumberOfKeysLimit is the limit of number of keys
 	// KeyName: frontend.searchAttributesNumberOfKeysLimit
 	// Value type: Int
@@ -1374,6 +1381,18 @@ const (
 	// Default value: 100
 	// Allowed filters: N/A
 	ConcreteExecutionsScannerBlobstoreFlushThreshold
+	// ConcreteExecutionsScannerBlobstoreWriteBudgetBytes is the total number of bytes a concrete execution scan may write to blobstore
+	// KeyName: worker.executionsScannerBlobstoreWriteBudgetBytes
+	// Value type: Int
+	// Default value: 0 (unlimited)
+	// Allowed filters: N/A
+	ConcreteExecutionsScannerBlobstoreWriteBudgetBytes
+	// ConcreteExecutionsScannerShardConcurrency is the number of shards scanned in parallel by each activity of the concrete execution scanner
+	// KeyName: worker.executionsScannerShardConcurrency
+	// Value type: Int
+	// Default value: 1
+	// Allowed filters: N/A
+	ConcreteExecutionsScannerShardConcurrency
 	// ConcreteExecutionsScannerActivityBatchSize indicates the batch size of scanner activities
 	// KeyName: worker.executionsScannerActivityBatchSize
 	// Value type: Int
@@ -1398,6 +1417,18 @@ const (
 	// Default value: 100
 	// Allowed filters: N/A
 	CurrentExecutionsScannerBlobstoreFlushThreshold
+	// CurrentExecutionsScannerBlobstoreWriteBudgetBytes is the total number of bytes a current executions scan may write to blobstore
+	// KeyName: worker.currentExecutionsBlobstoreWriteBudgetBytes
+	// Value type: Int
+	// Default value: 0 (unlimited)
+	// Allowed filters: N/A
+	CurrentExecutionsScannerBlobstoreWriteBudgetBytes
+	// CurrentExecutionsScannerShardConcurrency is the number of shards scanned in parallel by each activity of the current executions scanner
+	// KeyName: worker.currentExecutionsShardConcurrency
+	// Value type: Int
+	// Default value: 1
+	// Allowed filters: N/A
+	CurrentExecutionsScannerShardConcurrency
 	// CurrentExecutionsScannerActivityBatchSize indicates the batch size of scanner activities
 	// KeyName: worker.currentExecutionsActivityBatchSize
 	// Value type: Int
@@ -1428,6 +1459,18 @@ const (
 	// Default value: 100
 	// Allowed filters: N/A
 	TimersScannerBlobstoreFlushThreshold
+	// TimersScannerBlobstoreWriteBudgetBytes is the total number of bytes a timers scan may write to blobstore
+	// KeyName: worker.timersScannerBlobstoreWriteBudgetBytes
+	// Value type: Int
+	// Default value: 0 (unlimited)
+	// Allowed filters: N/A
+	TimersScannerBlobstoreWriteBudgetBytes
+	// TimersScannerShardConcurrency is the number of shards scanned in parallel by each activity of the timers scanner
+	// KeyName: worker.timersScannerShardConcurrency
+	// Value type: Int
+	// Default value: 1
+	// Allowed filters: N/A
+	TimersScannerShardConcurrency
 	// TimersScannerActivityBatchSize is TimersScannerActivityBatchSize
 	// KeyName: worker.timersScannerActivityBatchSize
 	// Value type: Int
@@ -1517,6 +1560,14 @@ const (
 	// Default value: 30
 	DeleteHistoryEventContextTimeout
 
+	// BufferedEventsCompressionThreshold is the size in bytes above which a batch of buffered events is
+	// stored snappy compressed. Only enable it once all hosts are able to read compressed buffered events.
+	// KeyName: system.bufferedEventsCompressionThreshold
+	// Value type: Int
+	// Default value: 0 (compression disabled)
+	// Allowed filters: N/A
+	BufferedEventsCompressionThreshold
+
 	// LastIntKey must be the last one in this const group
 	LastIntKey
 )
@@ -1912,6 +1963,18 @@ const (
 	// Default value: false
 	// Allowed filters: N/A
 	ConcreteExecutionsScannerInvariantCollectionStale
+	// ConcreteExecutionsScannerInvariantCollectionOrphanedHistory indicates if the orphaned history branch invariant should be run
+	// KeyName: worker.executionsScannerInvariantCollectionOrphanedHistory
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	ConcreteExecutionsScannerInvariantCollectionOrphanedHistory
+	// ConcreteExecutionsFixerInvariantCollectionOrphanedHistory indicates if the orphaned history branch invariant should be run
+	// KeyName: worker.executionsFixerInvariantCollectionOrphanedHistory
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	ConcreteExecutionsFixerInvariantCollectionOrphanedHistory
 	// CurrentExecutionsScannerEnabled indicates if current executions scanner should be started as part of worker.Scanner
 	// KeyName: worker.currentExecutionsScannerEnabled
 	// Value type: Bool
@@ -2406,6 +2469,13 @@ const (
 	// Default value: 0
 	// Allowed filters: N/A
 	FrontendShutdownDrainDuration
+	// FrontendRedirectionCircuitBreakerOpenDuration is how long forwarding to a cluster is short-circuited once its circuit breaker opens,
+	// before a single probe call is let through
+	// KeyName: frontend.redirectionCircuitBreakerOpenDuration
+	// Value type: Duration
+	// Default value: 10s (10*time.Second)
+	// Allowed filters: N/A
+	FrontendRedirectionCircuitBreakerOpenDuration
 	// FrontendFailoverCoolDown is duration between two domain failvoers
 	// KeyName: frontend.failoverCoolDown
 	// Value type: Duration
@@ -3226,6 +3296,11 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "FrontendMaxBadBinaries is the max number of bad binaries in domain config",
 		DefaultValue: 10,
 	},
+	FrontendRedirectionCircuitBreakerFailureThreshold: {
+		KeyName:      "frontend.redirectionCircuitBreakerFailureThreshold",
+		Description:  "FrontendRedirectionCircuitBreakerFailureThreshold is the number of consecutive failures of calls forwarded to a cluster after which forwarding to that cluster is short-circuited. 0 disables the circuit breaker",
+		DefaultValue: 0,
+	},
 	SearchAttributesNumberOfKeysLimit: {
 		KeyName:      "frontend.searchAttributesNumberOfKeysLimit",
 		Filters:      []Filter{DomainName},
@@ -3820,6 +3895,16 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "ConcreteExecutionsScannerBlobstoreFlushThreshold indicates the flush threshold of blobstore in concrete execution scanner",
 		DefaultValue: 100,
 	},
+	ConcreteExecutionsScannerBlobstoreWriteBudgetBytes: {
+		KeyName:      "worker.executionsScannerBlobstoreWriteBudgetBytes",
+		Description:  "ConcreteExecutionsScannerBlobstoreWriteBudgetBytes is the total number of bytes a concrete execution scan may write to blobstore, 0 means unlimited",
+		DefaultValue: 0,
+	},
+	ConcreteExecutionsScannerShardConcurrency: {
+		KeyName:      "worker.executionsScannerShardConcurrency",
+		Description:  "ConcreteExecutionsScannerShardConcurrency is the number of shards scanned in parallel by each activity of the concrete execution scanner",
+		DefaultValue: 1,
+	},
 	ConcreteExecutionsScannerActivityBatchSize: {
 		KeyName:      "worker.executionsScannerActivityBatchSize",
 		Description:  "ConcreteExecutionsScannerActivityBatchSize indicates the batch size of scanner activities",
@@ -3840,6 +3925,16 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "CurrentExecutionsScannerBlobstoreFlushThreshold indicates the flush threshold of blobstore in current executions scanner",
 		DefaultValue: 100,
 	},
+	CurrentExecutionsScannerBlobstoreWriteBudgetBytes: {
+		KeyName:      "worker.currentExecutionsBlobstoreWriteBudgetBytes",
+		Description:  "CurrentExecutionsScannerBlobstoreWriteBudgetBytes is the total number of bytes a current executions scan may write to blobstore, 0 means unlimited",
+		DefaultValue: 0,
+	},
+	CurrentExecutionsScannerShardConcurrency: {
+		KeyName:      "worker.currentExecutionsShardConcurrency",
+		Description:  "CurrentExecutionsScannerShardConcurrency is the number of shards scanned in parallel by each activity of the current executions scanner",
+		DefaultValue: 1,
+	},
 	CurrentExecutionsScannerActivityBatchSize: {
 		KeyName:      "worker.currentExecutionsActivityBatchSize",
 		Description:  "CurrentExecutionsScannerActivityBatchSize indicates the batch size of scanner activities",
@@ -3865,6 +3960,16 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "TimersScannerBlobstoreFlushThreshold is threshold to flush blob store",
 		DefaultValue: 100,
 	},
+	TimersScannerBlobstoreWriteBudgetBytes: {
+		KeyName:      "worker.timersScannerBlobstoreWriteBudgetBytes",
+		Description:  "TimersScannerBlobstoreWriteBudgetBytes is the total number of bytes a timers scan may write to blobstore, 0 means unlimited",
+		DefaultValue: 0,
+	},
+	TimersScannerShardConcurrency: {
+		KeyName:      "worker.timersScannerShardConcurrency",
+		Description:  "TimersScannerShardConcurrency is the number of shards scanned in parallel by each activity of the timers scanner",
+		DefaultValue: 1,
+	},
 	TimersScannerActivityBatchSize: {
 		KeyName:      "worker.timersScannerActivityBatchSize",
 		Description:  "TimersScannerActivityBatchSize is TimersScannerActivityBatchSize",
@@ -3945,6 +4050,11 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "This is the number of seconds allowed for a deleteHistoryEvent task to the database",
 		DefaultValue: 30,
 	},
+	BufferedEventsCompressionThreshold: {
+		KeyName:      "system.bufferedEventsCompressionThreshold",
+		Description:  "BufferedEventsCompressionThreshold is the size in bytes above which a batch of buffered events is stored snappy compressed, 0 disables compression",
+		DefaultValue: 0,
+	},
 }
 
 var BoolKeys = map[BoolKey]DynamicBool{
@@ -4284,6 +4394,16 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "ConcreteExecutionsFixerInvariantCollectionStale indicates if the stale-workflow invariant should be run",
 		DefaultValue: false, // may be enabled after further verification, but for now it's a bit too risky to enable by default
 	},
+	ConcreteExecutionsScannerInvariantCollectionOrphanedHistory: {
+		KeyName:      "worker.executionsScannerInvariantCollectionOrphanedHistory",
+		Description:  "ConcreteExecutionsScannerInvariantCollectionOrphanedHistory indicates if the orphaned history branch invariant should be run",
+		DefaultValue: false,
+	},
+	ConcreteExecutionsFixerInvariantCollectionOrphanedHistory: {
+		KeyName:      "worker.executionsFixerInvariantCollectionOrphanedHistory",
+		Description:  "ConcreteExecutionsFixerInvariantCollectionOrphanedHistory indicates if the orphaned history branch invariant should be run",
+		DefaultValue: false,
+	},
 	CurrentExecutionsScannerEnabled: {
 		KeyName:      "worker.currentExecutionsScannerEnabled",
 		Description:  "CurrentExecutionsScannerEnabled indicates if current executions scanner should be started as part of worker.Scanner",
@@ -4748,6 +4868,11 @@ var DurationKeys = map[DurationKey]DynamicDuration{
 		Description:  "FrontendShutdownDrainDuration is the duration of traffic drain during shutdown",
 		DefaultValue: 0,
 	},
+	FrontendRedirectionCircuitBreakerOpenDuration: {
+		KeyName:      "frontend.redirectionCircuitBreakerOpenDuration",
+		Description:  "FrontendRedirectionCircuitBreakerOpenDuration is how long forwarding to a cluster is short-circuited once its circuit breaker opens, before a single probe call is let through",
+		DefaultValue: time.Second * 10,
+	},
 	FrontendFailoverCoolDown: {
 		KeyName:      "frontend.failoverCoolDown",
 		Filters:      []Filter{DomainName},
@@ -380,6 +380,11 @@ func errorType(errorType string) Tag {
 	return newPredefinedStringTag("error-type", errorType)
 }
 
+// failoverType returns tag for failoverType
+func failoverType(failoverType string) Tag {
+	return newPredefinedStringTag("failover-type", failoverType)
+}
+
 // shardupdate returns tag for shardupdate
 func shardupdate(shardupdate string) Tag {
 	return newPredefinedStringTag("shard-update", shardupdate)
@@ -168,6 +168,13 @@ var (
 	ErrorTypeInvalidMemDecisionTaskAction = errorType("InvalidMemDecisionTaskAction")
 )
 
+// Pre-defined values for FailoverType
+var (
+	FailoverTypeGraceful = failoverType("graceful")
+	FailoverTypeForce    = failoverType("force")
+	FailoverTypeDrill    = failoverType("drill")
+)
+
 // Pre-defined values for SysShardUpdate
 var (
 	// Shard context events
@@ -229,6 +236,7 @@ var (
 	StoreOperationListTaskList          = storeOperation("list-task-list")
 	StoreOperationDeleteTaskList        = storeOperation("delete-task-list")
 	StoreOperationGetTaskListSize       = storeOperation("get-task-list-size")
+	StoreOperationGetTaskIDRange        = storeOperation("get-task-id-range")
 	StoreOperationStopTaskList          = storeOperation("stop-task-list")
 
 	StoreOperationCreateDomain       = storeOperation("create-domain")
@@ -220,6 +220,8 @@ const (
 	PersistenceDeleteTaskListScope
 	// PersistenceGetTaskListSizeScope is the metric scope for persistence.TaskManager.GetTaskListSize API
 	PersistenceGetTaskListSizeScope
+	// PersistenceGetTaskIDRangeScope is the metric scope for persistence.TaskManager.GetTaskIDRange API
+	PersistenceGetTaskIDRangeScope
 	// PersistenceAppendHistoryEventsScope tracks AppendHistoryEvents calls made by service to persistence layer
 	PersistenceAppendHistoryEventsScope
 	// PersistenceGetWorkflowExecutionHistoryScope tracks GetWorkflowExecutionHistory calls made by service to persistence layer
@@ -1414,6 +1416,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceListTaskListScope:                             {operation: "ListTaskList"},
 		PersistenceDeleteTaskListScope:                           {operation: "DeleteTaskList"},
 		PersistenceGetTaskListSizeScope:                          {operation: "GetTaskListSize"},
+		PersistenceGetTaskIDRangeScope:                           {operation: "GetTaskIDRange"},
 		PersistenceAppendHistoryEventsScope:                      {operation: "AppendHistoryEvents"},
 		PersistenceGetWorkflowExecutionHistoryScope:              {operation: "GetWorkflowExecutionHistory"},
 		PersistenceDeleteWorkflowExecutionHistoryScope:           {operation: "DeleteWorkflowExecutionHistory"},
@@ -2066,6 +2069,9 @@ const (
 	PersistenceErrDBUnavailableCounterPerDomain
 	PersistenceSampledCounterPerDomain
 	PersistenceEmptyResponseCounterPerDomain
+	PersistenceHistoryBlobCountPerDomain
+	PersistenceHistoryBlobSizePerDomain
+	PersistenceHistoryBlobDecodeLatencyPerDomain
 
 	CadenceClientRequests
 	CadenceClientFailures
@@ -2608,6 +2614,10 @@ const (
 	ScannerShardSizeSeventyFiveGauge
 	ScannerShardSizeTwentyFiveGauge
 	ScannerShardSizeTenGauge
+	ScannerShardScanDurationHistogram
+	ScannerShardScanDurationMinGauge
+	ScannerShardScanDurationMedianGauge
+	ScannerShardScanDurationNinetyGauge
 	ShardScannerScan
 	ShardScannerFix
 	DataCorruptionWorkflowCount
@@ -2700,6 +2710,9 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		PersistenceErrDBUnavailableCounterPerDomain:                  {metricName: "persistence_errors_db_unavailable_per_domain", metricRollupName: "persistence_errors_db_unavailable", metricType: Counter},
 		PersistenceSampledCounterPerDomain:                           {metricName: "persistence_sampled_per_domain", metricRollupName: "persistence_sampled", metricType: Counter},
 		PersistenceEmptyResponseCounterPerDomain:                     {metricName: "persistence_empty_response_per_domain", metricRollupName: "persistence_empty_response", metricType: Counter},
+		PersistenceHistoryBlobCountPerDomain:                         {metricName: "persistence_history_blob_count_per_domain", metricType: Timer},
+		PersistenceHistoryBlobSizePerDomain:                          {metricName: "persistence_history_blob_size_per_domain", metricType: Timer},
+		PersistenceHistoryBlobDecodeLatencyPerDomain:                 {metricName: "persistence_history_blob_decode_latency_per_domain", metricType: Timer},
 		CadenceClientRequests:                                        {metricName: "cadence_client_requests", metricType: Counter},
 		CadenceClientFailures:                                        {metricName: "cadence_client_errors", metricType: Counter},
 		CadenceClientLatency:                                         {metricName: "cadence_client_latency", metricType: Timer},
@@ -3247,6 +3260,10 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ScannerShardSizeSeventyFiveGauge:              {metricName: "scanner_shard_size_seventy_five", metricType: Gauge},
 		ScannerShardSizeTwentyFiveGauge:               {metricName: "scanner_shard_size_twenty_five", metricType: Gauge},
 		ScannerShardSizeTenGauge:                      {metricName: "scanner_shard_size_ten", metricType: Gauge},
+		ScannerShardScanDurationHistogram:             {metricName: "scanner_shard_scan_duration", metricType: Histogram, buckets: ShardScanDurationBuckets},
+		ScannerShardScanDurationMinGauge:              {metricName: "scanner_shard_scan_duration_min", metricType: Gauge},
+		ScannerShardScanDurationMedianGauge:           {metricName: "scanner_shard_scan_duration_median", metricType: Gauge},
+		ScannerShardScanDurationNinetyGauge:           {metricName: "scanner_shard_scan_duration_ninety", metricType: Gauge},
 		ShardScannerScan:                              {metricName: "shardscanner_scan", metricType: Counter},
 		ShardScannerFix:                               {metricName: "shardscanner_fix", metricType: Counter},
 		DataCorruptionWorkflowFailure:                 {metricName: "data_corruption_workflow_failure", metricType: Counter},
@@ -3324,6 +3341,24 @@ var PersistenceLatencyBuckets = tally.DurationBuckets([]time.Duration{
 	60 * time.Second,
 })
 
+// ShardScanDurationBuckets contains buckets for the wall-clock time the shard scanner spends on a single shard
+var ShardScanDurationBuckets = tally.DurationBuckets([]time.Duration{
+	1 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	1 * time.Minute,
+	2 * time.Minute,
+	5 * time.Minute,
+	10 * time.Minute,
+	20 * time.Minute,
+	30 * time.Minute,
+	1 * time.Hour,
+	2 * time.Hour,
+	4 * time.Hour,
+	8 * time.Hour,
+})
+
 // GlobalRatelimiterUsageHistogram contains buckets for tracking how many ratelimiters are
 // in which state (startup, healthy, failing).
 var GlobalRatelimiterUsageHistogram = tally.ValueBuckets{
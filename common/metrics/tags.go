@@ -48,6 +48,7 @@ const (
 	invariantType                 = "invariantType"
 	shardScannerScanResult        = "shardscanner_scan_result"
 	shardScannerFixResult         = "shardscanner_fix_result"
+	shardScannerShardSizeBucket   = "shardscanner_shard_size_bucket"
 	kafkaPartition                = "kafkaPartition"
 	transport                     = "transport"
 	caller                        = "caller"
@@ -186,6 +187,11 @@ func ShardScannerFixResult(value string) Tag {
 	return metricWithUnknown(shardScannerFixResult, value)
 }
 
+// ShardScannerShardSizeBucket returns a new shardscanner shard size bucket tag.
+func ShardScannerShardSizeBucket(value string) Tag {
+	return metricWithUnknown(shardScannerShardSizeBucket, value)
+}
+
 // InvariantTypeTag returns a new invariant type tag.
 func InvariantTypeTag(value string) Tag {
 	return metricWithUnknown(invariantType, value)
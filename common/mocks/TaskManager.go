@@ -162,6 +162,32 @@ func (_m *TaskManager) GetOrphanTasks(ctx context.Context, request *persistence.
 	return r0, r1
 }
 
+// GetTaskIDRange provides a mock function with given fields: ctx, request
+func (_m *TaskManager) GetTaskIDRange(ctx context.Context, request *persistence.GetTaskIDRangeRequest) (*persistence.GetTaskIDRangeResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *persistence.GetTaskIDRangeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.GetTaskIDRangeRequest) (*persistence.GetTaskIDRangeResponse, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *persistence.GetTaskIDRangeRequest) *persistence.GetTaskIDRangeResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.GetTaskIDRangeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *persistence.GetTaskIDRangeRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTaskListSize provides a mock function with given fields: ctx, request
 func (_m *TaskManager) GetTaskListSize(ctx context.Context, request *persistence.GetTaskListSizeRequest) (*persistence.GetTaskListSizeResponse, error) {
 	ret := _m.Called(ctx, request)
@@ -843,6 +843,15 @@ func (mr *MockTaskManagerMockRecorder) GetOrphanTasks(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrphanTasks", reflect.TypeOf((*MockTaskManager)(nil).GetOrphanTasks), arg0, arg1)
 }
 
+// GetTaskIDRange mocks base method.
+func (m *MockTaskManager) GetTaskIDRange(arg0 context.Context, arg1 *GetTaskIDRangeRequest) (*GetTaskIDRangeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskIDRange", arg0, arg1)
+	ret0, _ := ret[0].(*GetTaskIDRangeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // GetTaskListSize mocks base method.
 func (m *MockTaskManager) GetTaskListSize(arg0 context.Context, arg1 *GetTaskListSizeRequest) (*GetTaskListSizeResponse, error) {
 	m.ctrl.T.Helper()
@@ -852,6 +861,12 @@ func (m *MockTaskManager) GetTaskListSize(arg0 context.Context, arg1 *GetTaskLis
 	return ret0, ret1
 }
 
+// GetTaskIDRange indicates an expected call of GetTaskIDRange.
+func (mr *MockTaskManagerMockRecorder) GetTaskIDRange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskIDRange", reflect.TypeOf((*MockTaskManager)(nil).GetTaskIDRange), arg0, arg1)
+}
+
 // GetTaskListSize indicates an expected call of GetTaskListSize.
 func (mr *MockTaskManagerMockRecorder) GetTaskListSize(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
@@ -227,6 +227,8 @@ const (
 	WorkflowRequestTypeSignal
 	WorkflowRequestTypeCancel
 	WorkflowRequestTypeReset
+	// WorkflowRequestTypeDedupKey records the dedup key of a workflow creation
+	WorkflowRequestTypeDedupKey
 )
 
 // CreateWorkflowRequestMode is the mode of create workflow request
@@ -387,6 +389,7 @@ type (
 		WorkflowID   string
 		RunID        string
 		State        int
+		CloseStatus  int
 		CurrentRunID string
 	}
 
@@ -638,6 +641,10 @@ type (
 
 		WorkflowRequestMode CreateWorkflowRequestMode
 		DomainName          string
+		// DedupKey is optional, a new workflow creation is rejected with WorkflowExecutionAlreadyStartedError
+		// if the same key was used for the domain and workflow ID within the workflow request retention.
+		// Stores which do not persist workflow requests reject a non-empty key with InvalidPersistenceRequestError.
+		DedupKey string
 	}
 
 	// CreateWorkflowExecutionResponse is the response to CreateWorkflowExecutionRequest
@@ -670,6 +677,10 @@ type (
 	ListCurrentExecutionsRequest struct {
 		PageSize  int
 		PageToken []byte
+		// WorkflowState only returns executions in this state when set
+		WorkflowState *int
+		// CloseStatus only returns executions with this close status when set
+		CloseStatus *int
 	}
 
 	// ListCurrentExecutionsResponse is the response to ListCurrentExecutionsRequest
@@ -1061,6 +1072,30 @@ type (
 		Size int64
 	}
 
+	// GetTaskIDRangeRequest is used to get the range of task IDs of the outstanding tasks in a task list
+	GetTaskIDRangeRequest struct {
+		DomainID     string
+		TaskListName string
+		TaskListType int
+	}
+
+	// GetTaskIDRangeResponse is the response to GetTaskIDRange, both task IDs are 0 if the task list has no tasks
+	GetTaskIDRangeResponse struct {
+		MinTaskID int64
+		MaxTaskID int64
+	}
+
+	// GetTaskListsByDomainRequest is used to list the task lists of a domain
+	GetTaskListsByDomainRequest struct {
+		DomainID string
+	}
+
+	// GetTaskListsByDomainResponse maps task list names to their kind (TaskListKindNormal or TaskListKindSticky)
+	GetTaskListsByDomainResponse struct {
+		DecisionTaskLists map[string]int
+		ActivityTaskLists map[string]int
+	}
+
 	// CreateTasksRequest is used to create a new task for a workflow exectution
 	CreateTasksRequest struct {
 		TaskListInfo *TaskListInfo
@@ -1076,6 +1111,8 @@ type (
 
 	// CreateTasksResponse is the response to CreateTasksRequest
 	CreateTasksResponse struct {
+		// TaskIDs are the IDs of the created tasks, in the same order as CreateTasksRequest.Tasks
+		TaskIDs []int64
 	}
 
 	// GetTasksRequest is used to retrieve tasks of a task list
@@ -1109,6 +1146,10 @@ type (
 		TaskID       int64 // Tasks less than or equal to this ID will be completed
 		Limit        int   // Limit on the max number of tasks that can be completed. Required param
 		DomainName   string
+		// ExactCount deletes all tasks less than or equal to TaskID in batches of Limit and returns
+		// the exact number of rows deleted. Only SQL stores can honor it. NoSQL stores ignore it and
+		// still return UnknownNumRowsAffected, so callers must handle that value regardless.
+		ExactCount bool
 	}
 
 	// CompleteTasksLessThanResponse is the response of CompleteTasksLessThan
@@ -1238,10 +1279,20 @@ type (
 		Name string
 	}
 
+	// MarkDomainDeletedRequest is used to soft-delete a domain, identified either by ID or by Name.
+	// The domain row is kept with its deletion time recorded in the domain data until it is purged.
+	MarkDomainDeletedRequest struct {
+		ID        string
+		Name      string
+		DeletedAt time.Time
+	}
+
 	// ListDomainsRequest is used to list domains
 	ListDomainsRequest struct {
 		PageSize      int
 		NextPageToken []byte
+		// IncludeDeleted also returns domains that were soft-deleted via MarkDomainDeleted
+		IncludeDeleted bool
 	}
 
 	// ListDomainsResponse is the response for GetDomain
@@ -1419,6 +1470,8 @@ type (
 		ShardID *int
 		// DomainName to create metrics for Domain Cost Attribution
 		DomainName string
+		// ValidateForkNode reads the base branch to make sure ForkNodeID is a valid fork point before forking
+		ValidateForkNode bool
 	}
 
 	// ForkHistoryBranchResponse is the response to ForkHistoryBranchRequest
@@ -1471,6 +1524,8 @@ type (
 	GetHistoryTreeResponse struct {
 		// all branches of a tree
 		Branches []*workflow.HistoryBranch
+		// info the branches were created with, keyed by branch ID
+		BranchInfos map[string]string
 	}
 
 	// GetAllHistoryTreeBranchesRequest is a request of GetAllHistoryTreeBranches
@@ -1580,6 +1635,8 @@ type (
 		ListTaskList(ctx context.Context, request *ListTaskListRequest) (*ListTaskListResponse, error)
 		DeleteTaskList(ctx context.Context, request *DeleteTaskListRequest) error
 		GetTaskListSize(ctx context.Context, request *GetTaskListSizeRequest) (*GetTaskListSizeResponse, error)
+		// GetTaskIDRange returns the smallest and largest task ID of the outstanding tasks in a task list
+		GetTaskIDRange(ctx context.Context, request *GetTaskIDRangeRequest) (*GetTaskIDRangeResponse, error)
 		CreateTasks(ctx context.Context, request *CreateTasksRequest) (*CreateTasksResponse, error)
 		GetTasks(ctx context.Context, request *GetTasksRequest) (*GetTasksResponse, error)
 		CompleteTask(ctx context.Context, request *CompleteTaskRequest) error
@@ -32,7 +32,7 @@ import (
 	"github.com/uber/cadence/common/types"
 )
 
-//go:generate mockgen -package $GOPACKAGE -destination data_store_interfaces_mock.go -self_package github.com/uber/cadence/common/persistence github.com/uber/cadence/common/persistence ExecutionStore,ShardStore
+//go:generate mockgen -package $GOPACKAGE -destination data_store_interfaces_mock.go -self_package github.com/uber/cadence/common/persistence github.com/uber/cadence/common/persistence ExecutionStore,ShardStore,TaskStore,HistoryStore,DomainStore
 //go:generate mockgen -package $GOPACKAGE -destination visibility_store_mock.go -self_package github.com/uber/cadence/common/persistence github.com/uber/cadence/common/persistence VisibilityStore
 
 type (
@@ -50,6 +50,9 @@ type (
 		CreateShard(ctx context.Context, request *InternalCreateShardRequest) error
 		GetShard(ctx context.Context, request *InternalGetShardRequest) (*InternalGetShardResponse, error)
 		UpdateShard(ctx context.Context, request *InternalUpdateShardRequest) error
+		// ListShardsByOwner returns the IDs of the shards owned by a host.
+		// It is a store-only hook for admin tooling, ShardManager does not expose it.
+		ListShardsByOwner(ctx context.Context, owner string) ([]int, error)
 	}
 
 	// TaskStore is a lower level of TaskManager
@@ -61,6 +64,11 @@ type (
 		ListTaskList(ctx context.Context, request *ListTaskListRequest) (*ListTaskListResponse, error)
 		DeleteTaskList(ctx context.Context, request *DeleteTaskListRequest) error
 		GetTaskListSize(ctx context.Context, request *GetTaskListSizeRequest) (*GetTaskListSizeResponse, error)
+		// GetTaskIDRange returns the smallest and largest task ID of the outstanding tasks in a task list
+		GetTaskIDRange(ctx context.Context, request *GetTaskIDRangeRequest) (*GetTaskIDRangeResponse, error)
+		// GetTaskListsByDomain returns the names and kinds of all decision and activity task lists of a domain.
+		// It is only meant for admin tooling which works on the store directly and has no TaskManager counterpart.
+		GetTaskListsByDomain(ctx context.Context, request *GetTaskListsByDomainRequest) (*GetTaskListsByDomainResponse, error)
 		CreateTasks(ctx context.Context, request *InternalCreateTasksRequest) (*CreateTasksResponse, error)
 		GetTasks(ctx context.Context, request *GetTasksRequest) (*InternalGetTasksResponse, error)
 		CompleteTask(ctx context.Context, request *CompleteTaskRequest) error
@@ -73,6 +81,8 @@ type (
 		// On success, this method returns:
 		//  - number of rows actually deleted, if limit is honored
 		//  - UnknownNumRowsDeleted, when all rows below value are deleted
+		// If request.ExactCount is set, stores that can count deleted rows delete all rows below
+		// value and return the exact count instead; other stores behave as if it was unset.
 		CompleteTasksLessThan(ctx context.Context, request *CompleteTasksLessThanRequest) (*CompleteTasksLessThanResponse, error)
 		// GetOrphanTasks returns tasks that exist as records in the database but are part of task lists which
 		// _do not_ exist in the database. They are therefore unreachable and no longer represent valid items
@@ -89,7 +99,11 @@ type (
 		UpdateDomain(ctx context.Context, request *InternalUpdateDomainRequest) error
 		DeleteDomain(ctx context.Context, request *DeleteDomainRequest) error
 		DeleteDomainByName(ctx context.Context, request *DeleteDomainByNameRequest) error
+		MarkDomainDeleted(ctx context.Context, request *MarkDomainDeletedRequest) error
+		PurgeDeletedDomains(ctx context.Context, olderThan time.Time) error
 		ListDomains(ctx context.Context, request *ListDomainsRequest) (*InternalListDomainsResponse, error)
+		// ListDomainsByIsolationGroup returns all domains whose isolation group configuration references the given group
+		ListDomainsByIsolationGroup(ctx context.Context, group string) ([]*InternalGetDomainResponse, error)
 		GetMetadata(ctx context.Context) (*GetMetadataResponse, error)
 	}
 
@@ -108,6 +122,12 @@ type (
 		DeleteCurrentWorkflowExecution(ctx context.Context, request *DeleteCurrentWorkflowExecutionRequest) error
 		GetCurrentExecution(ctx context.Context, request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
 		IsWorkflowExecutionExists(ctx context.Context, request *IsWorkflowExecutionExistsRequest) (*IsWorkflowExecutionExistsResponse, error)
+		// BackfillFirstExecutionRunID sets the FirstExecutionRunID of a workflow execution created before the field existed.
+		// It is a store-only hook for backfill tooling, ExecutionManager does not expose it.
+		BackfillFirstExecutionRunID(ctx context.Context, request *BackfillFirstExecutionRunIDRequest) error
+		// ListWorkflowRequests returns the requests recorded for deduplication against a workflow execution.
+		// It is only meant for admin tooling inspecting the store and has no ExecutionManager counterpart.
+		ListWorkflowRequests(ctx context.Context, domainID, workflowID, runID string) ([]*WorkflowRequest, error)
 
 		// Transfer task related methods
 		GetTransferTasks(ctx context.Context, request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
@@ -151,6 +171,11 @@ type (
 		ForkHistoryBranch(ctx context.Context, request *InternalForkHistoryBranchRequest) (*InternalForkHistoryBranchResponse, error)
 		// DeleteHistoryBranch removes a branch
 		DeleteHistoryBranch(ctx context.Context, request *InternalDeleteHistoryBranchRequest) error
+		// TruncateHistoryBranch removes the nodes of a branch beyond a node ID.
+		// It is a store-only hook for repair tooling, HistoryManager does not expose it.
+		TruncateHistoryBranch(ctx context.Context, request *InternalTruncateHistoryBranchRequest) error
+		// BatchDeleteHistoryBranch removes multiple branches, reporting the outcome of each branch individually
+		BatchDeleteHistoryBranch(ctx context.Context, requests []InternalDeleteHistoryBranchRequest) (*InternalBatchDeleteHistoryBranchResponse, error)
 		// GetHistoryTree returns all branch information of a tree
 		GetHistoryTree(ctx context.Context, request *InternalGetHistoryTreeRequest) (*InternalGetHistoryTreeResponse, error)
 		// GetAllHistoryTreeBranches returns all branches of all trees
@@ -198,6 +223,9 @@ type (
 	Queue interface {
 		Closeable
 		EnqueueMessage(ctx context.Context, messagePayload []byte) error
+		// EnqueueMessages atomically enqueues a batch of messages and returns their IDs in the order of the payloads.
+		// QueueManager does not expose it yet, it is only available to callers using the store directly.
+		EnqueueMessages(ctx context.Context, messagePayloads [][]byte) ([]int64, error)
 		ReadMessages(ctx context.Context, lastMessageID int64, maxCount int) ([]*InternalQueueMessage, error)
 		DeleteMessagesBefore(ctx context.Context, messageID int64) error
 		UpdateAckLevel(ctx context.Context, messageID int64, clusterName string) error
@@ -238,6 +266,7 @@ type (
 		NewWorkflowSnapshot InternalWorkflowSnapshot
 
 		WorkflowRequestMode CreateWorkflowRequestMode
+		DedupKey            string
 	}
 
 	// InternalGetReplicationTasksResponse is the response to GetReplicationTask
@@ -548,6 +577,15 @@ type (
 		State *InternalWorkflowMutableState
 	}
 
+	// BackfillFirstExecutionRunIDRequest is used to set the FirstExecutionRunID of a workflow execution
+	BackfillFirstExecutionRunIDRequest struct {
+		RangeID             int64
+		DomainID            string
+		WorkflowID          string
+		RunID               string
+		FirstExecutionRunID string
+	}
+
 	// InternalListConcreteExecutionsResponse is the response to ListConcreteExecutions for Persistence Interface
 	InternalListConcreteExecutionsResponse struct {
 		Executions    []*InternalListConcreteExecutionsEntity
@@ -588,6 +626,24 @@ type (
 		ShardID int
 	}
 
+	// InternalTruncateHistoryBranchRequest is used to remove the tail of a history branch
+	InternalTruncateHistoryBranchRequest struct {
+		// branch to be truncated
+		BranchInfo types.HistoryBranch
+		// nodes with node ID greater than LastNodeID are removed
+		LastNodeID int64
+		// the truncation fails if a node to be removed was written with a greater transaction ID
+		TransactionID int64
+		// Used in sharded data stores to identify which shard to use
+		ShardID int
+	}
+
+	// InternalBatchDeleteHistoryBranchResponse is the response to BatchDeleteHistoryBranch
+	InternalBatchDeleteHistoryBranchResponse struct {
+		// Errors is aligned with the requests, a nil entry means the branch was deleted
+		Errors []error
+	}
+
 	// InternalReadHistoryBranchRequest is used to read a history branch
 	InternalReadHistoryBranchRequest struct {
 		// The tree of branch range to be read
@@ -646,6 +702,8 @@ type (
 	InternalGetHistoryTreeResponse struct {
 		// all branches of a tree
 		Branches []*types.HistoryBranch
+		// info the branches were created with, keyed by branch ID
+		BranchInfos map[string]string
 	}
 
 	// InternalVisibilityWorkflowExecutionInfo is visibility info for internal response
@@ -935,6 +993,19 @@ type (
 	}
 )
 
+// HasIsolationGroup returns true if the domain's isolation group configuration references the given group
+func (c *InternalDomainConfig) HasIsolationGroup(serializer PayloadSerializer, group string) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+	isolationGroups, err := serializer.DeserializeIsolationGroups(c.IsolationGroups)
+	if err != nil || isolationGroups == nil {
+		return false, err
+	}
+	_, ok := (*isolationGroups)[group]
+	return ok, nil
+}
+
 // NewDataBlob returns a new DataBlob
 func NewDataBlob(data []byte, encodingType common.EncodingType) *DataBlob {
 	if len(data) == 0 {
@@ -992,6 +1063,8 @@ func (d *DataBlob) GetEncoding() common.EncodingType {
 		return common.EncodingTypeJSON
 	case common.EncodingTypeThriftRW:
 		return common.EncodingTypeThriftRW
+	case common.EncodingTypeThriftRWSnappy:
+		return common.EncodingTypeThriftRWSnappy
 	case common.EncodingTypeEmpty:
 		return common.EncodingTypeEmpty
 	default:
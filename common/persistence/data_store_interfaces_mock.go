@@ -21,7 +21,7 @@
 // SOFTWARE.
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/uber/cadence/common/persistence (interfaces: ExecutionStore,ShardStore)
+// Source: github.com/uber/cadence/common/persistence (interfaces: ExecutionStore,ShardStore,TaskStore,HistoryStore,DomainStore)
 
 // Package persistence is a generated GoMock package.
 package persistence
@@ -29,6 +29,7 @@ package persistence
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -56,6 +57,20 @@ func (m *MockExecutionStore) EXPECT() *MockExecutionStoreMockRecorder {
 	return m.recorder
 }
 
+// BackfillFirstExecutionRunID mocks base method.
+func (m *MockExecutionStore) BackfillFirstExecutionRunID(arg0 context.Context, arg1 *BackfillFirstExecutionRunIDRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillFirstExecutionRunID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BackfillFirstExecutionRunID indicates an expected call of BackfillFirstExecutionRunID.
+func (mr *MockExecutionStoreMockRecorder) BackfillFirstExecutionRunID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillFirstExecutionRunID", reflect.TypeOf((*MockExecutionStore)(nil).BackfillFirstExecutionRunID), arg0, arg1)
+}
+
 // Close mocks base method.
 func (m *MockExecutionStore) Close() {
 	m.ctrl.T.Helper()
@@ -373,6 +388,21 @@ func (mr *MockExecutionStoreMockRecorder) ListCurrentExecutions(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurrentExecutions", reflect.TypeOf((*MockExecutionStore)(nil).ListCurrentExecutions), arg0, arg1)
 }
 
+// ListWorkflowRequests mocks base method.
+func (m *MockExecutionStore) ListWorkflowRequests(arg0 context.Context, arg1, arg2, arg3 string) ([]*WorkflowRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkflowRequests", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*WorkflowRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkflowRequests indicates an expected call of ListWorkflowRequests.
+func (mr *MockExecutionStoreMockRecorder) ListWorkflowRequests(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkflowRequests", reflect.TypeOf((*MockExecutionStore)(nil).ListWorkflowRequests), arg0, arg1, arg2, arg3)
+}
+
 // PutReplicationTaskToDLQ mocks base method.
 func (m *MockExecutionStore) PutReplicationTaskToDLQ(arg0 context.Context, arg1 *InternalPutReplicationTaskToDLQRequest) error {
 	m.ctrl.T.Helper()
@@ -539,6 +569,21 @@ func (mr *MockShardStoreMockRecorder) GetShard(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShard", reflect.TypeOf((*MockShardStore)(nil).GetShard), arg0, arg1)
 }
 
+// ListShardsByOwner mocks base method.
+func (m *MockShardStore) ListShardsByOwner(arg0 context.Context, arg1 string) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShardsByOwner", arg0, arg1)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShardsByOwner indicates an expected call of ListShardsByOwner.
+func (mr *MockShardStoreMockRecorder) ListShardsByOwner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShardsByOwner", reflect.TypeOf((*MockShardStore)(nil).ListShardsByOwner), arg0, arg1)
+}
+
 // UpdateShard mocks base method.
 func (m *MockShardStore) UpdateShard(arg0 context.Context, arg1 *InternalUpdateShardRequest) error {
 	m.ctrl.T.Helper()
@@ -552,3 +597,590 @@ func (mr *MockShardStoreMockRecorder) UpdateShard(arg0, arg1 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShard", reflect.TypeOf((*MockShardStore)(nil).UpdateShard), arg0, arg1)
 }
+
+// MockTaskStore is a mock of TaskStore interface.
+type MockTaskStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskStoreMockRecorder
+}
+
+// MockTaskStoreMockRecorder is the mock recorder for MockTaskStore.
+type MockTaskStoreMockRecorder struct {
+	mock *MockTaskStore
+}
+
+// NewMockTaskStore creates a new mock instance.
+func NewMockTaskStore(ctrl *gomock.Controller) *MockTaskStore {
+	mock := &MockTaskStore{ctrl: ctrl}
+	mock.recorder = &MockTaskStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskStore) EXPECT() *MockTaskStoreMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockTaskStore) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockTaskStoreMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockTaskStore)(nil).Close))
+}
+
+// CompleteTask mocks base method.
+func (m *MockTaskStore) CompleteTask(arg0 context.Context, arg1 *CompleteTaskRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteTask", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteTask indicates an expected call of CompleteTask.
+func (mr *MockTaskStoreMockRecorder) CompleteTask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteTask", reflect.TypeOf((*MockTaskStore)(nil).CompleteTask), arg0, arg1)
+}
+
+// CompleteTasksLessThan mocks base method.
+func (m *MockTaskStore) CompleteTasksLessThan(arg0 context.Context, arg1 *CompleteTasksLessThanRequest) (*CompleteTasksLessThanResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteTasksLessThan", arg0, arg1)
+	ret0, _ := ret[0].(*CompleteTasksLessThanResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteTasksLessThan indicates an expected call of CompleteTasksLessThan.
+func (mr *MockTaskStoreMockRecorder) CompleteTasksLessThan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteTasksLessThan", reflect.TypeOf((*MockTaskStore)(nil).CompleteTasksLessThan), arg0, arg1)
+}
+
+// CreateTasks mocks base method.
+func (m *MockTaskStore) CreateTasks(arg0 context.Context, arg1 *InternalCreateTasksRequest) (*CreateTasksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTasks", arg0, arg1)
+	ret0, _ := ret[0].(*CreateTasksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTasks indicates an expected call of CreateTasks.
+func (mr *MockTaskStoreMockRecorder) CreateTasks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTasks", reflect.TypeOf((*MockTaskStore)(nil).CreateTasks), arg0, arg1)
+}
+
+// DeleteTaskList mocks base method.
+func (m *MockTaskStore) DeleteTaskList(arg0 context.Context, arg1 *DeleteTaskListRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskList", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskList indicates an expected call of DeleteTaskList.
+func (mr *MockTaskStoreMockRecorder) DeleteTaskList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskList", reflect.TypeOf((*MockTaskStore)(nil).DeleteTaskList), arg0, arg1)
+}
+
+// GetName mocks base method.
+func (m *MockTaskStore) GetName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetName indicates an expected call of GetName.
+func (mr *MockTaskStoreMockRecorder) GetName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetName", reflect.TypeOf((*MockTaskStore)(nil).GetName))
+}
+
+// GetOrphanTasks mocks base method.
+func (m *MockTaskStore) GetOrphanTasks(arg0 context.Context, arg1 *GetOrphanTasksRequest) (*GetOrphanTasksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrphanTasks", arg0, arg1)
+	ret0, _ := ret[0].(*GetOrphanTasksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrphanTasks indicates an expected call of GetOrphanTasks.
+func (mr *MockTaskStoreMockRecorder) GetOrphanTasks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrphanTasks", reflect.TypeOf((*MockTaskStore)(nil).GetOrphanTasks), arg0, arg1)
+}
+
+// GetTaskIDRange mocks base method.
+func (m *MockTaskStore) GetTaskIDRange(arg0 context.Context, arg1 *GetTaskIDRangeRequest) (*GetTaskIDRangeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskIDRange", arg0, arg1)
+	ret0, _ := ret[0].(*GetTaskIDRangeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskIDRange indicates an expected call of GetTaskIDRange.
+func (mr *MockTaskStoreMockRecorder) GetTaskIDRange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskIDRange", reflect.TypeOf((*MockTaskStore)(nil).GetTaskIDRange), arg0, arg1)
+}
+
+// GetTaskListSize mocks base method.
+func (m *MockTaskStore) GetTaskListSize(arg0 context.Context, arg1 *GetTaskListSizeRequest) (*GetTaskListSizeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskListSize", arg0, arg1)
+	ret0, _ := ret[0].(*GetTaskListSizeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskListSize indicates an expected call of GetTaskListSize.
+func (mr *MockTaskStoreMockRecorder) GetTaskListSize(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskListSize", reflect.TypeOf((*MockTaskStore)(nil).GetTaskListSize), arg0, arg1)
+}
+
+// GetTaskListsByDomain mocks base method.
+func (m *MockTaskStore) GetTaskListsByDomain(arg0 context.Context, arg1 *GetTaskListsByDomainRequest) (*GetTaskListsByDomainResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskListsByDomain", arg0, arg1)
+	ret0, _ := ret[0].(*GetTaskListsByDomainResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskListsByDomain indicates an expected call of GetTaskListsByDomain.
+func (mr *MockTaskStoreMockRecorder) GetTaskListsByDomain(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskListsByDomain", reflect.TypeOf((*MockTaskStore)(nil).GetTaskListsByDomain), arg0, arg1)
+}
+
+// GetTasks mocks base method.
+func (m *MockTaskStore) GetTasks(arg0 context.Context, arg1 *GetTasksRequest) (*InternalGetTasksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasks", arg0, arg1)
+	ret0, _ := ret[0].(*InternalGetTasksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasks indicates an expected call of GetTasks.
+func (mr *MockTaskStoreMockRecorder) GetTasks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockTaskStore)(nil).GetTasks), arg0, arg1)
+}
+
+// LeaseTaskList mocks base method.
+func (m *MockTaskStore) LeaseTaskList(arg0 context.Context, arg1 *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaseTaskList", arg0, arg1)
+	ret0, _ := ret[0].(*LeaseTaskListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LeaseTaskList indicates an expected call of LeaseTaskList.
+func (mr *MockTaskStoreMockRecorder) LeaseTaskList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaseTaskList", reflect.TypeOf((*MockTaskStore)(nil).LeaseTaskList), arg0, arg1)
+}
+
+// ListTaskList mocks base method.
+func (m *MockTaskStore) ListTaskList(arg0 context.Context, arg1 *ListTaskListRequest) (*ListTaskListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskList", arg0, arg1)
+	ret0, _ := ret[0].(*ListTaskListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskList indicates an expected call of ListTaskList.
+func (mr *MockTaskStoreMockRecorder) ListTaskList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskList", reflect.TypeOf((*MockTaskStore)(nil).ListTaskList), arg0, arg1)
+}
+
+// UpdateTaskList mocks base method.
+func (m *MockTaskStore) UpdateTaskList(arg0 context.Context, arg1 *UpdateTaskListRequest) (*UpdateTaskListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskList", arg0, arg1)
+	ret0, _ := ret[0].(*UpdateTaskListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaskList indicates an expected call of UpdateTaskList.
+func (mr *MockTaskStoreMockRecorder) UpdateTaskList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskList", reflect.TypeOf((*MockTaskStore)(nil).UpdateTaskList), arg0, arg1)
+}
+
+// MockHistoryStore is a mock of HistoryStore interface.
+type MockHistoryStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryStoreMockRecorder
+}
+
+// MockHistoryStoreMockRecorder is the mock recorder for MockHistoryStore.
+type MockHistoryStoreMockRecorder struct {
+	mock *MockHistoryStore
+}
+
+// NewMockHistoryStore creates a new mock instance.
+func NewMockHistoryStore(ctrl *gomock.Controller) *MockHistoryStore {
+	mock := &MockHistoryStore{ctrl: ctrl}
+	mock.recorder = &MockHistoryStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryStore) EXPECT() *MockHistoryStoreMockRecorder {
+	return m.recorder
+}
+
+// AppendHistoryNodes mocks base method.
+func (m *MockHistoryStore) AppendHistoryNodes(arg0 context.Context, arg1 *InternalAppendHistoryNodesRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendHistoryNodes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendHistoryNodes indicates an expected call of AppendHistoryNodes.
+func (mr *MockHistoryStoreMockRecorder) AppendHistoryNodes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendHistoryNodes", reflect.TypeOf((*MockHistoryStore)(nil).AppendHistoryNodes), arg0, arg1)
+}
+
+// BatchDeleteHistoryBranch mocks base method.
+func (m *MockHistoryStore) BatchDeleteHistoryBranch(arg0 context.Context, arg1 []InternalDeleteHistoryBranchRequest) (*InternalBatchDeleteHistoryBranchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDeleteHistoryBranch", arg0, arg1)
+	ret0, _ := ret[0].(*InternalBatchDeleteHistoryBranchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchDeleteHistoryBranch indicates an expected call of BatchDeleteHistoryBranch.
+func (mr *MockHistoryStoreMockRecorder) BatchDeleteHistoryBranch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDeleteHistoryBranch", reflect.TypeOf((*MockHistoryStore)(nil).BatchDeleteHistoryBranch), arg0, arg1)
+}
+
+// Close mocks base method.
+func (m *MockHistoryStore) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockHistoryStoreMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockHistoryStore)(nil).Close))
+}
+
+// DeleteHistoryBranch mocks base method.
+func (m *MockHistoryStore) DeleteHistoryBranch(arg0 context.Context, arg1 *InternalDeleteHistoryBranchRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHistoryBranch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHistoryBranch indicates an expected call of DeleteHistoryBranch.
+func (mr *MockHistoryStoreMockRecorder) DeleteHistoryBranch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHistoryBranch", reflect.TypeOf((*MockHistoryStore)(nil).DeleteHistoryBranch), arg0, arg1)
+}
+
+// ForkHistoryBranch mocks base method.
+func (m *MockHistoryStore) ForkHistoryBranch(arg0 context.Context, arg1 *InternalForkHistoryBranchRequest) (*InternalForkHistoryBranchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForkHistoryBranch", arg0, arg1)
+	ret0, _ := ret[0].(*InternalForkHistoryBranchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForkHistoryBranch indicates an expected call of ForkHistoryBranch.
+func (mr *MockHistoryStoreMockRecorder) ForkHistoryBranch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForkHistoryBranch", reflect.TypeOf((*MockHistoryStore)(nil).ForkHistoryBranch), arg0, arg1)
+}
+
+// GetAllHistoryTreeBranches mocks base method.
+func (m *MockHistoryStore) GetAllHistoryTreeBranches(arg0 context.Context, arg1 *GetAllHistoryTreeBranchesRequest) (*GetAllHistoryTreeBranchesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllHistoryTreeBranches", arg0, arg1)
+	ret0, _ := ret[0].(*GetAllHistoryTreeBranchesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllHistoryTreeBranches indicates an expected call of GetAllHistoryTreeBranches.
+func (mr *MockHistoryStoreMockRecorder) GetAllHistoryTreeBranches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllHistoryTreeBranches", reflect.TypeOf((*MockHistoryStore)(nil).GetAllHistoryTreeBranches), arg0, arg1)
+}
+
+// GetHistoryTree mocks base method.
+func (m *MockHistoryStore) GetHistoryTree(arg0 context.Context, arg1 *InternalGetHistoryTreeRequest) (*InternalGetHistoryTreeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoryTree", arg0, arg1)
+	ret0, _ := ret[0].(*InternalGetHistoryTreeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoryTree indicates an expected call of GetHistoryTree.
+func (mr *MockHistoryStoreMockRecorder) GetHistoryTree(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoryTree", reflect.TypeOf((*MockHistoryStore)(nil).GetHistoryTree), arg0, arg1)
+}
+
+// GetName mocks base method.
+func (m *MockHistoryStore) GetName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetName indicates an expected call of GetName.
+func (mr *MockHistoryStoreMockRecorder) GetName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetName", reflect.TypeOf((*MockHistoryStore)(nil).GetName))
+}
+
+// ReadHistoryBranch mocks base method.
+func (m *MockHistoryStore) ReadHistoryBranch(arg0 context.Context, arg1 *InternalReadHistoryBranchRequest) (*InternalReadHistoryBranchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadHistoryBranch", arg0, arg1)
+	ret0, _ := ret[0].(*InternalReadHistoryBranchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadHistoryBranch indicates an expected call of ReadHistoryBranch.
+func (mr *MockHistoryStoreMockRecorder) ReadHistoryBranch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadHistoryBranch", reflect.TypeOf((*MockHistoryStore)(nil).ReadHistoryBranch), arg0, arg1)
+}
+
+// TruncateHistoryBranch mocks base method.
+func (m *MockHistoryStore) TruncateHistoryBranch(arg0 context.Context, arg1 *InternalTruncateHistoryBranchRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TruncateHistoryBranch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TruncateHistoryBranch indicates an expected call of TruncateHistoryBranch.
+func (mr *MockHistoryStoreMockRecorder) TruncateHistoryBranch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TruncateHistoryBranch", reflect.TypeOf((*MockHistoryStore)(nil).TruncateHistoryBranch), arg0, arg1)
+}
+
+// MockDomainStore is a mock of DomainStore interface.
+type MockDomainStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockDomainStoreMockRecorder
+}
+
+// MockDomainStoreMockRecorder is the mock recorder for MockDomainStore.
+type MockDomainStoreMockRecorder struct {
+	mock *MockDomainStore
+}
+
+// NewMockDomainStore creates a new mock instance.
+func NewMockDomainStore(ctrl *gomock.Controller) *MockDomainStore {
+	mock := &MockDomainStore{ctrl: ctrl}
+	mock.recorder = &MockDomainStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDomainStore) EXPECT() *MockDomainStoreMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockDomainStore) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockDomainStoreMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDomainStore)(nil).Close))
+}
+
+// CreateDomain mocks base method.
+func (m *MockDomainStore) CreateDomain(arg0 context.Context, arg1 *InternalCreateDomainRequest) (*CreateDomainResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDomain", arg0, arg1)
+	ret0, _ := ret[0].(*CreateDomainResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDomain indicates an expected call of CreateDomain.
+func (mr *MockDomainStoreMockRecorder) CreateDomain(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDomain", reflect.TypeOf((*MockDomainStore)(nil).CreateDomain), arg0, arg1)
+}
+
+// DeleteDomain mocks base method.
+func (m *MockDomainStore) DeleteDomain(arg0 context.Context, arg1 *DeleteDomainRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDomain", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDomain indicates an expected call of DeleteDomain.
+func (mr *MockDomainStoreMockRecorder) DeleteDomain(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDomain", reflect.TypeOf((*MockDomainStore)(nil).DeleteDomain), arg0, arg1)
+}
+
+// DeleteDomainByName mocks base method.
+func (m *MockDomainStore) DeleteDomainByName(arg0 context.Context, arg1 *DeleteDomainByNameRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDomainByName", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDomainByName indicates an expected call of DeleteDomainByName.
+func (mr *MockDomainStoreMockRecorder) DeleteDomainByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDomainByName", reflect.TypeOf((*MockDomainStore)(nil).DeleteDomainByName), arg0, arg1)
+}
+
+// GetDomain mocks base method.
+func (m *MockDomainStore) GetDomain(arg0 context.Context, arg1 *GetDomainRequest) (*InternalGetDomainResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDomain", arg0, arg1)
+	ret0, _ := ret[0].(*InternalGetDomainResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDomain indicates an expected call of GetDomain.
+func (mr *MockDomainStoreMockRecorder) GetDomain(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDomain", reflect.TypeOf((*MockDomainStore)(nil).GetDomain), arg0, arg1)
+}
+
+// GetMetadata mocks base method.
+func (m *MockDomainStore) GetMetadata(arg0 context.Context) (*GetMetadataResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetadata", arg0)
+	ret0, _ := ret[0].(*GetMetadataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetadata indicates an expected call of GetMetadata.
+func (mr *MockDomainStoreMockRecorder) GetMetadata(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadata", reflect.TypeOf((*MockDomainStore)(nil).GetMetadata), arg0)
+}
+
+// GetName mocks base method.
+func (m *MockDomainStore) GetName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetName indicates an expected call of GetName.
+func (mr *MockDomainStoreMockRecorder) GetName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetName", reflect.TypeOf((*MockDomainStore)(nil).GetName))
+}
+
+// ListDomains mocks base method.
+func (m *MockDomainStore) ListDomains(arg0 context.Context, arg1 *ListDomainsRequest) (*InternalListDomainsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDomains", arg0, arg1)
+	ret0, _ := ret[0].(*InternalListDomainsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDomains indicates an expected call of ListDomains.
+func (mr *MockDomainStoreMockRecorder) ListDomains(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDomains", reflect.TypeOf((*MockDomainStore)(nil).ListDomains), arg0, arg1)
+}
+
+// ListDomainsByIsolationGroup mocks base method.
+func (m *MockDomainStore) ListDomainsByIsolationGroup(arg0 context.Context, arg1 string) ([]*InternalGetDomainResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDomainsByIsolationGroup", arg0, arg1)
+	ret0, _ := ret[0].([]*InternalGetDomainResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDomainsByIsolationGroup indicates an expected call of ListDomainsByIsolationGroup.
+func (mr *MockDomainStoreMockRecorder) ListDomainsByIsolationGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDomainsByIsolationGroup", reflect.TypeOf((*MockDomainStore)(nil).ListDomainsByIsolationGroup), arg0, arg1)
+}
+
+// MarkDomainDeleted mocks base method.
+func (m *MockDomainStore) MarkDomainDeleted(arg0 context.Context, arg1 *MarkDomainDeletedRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDomainDeleted", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDomainDeleted indicates an expected call of MarkDomainDeleted.
+func (mr *MockDomainStoreMockRecorder) MarkDomainDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDomainDeleted", reflect.TypeOf((*MockDomainStore)(nil).MarkDomainDeleted), arg0, arg1)
+}
+
+// PurgeDeletedDomains mocks base method.
+func (m *MockDomainStore) PurgeDeletedDomains(arg0 context.Context, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedDomains", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeDeletedDomains indicates an expected call of PurgeDeletedDomains.
+func (mr *MockDomainStoreMockRecorder) PurgeDeletedDomains(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedDomains", reflect.TypeOf((*MockDomainStore)(nil).PurgeDeletedDomains), arg0, arg1)
+}
+
+// UpdateDomain mocks base method.
+func (m *MockDomainStore) UpdateDomain(arg0 context.Context, arg1 *InternalUpdateDomainRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDomain", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDomain indicates an expected call of UpdateDomain.
+func (mr *MockDomainStoreMockRecorder) UpdateDomain(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDomain", reflect.TypeOf((*MockDomainStore)(nil).UpdateDomain), arg0, arg1)
+}
@@ -119,6 +119,7 @@ func TestDataBlob(t *testing.T) {
 		same(common.EncodingTypeGob)
 		same(common.EncodingTypeJSON)
 		same(common.EncodingTypeThriftRW)
+		same(common.EncodingTypeThriftRWSnappy)
 		same(common.EncodingTypeEmpty)
 
 		// highly suspicious
@@ -167,6 +167,7 @@ func (m *domainManagerImpl) ListDomains(
 			FailoverVersion:             d.FailoverVersion,
 			FailoverNotificationVersion: d.FailoverNotificationVersion,
 			PreviousFailoverVersion:     d.PreviousFailoverVersion,
+			LastUpdatedTime:             d.LastUpdatedTime.UnixNano(),
 			NotificationVersion:         d.NotificationVersion,
 		}
 		if d.FailoverEndTime != nil {
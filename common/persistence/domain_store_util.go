@@ -0,0 +1,197 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	purgeDeletedDomainsPageSize         = 100
+	listDomainsByIsolationGroupPageSize = 100
+)
+
+// SoftDeletedTime returns the time the domain was soft-deleted via MarkDomainDeleted.
+// The second return value is false if the domain is not soft-deleted.
+func (d *DomainInfo) SoftDeletedTime() (time.Time, bool) {
+	if d == nil {
+		return time.Time{}, false
+	}
+	value, ok := d.Data[common.DomainDataKeyForSoftDeletedTime]
+	if !ok {
+		return time.Time{}, false
+	}
+	deletedAt, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return deletedAt, true
+}
+
+// IsSoftDeleted returns true if the domain was marked as deleted and is waiting to be purged
+func (r *InternalGetDomainResponse) IsSoftDeleted() bool {
+	_, ok := r.Info.SoftDeletedTime()
+	return ok
+}
+
+// toMarkDeletedRequest builds the update request which records the deletion time in the domain data
+func (r *InternalGetDomainResponse) toMarkDeletedRequest(
+	notificationVersion int64,
+	deletedAt time.Time,
+) *InternalUpdateDomainRequest {
+	info := *r.Info
+	info.Data = make(map[string]string, len(r.Info.Data)+1)
+	for k, v := range r.Info.Data {
+		info.Data[k] = v
+	}
+	info.Data[common.DomainDataKeyForSoftDeletedTime] = deletedAt.UTC().Format(time.RFC3339Nano)
+	return &InternalUpdateDomainRequest{
+		Info:                        &info,
+		Config:                      r.Config,
+		ReplicationConfig:           r.ReplicationConfig,
+		ConfigVersion:               r.ConfigVersion,
+		FailoverVersion:             r.FailoverVersion,
+		FailoverNotificationVersion: r.FailoverNotificationVersion,
+		PreviousFailoverVersion:     r.PreviousFailoverVersion,
+		FailoverEndTime:             r.FailoverEndTime,
+		LastUpdatedTime:             deletedAt,
+		NotificationVersion:         notificationVersion,
+	}
+}
+
+// FilterSoftDeletedDomains removes soft-deleted domains from the list
+func FilterSoftDeletedDomains(domains []*InternalGetDomainResponse) []*InternalGetDomainResponse {
+	var result []*InternalGetDomainResponse
+	for _, d := range domains {
+		if !d.IsSoftDeleted() {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// MarkDomainDeleted implements DomainStore.MarkDomainDeleted on top of the other DomainStore methods.
+// Only local domains can be soft-deleted: the change is made directly in persistence and would not
+// be replicated to the other clusters of a global domain.
+// Marking an already soft-deleted domain is a no-op, so the grace window is not extended.
+func MarkDomainDeleted(
+	ctx context.Context,
+	store DomainStore,
+	request *MarkDomainDeletedRequest,
+) error {
+	metadata, err := store.GetMetadata(ctx)
+	if err != nil {
+		return err
+	}
+	domain, err := store.GetDomain(ctx, &GetDomainRequest{ID: request.ID, Name: request.Name})
+	if err != nil {
+		return err
+	}
+	if domain.IsGlobalDomain {
+		return &types.BadRequestError{
+			Message: fmt.Sprintf("Cannot soft-delete global domain %v in persistence, deprecate it through the domain API instead.", domain.Info.Name),
+		}
+	}
+	if domain.IsSoftDeleted() {
+		return nil
+	}
+	return store.UpdateDomain(ctx, domain.toMarkDeletedRequest(metadata.NotificationVersion, request.DeletedAt))
+}
+
+// PurgeDeletedDomains implements DomainStore.PurgeDeletedDomains on top of the other DomainStore methods.
+// It hard-deletes the domains which were soft-deleted before olderThan.
+func PurgeDeletedDomains(
+	ctx context.Context,
+	store DomainStore,
+	olderThan time.Time,
+) error {
+	// collect all candidates before deleting so that paging is not affected by the deletes
+	var toPurge []string
+	var token []byte
+	for {
+		resp, err := store.ListDomains(ctx, &ListDomainsRequest{
+			PageSize:       purgeDeletedDomainsPageSize,
+			NextPageToken:  token,
+			IncludeDeleted: true,
+		})
+		if err != nil {
+			return err
+		}
+		for _, domain := range resp.Domains {
+			if deletedAt, ok := domain.Info.SoftDeletedTime(); ok && deletedAt.Before(olderThan) {
+				toPurge = append(toPurge, domain.Info.ID)
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		token = resp.NextPageToken
+	}
+
+	for _, id := range toPurge {
+		if err := store.DeleteDomain(ctx, &DeleteDomainRequest{ID: id}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListDomainsByIsolationGroup implements DomainStore.ListDomainsByIsolationGroup on top of DomainStore.ListDomains
+func ListDomainsByIsolationGroup(
+	ctx context.Context,
+	store DomainStore,
+	group string,
+) ([]*InternalGetDomainResponse, error) {
+	// isolation groups are stored as an encoded blob, so they have to be decoded and matched while scanning
+	serializer := NewPayloadSerializer()
+	var result []*InternalGetDomainResponse
+	var token []byte
+	for {
+		resp, err := store.ListDomains(ctx, &ListDomainsRequest{
+			PageSize:      listDomainsByIsolationGroupPageSize,
+			NextPageToken: token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, domain := range resp.Domains {
+			ok, err := domain.Config.HasIsolationGroup(serializer, group)
+			if err != nil {
+				return nil, &types.InternalServiceError{
+					Message: fmt.Sprintf("ListDomainsByIsolationGroup failed to decode isolation groups of domain %v: %v", domain.Info.Name, err),
+				}
+			}
+			if ok {
+				result = append(result, domain)
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		token = resp.NextPageToken
+	}
+	return result, nil
+}
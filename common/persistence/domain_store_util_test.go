@@ -0,0 +1,297 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestDomainInfoSoftDeletedTime(t *testing.T) {
+	deletedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		info     *DomainInfo
+		expected time.Time
+		ok       bool
+	}{
+		"nil info": {
+			info: nil,
+		},
+		"not soft-deleted": {
+			info: &DomainInfo{Status: DomainStatusDeleted},
+		},
+		"invalid deletion time": {
+			info: &DomainInfo{Data: map[string]string{common.DomainDataKeyForSoftDeletedTime: "yesterday"}},
+		},
+		"soft-deleted": {
+			info:     &DomainInfo{Data: map[string]string{common.DomainDataKeyForSoftDeletedTime: deletedAt.Format(time.RFC3339Nano)}},
+			expected: deletedAt,
+			ok:       true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, ok := test.info.SoftDeletedTime()
+			assert.Equal(t, test.ok, ok)
+			assert.True(t, test.expected.Equal(actual))
+		})
+	}
+}
+
+func TestMarkDomainDeleted(t *testing.T) {
+	deletedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	localDomain := func() *InternalGetDomainResponse {
+		return &InternalGetDomainResponse{
+			Info:              &DomainInfo{ID: "domain-id", Name: "domain", Data: map[string]string{"k": "v"}},
+			Config:            &InternalDomainConfig{},
+			ReplicationConfig: &DomainReplicationConfig{ActiveClusterName: "active"},
+			ConfigVersion:     3,
+			FailoverVersion:   4,
+		}
+	}
+
+	tests := map[string]struct {
+		setupMock   func(*MockDomainStore)
+		expectedErr error
+	}{
+		"Success": {
+			setupMock: func(store *MockDomainStore) {
+				domain := localDomain()
+				store.EXPECT().GetMetadata(gomock.Any()).Return(&GetMetadataResponse{NotificationVersion: 7}, nil)
+				store.EXPECT().GetDomain(gomock.Any(), &GetDomainRequest{Name: "domain"}).Return(domain, nil)
+				store.EXPECT().UpdateDomain(gomock.Any(), &InternalUpdateDomainRequest{
+					Info: &DomainInfo{ID: "domain-id", Name: "domain", Data: map[string]string{
+						"k":                                    "v",
+						common.DomainDataKeyForSoftDeletedTime: "2024-03-01T12:00:00Z",
+					}},
+					Config:              domain.Config,
+					ReplicationConfig:   domain.ReplicationConfig,
+					ConfigVersion:       3,
+					FailoverVersion:     4,
+					LastUpdatedTime:     deletedAt,
+					NotificationVersion: 7,
+				}).DoAndReturn(func(context.Context, *InternalUpdateDomainRequest) error {
+					// the data of the domain returned by the store must not be modified
+					assert.Equal(t, map[string]string{"k": "v"}, domain.Info.Data)
+					return nil
+				})
+			},
+		},
+		"Global domain": {
+			setupMock: func(store *MockDomainStore) {
+				domain := localDomain()
+				domain.IsGlobalDomain = true
+				store.EXPECT().GetMetadata(gomock.Any()).Return(&GetMetadataResponse{NotificationVersion: 7}, nil)
+				store.EXPECT().GetDomain(gomock.Any(), &GetDomainRequest{Name: "domain"}).Return(domain, nil)
+			},
+			expectedErr: &types.BadRequestError{Message: "Cannot soft-delete global domain domain in persistence, deprecate it through the domain API instead."},
+		},
+		"Already soft-deleted": {
+			setupMock: func(store *MockDomainStore) {
+				domain := localDomain()
+				domain.Info.Data[common.DomainDataKeyForSoftDeletedTime] = "2024-02-01T12:00:00Z"
+				store.EXPECT().GetMetadata(gomock.Any()).Return(&GetMetadataResponse{NotificationVersion: 7}, nil)
+				store.EXPECT().GetDomain(gomock.Any(), &GetDomainRequest{Name: "domain"}).Return(domain, nil)
+			},
+		},
+		"GetDomain failure": {
+			setupMock: func(store *MockDomainStore) {
+				store.EXPECT().GetMetadata(gomock.Any()).Return(&GetMetadataResponse{NotificationVersion: 7}, nil)
+				store.EXPECT().GetDomain(gomock.Any(), &GetDomainRequest{Name: "domain"}).Return(nil, &types.EntityNotExistsError{})
+			},
+			expectedErr: &types.EntityNotExistsError{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := NewMockDomainStore(ctrl)
+			test.setupMock(store)
+
+			err := MarkDomainDeleted(context.Background(), store, &MarkDomainDeletedRequest{Name: "domain", DeletedAt: deletedAt})
+			assert.Equal(t, test.expectedErr, err)
+		})
+	}
+}
+
+func TestPurgeDeletedDomains(t *testing.T) {
+	now := time.Now()
+	olderThan := now.Add(-time.Hour)
+	softDeleted := func(id string, deletedAt time.Time) *InternalGetDomainResponse {
+		return &InternalGetDomainResponse{Info: &DomainInfo{
+			ID:   id,
+			Data: map[string]string{common.DomainDataKeyForSoftDeletedTime: deletedAt.Format(time.RFC3339Nano)},
+		}}
+	}
+
+	tests := map[string]struct {
+		setupMock   func(*MockDomainStore)
+		expectedErr error
+	}{
+		"Success": {
+			setupMock: func(store *MockDomainStore) {
+				store.EXPECT().ListDomains(gomock.Any(), &ListDomainsRequest{
+					PageSize:       purgeDeletedDomainsPageSize,
+					IncludeDeleted: true,
+				}).Return(&InternalListDomainsResponse{
+					Domains: []*InternalGetDomainResponse{
+						{Info: &DomainInfo{ID: "registered"}, LastUpdatedTime: now.Add(-2 * time.Hour)},
+						{Info: &DomainInfo{ID: "deleted-status", Status: DomainStatusDeleted}, LastUpdatedTime: now.Add(-2 * time.Hour)},
+						softDeleted("expired-1", now.Add(-2*time.Hour)),
+					},
+					NextPageToken: []byte("token"),
+				}, nil)
+				store.EXPECT().ListDomains(gomock.Any(), &ListDomainsRequest{
+					PageSize:       purgeDeletedDomainsPageSize,
+					NextPageToken:  []byte("token"),
+					IncludeDeleted: true,
+				}).Return(&InternalListDomainsResponse{
+					Domains: []*InternalGetDomainResponse{
+						softDeleted("recent", now.Add(-time.Minute)),
+						softDeleted("expired-2", now.Add(-3*time.Hour)),
+					},
+				}, nil)
+				store.EXPECT().DeleteDomain(gomock.Any(), &DeleteDomainRequest{ID: "expired-1"}).Return(nil)
+				store.EXPECT().DeleteDomain(gomock.Any(), &DeleteDomainRequest{ID: "expired-2"}).Return(nil)
+			},
+		},
+		"ListDomains failure": {
+			setupMock: func(store *MockDomainStore) {
+				store.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(nil, errors.New("list failed"))
+			},
+			expectedErr: errors.New("list failed"),
+		},
+		"DeleteDomain failure": {
+			setupMock: func(store *MockDomainStore) {
+				store.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(&InternalListDomainsResponse{
+					Domains: []*InternalGetDomainResponse{softDeleted("expired", now.Add(-2*time.Hour))},
+				}, nil)
+				store.EXPECT().DeleteDomain(gomock.Any(), &DeleteDomainRequest{ID: "expired"}).Return(errors.New("delete failed"))
+			},
+			expectedErr: errors.New("delete failed"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := NewMockDomainStore(ctrl)
+			test.setupMock(store)
+
+			err := PurgeDeletedDomains(context.Background(), store, olderThan)
+			assert.Equal(t, test.expectedErr, err)
+		})
+	}
+}
+
+func TestListDomainsByIsolationGroup(t *testing.T) {
+	serializer := NewPayloadSerializer()
+	withGroup, err := serializer.SerializeIsolationGroups(&types.IsolationGroupConfiguration{
+		"zone-a": {Name: "zone-a", State: types.IsolationGroupStateDrained},
+	}, common.EncodingTypeThriftRW)
+	assert.NoError(t, err)
+	withOtherGroup, err := serializer.SerializeIsolationGroups(&types.IsolationGroupConfiguration{
+		"zone-b": {Name: "zone-b", State: types.IsolationGroupStateDrained},
+	}, common.EncodingTypeThriftRW)
+	assert.NoError(t, err)
+	domain := func(name string, isolationGroups *DataBlob) *InternalGetDomainResponse {
+		return &InternalGetDomainResponse{
+			Info:   &DomainInfo{Name: name},
+			Config: &InternalDomainConfig{IsolationGroups: isolationGroups},
+		}
+	}
+
+	tests := map[string]struct {
+		setupMock   func(*MockDomainStore)
+		expected    []string
+		expectedErr bool
+	}{
+		"Success": {
+			setupMock: func(store *MockDomainStore) {
+				store.EXPECT().ListDomains(gomock.Any(), &ListDomainsRequest{
+					PageSize: listDomainsByIsolationGroupPageSize,
+				}).Return(&InternalListDomainsResponse{
+					Domains: []*InternalGetDomainResponse{
+						domain("domain-with-group", withGroup),
+						domain("domain-with-other-group", withOtherGroup),
+					},
+					NextPageToken: []byte("token"),
+				}, nil)
+				store.EXPECT().ListDomains(gomock.Any(), &ListDomainsRequest{
+					PageSize:      listDomainsByIsolationGroupPageSize,
+					NextPageToken: []byte("token"),
+				}).Return(&InternalListDomainsResponse{
+					Domains: []*InternalGetDomainResponse{
+						domain("domain-without-groups", nil),
+						domain("another-domain-with-group", withGroup),
+					},
+				}, nil)
+			},
+			expected: []string{"domain-with-group", "another-domain-with-group"},
+		},
+		"Decode failure": {
+			setupMock: func(store *MockDomainStore) {
+				store.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(&InternalListDomainsResponse{
+					Domains: []*InternalGetDomainResponse{
+						domain("corrupted", &DataBlob{Data: []byte("corrupted"), Encoding: common.EncodingTypeThriftRW}),
+					},
+				}, nil)
+			},
+			expectedErr: true,
+		},
+		"ListDomains failure": {
+			setupMock: func(store *MockDomainStore) {
+				store.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(nil, errors.New("list failed"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := NewMockDomainStore(ctrl)
+			test.setupMock(store)
+
+			domains, err := ListDomainsByIsolationGroup(context.Background(), store, "zone-a")
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			var names []string
+			for _, d := range domains {
+				names = append(names, d.Info.Name)
+			}
+			assert.Equal(t, test.expected, names)
+		})
+	}
+}
@@ -83,8 +83,17 @@ type (
 		RequestType WorkflowRequestType
 		RunID       string
 	}
+
+	// InconsistentVersionHistoriesError is returned when persisted version histories fail validation
+	InconsistentVersionHistoriesError struct {
+		Msg string
+	}
 )
 
+func (e *InconsistentVersionHistoriesError) Error() string {
+	return e.Msg
+}
+
 func (e *DuplicateRequestError) Error() string {
 	return fmt.Sprintf("Request has already been applied to runID: %s", e.RunID)
 }
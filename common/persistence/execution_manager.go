@@ -25,6 +25,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/golang/snappy"
+
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/types"
@@ -37,6 +39,7 @@ type (
 		persistence   ExecutionStore
 		statsComputer statsComputer
 		logger        log.Logger
+		dc            *DynamicConfiguration
 	}
 )
 
@@ -47,12 +50,14 @@ func NewExecutionManagerImpl(
 	persistence ExecutionStore,
 	logger log.Logger,
 	serializer PayloadSerializer,
+	dc *DynamicConfiguration,
 ) ExecutionManager {
 	return &executionManagerImpl{
 		serializer:    serializer,
 		persistence:   persistence,
 		statsComputer: statsComputer{},
 		logger:        logger,
+		dc:            dc,
 	}
 }
 
@@ -219,6 +224,19 @@ func (m *executionManagerImpl) DeserializeBufferedEvents(
 	return events, nil
 }
 
+// compressBufferedEvents snappy compresses thriftrw encoded buffered events larger than the configured threshold.
+// The serializer decompresses them transparently on read.
+func (m *executionManagerImpl) compressBufferedEvents(blob *DataBlob) *DataBlob {
+	if blob == nil || blob.Encoding != common.EncodingTypeThriftRW || m.dc == nil || m.dc.BufferedEventsCompressionThreshold == nil {
+		return blob
+	}
+	threshold := m.dc.BufferedEventsCompressionThreshold()
+	if threshold <= 0 || len(blob.Data) <= threshold {
+		return blob
+	}
+	return NewDataBlob(snappy.Encode(nil, blob.Data), common.EncodingTypeThriftRWSnappy)
+}
+
 func (m *executionManagerImpl) DeserializeChildExecutionInfos(
 	infos map[int64]*InternalChildExecutionInfo,
 ) (map[int64]*ChildExecutionInfo, error) {
@@ -599,6 +617,7 @@ func (m *executionManagerImpl) CreateWorkflowExecution(
 		NewWorkflowSnapshot: *serializedNewWorkflowSnapshot,
 
 		WorkflowRequestMode: request.WorkflowRequestMode,
+		DedupKey:            request.DedupKey,
 	}
 
 	msuss := m.statsComputer.computeMutableStateCreateStats(newRequest)
@@ -640,6 +659,7 @@ func (m *executionManagerImpl) SerializeWorkflowMutation(
 		if err != nil {
 			return nil, err
 		}
+		serializedNewBufferedEvents = m.compressBufferedEvents(serializedNewBufferedEvents)
 	}
 
 	startVersion, err := getStartVersion(input.VersionHistories)
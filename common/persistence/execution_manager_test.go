@@ -30,10 +30,12 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/snappy"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/checksum"
+	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/log/testlogger"
 	"github.com/uber/cadence/common/types"
 )
@@ -185,7 +187,7 @@ func TestExecutionManager_ProxyStoreMethods(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockedStore := NewMockExecutionStore(ctrl)
 			tc.prepareMocks(mockedStore)
-			manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), nil)
+			manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), nil, nil)
 			v := reflect.ValueOf(manager)
 			method := v.MethodByName(tc.method)
 			methodType := method.Type()
@@ -261,7 +263,7 @@ func TestGetReplicationTasks(t *testing.T) {
 
 			mockedStore := NewMockExecutionStore(ctrl)
 			tc.prepareMocks(mockedStore)
-			manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), nil)
+			manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), nil, nil)
 			res, err := manager.GetReplicationTasks(context.Background(), &GetReplicationTasksRequest{})
 			tc.checkRes(t, res, err)
 		})
@@ -273,7 +275,7 @@ func TestExecutionManager_GetWorkflowExecution(t *testing.T) {
 	mockedStore := NewMockExecutionStore(ctrl)
 	mockedSerializer := NewMockPayloadSerializer(ctrl)
 
-	manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), mockedSerializer)
+	manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), mockedSerializer, nil)
 
 	request := &GetWorkflowExecutionRequest{
 		DomainID: testDomainID,
@@ -360,7 +362,7 @@ func TestExecutionManager_GetWorkflowExecution_NoWorkflow(t *testing.T) {
 	mockedStore := NewMockExecutionStore(ctrl)
 	mockedSerializer := NewMockPayloadSerializer(ctrl)
 
-	manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), mockedSerializer)
+	manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), mockedSerializer, nil)
 
 	request := &GetWorkflowExecutionRequest{
 		DomainID: "testDomain",
@@ -383,7 +385,7 @@ func TestExecutionManager_UpdateWorkflowExecution(t *testing.T) {
 	mockedStore := NewMockExecutionStore(ctrl)
 	mockedSerializer := NewMockPayloadSerializer(ctrl)
 
-	manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), mockedSerializer)
+	manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), mockedSerializer, nil)
 
 	expectedInfo := sampleInternalWorkflowMutation()
 
@@ -579,7 +581,7 @@ func TestSerializeWorkflowSnapshot(t *testing.T) {
 
 			mockedSerializer := NewMockPayloadSerializer(ctrl)
 			tc.prepareMocks(mockedSerializer)
-			manager := NewExecutionManagerImpl(nil, testlogger.New(t), mockedSerializer).(*executionManagerImpl)
+			manager := NewExecutionManagerImpl(nil, testlogger.New(t), mockedSerializer, nil).(*executionManagerImpl)
 			res, err := manager.SerializeWorkflowSnapshot(tc.input, common.EncodingTypeThriftRW)
 			tc.checkRes(t, res, err)
 		})
@@ -623,7 +625,7 @@ func TestDeserializeBufferedEvents(t *testing.T) {
 
 			tc.prepareMocks(mockedSerializer)
 
-			manager := NewExecutionManagerImpl(nil, testlogger.New(t), mockedSerializer).(*executionManagerImpl)
+			manager := NewExecutionManagerImpl(nil, testlogger.New(t), mockedSerializer, nil).(*executionManagerImpl)
 
 			events := []*DataBlob{
 				sampleEventData(),
@@ -636,10 +638,82 @@ func TestDeserializeBufferedEvents(t *testing.T) {
 	}
 }
 
+func TestSerializeWorkflowMutation_BufferedEventsCompression(t *testing.T) {
+	signalEvents := func(count int) []*types.HistoryEvent {
+		var events []*types.HistoryEvent
+		for i := 0; i < count; i++ {
+			events = append(events, &types.HistoryEvent{
+				ID:        common.BufferedEventID,
+				EventType: types.EventTypeWorkflowExecutionSignaled.Ptr(),
+				WorkflowExecutionSignaledEventAttributes: &types.WorkflowExecutionSignaledEventAttributes{
+					SignalName: "test-signal",
+					Input:      []byte(fmt.Sprintf("signal payload %d, repeated to make the batch compressible", i)),
+				},
+			})
+		}
+		return events
+	}
+
+	for _, tc := range []struct {
+		name         string
+		events       []*types.HistoryEvent
+		threshold    int
+		wantEncoding common.EncodingType
+	}{
+		{
+			name:         "large batch is compressed",
+			events:       signalEvents(100),
+			threshold:    1024,
+			wantEncoding: common.EncodingTypeThriftRWSnappy,
+		},
+		{
+			name:         "small batch is not compressed",
+			events:       signalEvents(1),
+			threshold:    1024,
+			wantEncoding: common.EncodingTypeThriftRW,
+		},
+		{
+			name:         "compression disabled",
+			events:       signalEvents(100),
+			threshold:    0,
+			wantEncoding: common.EncodingTypeThriftRW,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			serializer := NewPayloadSerializer()
+			manager := NewExecutionManagerImpl(nil, testlogger.New(t), serializer, &DynamicConfiguration{
+				BufferedEventsCompressionThreshold: dynamicconfig.GetIntPropertyFn(tc.threshold),
+			}).(*executionManagerImpl)
+
+			mutation := sampleWorkflowMutation()
+			mutation.NewBufferedEvents = tc.events
+			mutation.ClearBufferedEvents = true
+
+			res, err := manager.SerializeWorkflowMutation(mutation, common.EncodingTypeThriftRW)
+			assert.NoError(t, err)
+			assert.True(t, res.ClearBufferedEvents)
+			assert.Equal(t, tc.wantEncoding, res.NewBufferedEvents.Encoding)
+
+			uncompressed, err := serializer.SerializeBatchEvents(tc.events, common.EncodingTypeThriftRW)
+			assert.NoError(t, err)
+			if tc.wantEncoding == common.EncodingTypeThriftRWSnappy {
+				assert.Less(t, len(res.NewBufferedEvents.Data), len(uncompressed.Data))
+				decompressed, err := snappy.Decode(nil, res.NewBufferedEvents.Data)
+				assert.NoError(t, err)
+				assert.Equal(t, uncompressed.Data, decompressed)
+			}
+
+			events, err := manager.DeserializeBufferedEvents([]*DataBlob{res.NewBufferedEvents})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.events, events)
+		})
+	}
+}
+
 func TestPutReplicationTaskToDLQ(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockedStore := NewMockExecutionStore(ctrl)
-	manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), nil)
+	manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), nil, nil)
 
 	now := time.Now().UTC().Round(time.Second)
 
@@ -672,7 +746,7 @@ func TestPutReplicationTaskToDLQ(t *testing.T) {
 func TestGetReplicationTasksFromDLQ(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockedStore := NewMockExecutionStore(ctrl)
-	manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), nil)
+	manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), nil, nil)
 
 	request := &GetReplicationTasksFromDLQRequest{
 		SourceClusterName: "test-cluster",
@@ -975,7 +1049,7 @@ func TestListConcreteExecutions(t *testing.T) {
 
 			tc.prepareMocks(mockedStore, mockedSerializer)
 
-			manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), mockedSerializer)
+			manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), mockedSerializer, nil)
 
 			res, err := manager.ListConcreteExecutions(context.Background(), request)
 
@@ -1074,7 +1148,7 @@ func TestCreateWorkflowExecution(t *testing.T) {
 				WorkflowRequestMode:      CreateWorkflowRequestModeReplicated,
 			}
 
-			manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), mockedSerializer)
+			manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), mockedSerializer, nil)
 
 			res, err := manager.CreateWorkflowExecution(context.Background(), request)
 
@@ -1219,7 +1293,7 @@ func TestConflictResolveWorkflowExecution(t *testing.T) {
 
 			tc.prepareMocks(mockedStore, mockedSerializer)
 
-			manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), mockedSerializer)
+			manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), mockedSerializer, nil)
 
 			res, err := manager.ConflictResolveWorkflowExecution(context.Background(), tc.request)
 
@@ -23,6 +23,8 @@ package persistence
 import (
 	"context"
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/pborman/uuid"
 
@@ -32,6 +34,7 @@ import (
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/common/types/mapper/thrift"
 )
@@ -45,6 +48,7 @@ type (
 		thriftEncoder         codec.BinaryEncoder
 		pagingTokenSerializer *jsonHistoryTokenSerializer
 		transactionSizeLimit  dynamicconfig.IntPropertyFn
+		metricsClient         metrics.Client
 	}
 )
 
@@ -59,21 +63,38 @@ var (
 
 var _ HistoryManager = (*historyV2ManagerImpl)(nil)
 
+type HistoryManagerOption func(manager *historyV2ManagerImpl)
+
+// WithHistoryMetricsClient enables blob decode metrics of history reads
+func WithHistoryMetricsClient(metricsClient metrics.Client) HistoryManagerOption {
+	return func(manager *historyV2ManagerImpl) {
+		if metricsClient != nil {
+			manager.metricsClient = metricsClient
+		}
+	}
+}
+
 // NewHistoryV2ManagerImpl returns new HistoryManager
 func NewHistoryV2ManagerImpl(
 	persistence HistoryStore,
 	logger log.Logger,
 	transactionSizeLimit dynamicconfig.IntPropertyFn,
+	options ...HistoryManagerOption,
 ) HistoryManager {
 
-	return &historyV2ManagerImpl{
+	manager := &historyV2ManagerImpl{
 		historySerializer:     NewPayloadSerializer(),
 		persistence:           persistence,
 		logger:                logger,
 		thriftEncoder:         codec.NewThriftRWEncoder(),
 		pagingTokenSerializer: newJSONHistoryTokenSerializer(),
 		transactionSizeLimit:  transactionSizeLimit,
+		metricsClient:         metrics.NewNoopMetricsClient(),
+	}
+	for _, option := range options {
+		option(manager)
 	}
+	return manager
 }
 
 func (m *historyV2ManagerImpl) GetName() string {
@@ -112,6 +133,12 @@ func (m *historyV2ManagerImpl) ForkHistoryBranch(
 		ShardID:        shardID,
 	}
 
+	if request.ValidateForkNode {
+		if err := m.validateForkNode(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
 	resp, err := m.persistence.ForkHistoryBranch(ctx, req)
 	if err != nil {
 		return nil, err
@@ -127,6 +154,127 @@ func (m *historyV2ManagerImpl) ForkHistoryBranch(
 	}, nil
 }
 
+// validateForkNode checks that the fork node of the request is a valid fork point of the base branch.
+// The fork node must either be the first node of an event batch on the base branch, or come right
+// after the last event of the base branch. A fork node in the middle of a batch would corrupt the new branch.
+func (m *historyV2ManagerImpl) validateForkNode(
+	ctx context.Context,
+	request *InternalForkHistoryBranchRequest,
+) error {
+	forkB := request.ForkBranchInfo
+	forkNodeID := request.ForkNodeID
+
+	// find the branch range which holds the fork node
+	branchID := forkB.BranchID
+	beginNodeID := int64(common.FirstEventID)
+	if len(forkB.Ancestors) > 0 {
+		beginNodeID = forkB.Ancestors[len(forkB.Ancestors)-1].EndNodeID
+	}
+	endNodeID := int64(math.MaxInt64)
+	for _, br := range forkB.Ancestors {
+		if forkNodeID < br.EndNodeID {
+			branchID, beginNodeID, endNodeID = br.BranchID, br.BeginNodeID, br.EndNodeID
+			break
+		}
+	}
+	if forkNodeID <= beginNodeID {
+		if forkNodeID == beginNodeID && beginNodeID > common.FirstEventID {
+			// boundary between two branch ranges
+			return nil
+		}
+		return &InvalidPersistenceRequestError{
+			Msg: fmt.Sprintf("fork node %v is out of range of base branch %v", forkNodeID, forkB.BranchID),
+		}
+	}
+
+	resp, err := m.readFirstHistoryNode(ctx, forkB.TreeID, branchID, forkNodeID, endNodeID, request.ShardID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(resp.History) > 0 && resp.LastNodeID == forkNodeID:
+		return nil
+	case len(resp.History) == 0 && endNodeID == math.MaxInt64:
+		// forking after the last node of the base branch, which is only valid right after its last event
+		nextNodeID, err := m.getNextNodeID(ctx, forkB.TreeID, branchID, beginNodeID, forkNodeID, request.ShardID)
+		if err != nil {
+			return err
+		}
+		if forkNodeID == nextNodeID {
+			return nil
+		}
+		return &InvalidPersistenceRequestError{
+			Msg: fmt.Sprintf("fork node %v is past the next node %v of base branch %v", forkNodeID, nextNodeID, forkB.BranchID),
+		}
+	default:
+		return &InvalidPersistenceRequestError{
+			Msg: fmt.Sprintf("fork node %v is not the beginning of an event batch on base branch %v", forkNodeID, forkB.BranchID),
+		}
+	}
+}
+
+// getNextNodeID returns the node ID following the last event of a branch range which starts at beginNodeID
+// and has no node at or after endNodeID. Instead of reading the whole range, its last node is located by
+// a binary search over reads of a single node.
+func (m *historyV2ManagerImpl) getNextNodeID(
+	ctx context.Context,
+	treeID string,
+	branchID string,
+	beginNodeID int64,
+	endNodeID int64,
+	shardID int,
+) (int64, error) {
+	var lastBatch *DataBlob
+	// the last node of the range is after lastNodeID and before endNodeID, if there is any node after lastNodeID
+	lastNodeID := beginNodeID - 1
+	for lastNodeID+1 < endNodeID {
+		minNodeID := lastNodeID + 1 + (endNodeID-lastNodeID-1)/2
+		resp, err := m.readFirstHistoryNode(ctx, treeID, branchID, minNodeID, endNodeID, shardID)
+		if err != nil {
+			return 0, err
+		}
+		if len(resp.History) == 0 {
+			endNodeID = minNodeID
+			continue
+		}
+		lastBatch = resp.History[0]
+		lastNodeID = resp.LastNodeID
+	}
+	if lastBatch == nil {
+		return beginNodeID, nil
+	}
+
+	events, err := m.historySerializer.DeserializeBatchEvents(lastBatch)
+	if err != nil {
+		return 0, err
+	}
+	if len(events) == 0 {
+		return 0, &types.InternalDataInconsistencyError{
+			Message: fmt.Sprintf("empty event batch on branch %v", branchID),
+		}
+	}
+	return events[len(events)-1].ID + 1, nil
+}
+
+// readFirstHistoryNode reads the first node of a branch range between minNodeID (inclusive) and maxNodeID (exclusive)
+func (m *historyV2ManagerImpl) readFirstHistoryNode(
+	ctx context.Context,
+	treeID string,
+	branchID string,
+	minNodeID int64,
+	maxNodeID int64,
+	shardID int,
+) (*InternalReadHistoryBranchResponse, error) {
+	return m.persistence.ReadHistoryBranch(ctx, &InternalReadHistoryBranchRequest{
+		TreeID:    treeID,
+		BranchID:  branchID,
+		MinNodeID: minNodeID,
+		MaxNodeID: maxNodeID,
+		PageSize:  1,
+		ShardID:   shardID,
+	})
+}
+
 // DeleteHistoryBranch removes a branch
 func (m *historyV2ManagerImpl) DeleteHistoryBranch(
 	ctx context.Context,
@@ -181,7 +329,8 @@ func (m *historyV2ManagerImpl) GetHistoryTree(
 		branches = append(branches, thrift.FromHistoryBranch(b))
 	}
 	return &GetHistoryTreeResponse{
-		Branches: branches,
+		Branches:    branches,
+		BranchInfos: resp.BranchInfos,
 	}, nil
 }
 
@@ -461,8 +610,11 @@ func (m *historyV2ManagerImpl) readHistoryBranch(
 	// first_event_id of the last batch
 	lastFirstEventID := common.EmptyEventID
 
+	var decodeLatency time.Duration
 	for _, batch := range dataBlobs {
+		decodeStart := time.Now()
 		events, err := m.historySerializer.DeserializeBatchEvents(batch)
+		decodeLatency += time.Since(decodeStart)
 		if err != nil {
 			return nil, nil, nil, 0, 0, err
 		}
@@ -523,6 +675,8 @@ func (m *historyV2ManagerImpl) readHistoryBranch(
 		lastFirstEventID = firstEvent.ID
 	}
 
+	m.emitBlobDecodeMetrics(byBatch, request.DomainName, len(dataBlobs), dataSize, decodeLatency)
+
 	nextPageToken, err := m.serializeToken(token)
 	if err != nil {
 		return nil, nil, nil, 0, 0, err
@@ -531,6 +685,26 @@ func (m *historyV2ManagerImpl) readHistoryBranch(
 	return historyEvents, historyEventBatches, nextPageToken, dataSize, lastFirstEventID, nil
 }
 
+// emitBlobDecodeMetrics records how many blobs, and of what total size, a single read had to decode.
+// Domains with pathologically large events stand out by blob size and decode latency.
+func (m *historyV2ManagerImpl) emitBlobDecodeMetrics(
+	byBatch bool,
+	domainName string,
+	blobCount int,
+	blobSize int,
+	decodeLatency time.Duration,
+) {
+
+	scopeIdx := metrics.PersistenceReadHistoryBranchScope
+	if byBatch {
+		scopeIdx = metrics.PersistenceReadHistoryBranchByBatchScope
+	}
+	scope := m.metricsClient.Scope(scopeIdx, metrics.DomainTag(domainName))
+	scope.RecordTimer(metrics.PersistenceHistoryBlobCountPerDomain, time.Duration(blobCount))
+	scope.RecordTimer(metrics.PersistenceHistoryBlobSizePerDomain, time.Duration(blobSize))
+	scope.RecordTimer(metrics.PersistenceHistoryBlobDecodeLatencyPerDomain, decodeLatency)
+}
+
 func (m *historyV2ManagerImpl) deserializeToken(
 	token []byte,
 	defaultLastEventID int64,
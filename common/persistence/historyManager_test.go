@@ -0,0 +1,179 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/codec"
+	"github.com/uber/cadence/common/dynamicconfig"
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/types/mapper/thrift"
+)
+
+func TestHistoryManagerReadHistoryBranch_BlobDecodeMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := NewMockHistoryStore(ctrl)
+	serializer := NewPayloadSerializer()
+
+	var blobs []*DataBlob
+	totalSize := 0
+	for _, events := range [][]*types.HistoryEvent{
+		{{ID: 1, Version: 1}, {ID: 2, Version: 1}},
+		{{ID: 3, Version: 1}},
+		{{ID: 4, Version: 1}, {ID: 5, Version: 1}},
+	} {
+		blob, err := serializer.SerializeBatchEvents(events, common.EncodingTypeThriftRW)
+		require.NoError(t, err)
+		blobs = append(blobs, blob)
+		totalSize += len(blob.Data)
+	}
+	store.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).Return(&InternalReadHistoryBranchResponse{
+		History: blobs,
+	}, nil)
+
+	testScope := tally.NewTestScope("", nil)
+	manager := NewHistoryV2ManagerImpl(
+		store,
+		testlogger.New(t),
+		dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit),
+		WithHistoryMetricsClient(metrics.NewClient(testScope, metrics.History)),
+	)
+
+	branchToken, err := NewHistoryBranchToken("tree-id")
+	require.NoError(t, err)
+	resp, err := manager.ReadHistoryBranch(context.Background(), &ReadHistoryBranchRequest{
+		BranchToken: branchToken,
+		MinEventID:  common.FirstEventID,
+		MaxEventID:  common.EndEventID,
+		PageSize:    10,
+		ShardID:     common.IntPtr(1),
+		DomainName:  "test-domain",
+	})
+	require.NoError(t, err)
+	assert.Len(t, resp.HistoryEvents, 5)
+
+	timers := make(map[string]time.Duration)
+	for _, timer := range testScope.Snapshot().Timers() {
+		if timer.Tags()["domain"] != "test-domain" {
+			continue
+		}
+		assert.Equal(t, "ReadHistoryBranch", timer.Tags()["operation"])
+		require.Len(t, timer.Values(), 1)
+		timers[timer.Name()] = timer.Values()[0]
+	}
+	assert.Equal(t, time.Duration(len(blobs)), timers["persistence_history_blob_count_per_domain"])
+	assert.Equal(t, time.Duration(totalSize), timers["persistence_history_blob_size_per_domain"])
+	assert.Contains(t, timers, "persistence_history_blob_decode_latency_per_domain")
+}
+
+func TestHistoryManagerForkHistoryBranch_ValidateForkNode(t *testing.T) {
+	serializer := NewPayloadSerializer()
+	// the base branch forks from node 5 of its ancestor and holds the batches 5[5,6] and 7[7,8,9]
+	baseBranch := &types.HistoryBranch{
+		TreeID:   "tree-id",
+		BranchID: "base-branch-id",
+		Ancestors: []*types.HistoryBranchRange{
+			{BranchID: "ancestor-branch-id", BeginNodeID: 1, EndNodeID: 5},
+		},
+	}
+	nodes := map[string][]int64{
+		"ancestor-branch-id": {1, 3},
+		"base-branch-id":     {5, 7},
+	}
+	batches := map[int64][]*types.HistoryEvent{
+		1: {{ID: 1}, {ID: 2}},
+		3: {{ID: 3}, {ID: 4}},
+		5: {{ID: 5}, {ID: 6}},
+		7: {{ID: 7}, {ID: 8}, {ID: 9}},
+	}
+	branchToken, err := codec.NewThriftRWEncoder().Encode(thrift.FromHistoryBranch(baseBranch))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		forkNodeID int64
+		wantErr    bool
+	}{
+		{name: "valid fork node on base branch", forkNodeID: 7},
+		{name: "valid fork node on ancestor branch", forkNodeID: 3},
+		{name: "valid fork node at the beginning of base branch", forkNodeID: 5},
+		{name: "valid fork node right after the last event", forkNodeID: 10},
+		{name: "fork node past the last event is rejected", forkNodeID: 20, wantErr: true},
+		{name: "fork node in the middle of the last batch is rejected", forkNodeID: 8, wantErr: true},
+		{name: "fork node in the middle of a batch is rejected", forkNodeID: 6, wantErr: true},
+		{name: "fork node missing from ancestor branch is rejected", forkNodeID: 4, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := NewMockHistoryStore(ctrl)
+			// single node reads only, the base branch is never read in full
+			store.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, request *InternalReadHistoryBranchRequest) (*InternalReadHistoryBranchResponse, error) {
+					assert.Equal(t, 1, request.PageSize)
+					for _, nodeID := range nodes[request.BranchID] {
+						if nodeID >= request.MinNodeID && nodeID < request.MaxNodeID {
+							blob, err := serializer.SerializeBatchEvents(batches[nodeID], common.EncodingTypeThriftRW)
+							require.NoError(t, err)
+							return &InternalReadHistoryBranchResponse{History: []*DataBlob{blob}, LastNodeID: nodeID}, nil
+						}
+					}
+					return &InternalReadHistoryBranchResponse{}, nil
+				}).AnyTimes()
+			if !tc.wantErr {
+				store.EXPECT().ForkHistoryBranch(gomock.Any(), gomock.Any()).Return(&InternalForkHistoryBranchResponse{
+					NewBranchInfo: types.HistoryBranch{TreeID: "tree-id", BranchID: "new-branch-id"},
+				}, nil).Times(1)
+			}
+
+			manager := NewHistoryV2ManagerImpl(
+				store,
+				testlogger.New(t),
+				dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit),
+			)
+			_, err := manager.ForkHistoryBranch(context.Background(), &ForkHistoryBranchRequest{
+				ForkBranchToken:  branchToken,
+				ForkNodeID:       tc.forkNodeID,
+				ShardID:          common.IntPtr(1),
+				ValidateForkNode: true,
+			})
+			if tc.wantErr {
+				var invalidErr *InvalidPersistenceRequestError
+				assert.ErrorAs(t, err, &invalidErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+const missingValue = "<missing>"
+
+type (
+	// FieldDiff is a single field level difference between two mutable states.
+	// Path uses Go selector syntax rooted at InternalWorkflowMutableState,
+	// e.g. ExecutionInfo.NextEventID or ActivityInfos[5].StartedID.
+	FieldDiff struct {
+		Path string
+		A    string
+		B    string
+	}
+
+	blobDecoder func(PayloadSerializer, *DataBlob) (interface{}, error)
+)
+
+// blobDecoders maps the names of DataBlob fields in the mutable state to the
+// decoder that understands their payload. Blobs not listed here, or blobs
+// which fail to decode, are compared by their raw encoding and bytes.
+var blobDecoders = map[string]blobDecoder{
+	"CompletionEvent":  func(s PayloadSerializer, d *DataBlob) (interface{}, error) { return s.DeserializeEvent(d) },
+	"ScheduledEvent":   func(s PayloadSerializer, d *DataBlob) (interface{}, error) { return s.DeserializeEvent(d) },
+	"StartedEvent":     func(s PayloadSerializer, d *DataBlob) (interface{}, error) { return s.DeserializeEvent(d) },
+	"InitiatedEvent":   func(s PayloadSerializer, d *DataBlob) (interface{}, error) { return s.DeserializeEvent(d) },
+	"AutoResetPoints":  func(s PayloadSerializer, d *DataBlob) (interface{}, error) { return s.DeserializeResetPoints(d) },
+	"VersionHistories": func(s PayloadSerializer, d *DataBlob) (interface{}, error) { return s.DeserializeVersionHistories(d) },
+	"BufferedEvents":   func(s PayloadSerializer, d *DataBlob) (interface{}, error) { return s.DeserializeBatchEvents(d) },
+	"ChecksumData":     func(s PayloadSerializer, d *DataBlob) (interface{}, error) { return s.DeserializeChecksum(d) },
+}
+
+// DiffMutableState reports the field level differences between two mutable states,
+// for example the same workflow loaded from two clusters. Blobs are decoded before
+// comparison, so two blobs holding the same data in different encodings are equal.
+// Diffs are returned in a deterministic order; nil is returned if the states match.
+func DiffMutableState(a, b *InternalWorkflowMutableState) []FieldDiff {
+	d := &mutableStateDiffer{serializer: NewPayloadSerializer()}
+	d.diff("", reflect.ValueOf(a), reflect.ValueOf(b), nil)
+	return d.diffs
+}
+
+// DiffWorkflowMutableState is DiffMutableState for mutable states which are already decoded,
+// for example the JSON returned by the admin DescribeWorkflowExecution API.
+func DiffWorkflowMutableState(a, b *WorkflowMutableState) []FieldDiff {
+	d := &mutableStateDiffer{}
+	d.diff("", reflect.ValueOf(a), reflect.ValueOf(b), nil)
+	return d.diffs
+}
+
+type mutableStateDiffer struct {
+	serializer PayloadSerializer
+	diffs      []FieldDiff
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	dataBlobType = reflect.TypeOf(&DataBlob{})
+)
+
+func (d *mutableStateDiffer) diff(path string, a, b reflect.Value, decode blobDecoder) {
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			d.add(path, a, b)
+		}
+		return
+	}
+
+	if a.Type() == timeType {
+		if !a.Interface().(time.Time).Equal(b.Interface().(time.Time)) {
+			d.add(path, a, b)
+		}
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.add(path, a, b)
+			}
+			return
+		}
+		if a.Kind() == reflect.Interface && a.Elem().Type() != b.Elem().Type() {
+			d.add(path, a, b)
+			return
+		}
+		if a.Type() == dataBlobType && decode != nil {
+			d.diffBlobs(path, a.Interface().(*DataBlob), b.Interface().(*DataBlob), decode)
+			return
+		}
+		d.diff(path, a.Elem(), b.Elem(), decode)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			d.diff(joinPath(path, field.Name), a.Field(i), b.Field(i), blobDecoders[field.Name])
+		}
+	case reflect.Slice:
+		if a.Type().Elem().Kind() == reflect.Uint8 {
+			if !bytes.Equal(a.Bytes(), b.Bytes()) {
+				d.add(path, a, b)
+			}
+			return
+		}
+		for i := 0; i < a.Len() || i < b.Len(); i++ {
+			d.diff(fmt.Sprintf("%v[%d]", path, i), index(a, i), index(b, i), decode)
+		}
+	case reflect.Map:
+		for _, key := range sortedKeys(a, b) {
+			d.diff(fmt.Sprintf("%v[%v]", path, key), a.MapIndex(key), b.MapIndex(key), decode)
+		}
+	default:
+		if a.Interface() != b.Interface() {
+			d.add(path, a, b)
+		}
+	}
+}
+
+func (d *mutableStateDiffer) diffBlobs(path string, a, b *DataBlob, decode blobDecoder) {
+	if a.Encoding == b.Encoding && bytes.Equal(a.Data, b.Data) {
+		return
+	}
+	decodedA, errA := decode(d.serializer, a)
+	decodedB, errB := decode(d.serializer, b)
+	if errA != nil || errB != nil {
+		d.diff(path, reflect.ValueOf(*a), reflect.ValueOf(*b), nil)
+		return
+	}
+	d.diff(path, reflect.ValueOf(decodedA), reflect.ValueOf(decodedB), nil)
+}
+
+func (d *mutableStateDiffer) add(path string, a, b reflect.Value) {
+	d.diffs = append(d.diffs, FieldDiff{
+		Path: path,
+		A:    formatValue(a),
+		B:    formatValue(b),
+	})
+}
+
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return missingValue
+	}
+	for (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		return fmt.Sprintf("%x", v.Bytes())
+	}
+	return fmt.Sprintf("%+v", v.Interface())
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+func index(v reflect.Value, i int) reflect.Value {
+	if i >= v.Len() {
+		return reflect.Value{}
+	}
+	return v.Index(i)
+}
+
+func sortedKeys(a, b reflect.Value) []reflect.Value {
+	seen := make(map[interface{}]struct{})
+	var keys []reflect.Value
+	for _, m := range []reflect.Value{a, b} {
+		for _, key := range m.MapKeys() {
+			if _, ok := seen[key.Interface()]; !ok {
+				seen[key.Interface()] = struct{}{}
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		switch keys[i].Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return keys[i].Int() < keys[j].Int()
+		default:
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		}
+	})
+	return keys
+}
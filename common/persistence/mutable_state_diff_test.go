@@ -0,0 +1,155 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestDiffMutableState_Identical(t *testing.T) {
+	a := newTestMutableStateForDiff(t, common.EncodingTypeThriftRW)
+	b := newTestMutableStateForDiff(t, common.EncodingTypeThriftRW)
+
+	assert.Empty(t, DiffMutableState(a, b))
+	assert.Empty(t, DiffMutableState(nil, nil))
+}
+
+func TestDiffMutableState_DecodesBlobs(t *testing.T) {
+	// the same payloads in different encodings are not a drift
+	a := newTestMutableStateForDiff(t, common.EncodingTypeThriftRW)
+	b := newTestMutableStateForDiff(t, common.EncodingTypeJSON)
+
+	assert.Empty(t, DiffMutableState(a, b))
+}
+
+func TestDiffMutableState_Diverging(t *testing.T) {
+	a := newTestMutableStateForDiff(t, common.EncodingTypeThriftRW)
+	b := newTestMutableStateForDiff(t, common.EncodingTypeThriftRW)
+
+	b.ExecutionInfo.NextEventID = 12
+	b.ExecutionInfo.LastUpdatedTimestamp = a.ExecutionInfo.LastUpdatedTimestamp.Add(time.Second)
+	b.ActivityInfos[5].StartedID = 9
+	delete(b.TimerInfos, "timer")
+	b.SignalRequestedIDs["extra"] = struct{}{}
+
+	serializer := NewPayloadSerializer()
+	histories := newTestVersionHistoriesForDiff()
+	histories.Histories[0].Items[0].Version = 2
+	blob, err := serializer.SerializeVersionHistories(histories, common.EncodingTypeThriftRW)
+	require.NoError(t, err)
+	b.VersionHistories = blob
+
+	events := newTestBufferedEventsForDiff()
+	events = append(events, &types.HistoryEvent{ID: 11, Version: 1})
+	blob, err = serializer.SerializeBatchEvents(events, common.EncodingTypeThriftRW)
+	require.NoError(t, err)
+	b.BufferedEvents[0] = blob
+
+	diffs := DiffMutableState(a, b)
+	paths := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		paths = append(paths, diff.Path)
+	}
+	assert.Equal(t, []string{
+		"ExecutionInfo.NextEventID",
+		"ExecutionInfo.LastUpdatedTimestamp",
+		"VersionHistories.Histories[0].Items[0].Version",
+		"ActivityInfos[5].StartedID",
+		"TimerInfos[timer]",
+		"SignalRequestedIDs[extra]",
+		"BufferedEvents[0][1]",
+	}, paths)
+
+	assert.Equal(t, FieldDiff{Path: "ExecutionInfo.NextEventID", A: "11", B: "12"}, diffs[0])
+	assert.Equal(t, FieldDiff{Path: "VersionHistories.Histories[0].Items[0].Version", A: "1", B: "2"}, diffs[2])
+	assert.Equal(t, FieldDiff{Path: "ActivityInfos[5].StartedID", A: "-23", B: "9"}, diffs[3])
+	assert.Equal(t, missingValue, diffs[4].B)
+	assert.Equal(t, missingValue, diffs[5].A)
+	assert.Equal(t, missingValue, diffs[6].A)
+}
+
+func TestDiffWorkflowMutableState(t *testing.T) {
+	newState := func() *WorkflowMutableState {
+		return &WorkflowMutableState{
+			ExecutionInfo: &WorkflowExecutionInfo{WorkflowID: "workflow-id", NextEventID: 11},
+			VersionHistories: &VersionHistories{
+				Histories: []*VersionHistory{{Items: []*VersionHistoryItem{{EventID: 10, Version: 1}}}},
+			},
+			BufferedEvents: newTestBufferedEventsForDiff(),
+		}
+	}
+	a, b := newState(), newState()
+	assert.Empty(t, DiffWorkflowMutableState(a, b))
+
+	b.VersionHistories.Histories[0].Items[0].Version = 2
+	b.BufferedEvents[0].Version = 2
+	assert.Equal(t, []FieldDiff{
+		{Path: "BufferedEvents[0].Version", A: "1", B: "2"},
+		{Path: "VersionHistories.Histories[0].Items[0].Version", A: "1", B: "2"},
+	}, DiffWorkflowMutableState(a, b))
+}
+
+func newTestMutableStateForDiff(t *testing.T, encoding common.EncodingType) *InternalWorkflowMutableState {
+	serializer := NewPayloadSerializer()
+	histories, err := serializer.SerializeVersionHistories(newTestVersionHistoriesForDiff(), encoding)
+	require.NoError(t, err)
+	events, err := serializer.SerializeBatchEvents(newTestBufferedEventsForDiff(), encoding)
+	require.NoError(t, err)
+
+	return &InternalWorkflowMutableState{
+		ExecutionInfo: &InternalWorkflowExecutionInfo{
+			DomainID:             "domain-id",
+			WorkflowID:           "workflow-id",
+			RunID:                "run-id",
+			NextEventID:          11,
+			LastUpdatedTimestamp: time.Unix(1700000000, 0),
+			BranchToken:          []byte("branch-token"),
+		},
+		VersionHistories: histories,
+		ActivityInfos: map[int64]*InternalActivityInfo{
+			5: {ScheduleID: 5, StartedID: common.EmptyEventID, ActivityID: "activity"},
+		},
+		TimerInfos:         map[string]*TimerInfo{"timer": {TimerID: "timer", StartedID: 7}},
+		SignalRequestedIDs: map[string]struct{}{"signal": {}},
+		BufferedEvents:     []*DataBlob{events},
+	}
+}
+
+func newTestVersionHistoriesForDiff() *types.VersionHistories {
+	return &types.VersionHistories{
+		Histories: []*types.VersionHistory{{
+			BranchToken: []byte("branch-token"),
+			Items:       []*types.VersionHistoryItem{{EventID: 10, Version: 1}},
+		}},
+	}
+}
+
+func newTestBufferedEventsForDiff() []*types.HistoryEvent {
+	return []*types.HistoryEvent{{ID: 10, Version: 1}}
+}
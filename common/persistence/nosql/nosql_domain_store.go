@@ -23,6 +23,7 @@ package nosql
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cluster"
@@ -229,6 +230,10 @@ func (m *nosqlDomainStore) ListDomains(
 		})
 	}
 
+	if !request.IncludeDeleted {
+		domains = persistence.FilterSoftDeletedDomains(domains)
+	}
+
 	return &persistence.InternalListDomainsResponse{
 		Domains:       domains,
 		NextPageToken: nextPageToken,
@@ -257,6 +262,27 @@ func (m *nosqlDomainStore) DeleteDomainByName(
 	return nil
 }
 
+func (m *nosqlDomainStore) MarkDomainDeleted(
+	ctx context.Context,
+	request *persistence.MarkDomainDeletedRequest,
+) error {
+	return persistence.MarkDomainDeleted(ctx, m, request)
+}
+
+func (m *nosqlDomainStore) PurgeDeletedDomains(
+	ctx context.Context,
+	olderThan time.Time,
+) error {
+	return persistence.PurgeDeletedDomains(ctx, m, olderThan)
+}
+
+func (m *nosqlDomainStore) ListDomainsByIsolationGroup(
+	ctx context.Context,
+	group string,
+) ([]*persistence.InternalGetDomainResponse, error) {
+	return persistence.ListDomainsByIsolationGroup(ctx, m, group)
+}
+
 func (m *nosqlDomainStore) GetMetadata(
 	ctx context.Context,
 ) (*persistence.GetMetadataResponse, error) {
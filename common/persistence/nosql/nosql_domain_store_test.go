@@ -0,0 +1,139 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package nosql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+)
+
+func setupNoSQLDomainStoreMocks(t *testing.T) (*nosqlDomainStore, *nosqlplugin.MockDB) {
+	ctrl := gomock.NewController(t)
+	dbMock := nosqlplugin.NewMockDB(ctrl)
+	store := &nosqlDomainStore{
+		nosqlStore: nosqlStore{
+			logger: log.NewNoop(),
+			db:     dbMock,
+		},
+		currentClusterName: "active",
+	}
+	return store, dbMock
+}
+
+func newTestDomainRow(id string, data map[string]string) *nosqlplugin.DomainRow {
+	return &nosqlplugin.DomainRow{
+		Info:              &persistence.DomainInfo{ID: id, Name: id, Data: data},
+		Config:            &nosqlplugin.NoSQLInternalDomainConfig{},
+		ReplicationConfig: &persistence.DomainReplicationConfig{},
+	}
+}
+
+func softDeletedData(deletedAt time.Time) map[string]string {
+	return map[string]string{common.DomainDataKeyForSoftDeletedTime: deletedAt.Format(time.RFC3339Nano)}
+}
+
+func TestNoSQLDomainStoreListDomains_SoftDeleted(t *testing.T) {
+	testCases := []struct {
+		name           string
+		includeDeleted bool
+		want           []string
+	}{
+		{
+			name: "soft-deleted domains are hidden by default",
+			want: []string{"registered", "deleted-status"},
+		},
+		{
+			name:           "soft-deleted domains are returned if requested",
+			includeDeleted: true,
+			want:           []string{"registered", "deleted-status", "soft-deleted"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store, db := setupNoSQLDomainStoreMocks(t)
+			deletedStatus := newTestDomainRow("deleted-status", nil)
+			deletedStatus.Info.Status = persistence.DomainStatusDeleted
+			db.EXPECT().SelectAllDomains(gomock.Any(), 10, nil).Return([]*nosqlplugin.DomainRow{
+				newTestDomainRow("registered", nil),
+				deletedStatus,
+				newTestDomainRow("soft-deleted", softDeletedData(time.Now())),
+			}, nil, nil)
+
+			resp, err := store.ListDomains(context.Background(), &persistence.ListDomainsRequest{
+				PageSize:       10,
+				IncludeDeleted: tc.includeDeleted,
+			})
+			assert.NoError(t, err)
+			var names []string
+			for _, domain := range resp.Domains {
+				names = append(names, domain.Info.Name)
+			}
+			assert.Equal(t, tc.want, names)
+		})
+	}
+}
+
+func TestNoSQLDomainStoreMarkDomainDeleted(t *testing.T) {
+	store, db := setupNoSQLDomainStoreMocks(t)
+	deletedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	db.EXPECT().SelectDomainMetadata(gomock.Any()).Return(int64(7), nil)
+	db.EXPECT().SelectDomain(gomock.Any(), nil, common.StringPtr("domain")).Return(newTestDomainRow("domain", map[string]string{"k": "v"}), nil)
+	db.EXPECT().UpdateDomain(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, row *nosqlplugin.DomainRow) error {
+		assert.Equal(t, map[string]string{"k": "v", common.DomainDataKeyForSoftDeletedTime: "2024-03-01T12:00:00Z"}, row.Info.Data)
+		assert.Equal(t, 0, row.Info.Status)
+		assert.Equal(t, int64(7), row.NotificationVersion)
+		assert.Equal(t, deletedAt, row.LastUpdatedTime)
+		return nil
+	})
+
+	err := store.MarkDomainDeleted(context.Background(), &persistence.MarkDomainDeletedRequest{Name: "domain", DeletedAt: deletedAt})
+	assert.NoError(t, err)
+}
+
+func TestNoSQLDomainStorePurgeDeletedDomains(t *testing.T) {
+	store, db := setupNoSQLDomainStoreMocks(t)
+	now := time.Now()
+
+	deletedStatus := newTestDomainRow("deleted-status", nil)
+	deletedStatus.Info.Status = persistence.DomainStatusDeleted
+	deletedStatus.LastUpdatedTime = now.Add(-2 * time.Hour)
+	db.EXPECT().SelectAllDomains(gomock.Any(), gomock.Any(), nil).Return([]*nosqlplugin.DomainRow{
+		newTestDomainRow("registered", nil),
+		deletedStatus,
+		newTestDomainRow("recently-deleted", softDeletedData(now.Add(-time.Minute))),
+		newTestDomainRow("expired", softDeletedData(now.Add(-2*time.Hour))),
+	}, nil, nil)
+	db.EXPECT().DeleteDomain(gomock.Any(), common.StringPtr("expired"), nil).Return(nil)
+
+	err := store.PurgeDeletedDomains(context.Background(), now.Add(-time.Hour))
+	assert.NoError(t, err)
+}
@@ -100,6 +100,9 @@ func (d *nosqlExecutionStore) CreateWorkflowExecution(
 	}
 
 	workflowRequests := d.prepareWorkflowRequestRows(domainID, workflowID, runID, newWorkflow.WorkflowRequests, nil)
+	if request.DedupKey != "" {
+		workflowRequests = append(workflowRequests, d.prepareDedupKeyRow(domainID, workflowID, runID, request.DedupKey, lastWriteVersion))
+	}
 
 	shardCondition := &nosqlplugin.ShardCondition{
 		ShardID: d.shardID,
@@ -146,6 +149,13 @@ func (d *nosqlExecutionStore) CreateWorkflowExecution(
 					CloseStatus:      conditionFailureErr.WorkflowExecutionAlreadyExists.CloseStatus,
 					LastWriteVersion: conditionFailureErr.WorkflowExecutionAlreadyExists.LastWriteVersion,
 				}
+			case conditionFailureErr.DuplicateRequest != nil &&
+				conditionFailureErr.DuplicateRequest.RequestType == persistence.WorkflowRequestTypeDedupKey:
+				return nil, &persistence.WorkflowExecutionAlreadyStartedError{
+					Msg: fmt.Sprintf("Workflow execution with dedup key %v already started. WorkflowId: %v, RunId: %v",
+						request.DedupKey, workflowID, conditionFailureErr.DuplicateRequest.RunID),
+					RunID: conditionFailureErr.DuplicateRequest.RunID,
+				}
 			case conditionFailureErr.DuplicateRequest != nil:
 				return nil, &persistence.DuplicateRequestError{
 					RequestType: conditionFailureErr.DuplicateRequest.RequestType,
@@ -554,6 +564,20 @@ func (d *nosqlExecutionStore) ListCurrentExecutions(
 	if err != nil {
 		return nil, convertCommonErrors(d.db, "ListCurrentExecutions", err)
 	}
+	// plugins cannot filter current rows by state in the query, so a filtered page may hold fewer than PageSize executions
+	if request.WorkflowState != nil || request.CloseStatus != nil {
+		filtered := executions[:0]
+		for _, execution := range executions {
+			if request.WorkflowState != nil && execution.State != *request.WorkflowState {
+				continue
+			}
+			if request.CloseStatus != nil && execution.CloseStatus != *request.CloseStatus {
+				continue
+			}
+			filtered = append(filtered, execution)
+		}
+		executions = filtered
+	}
 	return &persistence.ListCurrentExecutionsResponse{
 		Executions: executions,
 		PageToken:  token,
@@ -573,6 +597,75 @@ func (d *nosqlExecutionStore) IsWorkflowExecutionExists(
 	}, nil
 }
 
+func (d *nosqlExecutionStore) ListWorkflowRequests(
+	ctx context.Context,
+	domainID, workflowID, runID string,
+) ([]*persistence.WorkflowRequest, error) {
+	rows, err := d.db.SelectWorkflowRequests(ctx, d.shardID, domainID, workflowID, runID)
+	if err != nil {
+		return nil, convertCommonErrors(d.db, "ListWorkflowRequests", err)
+	}
+	requests := make([]*persistence.WorkflowRequest, 0, len(rows))
+	for _, row := range rows {
+		requests = append(requests, &persistence.WorkflowRequest{
+			RequestID:   row.RequestID,
+			Version:     row.Version,
+			RequestType: row.RequestType,
+		})
+	}
+	return requests, nil
+}
+
+func (d *nosqlExecutionStore) BackfillFirstExecutionRunID(
+	ctx context.Context,
+	request *persistence.BackfillFirstExecutionRunIDRequest,
+) error {
+	state, err := d.db.SelectWorkflowExecution(ctx, d.shardID, request.DomainID, request.WorkflowID, request.RunID)
+	if err != nil {
+		if d.db.IsNotFoundError(err) {
+			return &types.EntityNotExistsError{
+				Message: fmt.Sprintf("Workflow execution not found.  WorkflowId: %v, RunId: %v",
+					request.WorkflowID, request.RunID),
+			}
+		}
+		return convertCommonErrors(d.db, "BackfillFirstExecutionRunID", err)
+	}
+
+	executionInfo := state.ExecutionInfo
+	executionInfo.FirstExecutionRunID = request.FirstExecutionRunID
+	lastWriteVersion, err := getLastWriteVersion(state.VersionHistories)
+	if err != nil {
+		return err
+	}
+
+	// rewrite the execution record as is, without touching the maps, buffered events or the current workflow record
+	mutateExecution, err := d.prepareUpdateWorkflowExecutionRequestWithMapsAndEventBuffer(&persistence.InternalWorkflowMutation{
+		ExecutionInfo:    executionInfo,
+		VersionHistories: state.VersionHistories,
+		LastWriteVersion: lastWriteVersion,
+		Condition:        executionInfo.NextEventID,
+		Checksum:         state.Checksum,
+	})
+	if err != nil {
+		return err
+	}
+	currentWorkflowWriteReq := &nosqlplugin.CurrentWorkflowWriteRequest{
+		WriteMode: nosqlplugin.CurrentWorkflowWriteModeNoop,
+	}
+	shardCondition := &nosqlplugin.ShardCondition{
+		ShardID: d.shardID,
+		RangeID: request.RangeID,
+	}
+
+	err = d.db.UpdateWorkflowExecutionWithTasks(
+		ctx, nil, currentWorkflowWriteReq,
+		mutateExecution, nil, nil,
+		nil, nil, nil, nil,
+		shardCondition)
+
+	return d.processUpdateWorkflowResult(err, request.RangeID)
+}
+
 func (d *nosqlExecutionStore) ListConcreteExecutions(
 	ctx context.Context,
 	request *persistence.ListConcreteExecutionsRequest,
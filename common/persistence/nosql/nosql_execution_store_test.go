@@ -191,6 +191,74 @@ func TestCreateWorkflowExecution(t *testing.T) {
 	}
 }
 
+func TestCreateWorkflowExecution_DedupKey(t *testing.T) {
+	ctx := context.Background()
+	controller := gomock.NewController(t)
+	mockDB := nosqlplugin.NewMockDB(controller)
+	store := newTestNosqlExecutionStore(mockDB, log.NewNoop())
+
+	var dedupRequestIDs []string
+	mockDB.EXPECT().
+		InsertWorkflowExecutionWithTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			requests *nosqlplugin.WorkflowRequestsWriteRequest,
+			_ *nosqlplugin.CurrentWorkflowWriteRequest,
+			_ *nosqlplugin.WorkflowExecutionRequest,
+			_ []*nosqlplugin.TransferTask,
+			_ []*nosqlplugin.CrossClusterTask,
+			_ []*nosqlplugin.ReplicationTask,
+			_ []*nosqlplugin.TimerTask,
+			_ *nosqlplugin.ShardCondition,
+		) error {
+			assert.Equal(t, nosqlplugin.WorkflowRequestWriteModeInsert, requests.WriteMode)
+			for _, row := range requests.Rows {
+				if row.RequestType == persistence.WorkflowRequestTypeDedupKey {
+					assert.Equal(t, constants.TestDomainID, row.DomainID)
+					assert.Equal(t, constants.TestWorkflowID, row.WorkflowID)
+					dedupRequestIDs = append(dedupRequestIDs, row.RequestID)
+				}
+			}
+			return nil
+		}).Times(4)
+
+	for _, dedupKey := range []string{"", "key-1", "key-2", "key-1"} {
+		request := newCreateWorkflowExecutionRequest()
+		request.DedupKey = dedupKey
+		_, err := store.CreateWorkflowExecution(ctx, request)
+		require.NoError(t, err)
+	}
+	// no row without a dedup key, distinct keys get distinct request IDs and the same key the same request ID
+	require.Len(t, dedupRequestIDs, 3)
+	assert.NotEqual(t, dedupRequestIDs[0], dedupRequestIDs[1])
+	assert.Equal(t, dedupRequestIDs[0], dedupRequestIDs[2])
+}
+
+func TestCreateWorkflowExecution_DuplicateDedupKey(t *testing.T) {
+	ctx := context.Background()
+	controller := gomock.NewController(t)
+	mockDB := nosqlplugin.NewMockDB(controller)
+	store := newTestNosqlExecutionStore(mockDB, log.NewNoop())
+
+	mockDB.EXPECT().
+		InsertWorkflowExecutionWithTasks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&nosqlplugin.WorkflowOperationConditionFailure{
+			DuplicateRequest: &nosqlplugin.DuplicateRequest{
+				RequestType: persistence.WorkflowRequestTypeDedupKey,
+				RunID:       "existing-run-id",
+			},
+		})
+
+	request := newCreateWorkflowExecutionRequest()
+	request.DedupKey = "key-1"
+	resp, err := store.CreateWorkflowExecution(ctx, request)
+	assert.Nil(t, resp)
+	var alreadyStartedErr *persistence.WorkflowExecutionAlreadyStartedError
+	require.ErrorAs(t, err, &alreadyStartedErr)
+	assert.Equal(t, "existing-run-id", alreadyStartedErr.RunID)
+	assert.Contains(t, alreadyStartedErr.Msg, "key-1")
+}
+
 func TestUpdateWorkflowExecution(t *testing.T) {
 	ctx := context.Background()
 
@@ -1479,6 +1547,211 @@ func TestIsWorkflowExecutionExists(t *testing.T) {
 	}
 }
 
+func TestListWorkflowRequests(t *testing.T) {
+	ctx := context.Background()
+	gomockController := gomock.NewController(t)
+
+	mockDB := nosqlplugin.NewMockDB(gomockController)
+	store := &nosqlExecutionStore{
+		shardID:    1,
+		nosqlStore: nosqlStore{db: mockDB},
+	}
+
+	domainID := "testDomainID"
+	workflowID := "testWorkflowID"
+	runID := "testRunID"
+
+	tests := []struct {
+		name             string
+		setupMock        func()
+		expectedRequests []*persistence.WorkflowRequest
+		expectedError    bool
+	}{
+		{
+			name: "Recorded requests are returned",
+			setupMock: func() {
+				mockDB.EXPECT().SelectWorkflowRequests(ctx, store.shardID, domainID, workflowID, runID).Return([]*nosqlplugin.WorkflowRequestRow{
+					{
+						ShardID:     store.shardID,
+						DomainID:    domainID,
+						WorkflowID:  workflowID,
+						RequestType: persistence.WorkflowRequestTypeStart,
+						RequestID:   "startRequestID",
+						Version:     1,
+						RunID:       runID,
+					},
+					{
+						ShardID:     store.shardID,
+						DomainID:    domainID,
+						WorkflowID:  workflowID,
+						RequestType: persistence.WorkflowRequestTypeSignal,
+						RequestID:   "signalRequestID",
+						Version:     2,
+						RunID:       runID,
+					},
+				}, nil)
+			},
+			expectedRequests: []*persistence.WorkflowRequest{
+				{RequestID: "startRequestID", Version: 1, RequestType: persistence.WorkflowRequestTypeStart},
+				{RequestID: "signalRequestID", Version: 2, RequestType: persistence.WorkflowRequestTypeSignal},
+			},
+		},
+		{
+			name: "No recorded requests",
+			setupMock: func() {
+				mockDB.EXPECT().SelectWorkflowRequests(ctx, store.shardID, domainID, workflowID, runID).Return(nil, nil)
+			},
+			expectedRequests: []*persistence.WorkflowRequest{},
+		},
+		{
+			name: "Database error",
+			setupMock: func() {
+				err := errors.New("database error")
+				mockDB.EXPECT().SelectWorkflowRequests(ctx, store.shardID, domainID, workflowID, runID).Return(nil, err)
+				mockDB.EXPECT().IsNotFoundError(err).Return(false).AnyTimes()
+				mockDB.EXPECT().IsTimeoutError(err).Return(false).AnyTimes()
+				mockDB.EXPECT().IsThrottlingError(err).Return(false).AnyTimes()
+				mockDB.EXPECT().IsDBUnavailableError(err).Return(false).AnyTimes()
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			requests, err := store.ListWorkflowRequests(ctx, domainID, workflowID, runID)
+
+			if tc.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedRequests, requests)
+			}
+		})
+	}
+}
+
+func TestBackfillFirstExecutionRunID(t *testing.T) {
+	ctx := context.Background()
+	versionHistories, err := persistence.NewPayloadSerializer().SerializeVersionHistories(&types.VersionHistories{
+		CurrentVersionHistoryIndex: 0,
+		Histories: []*types.VersionHistory{
+			{
+				BranchToken: []byte("branchToken"),
+				Items:       []*types.VersionHistoryItem{{EventID: 5, Version: 1}, {EventID: 9, Version: 3}},
+			},
+		},
+	}, common.EncodingTypeThriftRW)
+	require.NoError(t, err)
+
+	request := &persistence.BackfillFirstExecutionRunIDRequest{
+		RangeID:             123,
+		DomainID:            "testDomainID",
+		WorkflowID:          "testWorkflowID",
+		RunID:               "testRunID",
+		FirstExecutionRunID: "firstRunID",
+	}
+	newState := func() *nosqlplugin.WorkflowExecution {
+		return &nosqlplugin.WorkflowExecution{
+			ExecutionInfo: &persistence.InternalWorkflowExecutionInfo{
+				DomainID:         "testDomainID",
+				WorkflowID:       "testWorkflowID",
+				RunID:            "testRunID",
+				ParentDomainID:   "parentDomainID",
+				ParentWorkflowID: "parentWorkflowID",
+				ParentRunID:      "parentRunID",
+				InitiatedID:      7,
+				TaskList:         "testTaskList",
+				WorkflowTypeName: "testWorkflowType",
+				NextEventID:      10,
+				State:            persistence.WorkflowStateRunning,
+				CloseStatus:      persistence.WorkflowCloseStatusNone,
+			},
+			VersionHistories: versionHistories,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		setupMock     func(*nosqlplugin.MockDB)
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupMock: func(mockDB *nosqlplugin.MockDB) {
+				mockDB.EXPECT().SelectWorkflowExecution(ctx, 1, "testDomainID", "testWorkflowID", "testRunID").Return(newState(), nil)
+				mockDB.EXPECT().UpdateWorkflowExecutionWithTasks(
+					ctx, nil, gomock.Any(), gomock.Any(), nil, nil, nil, nil, nil, nil, gomock.Any(),
+				).DoAndReturn(func(
+					_ context.Context,
+					_ *nosqlplugin.WorkflowRequestsWriteRequest,
+					currentWorkflowRequest *nosqlplugin.CurrentWorkflowWriteRequest,
+					mutatedExecution *nosqlplugin.WorkflowExecutionRequest,
+					_, _ *nosqlplugin.WorkflowExecutionRequest,
+					_ []*nosqlplugin.TransferTask,
+					_ []*nosqlplugin.CrossClusterTask,
+					_ []*nosqlplugin.ReplicationTask,
+					_ []*nosqlplugin.TimerTask,
+					shardCondition *nosqlplugin.ShardCondition,
+				) error {
+					expectedInfo := *newState().ExecutionInfo
+					expectedInfo.FirstExecutionRunID = "firstRunID"
+					expectedInfo.CompletionEvent = expectedInfo.CompletionEvent.ToNilSafeDataBlob()
+					expectedInfo.AutoResetPoints = expectedInfo.AutoResetPoints.ToNilSafeDataBlob()
+					assert.Equal(t, expectedInfo, mutatedExecution.InternalWorkflowExecutionInfo)
+					assert.Equal(t, versionHistories, mutatedExecution.VersionHistories)
+					assert.Equal(t, int64(3), mutatedExecution.LastWriteVersion)
+					assert.Equal(t, int64(10), *mutatedExecution.PreviousNextEventIDCondition)
+					assert.Equal(t, nosqlplugin.WorkflowExecutionMapsWriteModeUpdate, mutatedExecution.MapsWriteMode)
+					assert.Empty(t, mutatedExecution.ActivityInfos)
+					assert.Empty(t, mutatedExecution.ActivityInfoKeysToDelete)
+					assert.Equal(t, nosqlplugin.EventBufferWriteModeNone, mutatedExecution.EventBufferWriteMode)
+					assert.Equal(t, nosqlplugin.CurrentWorkflowWriteModeNoop, currentWorkflowRequest.WriteMode)
+					assert.Equal(t, &nosqlplugin.ShardCondition{ShardID: 1, RangeID: 123}, shardCondition)
+					return nil
+				})
+			},
+		},
+		{
+			name: "workflow not found",
+			setupMock: func(mockDB *nosqlplugin.MockDB) {
+				notFoundErr := errors.New("not found")
+				mockDB.EXPECT().SelectWorkflowExecution(ctx, 1, "testDomainID", "testWorkflowID", "testRunID").Return(nil, notFoundErr)
+				mockDB.EXPECT().IsNotFoundError(notFoundErr).Return(true)
+			},
+			expectedError: &types.EntityNotExistsError{},
+		},
+		{
+			name: "shard range id mismatch",
+			setupMock: func(mockDB *nosqlplugin.MockDB) {
+				mockDB.EXPECT().SelectWorkflowExecution(ctx, 1, "testDomainID", "testWorkflowID", "testRunID").Return(newState(), nil)
+				mockDB.EXPECT().UpdateWorkflowExecutionWithTasks(
+					ctx, nil, gomock.Any(), gomock.Any(), nil, nil, nil, nil, nil, nil, gomock.Any(),
+				).Return(&nosqlplugin.WorkflowOperationConditionFailure{ShardRangeIDNotMatch: common.Int64Ptr(124)})
+			},
+			expectedError: &persistence.ShardOwnershipLostError{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB := nosqlplugin.NewMockDB(gomock.NewController(t))
+			store := newTestNosqlExecutionStore(mockDB, log.NewNoop())
+			tc.setupMock(mockDB)
+
+			err := store.BackfillFirstExecutionRunID(ctx, request)
+			if tc.expectedError != nil {
+				require.Error(t, err)
+				assert.IsType(t, tc.expectedError, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestConflictResolveWorkflowExecution(t *testing.T) {
 	ctx := context.Background()
 	gomockController := gomock.NewController(t)
@@ -1617,6 +1890,68 @@ func getNewWorkflowSnapshot() persistence.InternalWorkflowSnapshot {
 		},
 	}
 }
+func TestListCurrentExecutions_Filters(t *testing.T) {
+	executions := func() []*persistence.CurrentWorkflowExecution {
+		return []*persistence.CurrentWorkflowExecution{
+			{WorkflowID: "running", State: persistence.WorkflowStateRunning, CloseStatus: persistence.WorkflowCloseStatusNone},
+			{WorkflowID: "completed", State: persistence.WorkflowStateCompleted, CloseStatus: persistence.WorkflowCloseStatusCompleted},
+			{WorkflowID: "failed", State: persistence.WorkflowStateCompleted, CloseStatus: persistence.WorkflowCloseStatusFailed},
+			{WorkflowID: "created", State: persistence.WorkflowStateCreated, CloseStatus: persistence.WorkflowCloseStatusNone},
+		}
+	}
+	tests := map[string]struct {
+		request         *persistence.ListCurrentExecutionsRequest
+		wantWorkflowIDs []string
+	}{
+		"no filters": {
+			request:         &persistence.ListCurrentExecutionsRequest{PageSize: 10},
+			wantWorkflowIDs: []string{"running", "completed", "failed", "created"},
+		},
+		"running state": {
+			request: &persistence.ListCurrentExecutionsRequest{
+				PageSize:      10,
+				WorkflowState: common.IntPtr(persistence.WorkflowStateRunning),
+			},
+			wantWorkflowIDs: []string{"running"},
+		},
+		"close status": {
+			request: &persistence.ListCurrentExecutionsRequest{
+				PageSize:    10,
+				CloseStatus: common.IntPtr(persistence.WorkflowCloseStatusFailed),
+			},
+			wantWorkflowIDs: []string{"failed"},
+		},
+		"state and close status": {
+			request: &persistence.ListCurrentExecutionsRequest{
+				PageSize:      10,
+				WorkflowState: common.IntPtr(persistence.WorkflowStateCompleted),
+				CloseStatus:   common.IntPtr(persistence.WorkflowCloseStatusCompleted),
+			},
+			wantWorkflowIDs: []string{"completed"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDB := nosqlplugin.NewMockDB(ctrl)
+			store := newTestNosqlExecutionStore(mockDB, log.NewNoop())
+			mockDB.EXPECT().
+				SelectAllCurrentWorkflows(gomock.Any(), store.GetShardID(), []byte("token"), 10).
+				Return(executions(), []byte("next-token"), nil)
+
+			tc.request.PageToken = []byte("token")
+			resp, err := store.ListCurrentExecutions(context.Background(), tc.request)
+			require.NoError(t, err)
+			var gotWorkflowIDs []string
+			for _, execution := range resp.Executions {
+				gotWorkflowIDs = append(gotWorkflowIDs, execution.WorkflowID)
+			}
+			assert.Equal(t, tc.wantWorkflowIDs, gotWorkflowIDs)
+			assert.Equal(t, []byte("next-token"), resp.PageToken)
+		})
+	}
+}
+
 func newTestNosqlExecutionStore(db nosqlplugin.DB, logger log.Logger) *nosqlExecutionStore {
 	return &nosqlExecutionStore{
 		shardID:    1,
@@ -26,6 +26,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/pborman/uuid"
+
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/checksum"
 	"github.com/uber/cadence/common/log/tag"
@@ -93,6 +95,24 @@ func (d *nosqlExecutionStore) prepareWorkflowRequestRows(
 	return requestRowsToAppend
 }
 
+// prepareDedupKeyRow records the dedup key of a workflow creation as a workflow request,
+// the request ID is derived from the key as request rows are keyed by UUID.
+func (d *nosqlExecutionStore) prepareDedupKeyRow(
+	domainID, workflowID, runID string,
+	dedupKey string,
+	version int64,
+) *nosqlplugin.WorkflowRequestRow {
+	return &nosqlplugin.WorkflowRequestRow{
+		ShardID:     d.shardID,
+		DomainID:    domainID,
+		WorkflowID:  workflowID,
+		RequestType: persistence.WorkflowRequestTypeDedupKey,
+		RequestID:   uuid.NewSHA1(uuid.NameSpace_OID, []byte(dedupKey)).String(),
+		Version:     version,
+		RunID:       runID,
+	}
+}
+
 func (d *nosqlExecutionStore) prepareResetWorkflowExecutionRequestWithMapsAndEventBuffer(resetWorkflow *persistence.InternalWorkflowSnapshot) (*nosqlplugin.WorkflowExecutionRequest, error) {
 	executionInfo := resetWorkflow.ExecutionInfo
 	lastWriteVersion := resetWorkflow.LastWriteVersion
@@ -784,6 +804,26 @@ func (d *nosqlExecutionStore) assertNotCurrentExecution(
 	return nil
 }
 
+func getLastWriteVersion(versionHistories *persistence.DataBlob) (int64, error) {
+	if versionHistories == nil || len(versionHistories.Data) == 0 {
+		return common.EmptyVersion, nil
+	}
+
+	histories, err := persistence.NewPayloadSerializer().DeserializeVersionHistories(versionHistories)
+	if err != nil {
+		return 0, err
+	}
+	currentHistory, err := persistence.NewVersionHistoriesFromInternalType(histories).GetCurrentVersionHistory()
+	if err != nil {
+		return 0, err
+	}
+	lastItem, err := currentHistory.GetLastItem()
+	if err != nil {
+		return 0, err
+	}
+	return lastItem.Version, nil
+}
+
 func getWorkflowRequestWriteMode(mode persistence.CreateWorkflowRequestMode) (nosqlplugin.WorkflowRequestWriteMode, error) {
 	switch mode {
 	case persistence.CreateWorkflowRequestModeNew:
@@ -22,6 +22,8 @@ package nosql
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/uber/cadence/common"
@@ -33,6 +35,8 @@ import (
 	"github.com/uber/cadence/common/types"
 )
 
+const truncateHistoryBranchPageSize = 1000
+
 type nosqlHistoryStore struct {
 	shardedNosqlStore
 }
@@ -408,6 +412,74 @@ func (h *nosqlHistoryStore) GetAllHistoryTreeBranches(
 	return response, nil
 }
 
+// TruncateHistoryBranch removes the nodes of a branch with node ID greater than request.LastNodeID
+func (h *nosqlHistoryStore) TruncateHistoryBranch(
+	ctx context.Context,
+	request *persistence.InternalTruncateHistoryBranchRequest,
+) error {
+
+	branch := request.BranchInfo
+	minNodeID := request.LastNodeID + 1
+	if beginNodeID := persistenceutils.GetBeginNodeID(branch); minNodeID < beginNodeID {
+		return &persistence.InvalidPersistenceRequestError{
+			Msg: fmt.Sprintf("cannot truncate branch %v before its begin node %v", branch.BranchID, beginNodeID),
+		}
+	}
+
+	storeShard, err := h.GetStoreShardByHistoryShard(request.ShardID)
+	if err != nil {
+		return err
+	}
+
+	// make sure none of the nodes to be removed were written by a newer transaction
+	filter := &nosqlplugin.HistoryNodeFilter{
+		ShardID:   request.ShardID,
+		TreeID:    branch.TreeID,
+		BranchID:  branch.BranchID,
+		MinNodeID: minNodeID,
+		MaxNodeID: math.MaxInt64,
+		PageSize:  truncateHistoryBranchPageSize,
+	}
+	for {
+		rows, pagingToken, err := storeShard.db.SelectFromHistoryNode(ctx, filter)
+		if err != nil {
+			return convertCommonErrors(storeShard.db, "TruncateHistoryBranch", err)
+		}
+		for _, row := range rows {
+			if *row.TxnID > request.TransactionID {
+				return &persistence.ConditionFailedError{
+					Msg: fmt.Sprintf("node %v was written by transaction %v which is newer than %v", row.NodeID, *row.TxnID, request.TransactionID),
+				}
+			}
+		}
+		if len(pagingToken) == 0 {
+			break
+		}
+		filter.NextPageToken = pagingToken
+	}
+
+	err = storeShard.db.DeleteFromHistoryTreeAndNode(ctx, nil, []*nosqlplugin.HistoryNodeFilter{
+		{
+			ShardID:   request.ShardID,
+			TreeID:    branch.TreeID,
+			BranchID:  branch.BranchID,
+			MinNodeID: minNodeID,
+		},
+	})
+	if err != nil {
+		return convertCommonErrors(storeShard.db, "TruncateHistoryBranch", err)
+	}
+	return nil
+}
+
+// BatchDeleteHistoryBranch removes multiple branches, reporting the outcome of each branch individually
+func (h *nosqlHistoryStore) BatchDeleteHistoryBranch(
+	ctx context.Context,
+	requests []persistence.InternalDeleteHistoryBranchRequest,
+) (*persistence.InternalBatchDeleteHistoryBranchResponse, error) {
+	return persistenceutils.BatchDeleteHistoryBranchSequentially(ctx, h, requests), nil
+}
+
 // GetHistoryTree returns all branch information of a tree
 func (h *nosqlHistoryStore) GetHistoryTree(
 	ctx context.Context,
@@ -429,6 +501,7 @@ func (h *nosqlHistoryStore) GetHistoryTree(
 	}
 
 	branches := make([]*types.HistoryBranch, 0)
+	branchInfos := make(map[string]string)
 	for _, dbBr := range dbBranches {
 		br := &types.HistoryBranch{
 			TreeID:    treeID,
@@ -436,8 +509,10 @@ func (h *nosqlHistoryStore) GetHistoryTree(
 			Ancestors: dbBr.Ancestors,
 		}
 		branches = append(branches, br)
+		branchInfos[br.BranchID] = dbBr.Info
 	}
 	return &persistence.InternalGetHistoryTreeResponse{
-		Branches: branches,
+		Branches:    branches,
+		BranchInfos: branchInfos,
 	}, nil
 }
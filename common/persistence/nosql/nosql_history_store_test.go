@@ -26,6 +26,7 @@ import (
 	ctx "context"
 	"errors"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -576,6 +577,129 @@ func TestDeleteHistoryBranch_usedBranch(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func validInternalTruncateHistoryBranchRequest() *persistence.InternalTruncateHistoryBranchRequest {
+	return &persistence.InternalTruncateHistoryBranchRequest{
+		BranchInfo: types.HistoryBranch{
+			TreeID:   "TestTreeID",
+			BranchID: "TestBranchID",
+			Ancestors: []*types.HistoryBranchRange{
+				{
+					BranchID:    "TestAncestorBranchID",
+					BeginNodeID: 1,
+					EndNodeID:   5,
+				},
+			},
+		},
+		LastNodeID:    10,
+		TransactionID: 100,
+		ShardID:       testShardID,
+	}
+}
+
+func TestTruncateHistoryBranch(t *testing.T) {
+	store, dbMock, _ := setUpMocks(t)
+
+	dbMock.EXPECT().SelectFromHistoryNode(gomock.Any(), &nosqlplugin.HistoryNodeFilter{
+		ShardID:   testShardID,
+		TreeID:    "TestTreeID",
+		BranchID:  "TestBranchID",
+		MinNodeID: 11,
+		MaxNodeID: math.MaxInt64,
+		PageSize:  truncateHistoryBranchPageSize,
+	}).Return([]*nosqlplugin.HistoryNodeRow{
+		{NodeID: 11, TxnID: common.Ptr(int64(90))},
+		{NodeID: 13, TxnID: common.Ptr(int64(95))},
+	}, nil, nil).Times(1)
+	// nodes up to 10 are preserved, nodes from 11 are removed, the branch record is kept
+	dbMock.EXPECT().DeleteFromHistoryTreeAndNode(gomock.Any(), nil, []*nosqlplugin.HistoryNodeFilter{
+		{
+			ShardID:   testShardID,
+			TreeID:    "TestTreeID",
+			BranchID:  "TestBranchID",
+			MinNodeID: 11,
+		},
+	}).Return(nil).Times(1)
+
+	err := store.TruncateHistoryBranch(ctx.Background(), validInternalTruncateHistoryBranchRequest())
+	assert.NoError(t, err)
+}
+
+func TestTruncateHistoryBranch_NewerTransaction(t *testing.T) {
+	store, dbMock, _ := setUpMocks(t)
+
+	dbMock.EXPECT().SelectFromHistoryNode(gomock.Any(), gomock.Any()).Return([]*nosqlplugin.HistoryNodeRow{
+		{NodeID: 11, TxnID: common.Ptr(int64(101))},
+	}, nil, nil).Times(1)
+
+	err := store.TruncateHistoryBranch(ctx.Background(), validInternalTruncateHistoryBranchRequest())
+	var conditionFailedErr *persistence.ConditionFailedError
+	assert.ErrorAs(t, err, &conditionFailedErr)
+}
+
+func TestTruncateHistoryBranch_BeforeBeginNode(t *testing.T) {
+	store, _, _ := setUpMocks(t)
+
+	request := validInternalTruncateHistoryBranchRequest()
+	request.LastNodeID = 3
+
+	err := store.TruncateHistoryBranch(ctx.Background(), request)
+	var invalidErr *persistence.InvalidPersistenceRequestError
+	assert.ErrorAs(t, err, &invalidErr)
+}
+
+func TestBatchDeleteHistoryBranch_partialFailure(t *testing.T) {
+	store, dbMock, _ := setUpMocks(t)
+
+	failing := *getValidInternalDeleteHistoryBranchRequest()
+	failing.BranchInfo.TreeID = "FailingTreeID"
+	requests := []persistence.InternalDeleteHistoryBranchRequest{
+		*getValidInternalDeleteHistoryBranchRequest(),
+		failing,
+		*getValidInternalDeleteHistoryBranchRequest(),
+	}
+
+	dbErr := errors.New("db error")
+	dbMock.EXPECT().SelectFromHistoryTree(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ ctx.Context, filter *nosqlplugin.HistoryTreeFilter) ([]*nosqlplugin.HistoryTreeRow, error) {
+			if filter.TreeID == "FailingTreeID" {
+				return nil, dbErr
+			}
+			return nil, nil
+		}).Times(3)
+	dbMock.EXPECT().IsNotFoundError(dbErr).Return(false).AnyTimes()
+	dbMock.EXPECT().IsTimeoutError(dbErr).Return(false).AnyTimes()
+	dbMock.EXPECT().IsThrottlingError(dbErr).Return(false).AnyTimes()
+	dbMock.EXPECT().IsDBUnavailableError(dbErr).Return(false).AnyTimes()
+	dbMock.EXPECT().DeleteFromHistoryTreeAndNode(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil).Times(2)
+
+	resp, err := store.BatchDeleteHistoryBranch(ctx.Background(), requests)
+	require.NoError(t, err)
+	require.Len(t, resp.Errors, 3)
+	assert.NoError(t, resp.Errors[0])
+	assert.Error(t, resp.Errors[1])
+	assert.NoError(t, resp.Errors[2])
+}
+
+func TestBatchDeleteHistoryBranch_contextCanceled(t *testing.T) {
+	store, _, _ := setUpMocks(t)
+
+	requests := []persistence.InternalDeleteHistoryBranchRequest{
+		*getValidInternalDeleteHistoryBranchRequest(),
+		*getValidInternalDeleteHistoryBranchRequest(),
+	}
+
+	cancelledCtx, cancel := ctx.WithCancel(ctx.Background())
+	cancel()
+
+	resp, err := store.BatchDeleteHistoryBranch(cancelledCtx, requests)
+	require.NoError(t, err)
+	require.Len(t, resp.Errors, 2)
+	for _, branchErr := range resp.Errors {
+		assert.ErrorIs(t, branchErr, ctx.Canceled)
+	}
+}
+
 func TestGetAllHistoryTreeBranches(t *testing.T) {
 	request := &persistence.GetAllHistoryTreeBranchesRequest{
 		NextPageToken: []byte("nextPageToken"),
@@ -104,6 +104,49 @@ func (q *nosqlQueueStore) EnqueueMessage(
 	return err
 }
 
+func (q *nosqlQueueStore) EnqueueMessages(
+	ctx context.Context,
+	messagePayloads [][]byte,
+) ([]int64, error) {
+	if len(messagePayloads) == 0 {
+		return nil, nil
+	}
+
+	lastMessageID, err := q.getLastMessageID(ctx, q.queueType)
+	if err != nil {
+		return nil, err
+	}
+	ackLevels, err := q.GetAckLevels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nextID := getNextID(ackLevels, lastMessageID)
+	rows := make([]*nosqlplugin.QueueMessageRow, 0, len(messagePayloads))
+	messageIDs := make([]int64, 0, len(messagePayloads))
+	for i, payload := range messagePayloads {
+		messageID := nextID + int64(i)
+		rows = append(rows, &nosqlplugin.QueueMessageRow{
+			QueueType: q.queueType,
+			ID:        messageID,
+			Payload:   payload,
+		})
+		messageIDs = append(messageIDs, messageID)
+	}
+
+	if err := q.db.BatchInsertIntoQueue(ctx, rows); err != nil {
+		if _, ok := err.(*nosqlplugin.ConditionFailure); ok {
+			return nil, &persistence.ConditionFailedError{
+				Msg: fmt.Sprintf("message IDs [%v, %v] conflict with existing messages in queue", nextID, messageIDs[len(messageIDs)-1]),
+			}
+		}
+
+		return nil, convertCommonErrors(q.db, fmt.Sprintf("EnqueueMessages, Type: %v", q.queueType), err)
+	}
+
+	return messageIDs, nil
+}
+
 func (q *nosqlQueueStore) EnqueueMessageToDLQ(
 	ctx context.Context,
 	messagePayload []byte,
@@ -253,6 +253,63 @@ func TestEnqueueMessage_FailsIfCantInsertMessageToQueue(t *testing.T) {
 	assert.ErrorContains(t, store.EnqueueMessage(ctx, testPayload), errInsert.Error())
 }
 
+func TestEnqueueMessages_Succeeds(t *testing.T) {
+	const lastMessageID = int64(123)
+	td := newQueueStoreTestData(t)
+	store := td.createValidQueueStore(t)
+	ctx := context.Background()
+
+	clusterAckLevels := map[string]int64{"cluster1": lastMessageID + 10}
+	payloads := [][]byte{[]byte("first"), []byte("second"), []byte("third")}
+
+	td.mockDB.EXPECT().SelectLastEnqueuedMessageID(ctx, testQueueType).Return(lastMessageID, nil)
+	td.mockDB.EXPECT().SelectQueueMetadata(ctx, testQueueType).
+		Return(&nosqlplugin.QueueMetadataRow{ClusterAckLevels: clusterAckLevels}, nil)
+	td.mockDB.EXPECT().BatchInsertIntoQueue(ctx, []*nosqlplugin.QueueMessageRow{
+		{QueueType: testQueueType, ID: lastMessageID + 11, Payload: payloads[0]},
+		{QueueType: testQueueType, ID: lastMessageID + 12, Payload: payloads[1]},
+		{QueueType: testQueueType, ID: lastMessageID + 13, Payload: payloads[2]},
+	}).Return(nil)
+
+	ids, err := store.EnqueueMessages(ctx, payloads)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{lastMessageID + 11, lastMessageID + 12, lastMessageID + 13}, ids)
+}
+
+func TestEnqueueMessages_FailsOnConditionFailure(t *testing.T) {
+	td := newQueueStoreTestData(t)
+	store := td.createValidQueueStore(t)
+	ctx := context.Background()
+
+	td.mockDB.EXPECT().SelectLastEnqueuedMessageID(ctx, testQueueType).Return(int64(0), nil)
+	td.mockDB.EXPECT().SelectQueueMetadata(ctx, testQueueType).
+		Return(&nosqlplugin.QueueMetadataRow{}, nil)
+	td.mockDB.EXPECT().BatchInsertIntoQueue(ctx, gomock.Len(2)).
+		Return(nosqlplugin.NewConditionFailure("queue"))
+
+	ids, err := store.EnqueueMessages(ctx, [][]byte{testPayload, testPayload})
+	assert.Nil(t, ids)
+	var condErr *persistence.ConditionFailedError
+	assert.ErrorAs(t, err, &condErr)
+}
+
+func TestEnqueueMessages_FailsIfCantInsertMessagesToQueue(t *testing.T) {
+	errInsert := errors.New("fail to insert into queue")
+	td := newQueueStoreTestData(t)
+	store := td.createValidQueueStore(t)
+	ctx := context.Background()
+
+	td.mockDB.EXPECT().SelectLastEnqueuedMessageID(ctx, testQueueType).Return(int64(0), nil)
+	td.mockDB.EXPECT().SelectQueueMetadata(ctx, testQueueType).
+		Return(&nosqlplugin.QueueMetadataRow{}, nil)
+	td.mockDB.EXPECT().BatchInsertIntoQueue(ctx, gomock.Any()).Return(errInsert)
+	td.mockErrConversion(errInsert)
+
+	ids, err := store.EnqueueMessages(ctx, [][]byte{testPayload})
+	assert.Nil(t, ids)
+	assert.ErrorContains(t, err, errInsert.Error())
+}
+
 func TestEnqueueMessageToDLQ_Succeeds(t *testing.T) {
 	const dlqMessageType = -testQueueType
 	lastMessageID := int64(123)
@@ -23,6 +23,7 @@ package nosql
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/log"
@@ -176,3 +177,27 @@ func (sh *nosqlShardStore) UpdateShard(
 
 	return nil
 }
+
+func (sh *nosqlShardStore) ListShardsByOwner(
+	ctx context.Context,
+	owner string,
+) ([]int, error) {
+	storeShards, err := sh.GetStoreShardsForHistory()
+	if err != nil {
+		return nil, err
+	}
+	var shardIDs []int
+	for _, storeShard := range storeShards {
+		shards, err := storeShard.db.SelectAllShards(ctx, sh.currentClusterName)
+		if err != nil {
+			return nil, convertCommonErrors(storeShard.db, "ListShardsByOwner", err)
+		}
+		for _, shard := range shards {
+			if shard.Owner == owner {
+				shardIDs = append(shardIDs, shard.ShardID)
+			}
+		}
+	}
+	sort.Ints(shardIDs)
+	return shardIDs, nil
+}
@@ -0,0 +1,124 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+
+package nosql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+)
+
+func TestListShardsByOwner(t *testing.T) {
+	testCases := []struct {
+		name      string
+		owner     string
+		mockSetup func(*MockshardedNosqlStore, *nosqlplugin.MockDB, *nosqlplugin.MockDB)
+		want      []int
+		wantErr   bool
+	}{
+		{
+			name:  "success with shards owned by multiple hosts",
+			owner: "host-a",
+			mockSetup: func(sharded *MockshardedNosqlStore, db1, db2 *nosqlplugin.MockDB) {
+				sharded.EXPECT().GetStoreShardsForHistory().Return([]*nosqlStore{
+					{db: db1, logger: log.NewNoop()},
+					{db: db2, logger: log.NewNoop()},
+				}, nil)
+				db1.EXPECT().SelectAllShards(gomock.Any(), "active").Return([]*nosqlplugin.ShardRow{
+					{ShardID: 3, Owner: "host-a"},
+					{ShardID: 0, Owner: "host-b"},
+					{ShardID: 1, Owner: "host-a"},
+				}, nil)
+				db2.EXPECT().SelectAllShards(gomock.Any(), "active").Return([]*nosqlplugin.ShardRow{
+					{ShardID: 2, Owner: "host-c"},
+					{ShardID: 4, Owner: "host-a"},
+				}, nil)
+			},
+			want: []int{1, 3, 4},
+		},
+		{
+			name:  "no shards owned by host",
+			owner: "host-d",
+			mockSetup: func(sharded *MockshardedNosqlStore, db1, db2 *nosqlplugin.MockDB) {
+				sharded.EXPECT().GetStoreShardsForHistory().Return([]*nosqlStore{
+					{db: db1, logger: log.NewNoop()},
+				}, nil)
+				db1.EXPECT().SelectAllShards(gomock.Any(), "active").Return([]*nosqlplugin.ShardRow{
+					{ShardID: 0, Owner: "host-a"},
+				}, nil)
+			},
+			want: nil,
+		},
+		{
+			name:  "failed to get store shards",
+			owner: "host-a",
+			mockSetup: func(sharded *MockshardedNosqlStore, db1, db2 *nosqlplugin.MockDB) {
+				sharded.EXPECT().GetStoreShardsForHistory().Return(nil, &ShardingError{Message: "unknown db shard"})
+			},
+			wantErr: true,
+		},
+		{
+			name:  "failed to scan shards",
+			owner: "host-a",
+			mockSetup: func(sharded *MockshardedNosqlStore, db1, db2 *nosqlplugin.MockDB) {
+				err := errors.New("scan failed")
+				sharded.EXPECT().GetStoreShardsForHistory().Return([]*nosqlStore{
+					{db: db1, logger: log.NewNoop()},
+				}, nil)
+				db1.EXPECT().SelectAllShards(gomock.Any(), "active").Return(nil, err)
+				db1.EXPECT().IsNotFoundError(err).Return(false)
+				db1.EXPECT().IsTimeoutError(err).Return(false)
+				db1.EXPECT().IsThrottlingError(err).Return(false)
+				db1.EXPECT().IsDBUnavailableError(err).Return(false)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			sharded := NewMockshardedNosqlStore(ctrl)
+			db1 := nosqlplugin.NewMockDB(ctrl)
+			db2 := nosqlplugin.NewMockDB(ctrl)
+			tc.mockSetup(sharded, db1, db2)
+
+			store := &nosqlShardStore{
+				shardedNosqlStore:  sharded,
+				currentClusterName: "active",
+			}
+			got, err := store.ListShardsByOwner(context.Background(), tc.owner)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -88,6 +88,22 @@ func (t *nosqlTaskStore) GetTaskListSize(ctx context.Context, request *persisten
 	return &persistence.GetTaskListSizeResponse{Size: size}, nil
 }
 
+func (t *nosqlTaskStore) GetTaskIDRange(ctx context.Context, request *persistence.GetTaskIDRangeRequest) (*persistence.GetTaskIDRangeResponse, error) {
+	storeShard, err := t.GetStoreShardByTaskList(request.DomainID, request.TaskListName, request.TaskListType)
+	if err != nil {
+		return nil, err
+	}
+	minTaskID, maxTaskID, err := storeShard.db.GetTaskIDRange(ctx, &nosqlplugin.TaskListFilter{
+		DomainID:     request.DomainID,
+		TaskListName: request.TaskListName,
+		TaskListType: request.TaskListType,
+	})
+	if err != nil {
+		return nil, convertCommonErrors(storeShard.db, "GetTaskIDRange", err)
+	}
+	return &persistence.GetTaskIDRangeResponse{MinTaskID: minTaskID, MaxTaskID: maxTaskID}, nil
+}
+
 func (t *nosqlTaskStore) LeaseTaskList(
 	ctx context.Context,
 	request *persistence.LeaseTaskListRequest,
@@ -221,6 +237,16 @@ func (t *nosqlTaskStore) ListTaskList(
 	}
 }
 
+func (t *nosqlTaskStore) GetTaskListsByDomain(
+	_ context.Context,
+	_ *persistence.GetTaskListsByDomainRequest,
+) (*persistence.GetTaskListsByDomainResponse, error) {
+	// task lists are not indexed by domain and ListTaskList is not supported either
+	return nil, &types.InternalServiceError{
+		Message: "unsupported operation",
+	}
+}
+
 func (t *nosqlTaskStore) DeleteTaskList(
 	ctx context.Context,
 	request *persistence.DeleteTaskListRequest,
@@ -256,7 +282,9 @@ func (t *nosqlTaskStore) CreateTasks(
 ) (*persistence.CreateTasksResponse, error) {
 	now := time.Now()
 	var tasks []*nosqlplugin.TaskRowForInsert
+	taskIDs := make([]int64, 0, len(request.Tasks))
 	for _, t := range request.Tasks {
+		taskIDs = append(taskIDs, t.TaskID)
 		task := &nosqlplugin.TaskRow{
 			DomainID:        request.TaskListInfo.DomainID,
 			TaskListName:    request.TaskListInfo.Name,
@@ -301,7 +329,7 @@ func (t *nosqlTaskStore) CreateTasks(
 		return nil, convertCommonErrors(storeShard.db, "CreateTasks", err)
 	}
 
-	return &persistence.CreateTasksResponse{}, nil
+	return &persistence.CreateTasksResponse{TaskIDs: taskIDs}, nil
 }
 
 func (t *nosqlTaskStore) GetTasks(
@@ -388,7 +416,8 @@ func (t *nosqlTaskStore) CompleteTask(
 
 // CompleteTasksLessThan deletes all tasks less than or equal to the given task id. This API ignores the
 // Limit request parameter i.e. either all tasks leq the task_id will be deleted or an error will
-// be returned to the caller
+// be returned to the caller. The ExactCount request parameter is ignored as well, plugins that delete
+// by range cannot count the deleted rows and return persistence.UnknownNumRowsAffected
 func (t *nosqlTaskStore) CompleteTasksLessThan(
 	ctx context.Context,
 	request *persistence.CompleteTasksLessThanRequest,
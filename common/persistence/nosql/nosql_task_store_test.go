@@ -116,6 +116,68 @@ func TestGetTaskListSize(t *testing.T) {
 	)
 }
 
+func TestGetTaskIDRange(t *testing.T) {
+	testCases := []struct {
+		name    string
+		minID   int64
+		maxID   int64
+		dbErr   error
+		want    *persistence.GetTaskIDRangeResponse
+		wantErr bool
+	}{
+		{
+			name: "empty task list",
+			want: &persistence.GetTaskIDRangeResponse{MinTaskID: 0, MaxTaskID: 0},
+		},
+		{
+			name:  "populated task list",
+			minID: 10,
+			maxID: 42,
+			want:  &persistence.GetTaskIDRangeResponse{MinTaskID: 10, MaxTaskID: 42},
+		},
+		{
+			name:    "db error",
+			dbErr:   assert.AnError,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store, db := setupNoSQLStoreMocks(t)
+
+			db.EXPECT().GetTaskIDRange(gomock.Any(), getDecisionTaskListFilter()).Return(tc.minID, tc.maxID, tc.dbErr)
+			if tc.dbErr != nil {
+				db.EXPECT().IsNotFoundError(tc.dbErr).Return(true)
+			}
+
+			got, err := store.GetTaskIDRange(context.Background(), &persistence.GetTaskIDRangeRequest{
+				DomainID:     TestDomainID,
+				TaskListName: TestTaskListName,
+				TaskListType: int(types.TaskListTypeDecision),
+			})
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGetTaskListsByDomain(t *testing.T) {
+	store, _ := setupNoSQLStoreMocks(t)
+
+	_, err := store.GetTaskListsByDomain(context.Background(), &persistence.GetTaskListsByDomainRequest{
+		DomainID: TestDomainID,
+	})
+
+	assert.ErrorAs(t, err, new(*types.InternalServiceError))
+	assert.ErrorContains(t, err, "unsupported operation")
+}
+
 func TestLeaseTaskList_emptyTaskList(t *testing.T) {
 	store, _ := setupNoSQLStoreMocks(t)
 
@@ -420,6 +482,63 @@ func TestCompleteTasksLessThan(t *testing.T) {
 	assert.Equal(t, 13, resp.TasksCompleted)
 }
 
+func TestCompleteTasksLessThan_ExactCountUnsupported(t *testing.T) {
+	store, db := setupNoSQLStoreMocks(t)
+
+	db.EXPECT().RangeDeleteTasks(gomock.Any(), &nosqlplugin.TasksFilter{
+		TaskListFilter: *getDecisionTaskListFilter(),
+		MinTaskID:      0,
+		MaxTaskID:      12,
+		BatchSize:      100,
+	}).Return(persistence.UnknownNumRowsAffected, nil)
+
+	resp, err := store.CompleteTasksLessThan(context.Background(), &persistence.CompleteTasksLessThanRequest{
+		DomainID:     TestDomainID,
+		TaskListName: TestTaskListName,
+		TaskType:     0,
+		TaskID:       12,
+		Limit:        100,
+		DomainName:   TestDomainName,
+		ExactCount:   true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, persistence.UnknownNumRowsAffected, resp.TasksCompleted)
+}
+
+func TestCreateTasks(t *testing.T) {
+	store, db := setupNoSQLStoreMocks(t)
+
+	db.EXPECT().InsertTasks(gomock.Any(), gomock.Any(), getCreateTasksConditionRow()).DoAndReturn(
+		func(_ context.Context, tasks []*nosqlplugin.TaskRowForInsert, _ *nosqlplugin.TaskListRow) error {
+			assert.Len(t, tasks, 3)
+			assert.Equal(t, int64(12), tasks[0].TaskID)
+			assert.Equal(t, int64(10), tasks[1].TaskID)
+			assert.Equal(t, int64(11), tasks[2].TaskID)
+			return nil
+		})
+
+	resp, err := store.CreateTasks(context.Background(), getValidCreateTasksRequest(12, 10, 11))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{12, 10, 11}, resp.TaskIDs)
+}
+
+func TestCreateTasks_ConditionFailure(t *testing.T) {
+	store, db := setupNoSQLStoreMocks(t)
+
+	db.EXPECT().InsertTasks(gomock.Any(), gomock.Any(), getCreateTasksConditionRow()).Return(&nosqlplugin.TaskOperationConditionFailure{
+		Details: "test-details",
+	})
+
+	resp, err := store.CreateTasks(context.Background(), getValidCreateTasksRequest(12))
+
+	assert.Nil(t, resp)
+	var expectedErr *persistence.ConditionFailedError
+	assert.ErrorAs(t, err, &expectedErr)
+	assert.ErrorContains(t, err, "Failed to insert tasks. name: test-tasklist, type: 0, rangeID: 1, columns: (test-details)")
+}
+
 func getValidLeaseTaskListRequest() *persistence.LeaseTaskListRequest {
 	return &persistence.LeaseTaskListRequest{
 		DomainID:     TestDomainID,
@@ -514,3 +633,30 @@ func getValidDeleteTaskListRequest() *persistence.DeleteTaskListRequest {
 		RangeID:      0,
 	}
 }
+
+func getValidCreateTasksRequest(taskIDs ...int64) *persistence.InternalCreateTasksRequest {
+	request := &persistence.InternalCreateTasksRequest{
+		TaskListInfo: getExpectedTaskListInfo(),
+	}
+	for _, taskID := range taskIDs {
+		request.Tasks = append(request.Tasks, &persistence.InternalCreateTasksInfo{
+			TaskID: taskID,
+			Data: &persistence.InternalTaskInfo{
+				DomainID:   TestDomainID,
+				WorkflowID: TestWorkflowID,
+				RunID:      TestRunID,
+				TaskID:     taskID,
+			},
+		})
+	}
+	return request
+}
+
+func getCreateTasksConditionRow() *nosqlplugin.TaskListRow {
+	return &nosqlplugin.TaskListRow{
+		DomainID:     TestDomainID,
+		TaskListName: TestTaskListName,
+		TaskListType: int(types.TaskListTypeDecision),
+		RangeID:      initialRangeID,
+	}
+}
@@ -67,6 +67,7 @@ const (
 	rowTypeWorkflowRequestSignal
 	rowTypeWorkflowRequestCancel
 	rowTypeWorkflowRequestReset
+	rowTypeWorkflowRequestDedupKey
 )
 
 // Guidelines for creating new special UUID constants
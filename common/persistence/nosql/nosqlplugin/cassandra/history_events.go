@@ -100,7 +100,9 @@ func (db *cdb) SelectFromHistoryNode(ctx context.Context, filter *nosqlplugin.Hi
 // DeleteFromHistoryTreeAndNode delete a branch record, and a list of ranges of nodes.
 func (db *cdb) DeleteFromHistoryTreeAndNode(ctx context.Context, treeFilter *nosqlplugin.HistoryTreeFilter, nodeFilters []*nosqlplugin.HistoryNodeFilter) error {
 	batch := db.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
-	batch.Query(v2templateDeleteBranch, treeFilter.TreeID, treeFilter.BranchID)
+	if treeFilter != nil {
+		batch.Query(v2templateDeleteBranch, treeFilter.TreeID, treeFilter.BranchID)
+	}
 	for _, nodeFilter := range nodeFilters {
 		batch.Query(v2templateRangeDeleteData,
 			nodeFilter.TreeID,
@@ -166,6 +168,7 @@ func (db *cdb) SelectFromHistoryTree(ctx context.Context, filter *nosqlplugin.Hi
 				TreeID:    filter.TreeID,
 				BranchID:  branchUUID,
 				Ancestors: ancs,
+				Info:      info,
 			}
 			rows = append(rows, row)
 
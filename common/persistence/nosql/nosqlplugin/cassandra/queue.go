@@ -26,6 +26,7 @@ import (
 
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin/cassandra/gocql"
 )
 
 // Insert message into queue, return error if failed or already exists
@@ -47,6 +48,28 @@ func (db *cdb) InsertIntoQueue(
 	return nil
 }
 
+// Insert multiple messages into queue in a single conditional batch
+// Must return ConditionFailure error if any row already exists
+func (db *cdb) BatchInsertIntoQueue(
+	ctx context.Context,
+	rows []*nosqlplugin.QueueMessageRow,
+) error {
+	batch := db.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	for _, row := range rows {
+		batch.Query(templateEnqueueMessageQuery, row.QueueType, row.ID, row.Payload)
+	}
+	previous := make(map[string]interface{})
+	applied, _, err := db.session.MapExecuteBatchCAS(batch, previous)
+	if err != nil {
+		return err
+	}
+
+	if !applied {
+		return nosqlplugin.NewConditionFailure("queue")
+	}
+	return nil
+}
+
 // Get the ID of last message inserted into the queue
 func (db *cdb) SelectLastEnqueuedMessageID(
 	ctx context.Context,
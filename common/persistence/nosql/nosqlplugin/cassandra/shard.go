@@ -29,6 +29,7 @@ import (
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/common/types"
 )
 
 // InsertShard creates a new shard, return error is there is any.
@@ -116,6 +117,29 @@ func (db *cdb) SelectShard(ctx context.Context, shardID int, currentClusterName
 	return rangeID, convertToShardInfo(currentClusterName, shardInfoRangeID, shard), nil
 }
 
+// SelectAllShards scans all shards
+func (db *cdb) SelectAllShards(ctx context.Context, currentClusterName string) ([]*nosqlplugin.ShardRow, error) {
+	query := db.session.Query(templateListShardsQuery,
+		rowTypeShard,
+	).WithContext(ctx)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &types.InternalServiceError{
+			Message: "SelectAllShards operation failed. Not able to create query iterator.",
+		}
+	}
+	var shards []*nosqlplugin.ShardRow
+	result := make(map[string]interface{})
+	for iter.MapScan(result) {
+		shard := result["shard"].(map[string]interface{})
+		shardInfoRangeID := shard["range_id"].(int64)
+		shards = append(shards, convertToShardInfo(currentClusterName, shardInfoRangeID, shard))
+		result = make(map[string]interface{})
+	}
+	return shards, iter.Close()
+}
+
 func convertToShardInfo(
 	currentCluster string,
 	rangeID int64,
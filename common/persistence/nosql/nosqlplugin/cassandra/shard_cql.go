@@ -58,6 +58,11 @@ const (
 		`and visibility_ts = ? ` +
 		`and task_id = ?`
 
+	templateListShardsQuery = `SELECT shard, range_id ` +
+		`FROM executions ` +
+		`WHERE type = ? ` +
+		`ALLOW FILTERING`
+
 	templateUpdateShardQuery = `UPDATE executions ` +
 		`SET shard = ` + templateShardType + `, range_id = ? ` +
 		`WHERE shard_id = ? ` +
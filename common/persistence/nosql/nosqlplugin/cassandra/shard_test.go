@@ -286,6 +286,112 @@ func TestSelectShard(t *testing.T) {
 	}
 }
 
+func TestSelectAllShards(t *testing.T) {
+	ts, err := time.Parse(time.RFC3339, "2024-04-02T18:00:00Z")
+	if err != nil {
+		t.Fatalf("Failed to parse time: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		cluster      string
+		itrMockFn    func(itr *gocql.MockIter)
+		nilIter      bool
+		wantShardIDs []int
+		wantOwners   []string
+		wantErr      bool
+	}{
+		{
+			name:    "success",
+			cluster: "cluster1",
+			itrMockFn: func(itr *gocql.MockIter) {
+				for i, owner := range []string{"host-a", "host-b"} {
+					shardID := i + 1
+					owner := owner
+					itr.EXPECT().MapScan(gomock.Any()).DoAndReturn(func(m map[string]interface{}) bool {
+						shard := testdata.NewShardMap(ts)
+						shard["shard_id"] = shardID
+						shard["owner"] = owner
+						m["range_id"] = int64(1000)
+						m["shard"] = shard
+						return true
+					}).Times(1)
+				}
+				itr.EXPECT().MapScan(gomock.Any()).Return(false).Times(1)
+				itr.EXPECT().Close().Return(nil).Times(1)
+			},
+			wantShardIDs: []int{1, 2},
+			wantOwners:   []string{"host-a", "host-b"},
+		},
+		{
+			name:    "iterator close failed",
+			cluster: "cluster1",
+			itrMockFn: func(itr *gocql.MockIter) {
+				itr.EXPECT().MapScan(gomock.Any()).Return(false).Times(1)
+				itr.EXPECT().Close().Return(errors.New("close failed")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "nil iterator",
+			cluster: "cluster1",
+			nilIter: true,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			query := gocql.NewMockQuery(ctrl)
+			query.EXPECT().WithContext(gomock.Any()).Return(query).Times(1)
+			if tc.nilIter {
+				query.EXPECT().Iter().Return(nil).Times(1)
+			} else {
+				itr := gocql.NewMockIter(ctrl)
+				tc.itrMockFn(itr)
+				query.EXPECT().Iter().Return(itr).Times(1)
+			}
+			session := &fakeSession{
+				query: query,
+			}
+			client := gocql.NewMockClient(ctrl)
+			cfg := &config.NoSQL{}
+			logger := testlogger.New(t)
+			dc := &persistence.DynamicConfiguration{}
+			db := newCassandraDBFromSession(cfg, session, logger, dc, dbWithClient(client))
+
+			gotShards, err := db.SelectAllShards(context.Background(), tc.cluster)
+
+			if (err != nil) != tc.wantErr {
+				t.Errorf("SelectAllShards() error = %v, wantErr %v", err, tc.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			var gotShardIDs []int
+			var gotOwners []string
+			for _, shard := range gotShards {
+				gotShardIDs = append(gotShardIDs, shard.ShardID)
+				gotOwners = append(gotOwners, shard.Owner)
+			}
+			if diff := cmp.Diff(tc.wantShardIDs, gotShardIDs); diff != "" {
+				t.Fatalf("ShardID mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantOwners, gotOwners); diff != "" {
+				t.Fatalf("Owner mismatch (-want +got):\n%s", diff)
+			}
+
+			wantQueries := []string{`SELECT shard, range_id FROM executions WHERE type = 0 ALLOW FILTERING`}
+			if diff := cmp.Diff(wantQueries, session.queries); diff != "" {
+				t.Fatalf("Query mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestUpdateRangeID(t *testing.T) {
 	ts, err := time.Parse(time.RFC3339, "2024-04-02T18:00:00Z")
 	if err != nil {
@@ -316,6 +316,25 @@ func (db *cdb) GetTasksCount(ctx context.Context, filter *nosqlplugin.TasksFilte
 	return queueSize, nil
 }
 
+// GetTaskIDRange returns the min and max task ID of a tasklist, both are 0 if there are no tasks
+func (db *cdb) GetTaskIDRange(ctx context.Context, filter *nosqlplugin.TaskListFilter) (int64, int64, error) {
+	query := db.session.Query(templateGetTaskIDRangeQuery,
+		filter.DomainID,
+		filter.TaskListName,
+		filter.TaskListType,
+		rowTypeTask,
+	).WithContext(ctx)
+	result := make(map[string]interface{})
+	if err := query.MapScan(result); err != nil {
+		return 0, 0, err
+	}
+
+	// aggregates over no rows are null
+	minTaskID, _ := result["min_task_id"].(int64)
+	maxTaskID, _ := result["max_task_id"].(int64)
+	return minTaskID, maxTaskID, nil
+}
+
 // SelectTasks return tasks that associated to a tasklist
 func (db *cdb) SelectTasks(ctx context.Context, filter *nosqlplugin.TasksFilter) ([]*nosqlplugin.TaskRow, error) {
 	// Reading tasklist tasks need to be quorum level consistent, otherwise we could loose task
@@ -65,6 +65,13 @@ const (
 		`and type = ? ` +
 		`and task_id > ? `
 
+	templateGetTaskIDRangeQuery = `SELECT min(task_id) as min_task_id, max(task_id) as max_task_id ` +
+		`FROM tasks ` +
+		`WHERE domain_id = ? ` +
+		`and task_list_name = ? ` +
+		`and task_list_type = ? ` +
+		`and type = ? `
+
 	templateCompleteTasksLessThanQuery = `DELETE FROM tasks ` +
 		`WHERE domain_id = ? ` +
 		`AND task_list_name = ? ` +
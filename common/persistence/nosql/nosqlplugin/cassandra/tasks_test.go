@@ -823,6 +823,107 @@ func TestGetTasksCount(t *testing.T) {
 	}
 }
 
+func TestGetTaskIDRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      *nosqlplugin.TaskListFilter
+		queryMockFn func(query *gocql.MockQuery)
+		wantQueries []string
+		wantMinID   int64
+		wantMaxID   int64
+		wantErr     bool
+	}{
+		{
+			name: "success",
+			filter: &nosqlplugin.TaskListFilter{
+				DomainID:     "domain1",
+				TaskListName: "tasklist1",
+				TaskListType: 1,
+			},
+			queryMockFn: func(query *gocql.MockQuery) {
+				query.EXPECT().WithContext(gomock.Any()).Return(query).Times(1)
+				query.EXPECT().MapScan(gomock.Any()).DoAndReturn(func(result map[string]interface{}) error {
+					result["min_task_id"] = int64(10)
+					result["max_task_id"] = int64(42)
+					return nil
+				}).Times(1)
+			},
+			wantMinID: 10,
+			wantMaxID: 42,
+			wantQueries: []string{
+				`SELECT min(task_id) as min_task_id, max(task_id) as max_task_id FROM tasks WHERE domain_id = domain1 and task_list_name = tasklist1 and task_list_type = 1 and type = 0 `,
+			},
+		},
+		{
+			name: "empty task list",
+			filter: &nosqlplugin.TaskListFilter{
+				DomainID:     "domain1",
+				TaskListName: "tasklist1",
+				TaskListType: 1,
+			},
+			queryMockFn: func(query *gocql.MockQuery) {
+				query.EXPECT().WithContext(gomock.Any()).Return(query).Times(1)
+				query.EXPECT().MapScan(gomock.Any()).DoAndReturn(func(result map[string]interface{}) error {
+					result["min_task_id"] = nil
+					result["max_task_id"] = nil
+					return nil
+				}).Times(1)
+			},
+			wantQueries: []string{
+				`SELECT min(task_id) as min_task_id, max(task_id) as max_task_id FROM tasks WHERE domain_id = domain1 and task_list_name = tasklist1 and task_list_type = 1 and type = 0 `,
+			},
+		},
+		{
+			name: "scan failure",
+			filter: &nosqlplugin.TaskListFilter{
+				DomainID:     "domain1",
+				TaskListName: "tasklist1",
+				TaskListType: 1,
+			},
+			queryMockFn: func(query *gocql.MockQuery) {
+				query.EXPECT().WithContext(gomock.Any()).Return(query).Times(1)
+				query.EXPECT().MapScan(gomock.Any()).Return(errors.New("some random error")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			query := gocql.NewMockQuery(ctrl)
+			tc.queryMockFn(query)
+			session := &fakeSession{
+				query: query,
+			}
+			client := gocql.NewMockClient(ctrl)
+			cfg := &config.NoSQL{}
+			logger := testlogger.New(t)
+			dc := &persistence.DynamicConfiguration{}
+
+			db := newCassandraDBFromSession(cfg, session, logger, dc, dbWithClient(client))
+
+			minID, maxID, err := db.GetTaskIDRange(context.Background(), tc.filter)
+
+			if (err != nil) != tc.wantErr {
+				t.Errorf("GetTaskIDRange() error = %v, wantErr %v", err, tc.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.wantQueries, session.queries); diff != "" {
+				t.Fatalf("Query mismatch (-want +got):\n%s", diff)
+			}
+
+			if minID != tc.wantMinID || maxID != tc.wantMaxID {
+				t.Fatalf("Got task ID range: [%d, %d], want: [%d, %d]", minID, maxID, tc.wantMinID, tc.wantMaxID)
+			}
+		})
+	}
+}
+
 func TestSelectTasks(t *testing.T) {
 	ts, err := time.Parse(time.RFC3339, "2024-04-01T22:08:41Z")
 	if err != nil {
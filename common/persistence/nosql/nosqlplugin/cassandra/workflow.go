@@ -294,6 +294,55 @@ func (db *cdb) DeleteWorkflowExecution(ctx context.Context, shardID int, domainI
 	return db.executeWithConsistencyAll(query)
 }
 
+func (db *cdb) SelectWorkflowRequests(ctx context.Context, shardID int, domainID, workflowID, runID string) ([]*nosqlplugin.WorkflowRequestRow, error) {
+	var rows []*nosqlplugin.WorkflowRequestRow
+	for _, rowType := range []int{
+		rowTypeWorkflowRequestStart,
+		rowTypeWorkflowRequestSignal,
+		rowTypeWorkflowRequestCancel,
+		rowTypeWorkflowRequestReset,
+	} {
+		requestType, err := fromRequestRowType(rowType)
+		if err != nil {
+			return nil, err
+		}
+		query := db.session.Query(templateListWorkflowRequestsQuery,
+			shardID,
+			rowType,
+			domainID,
+			workflowID,
+		).WithContext(ctx)
+
+		iter := query.Iter()
+		if iter == nil {
+			return nil, &types.InternalServiceError{
+				Message: "SelectWorkflowRequests operation failed. Not able to create query iterator.",
+			}
+		}
+		result := make(map[string]interface{})
+		for iter.MapScan(result) {
+			version := result["task_id"].(int64) * -1
+			// every request is written with an additional placeholder row of empty version, which is not a real request
+			if version != emptyWorkflowRequestVersion && result["current_run_id"].(gocql.UUID).String() == runID {
+				rows = append(rows, &nosqlplugin.WorkflowRequestRow{
+					ShardID:     shardID,
+					DomainID:    domainID,
+					WorkflowID:  workflowID,
+					RequestType: requestType,
+					RequestID:   result["run_id"].(gocql.UUID).String(),
+					Version:     version,
+					RunID:       runID,
+				})
+			}
+			result = make(map[string]interface{})
+		}
+		if err := iter.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
 func (db *cdb) SelectAllCurrentWorkflows(ctx context.Context, shardID int, pageToken []byte, pageSize int) ([]*persistence.CurrentWorkflowExecution, []byte, error) {
 	query := db.session.Query(
 		templateListCurrentExecutionsQuery,
@@ -315,11 +364,16 @@ func (db *cdb) SelectAllCurrentWorkflows(ctx context.Context, shardID int, pageT
 			result = make(map[string]interface{})
 			continue
 		}
+		closeStatus := persistence.WorkflowCloseStatusNone
+		if execution, ok := result["execution"].(map[string]interface{}); ok {
+			closeStatus = parseWorkflowExecutionInfo(execution).CloseStatus
+		}
 		executions = append(executions, &persistence.CurrentWorkflowExecution{
 			DomainID:     result["domain_id"].(gocql.UUID).String(),
 			WorkflowID:   result["workflow_id"].(string),
 			RunID:        permanentRunID,
 			State:        result["workflow_state"].(int),
+			CloseStatus:  closeStatus,
 			CurrentRunID: result["current_run_id"].(gocql.UUID).String(),
 		})
 		result = make(map[string]interface{})
@@ -245,6 +245,13 @@ const (
 		`shard_id, type, domain_id, workflow_id, run_id, visibility_ts, task_id, current_run_id) ` +
 		`VALUES(?, ?, ?, ?, ?, ?, ?, ?) USING TTL ?`
 
+	templateListWorkflowRequestsQuery = `SELECT run_id, task_id, current_run_id ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? `
+
 	templateGetLatestWorkflowRequestQuery = `SELECT current_run_id ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
@@ -313,7 +320,7 @@ const (
 		`and visibility_ts = ? ` +
 		`and task_id = ?`
 
-	templateListCurrentExecutionsQuery = `SELECT domain_id, workflow_id, run_id, current_run_id, workflow_state ` +
+	templateListCurrentExecutionsQuery = `SELECT domain_id, workflow_id, run_id, current_run_id, workflow_state, execution ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
 		`and type = ?`
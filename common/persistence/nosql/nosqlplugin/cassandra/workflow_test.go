@@ -714,6 +714,132 @@ func TestDeleteWorkflowExecution(t *testing.T) {
 	}
 }
 
+func TestSelectWorkflowRequests(t *testing.T) {
+	const runID = "runid1"
+	tests := []struct {
+		name     string
+		iters    []*fakeIter
+		wantRows []*nosqlplugin.WorkflowRequestRow
+		wantErr  bool
+	}{
+		{
+			name:    "nil iter returned",
+			iters:   []*fakeIter{nil},
+			wantErr: true,
+		},
+		{
+			name: "iter close failed",
+			iters: []*fakeIter{
+				{closeErr: errors.New("some random error")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "requests of the run are returned, placeholders and other runs are skipped",
+			iters: []*fakeIter{
+				// start requests
+				{
+					mapScanInputs: []map[string]interface{}{
+						{
+							"run_id":         &fakeUUID{uuid: "request1"},
+							"task_id":        emptyWorkflowRequestVersion * -1,
+							"current_run_id": &fakeUUID{uuid: runID},
+						},
+						{
+							"run_id":         &fakeUUID{uuid: "request1"},
+							"task_id":        int64(-5),
+							"current_run_id": &fakeUUID{uuid: runID},
+						},
+						{
+							"run_id":         &fakeUUID{uuid: "request2"},
+							"task_id":        int64(-3),
+							"current_run_id": &fakeUUID{uuid: "runid2"},
+						},
+					},
+				},
+				// signal requests
+				{
+					mapScanInputs: []map[string]interface{}{
+						{
+							"run_id":         &fakeUUID{uuid: "request3"},
+							"task_id":        int64(-7),
+							"current_run_id": &fakeUUID{uuid: runID},
+						},
+					},
+				},
+				// cancel requests
+				{},
+				// reset requests
+				{},
+			},
+			wantRows: []*nosqlplugin.WorkflowRequestRow{
+				{
+					ShardID:     1,
+					DomainID:    "domain1",
+					WorkflowID:  "wfid1",
+					RequestType: persistence.WorkflowRequestTypeStart,
+					RequestID:   "request1",
+					Version:     5,
+					RunID:       runID,
+				},
+				{
+					ShardID:     1,
+					DomainID:    "domain1",
+					WorkflowID:  "wfid1",
+					RequestType: persistence.WorkflowRequestTypeSignal,
+					RequestID:   "request3",
+					Version:     7,
+					RunID:       runID,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			query := gocql.NewMockQuery(ctrl)
+			query.EXPECT().WithContext(gomock.Any()).Return(query).Times(len(tc.iters))
+			for _, iter := range tc.iters {
+				if iter != nil {
+					query.EXPECT().Iter().Return(iter).Times(1)
+				} else {
+					query.EXPECT().Iter().Return(nil).Times(1)
+				}
+			}
+
+			session := &fakeSession{
+				query: query,
+			}
+			client := gocql.NewMockClient(ctrl)
+			cfg := &config.NoSQL{}
+			logger := testlogger.New(t)
+			dc := &persistence.DynamicConfiguration{}
+			db := newCassandraDBFromSession(cfg, session, logger, dc, dbWithClient(client))
+
+			gotRows, err := db.SelectWorkflowRequests(context.Background(), 1, "domain1", "wfid1", runID)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("SelectWorkflowRequests() error: %v, wantErr %v", err, tc.wantErr)
+			}
+
+			if err != nil || tc.wantErr {
+				return
+			}
+
+			if diff := cmp.Diff(tc.wantRows, gotRows); diff != "" {
+				t.Fatalf("Rows mismatch (-want +got):\n%s", diff)
+			}
+
+			for _, iter := range tc.iters {
+				if !iter.closed {
+					t.Error("iter was not closed")
+				}
+			}
+		})
+	}
+}
+
 func TestSelectAllCurrentWorkflows(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -787,6 +913,38 @@ func TestSelectAllCurrentWorkflows(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "close status parsed from execution",
+			shardID:   1,
+			pageToken: []byte("test-page-token"),
+			pageSize:  10,
+			iter: &fakeIter{
+				mapScanInputs: []map[string]interface{}{
+					{
+						"run_id":         &fakeUUID{uuid: permanentRunID},
+						"domain_id":      &fakeUUID{uuid: "domain1"},
+						"current_run_id": &fakeUUID{uuid: "runid1"},
+						"workflow_id":    "wfid1",
+						"workflow_state": persistence.WorkflowStateCompleted,
+						"execution": map[string]interface{}{
+							"state":        persistence.WorkflowStateCompleted,
+							"close_status": persistence.WorkflowCloseStatusTimedOut,
+						},
+					},
+				},
+				pageState: []byte("test-page-token-2"),
+			},
+			wantExecutions: []*persistence.CurrentWorkflowExecution{
+				{
+					DomainID:     "domain1",
+					WorkflowID:   "wfid1",
+					RunID:        "30000000-0000-f000-f000-000000000001",
+					State:        persistence.WorkflowStateCompleted,
+					CloseStatus:  persistence.WorkflowCloseStatusTimedOut,
+					CurrentRunID: "runid1",
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -1471,7 +1471,7 @@ func createWorkflowExecution(
 }
 
 func isRequestRowType(rowType int) bool {
-	if rowType >= rowTypeWorkflowRequestStart && rowType <= rowTypeWorkflowRequestReset {
+	if rowType >= rowTypeWorkflowRequestStart && rowType <= rowTypeWorkflowRequestDedupKey {
 		return true
 	}
 	return false
@@ -1487,6 +1487,8 @@ func toRequestRowType(requestType persistence.WorkflowRequestType) (int, error)
 		return rowTypeWorkflowRequestCancel, nil
 	case persistence.WorkflowRequestTypeReset:
 		return rowTypeWorkflowRequestReset, nil
+	case persistence.WorkflowRequestTypeDedupKey:
+		return rowTypeWorkflowRequestDedupKey, nil
 	default:
 		return 0, fmt.Errorf("unknown workflow request type %v", requestType)
 	}
@@ -1502,6 +1504,8 @@ func fromRequestRowType(rowType int) (persistence.WorkflowRequestType, error) {
 		return persistence.WorkflowRequestTypeCancel, nil
 	case rowTypeWorkflowRequestReset:
 		return persistence.WorkflowRequestTypeReset, nil
+	case rowTypeWorkflowRequestDedupKey:
+		return persistence.WorkflowRequestTypeDedupKey, nil
 	default:
 		return persistence.WorkflowRequestType(0), fmt.Errorf("unknown request row type %v", rowType)
 	}
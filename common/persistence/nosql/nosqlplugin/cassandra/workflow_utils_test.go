@@ -831,6 +831,12 @@ func TestToRequestRowType(t *testing.T) {
 			wantErr:     false,
 			want:        rowTypeWorkflowRequestReset,
 		},
+		{
+			name:        "DedupKey request",
+			requestType: persistence.WorkflowRequestTypeDedupKey,
+			wantErr:     false,
+			want:        rowTypeWorkflowRequestDedupKey,
+		},
 		{
 			name:        "unknown request",
 			requestType: persistence.WorkflowRequestType(-999),
@@ -893,6 +899,12 @@ func TestFromRequestRowType(t *testing.T) {
 			wantErr:     false,
 			want:        persistence.WorkflowRequestTypeReset,
 		},
+		{
+			name:        "DedupKey request",
+			requestType: rowTypeWorkflowRequestDedupKey,
+			wantErr:     false,
+			want:        persistence.WorkflowRequestTypeDedupKey,
+		},
 		{
 			name:        "unknown request",
 			requestType: rowTypeShard,
@@ -2946,6 +2958,7 @@ func TestIsRequestRowType(t *testing.T) {
 	assert.True(t, isRequestRowType(rowTypeWorkflowRequestSignal))
 	assert.True(t, isRequestRowType(rowTypeWorkflowRequestCancel))
 	assert.True(t, isRequestRowType(rowTypeWorkflowRequestReset))
+	assert.True(t, isRequestRowType(rowTypeWorkflowRequestDedupKey))
 }
 
 func errDiff(want, got error) string {
@@ -36,6 +36,15 @@ func (db *ddb) InsertIntoQueue(
 	panic("TODO")
 }
 
+// Insert multiple messages into queue atomically
+// Return ConditionFailure if any of the messages already exists
+func (db *ddb) BatchInsertIntoQueue(
+	ctx context.Context,
+	rows []*nosqlplugin.QueueMessageRow,
+) error {
+	panic("TODO")
+}
+
 // Get the ID of last message inserted into the queue
 func (db *ddb) SelectLastEnqueuedMessageID(
 	ctx context.Context,
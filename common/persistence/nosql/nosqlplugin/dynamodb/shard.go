@@ -37,6 +37,11 @@ func (db *ddb) SelectShard(ctx context.Context, shardID int, currentClusterName
 	panic("TODO")
 }
 
+// SelectAllShards scans all shards
+func (db *ddb) SelectAllShards(ctx context.Context, currentClusterName string) ([]*nosqlplugin.ShardRow, error) {
+	panic("TODO")
+}
+
 // UpdateRangeID updates the rangeID, return error is there is any
 // Return ShardOperationConditionFailure if the condition doesn't meet
 func (db *ddb) UpdateRangeID(ctx context.Context, shardID int, rangeID int64, previousRangeID int64) error {
@@ -93,6 +93,11 @@ func (db *ddb) GetTasksCount(ctx context.Context, filter *nosqlplugin.TasksFilte
 	panic("TODO")
 }
 
+// GetTaskIDRange returns the min and max task ID of a tasklist
+func (db *ddb) GetTaskIDRange(ctx context.Context, filter *nosqlplugin.TaskListFilter) (int64, int64, error) {
+	panic("TODO")
+}
+
 // DeleteTask delete a batch tasks that taskIDs less than the row
 // If TTL is not implemented, then should also return the number of rows deleted, otherwise persistence.UnknownNumRowsAffected
 // NOTE: This API ignores the `BatchSize` request parameter i.e. either all tasks leq the task_id will be deleted or an error will
@@ -77,6 +77,10 @@ func (db *ddb) DeleteWorkflowExecution(ctx context.Context, shardID int, domainI
 	panic("TODO")
 }
 
+func (db *ddb) SelectWorkflowRequests(ctx context.Context, shardID int, domainID, workflowID, runID string) ([]*nosqlplugin.WorkflowRequestRow, error) {
+	panic("TODO")
+}
+
 func (db *ddb) SelectAllCurrentWorkflows(ctx context.Context, shardID int, pageToken []byte, pageSize int) ([]*persistence.CurrentWorkflowExecution, []byte, error) {
 	panic("TODO")
 }
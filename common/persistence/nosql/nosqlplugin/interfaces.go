@@ -99,6 +99,7 @@ type (
 
 		// DeleteFromHistoryTreeAndNode delete a branch record, and a list of ranges of nodes.
 		// for each range, it will delete all nodes starting from MinNodeID(inclusive)
+		// treeFilter can be nil to only delete the nodes
 		DeleteFromHistoryTreeAndNode(ctx context.Context, treeFilter *HistoryTreeFilter, nodeFilters []*HistoryNodeFilter) error
 
 		// SelectAllHistoryTrees will return all tree branches with pagination
@@ -122,6 +123,9 @@ type (
 		// Insert message into queue, return error if failed or already exists
 		// Must return conditionFailed error if row already exists
 		InsertIntoQueue(ctx context.Context, row *QueueMessageRow) error
+		// Insert multiple messages of the same queue atomically, return error if failed or any of them already exists
+		// Must return conditionFailed error if any row already exists
+		BatchInsertIntoQueue(ctx context.Context, rows []*QueueMessageRow) error
 		// Get the ID of last message inserted into the queue
 		SelectLastEnqueuedMessageID(ctx context.Context, queueType persistence.QueueType) (int64, error)
 		// Read queue messages starting from the exclusiveBeginMessageID
@@ -208,6 +212,8 @@ type (
 		InsertShard(ctx context.Context, row *ShardRow) error
 		// SelectShard gets a shard, rangeID is the current rangeID in shard row
 		SelectShard(ctx context.Context, shardID int, currentClusterName string) (rangeID int64, shard *ShardRow, err error)
+		// SelectAllShards scans all shards stored in the database
+		SelectAllShards(ctx context.Context, currentClusterName string) ([]*ShardRow, error)
 		// UpdateRangeID updates the rangeID
 		// Return error is there is any thing wrong
 		// Return the ShardOperationConditionFailure when doesn't meet the condition
@@ -346,6 +352,8 @@ type (
 		RangeDeleteTasks(ctx context.Context, filter *TasksFilter) (rowsDeleted int, err error)
 		// GetTasksCount return the number of tasks
 		GetTasksCount(ctx context.Context, filter *TasksFilter) (int64, error)
+		// GetTaskIDRange returns the min and max task ID of a tasklist, both are 0 if there are no tasks
+		GetTaskIDRange(ctx context.Context, filter *TaskListFilter) (minTaskID int64, maxTaskID int64, err error)
 	}
 
 	/**
@@ -471,6 +479,10 @@ type (
 		// Delete the workflow execution row
 		DeleteWorkflowExecution(ctx context.Context, shardID int, domainID, workflowID, runID string) error
 
+		// workflow_request table
+		// Return all the requests recorded for the workflow execution
+		SelectWorkflowRequests(ctx context.Context, shardID int, domainID, workflowID, runID string) ([]*WorkflowRequestRow, error)
+
 		// transfer_task table
 		// within a shard, paging through transfer tasks order by taskID(ASC), filtered by minTaskID(exclusive) and maxTaskID(inclusive)
 		SelectTransferTasksOrderByTaskID(ctx context.Context, shardID, pageSize int, pageToken []byte, exclusiveMinTaskID, inclusiveMaxTaskID int64) ([]*TransferTask, []byte, error)
@@ -165,6 +165,20 @@ func (m *MockDB) EXPECT() *MockDBMockRecorder {
 	return m.recorder
 }
 
+// BatchInsertIntoQueue mocks base method.
+func (m *MockDB) BatchInsertIntoQueue(ctx context.Context, rows []*QueueMessageRow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchInsertIntoQueue", ctx, rows)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchInsertIntoQueue indicates an expected call of BatchInsertIntoQueue.
+func (mr *MockDBMockRecorder) BatchInsertIntoQueue(ctx, rows interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchInsertIntoQueue", reflect.TypeOf((*MockDB)(nil).BatchInsertIntoQueue), ctx, rows)
+}
+
 // Close mocks base method.
 func (m *MockDB) Close() {
 	m.ctrl.T.Helper()
@@ -388,6 +402,22 @@ func (mr *MockDBMockRecorder) GetQueueSize(ctx, queueType interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueSize", reflect.TypeOf((*MockDB)(nil).GetQueueSize), ctx, queueType)
 }
 
+// GetTaskIDRange mocks base method.
+func (m *MockDB) GetTaskIDRange(ctx context.Context, filter *TaskListFilter) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskIDRange", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTaskIDRange indicates an expected call of GetTaskIDRange.
+func (mr *MockDBMockRecorder) GetTaskIDRange(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskIDRange", reflect.TypeOf((*MockDB)(nil).GetTaskIDRange), ctx, filter)
+}
+
 // GetTasksCount mocks base method.
 func (m *MockDB) GetTasksCount(ctx context.Context, filter *TasksFilter) (int64, error) {
 	m.ctrl.T.Helper()
@@ -804,6 +834,21 @@ func (mr *MockDBMockRecorder) SelectAllHistoryTrees(ctx, nextPageToken, pageSize
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAllHistoryTrees", reflect.TypeOf((*MockDB)(nil).SelectAllHistoryTrees), ctx, nextPageToken, pageSize)
 }
 
+// SelectAllShards mocks base method.
+func (m *MockDB) SelectAllShards(ctx context.Context, currentClusterName string) ([]*ShardRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAllShards", ctx, currentClusterName)
+	ret0, _ := ret[0].([]*ShardRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAllShards indicates an expected call of SelectAllShards.
+func (mr *MockDBMockRecorder) SelectAllShards(ctx, currentClusterName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAllShards", reflect.TypeOf((*MockDB)(nil).SelectAllShards), ctx, currentClusterName)
+}
+
 // SelectAllWorkflowExecutions mocks base method.
 func (m *MockDB) SelectAllWorkflowExecutions(ctx context.Context, shardID int, pageToken []byte, pageSize int) ([]*persistence.InternalListConcreteExecutionsEntity, []byte, error) {
 	m.ctrl.T.Helper()
@@ -1157,6 +1202,21 @@ func (mr *MockDBMockRecorder) SelectWorkflowExecution(ctx, shardID, domainID, wo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWorkflowExecution", reflect.TypeOf((*MockDB)(nil).SelectWorkflowExecution), ctx, shardID, domainID, workflowID, runID)
 }
 
+// SelectWorkflowRequests mocks base method.
+func (m *MockDB) SelectWorkflowRequests(ctx context.Context, shardID int, domainID, workflowID, runID string) ([]*WorkflowRequestRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectWorkflowRequests", ctx, shardID, domainID, workflowID, runID)
+	ret0, _ := ret[0].([]*WorkflowRequestRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectWorkflowRequests indicates an expected call of SelectWorkflowRequests.
+func (mr *MockDBMockRecorder) SelectWorkflowRequests(ctx, shardID, domainID, workflowID, runID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWorkflowRequests", reflect.TypeOf((*MockDB)(nil).SelectWorkflowRequests), ctx, shardID, domainID, workflowID, runID)
+}
+
 // UpdateDomain mocks base method.
 func (m *MockDB) UpdateDomain(ctx context.Context, row *DomainRow) error {
 	m.ctrl.T.Helper()
@@ -1292,6 +1352,20 @@ func (m *MocktableCRUD) EXPECT() *MocktableCRUDMockRecorder {
 	return m.recorder
 }
 
+// BatchInsertIntoQueue mocks base method.
+func (m *MocktableCRUD) BatchInsertIntoQueue(ctx context.Context, rows []*QueueMessageRow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchInsertIntoQueue", ctx, rows)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchInsertIntoQueue indicates an expected call of BatchInsertIntoQueue.
+func (mr *MocktableCRUDMockRecorder) BatchInsertIntoQueue(ctx, rows interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchInsertIntoQueue", reflect.TypeOf((*MocktableCRUD)(nil).BatchInsertIntoQueue), ctx, rows)
+}
+
 // DeleteCrossClusterTask mocks base method.
 func (m *MocktableCRUD) DeleteCrossClusterTask(ctx context.Context, shardID int, targetCluster string, taskID int64) error {
 	m.ctrl.T.Helper()
@@ -1503,6 +1577,22 @@ func (mr *MocktableCRUDMockRecorder) GetQueueSize(ctx, queueType interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueSize", reflect.TypeOf((*MocktableCRUD)(nil).GetQueueSize), ctx, queueType)
 }
 
+// GetTaskIDRange mocks base method.
+func (m *MocktableCRUD) GetTaskIDRange(ctx context.Context, filter *TaskListFilter) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskIDRange", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTaskIDRange indicates an expected call of GetTaskIDRange.
+func (mr *MocktableCRUDMockRecorder) GetTaskIDRange(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskIDRange", reflect.TypeOf((*MocktableCRUD)(nil).GetTaskIDRange), ctx, filter)
+}
+
 // GetTasksCount mocks base method.
 func (m *MocktableCRUD) GetTasksCount(ctx context.Context, filter *TasksFilter) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1849,6 +1939,21 @@ func (mr *MocktableCRUDMockRecorder) SelectAllHistoryTrees(ctx, nextPageToken, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAllHistoryTrees", reflect.TypeOf((*MocktableCRUD)(nil).SelectAllHistoryTrees), ctx, nextPageToken, pageSize)
 }
 
+// SelectAllShards mocks base method.
+func (m *MocktableCRUD) SelectAllShards(ctx context.Context, currentClusterName string) ([]*ShardRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAllShards", ctx, currentClusterName)
+	ret0, _ := ret[0].([]*ShardRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAllShards indicates an expected call of SelectAllShards.
+func (mr *MocktableCRUDMockRecorder) SelectAllShards(ctx, currentClusterName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAllShards", reflect.TypeOf((*MocktableCRUD)(nil).SelectAllShards), ctx, currentClusterName)
+}
+
 // SelectAllWorkflowExecutions mocks base method.
 func (m *MocktableCRUD) SelectAllWorkflowExecutions(ctx context.Context, shardID int, pageToken []byte, pageSize int) ([]*persistence.InternalListConcreteExecutionsEntity, []byte, error) {
 	m.ctrl.T.Helper()
@@ -2202,6 +2307,21 @@ func (mr *MocktableCRUDMockRecorder) SelectWorkflowExecution(ctx, shardID, domai
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWorkflowExecution", reflect.TypeOf((*MocktableCRUD)(nil).SelectWorkflowExecution), ctx, shardID, domainID, workflowID, runID)
 }
 
+// SelectWorkflowRequests mocks base method.
+func (m *MocktableCRUD) SelectWorkflowRequests(ctx context.Context, shardID int, domainID, workflowID, runID string) ([]*WorkflowRequestRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectWorkflowRequests", ctx, shardID, domainID, workflowID, runID)
+	ret0, _ := ret[0].([]*WorkflowRequestRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectWorkflowRequests indicates an expected call of SelectWorkflowRequests.
+func (mr *MocktableCRUDMockRecorder) SelectWorkflowRequests(ctx, shardID, domainID, workflowID, runID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWorkflowRequests", reflect.TypeOf((*MocktableCRUD)(nil).SelectWorkflowRequests), ctx, shardID, domainID, workflowID, runID)
+}
+
 // UpdateDomain mocks base method.
 func (m *MocktableCRUD) UpdateDomain(ctx context.Context, row *DomainRow) error {
 	m.ctrl.T.Helper()
@@ -2514,6 +2634,20 @@ func (m *MockMessageQueueCRUD) EXPECT() *MockMessageQueueCRUDMockRecorder {
 	return m.recorder
 }
 
+// BatchInsertIntoQueue mocks base method.
+func (m *MockMessageQueueCRUD) BatchInsertIntoQueue(ctx context.Context, rows []*QueueMessageRow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchInsertIntoQueue", ctx, rows)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchInsertIntoQueue indicates an expected call of BatchInsertIntoQueue.
+func (mr *MockMessageQueueCRUDMockRecorder) BatchInsertIntoQueue(ctx, rows interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchInsertIntoQueue", reflect.TypeOf((*MockMessageQueueCRUD)(nil).BatchInsertIntoQueue), ctx, rows)
+}
+
 // DeleteMessage mocks base method.
 func (m *MockMessageQueueCRUD) DeleteMessage(ctx context.Context, queueType persistence.QueueType, messageID int64) error {
 	m.ctrl.T.Helper()
@@ -2821,6 +2955,21 @@ func (mr *MockShardCRUDMockRecorder) InsertShard(ctx, row interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertShard", reflect.TypeOf((*MockShardCRUD)(nil).InsertShard), ctx, row)
 }
 
+// SelectAllShards mocks base method.
+func (m *MockShardCRUD) SelectAllShards(ctx context.Context, currentClusterName string) ([]*ShardRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAllShards", ctx, currentClusterName)
+	ret0, _ := ret[0].([]*ShardRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAllShards indicates an expected call of SelectAllShards.
+func (mr *MockShardCRUDMockRecorder) SelectAllShards(ctx, currentClusterName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAllShards", reflect.TypeOf((*MockShardCRUD)(nil).SelectAllShards), ctx, currentClusterName)
+}
+
 // SelectShard mocks base method.
 func (m *MockShardCRUD) SelectShard(ctx context.Context, shardID int, currentClusterName string) (int64, *ShardRow, error) {
 	m.ctrl.T.Helper()
@@ -2997,6 +3146,22 @@ func (mr *MockTaskCRUDMockRecorder) DeleteTaskList(ctx, filter, previousRangeID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskList", reflect.TypeOf((*MockTaskCRUD)(nil).DeleteTaskList), ctx, filter, previousRangeID)
 }
 
+// GetTaskIDRange mocks base method.
+func (m *MockTaskCRUD) GetTaskIDRange(ctx context.Context, filter *TaskListFilter) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskIDRange", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTaskIDRange indicates an expected call of GetTaskIDRange.
+func (mr *MockTaskCRUDMockRecorder) GetTaskIDRange(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskIDRange", reflect.TypeOf((*MockTaskCRUD)(nil).GetTaskIDRange), ctx, filter)
+}
+
 // GetTasksCount mocks base method.
 func (m *MockTaskCRUD) GetTasksCount(ctx context.Context, filter *TasksFilter) (int64, error) {
 	m.ctrl.T.Helper()
@@ -3533,6 +3698,21 @@ func (mr *MockWorkflowCRUDMockRecorder) SelectWorkflowExecution(ctx, shardID, do
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWorkflowExecution", reflect.TypeOf((*MockWorkflowCRUD)(nil).SelectWorkflowExecution), ctx, shardID, domainID, workflowID, runID)
 }
 
+// SelectWorkflowRequests mocks base method.
+func (m *MockWorkflowCRUD) SelectWorkflowRequests(ctx context.Context, shardID int, domainID, workflowID, runID string) ([]*WorkflowRequestRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectWorkflowRequests", ctx, shardID, domainID, workflowID, runID)
+	ret0, _ := ret[0].([]*WorkflowRequestRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectWorkflowRequests indicates an expected call of SelectWorkflowRequests.
+func (mr *MockWorkflowCRUDMockRecorder) SelectWorkflowRequests(ctx, shardID, domainID, workflowID, runID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWorkflowRequests", reflect.TypeOf((*MockWorkflowCRUD)(nil).SelectWorkflowRequests), ctx, shardID, domainID, workflowID, runID)
+}
+
 // UpdateWorkflowExecutionWithTasks mocks base method.
 func (m *MockWorkflowCRUD) UpdateWorkflowExecutionWithTasks(ctx context.Context, requests *WorkflowRequestsWriteRequest, currentWorkflowRequest *CurrentWorkflowWriteRequest, mutatedExecution, insertedExecution, resetExecution *WorkflowExecutionRequest, transferTasks []*TransferTask, crossClusterTasks []*CrossClusterTask, replicationTasks []*ReplicationTask, timerTasks []*TimerTask, shardCondition *ShardCondition) error {
 	m.ctrl.T.Helper()
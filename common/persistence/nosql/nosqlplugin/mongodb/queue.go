@@ -37,6 +37,15 @@ func (db *mdb) InsertIntoQueue(
 	panic("TODO")
 }
 
+// Insert multiple messages into queue atomically
+// Return ConditionFailure if any of the messages already exists
+func (db *mdb) BatchInsertIntoQueue(
+	ctx context.Context,
+	rows []*nosqlplugin.QueueMessageRow,
+) error {
+	panic("TODO")
+}
+
 // Get the ID of last message inserted into the queue
 func (db *mdb) SelectLastEnqueuedMessageID(
 	ctx context.Context,
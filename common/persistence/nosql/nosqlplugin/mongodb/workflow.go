@@ -77,6 +77,10 @@ func (db *mdb) DeleteWorkflowExecution(ctx context.Context, shardID int, domainI
 	panic("TODO")
 }
 
+func (db *mdb) SelectWorkflowRequests(ctx context.Context, shardID int, domainID, workflowID, runID string) ([]*nosqlplugin.WorkflowRequestRow, error) {
+	panic("TODO")
+}
+
 func (db *mdb) SelectAllCurrentWorkflows(ctx context.Context, shardID int, pageToken []byte, pageSize int) ([]*persistence.CurrentWorkflowExecution, []byte, error) {
 	panic("TODO")
 }
@@ -34,6 +34,7 @@ import (
 
 type shardedNosqlStore interface {
 	GetStoreShardByHistoryShard(shardID int) (*nosqlStore, error)
+	GetStoreShardsForHistory() ([]*nosqlStore, error)
 	GetStoreShardByTaskList(domainID string, taskListName string, taskType int) (*nosqlStore, error)
 	GetDefaultShard() nosqlStore
 	Close()
@@ -90,6 +91,19 @@ func (sn *shardedNosqlStoreImpl) GetStoreShardByHistoryShard(shardID int) (*nosq
 	return sn.getShard(shardName)
 }
 
+func (sn *shardedNosqlStoreImpl) GetStoreShardsForHistory() ([]*nosqlStore, error) {
+	shardNames := sn.shardingPolicy.getHistoryShardNames()
+	shards := make([]*nosqlStore, 0, len(shardNames))
+	for _, shardName := range shardNames {
+		shard, err := sn.getShard(shardName)
+		if err != nil {
+			return nil, err
+		}
+		shards = append(shards, shard)
+	}
+	return shards, nil
+}
+
 func (sn *shardedNosqlStoreImpl) GetStoreShardByTaskList(domainID string, taskListName string, taskType int) (*nosqlStore, error) {
 	shardName := sn.shardingPolicy.getTaskListShardName(domainID, taskListName, taskType)
 	return sn.getShard(shardName)
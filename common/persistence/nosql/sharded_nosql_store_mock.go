@@ -154,3 +154,18 @@ func (mr *MockshardedNosqlStoreMockRecorder) GetStoreShardByTaskList(domainID, t
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStoreShardByTaskList", reflect.TypeOf((*MockshardedNosqlStore)(nil).GetStoreShardByTaskList), domainID, taskListName, taskType)
 }
+
+// GetStoreShardsForHistory mocks base method.
+func (m *MockshardedNosqlStore) GetStoreShardsForHistory() ([]*nosqlStore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStoreShardsForHistory")
+	ret0, _ := ret[0].([]*nosqlStore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStoreShardsForHistory indicates an expected call of GetStoreShardsForHistory.
+func (mr *MockshardedNosqlStoreMockRecorder) GetStoreShardsForHistory() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStoreShardsForHistory", reflect.TypeOf((*MockshardedNosqlStore)(nil).GetStoreShardsForHistory))
+}
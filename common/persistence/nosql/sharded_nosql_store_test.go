@@ -81,6 +81,36 @@ func (s *shardedNosqlStoreTestSuite) TestValidConfiguration() {
 	s.True(store.shardingPolicy.hasShardedHistory)
 }
 
+func (s *shardedNosqlStoreTestSuite) TestStoreShardsForHistory() {
+	mockDB1 := nosqlplugin.NewMockDB(s.mockController)
+	mockDB2 := nosqlplugin.NewMockDB(s.mockController)
+
+	mockPlugin := nosqlplugin.NewMockPlugin(s.mockController)
+	gomock.InOrder(
+		mockPlugin.EXPECT().
+			CreateDB(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(mockDB1, nil),
+		mockPlugin.EXPECT().
+			CreateDB(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(mockDB2, nil),
+	)
+	delete(supportedPlugins, "cassandra")
+	RegisterPlugin("cassandra", mockPlugin)
+
+	cfg := getValidShardedNoSQLConfig()
+
+	storeInterface, err := newShardedNosqlStore(cfg, log.NewNoop(), nil)
+	s.NoError(err)
+	store := storeInterface.(*shardedNosqlStoreImpl)
+
+	storeShards, err := store.GetStoreShardsForHistory()
+	s.NoError(err)
+	s.Equal(2, len(storeShards))
+	s.True(mockDB1 == storeShards[0].db)
+	s.True(mockDB2 == storeShards[1].db)
+	s.Equal(2, len(store.connectedShards))
+}
+
 func (s *shardedNosqlStoreTestSuite) TestStoreSelectionForHistoryShard() {
 	mockDB1 := nosqlplugin.NewMockDB(s.mockController)
 	mockDB2 := nosqlplugin.NewMockDB(s.mockController)
@@ -95,6 +95,22 @@ func (sp *shardingPolicy) getHistoryShardName(shardID int) (string, error) {
 	}
 }
 
+func (sp *shardingPolicy) getHistoryShardNames() []string {
+	if !sp.hasShardedHistory {
+		return []string{sp.defaultShard}
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, r := range sp.config.ShardingPolicy.HistoryShardMapping {
+		if !seen[r.Shard] {
+			seen[r.Shard] = true
+			names = append(names, r.Shard)
+		}
+	}
+	return names
+}
+
 func (sp *shardingPolicy) getTaskListShardName(domainID string, taskListName string, taskType int) string {
 	if !sp.hasShardedTasklist {
 		sp.logger.Debug("Selected default store shard for tasklist", tag.StoreShard(sp.defaultShard), tag.WorkflowTaskListName(taskListName))
@@ -83,6 +83,18 @@ func TestHistorySharding(t *testing.T) {
 	require.Equal(t, "shard-2", shardName1, "shard name must be correct for shard 1")
 }
 
+func TestHistoryShardNames(t *testing.T) {
+	cfg := getValidShardedNoSQLConfig()
+	sp, err := newShardingPolicy(log.NewNoop(), cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"shard-1", "shard-2"}, sp.getHistoryShardNames())
+
+	cfg.ShardingPolicy.HistoryShardMapping = []HistoryShardRange{} // remove only the history sharding
+	sp, err = newShardingPolicy(log.NewNoop(), cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"shard-1"}, sp.getHistoryShardNames())
+}
+
 func TestHistorySharding_UnexpectedGapInHistoryRanges(t *testing.T) {
 	cfg := getValidShardedNoSQLConfig()
 	cfg.ShardingPolicy.HistoryShardMapping = []HistoryShardRange{
@@ -23,12 +23,22 @@ package persistenceutils
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/codec"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
 )
 
+// HistoryTreeBranch is a branch of a history tree returned by GetHistoryTreeContainingBranch
+type HistoryTreeBranch struct {
+	Branch *workflow.HistoryBranch
+	// Matched is true for the branch the looked up branch token refers to
+	Matched bool
+}
+
 // ReadFullPageV2Events reads a full page of history events from HistoryManager. Due to storage format of V2 History
 // it is not guaranteed that pageSize amount of data is returned. Function returns the list of history events, the size
 // of data read, the next page token, and an error if present.
@@ -170,3 +180,61 @@ func GetBranchesMaxReferredNodeIDs(branches []*types.HistoryBranch) map[string]i
 	}
 	return validBRsMaxEndNode
 }
+
+// BatchDeleteHistoryBranchSequentially deletes the given branches one by one using DeleteHistoryBranch.
+// It is the default BatchDeleteHistoryBranch implementation for stores without a native batch delete.
+// A failure to delete one branch does not prevent the remaining branches from being deleted.
+func BatchDeleteHistoryBranchSequentially(
+	ctx context.Context,
+	store persistence.HistoryStore,
+	requests []persistence.InternalDeleteHistoryBranchRequest,
+) *persistence.InternalBatchDeleteHistoryBranchResponse {
+	errs := make([]error, len(requests))
+	for i := range requests {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		errs[i] = store.DeleteHistoryBranch(ctx, &requests[i])
+	}
+	return &persistence.InternalBatchDeleteHistoryBranchResponse{Errors: errs}
+}
+
+// GetHistoryTreeContainingBranch returns all branches of the history tree the branch token belongs to,
+// with the branch identified by the token flagged as Matched. An EntityNotExistsError is returned
+// when the tree no longer contains that branch.
+func GetHistoryTreeContainingBranch(
+	ctx context.Context,
+	historyV2Mgr persistence.HistoryManager,
+	shardID int,
+	branchToken []byte,
+	domainName string,
+) ([]HistoryTreeBranch, error) {
+	var target workflow.HistoryBranch
+	if err := codec.NewThriftRWEncoder().Decode(branchToken, &target); err != nil {
+		return nil, err
+	}
+	resp, err := historyV2Mgr.GetHistoryTree(ctx, &persistence.GetHistoryTreeRequest{
+		TreeID:      target.GetTreeID(),
+		ShardID:     &shardID,
+		BranchToken: branchToken,
+		DomainName:  domainName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	branches := make([]HistoryTreeBranch, 0, len(resp.Branches))
+	for _, b := range resp.Branches {
+		matched := b.GetBranchID() == target.GetBranchID()
+		found = found || matched
+		branches = append(branches, HistoryTreeBranch{Branch: b, Matched: matched})
+	}
+	if !found {
+		return nil, &types.EntityNotExistsError{
+			Message: fmt.Sprintf("branch %v not found in history tree %v", target.GetBranchID(), target.GetTreeID()),
+		}
+	}
+	return branches, nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistenceutils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestGetHistoryTreeContainingBranch(t *testing.T) {
+	const (
+		treeID     = "tree-id"
+		domainName = "test-domain"
+		shardID    = 3
+	)
+	branchToken, err := persistence.NewHistoryBranchTokenByBranchID(treeID, "branch-2")
+	require.NoError(t, err)
+	tree := []*workflow.HistoryBranch{
+		{TreeID: common.StringPtr(treeID), BranchID: common.StringPtr("branch-1")},
+		{TreeID: common.StringPtr(treeID), BranchID: common.StringPtr("branch-2")},
+		{TreeID: common.StringPtr(treeID), BranchID: common.StringPtr("branch-3")},
+	}
+
+	tests := map[string]struct {
+		branches []*workflow.HistoryBranch
+		matched  []bool
+		wantErr  bool
+	}{
+		"branch flagged in tree with multiple branches": {
+			branches: tree,
+			matched:  []bool{false, true, false},
+		},
+		"branch missing from tree": {
+			branches: []*workflow.HistoryBranch{tree[0], tree[2]},
+			wantErr:  true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			historyManager := persistence.NewMockHistoryManager(ctrl)
+			historyManager.EXPECT().GetHistoryTree(gomock.Any(), &persistence.GetHistoryTreeRequest{
+				TreeID:      treeID,
+				ShardID:     common.IntPtr(shardID),
+				BranchToken: branchToken,
+				DomainName:  domainName,
+			}).Return(&persistence.GetHistoryTreeResponse{Branches: tc.branches}, nil)
+
+			branches, err := GetHistoryTreeContainingBranch(context.Background(), historyManager, shardID, branchToken, domainName)
+			if tc.wantErr {
+				var notExists *types.EntityNotExistsError
+				assert.ErrorAs(t, err, &notExists)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, branches, len(tc.branches))
+			for i, b := range branches {
+				assert.Equal(t, tc.branches[i], b.Branch)
+				assert.Equal(t, tc.matched[i], b.Matched, b.Branch.GetBranchID())
+			}
+		})
+	}
+}
+
+func TestGetHistoryTreeContainingBranch_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	historyManager := persistence.NewMockHistoryManager(ctrl)
+
+	_, err := GetHistoryTreeContainingBranch(context.Background(), historyManager, 0, []byte("invalid"), "test-domain")
+	assert.Error(t, err)
+}
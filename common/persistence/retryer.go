@@ -38,6 +38,7 @@ type Retryer interface {
 	GetCurrentExecution(context.Context, *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
 	IsWorkflowExecutionExists(context.Context, *IsWorkflowExecutionExistsRequest) (*IsWorkflowExecutionExistsResponse, error)
 	ReadHistoryBranch(context.Context, *ReadHistoryBranchRequest) (*ReadHistoryBranchResponse, error)
+	GetHistoryTree(context.Context, *GetHistoryTreeRequest) (*GetHistoryTreeResponse, error)
 	DeleteWorkflowExecution(context.Context, *DeleteWorkflowExecutionRequest) error
 	DeleteCurrentWorkflowExecution(context.Context, *DeleteCurrentWorkflowExecutionRequest) error
 	GetShardID() int
@@ -177,6 +178,24 @@ func (pr *persistenceRetryer) ReadHistoryBranch(
 	return resp, nil
 }
 
+// GetHistoryTree retries GetHistoryTree
+func (pr *persistenceRetryer) GetHistoryTree(
+	ctx context.Context,
+	req *GetHistoryTreeRequest,
+) (*GetHistoryTreeResponse, error) {
+	var resp *GetHistoryTreeResponse
+	op := func() error {
+		var err error
+		resp, err = pr.historyManager.GetHistoryTree(ctx, req)
+		return err
+	}
+	err := pr.throttleRetry.Do(ctx, op)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // DeleteWorkflowExecution retries DeleteWorkflowExecution
 func (pr *persistenceRetryer) DeleteWorkflowExecution(
 	ctx context.Context,
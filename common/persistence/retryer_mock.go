@@ -113,6 +113,21 @@ func (mr *MockRetryerMockRecorder) GetCurrentExecution(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentExecution", reflect.TypeOf((*MockRetryer)(nil).GetCurrentExecution), arg0, arg1)
 }
 
+// GetHistoryTree mocks base method.
+func (m *MockRetryer) GetHistoryTree(arg0 context.Context, arg1 *GetHistoryTreeRequest) (*GetHistoryTreeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoryTree", arg0, arg1)
+	ret0, _ := ret[0].(*GetHistoryTreeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoryTree indicates an expected call of GetHistoryTree.
+func (mr *MockRetryerMockRecorder) GetHistoryTree(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoryTree", reflect.TypeOf((*MockRetryer)(nil).GetHistoryTree), arg0, arg1)
+}
+
 // GetShardID mocks base method.
 func (m *MockRetryer) GetShardID() int {
 	m.ctrl.T.Helper()
@@ -24,6 +24,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cluster"
@@ -401,6 +402,27 @@ func (m *sqlDomainStore) DeleteDomainByName(
 	})
 }
 
+func (m *sqlDomainStore) MarkDomainDeleted(
+	ctx context.Context,
+	request *persistence.MarkDomainDeletedRequest,
+) error {
+	return persistence.MarkDomainDeleted(ctx, m, request)
+}
+
+func (m *sqlDomainStore) PurgeDeletedDomains(
+	ctx context.Context,
+	olderThan time.Time,
+) error {
+	return persistence.PurgeDeletedDomains(ctx, m, olderThan)
+}
+
+func (m *sqlDomainStore) ListDomainsByIsolationGroup(
+	ctx context.Context,
+	group string,
+) ([]*persistence.InternalGetDomainResponse, error) {
+	return persistence.ListDomainsByIsolationGroup(ctx, m, group)
+}
+
 func (m *sqlDomainStore) GetMetadata(
 	ctx context.Context,
 ) (*persistence.GetMetadataResponse, error) {
@@ -440,6 +462,10 @@ func (m *sqlDomainStore) ListDomains(
 		domains = append(domains, resp)
 	}
 
+	if !request.IncludeDeleted {
+		domains = persistence.FilterSoftDeletedDomains(domains)
+	}
+
 	resp := &persistence.InternalListDomainsResponse{Domains: domains}
 	if len(rows) >= request.PageSize {
 		resp.NextPageToken = rows[len(rows)-1].ID
@@ -159,6 +159,121 @@ func TestListDomains(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:              "Soft-deleted domain is hidden by default",
+			activeClusterName: "active",
+			req: &persistence.ListDomainsRequest{
+				PageSize: 1,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().SelectFromDomain(gomock.Any(), &sqlplugin.DomainFilter{
+					PageSize: common.IntPtr(1),
+				}).Return([]sqlplugin.DomainRow{
+					{
+						ID:           serialization.MustParseUUID("9a3dc7e2-1e67-41aa-8eaf-6d6e27f7e47c"),
+						Name:         "test",
+						Data:         []byte(`aaaa`),
+						DataEncoding: string(common.EncodingTypeThriftRW),
+					},
+				}, nil)
+				mockParser.EXPECT().DomainInfoFromBlob([]byte(`aaaa`), string(common.EncodingTypeThriftRW)).Return(&serialization.DomainInfo{
+					Data: map[string]string{common.DomainDataKeyForSoftDeletedTime: "2024-03-01T12:00:00Z"},
+				}, nil)
+			},
+			want: &persistence.InternalListDomainsResponse{
+				NextPageToken: serialization.MustParseUUID("9a3dc7e2-1e67-41aa-8eaf-6d6e27f7e47c"),
+			},
+			wantErr: false,
+		},
+		{
+			name:              "Domain with deleted status is returned by default",
+			activeClusterName: "active",
+			req: &persistence.ListDomainsRequest{
+				PageSize: 1,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().SelectFromDomain(gomock.Any(), &sqlplugin.DomainFilter{
+					PageSize: common.IntPtr(1),
+				}).Return([]sqlplugin.DomainRow{
+					{
+						ID:           serialization.MustParseUUID("9a3dc7e2-1e67-41aa-8eaf-6d6e27f7e47c"),
+						Name:         "test",
+						Data:         []byte(`aaaa`),
+						DataEncoding: string(common.EncodingTypeThriftRW),
+					},
+				}, nil)
+				mockParser.EXPECT().DomainInfoFromBlob([]byte(`aaaa`), string(common.EncodingTypeThriftRW)).Return(&serialization.DomainInfo{
+					Status: persistence.DomainStatusDeleted,
+				}, nil)
+			},
+			want: &persistence.InternalListDomainsResponse{
+				Domains: []*persistence.InternalGetDomainResponse{
+					{
+						Info: &persistence.DomainInfo{
+							ID:     "9a3dc7e2-1e67-41aa-8eaf-6d6e27f7e47c",
+							Name:   "test",
+							Status: persistence.DomainStatusDeleted,
+						},
+						Config: &persistence.InternalDomainConfig{},
+						ReplicationConfig: &persistence.DomainReplicationConfig{
+							ActiveClusterName: "active",
+							Clusters: []*persistence.ClusterReplicationConfig{
+								&persistence.ClusterReplicationConfig{
+									ClusterName: "active",
+								},
+							},
+						},
+					},
+				},
+				NextPageToken: serialization.MustParseUUID("9a3dc7e2-1e67-41aa-8eaf-6d6e27f7e47c"),
+			},
+			wantErr: false,
+		},
+		{
+			name:              "Soft-deleted domain is returned if requested",
+			activeClusterName: "active",
+			req: &persistence.ListDomainsRequest{
+				PageSize:       1,
+				IncludeDeleted: true,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().SelectFromDomain(gomock.Any(), &sqlplugin.DomainFilter{
+					PageSize: common.IntPtr(1),
+				}).Return([]sqlplugin.DomainRow{
+					{
+						ID:           serialization.MustParseUUID("9a3dc7e2-1e67-41aa-8eaf-6d6e27f7e47c"),
+						Name:         "test",
+						Data:         []byte(`aaaa`),
+						DataEncoding: string(common.EncodingTypeThriftRW),
+					},
+				}, nil)
+				mockParser.EXPECT().DomainInfoFromBlob([]byte(`aaaa`), string(common.EncodingTypeThriftRW)).Return(&serialization.DomainInfo{
+					Data: map[string]string{common.DomainDataKeyForSoftDeletedTime: "2024-03-01T12:00:00Z"},
+				}, nil)
+			},
+			want: &persistence.InternalListDomainsResponse{
+				Domains: []*persistence.InternalGetDomainResponse{
+					{
+						Info: &persistence.DomainInfo{
+							ID:   "9a3dc7e2-1e67-41aa-8eaf-6d6e27f7e47c",
+							Name: "test",
+							Data: map[string]string{common.DomainDataKeyForSoftDeletedTime: "2024-03-01T12:00:00Z"},
+						},
+						Config: &persistence.InternalDomainConfig{},
+						ReplicationConfig: &persistence.DomainReplicationConfig{
+							ActiveClusterName: "active",
+							Clusters: []*persistence.ClusterReplicationConfig{
+								&persistence.ClusterReplicationConfig{
+									ClusterName: "active",
+								},
+							},
+						},
+					},
+				},
+				NextPageToken: serialization.MustParseUUID("9a3dc7e2-1e67-41aa-8eaf-6d6e27f7e47c"),
+			},
+			wantErr: false,
+		},
 		{
 			name:              "No Record case",
 			activeClusterName: "active",
@@ -878,3 +993,192 @@ func TestUpdateDomain(t *testing.T) {
 		})
 	}
 }
+
+func TestMarkDomainDeleted(t *testing.T) {
+	deletedAt := time.Unix(1700000000, 0)
+	testCases := []struct {
+		name      string
+		req       *persistence.MarkDomainDeletedRequest
+		mockSetup func(*sqlplugin.MockDB, *sqlplugin.MockTx, *serialization.MockParser)
+		wantErr   bool
+	}{
+		{
+			name: "Success case",
+			req: &persistence.MarkDomainDeletedRequest{
+				Name:      "test",
+				DeletedAt: deletedAt,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().SelectFromDomainMetadata(gomock.Any()).Return(&sqlplugin.DomainMetadataRow{NotificationVersion: 7}, nil)
+				mockDB.EXPECT().SelectFromDomain(gomock.Any(), &sqlplugin.DomainFilter{Name: common.StringPtr("test")}).Return([]sqlplugin.DomainRow{
+					{
+						ID:           serialization.MustParseUUID("9a3dc7e2-1e67-41aa-8eaf-6d6e27f7e47c"),
+						Name:         "test",
+						Data:         []byte(`aaaa`),
+						DataEncoding: string(common.EncodingTypeThriftRW),
+					},
+				}, nil)
+				mockParser.EXPECT().DomainInfoFromBlob([]byte(`aaaa`), string(common.EncodingTypeThriftRW)).Return(&serialization.DomainInfo{
+					Status:              persistence.DomainStatusRegistered,
+					Description:         "n/a",
+					ActiveClusterName:   "active",
+					Clusters:            []string{"active"},
+					NotificationVersion: 3,
+				}, nil)
+				mockParser.EXPECT().DomainInfoToBlob(gomock.Any()).DoAndReturn(func(info *serialization.DomainInfo) (persistence.DataBlob, error) {
+					assert.Equal(t, int32(persistence.DomainStatusRegistered), info.Status)
+					assert.Equal(t, map[string]string{common.DomainDataKeyForSoftDeletedTime: "2023-11-14T22:13:20Z"}, info.Data)
+					assert.Equal(t, deletedAt, info.LastUpdatedTimestamp)
+					assert.Equal(t, int64(7), info.NotificationVersion)
+					assert.Equal(t, "n/a", info.Description)
+					return persistence.DataBlob{Data: []byte(`bbbb`), Encoding: common.EncodingTypeThriftRW}, nil
+				})
+				mockDB.EXPECT().BeginTx(gomock.Any(), sqlplugin.DbDefaultShard).Return(mockTx, nil)
+				mockTx.EXPECT().UpdateDomain(gomock.Any(), &sqlplugin.DomainRow{
+					Name:         "test",
+					ID:           serialization.MustParseUUID("9a3dc7e2-1e67-41aa-8eaf-6d6e27f7e47c"),
+					Data:         []byte(`bbbb`),
+					DataEncoding: string(common.EncodingTypeThriftRW),
+				}).Return(&sqlResult{rowsAffected: 1}, nil)
+				mockTx.EXPECT().LockDomainMetadata(gomock.Any()).Return(nil)
+				mockTx.EXPECT().UpdateDomainMetadata(gomock.Any(), &sqlplugin.DomainMetadataRow{NotificationVersion: 7}).Return(&sqlResult{rowsAffected: 1}, nil)
+				mockTx.EXPECT().Commit().Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error case - domain not found",
+			req: &persistence.MarkDomainDeletedRequest{
+				Name:      "test",
+				DeletedAt: deletedAt,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().SelectFromDomainMetadata(gomock.Any()).Return(&sqlplugin.DomainMetadataRow{NotificationVersion: 7}, nil)
+				mockDB.EXPECT().SelectFromDomain(gomock.Any(), &sqlplugin.DomainFilter{Name: common.StringPtr("test")}).Return(nil, sql.ErrNoRows)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := sqlplugin.NewMockDB(ctrl)
+			mockTx := sqlplugin.NewMockTx(ctrl)
+			mockParser := serialization.NewMockParser(ctrl)
+			store := &sqlDomainStore{
+				sqlStore:          sqlStore{db: mockDB, parser: mockParser},
+				activeClusterName: "active",
+			}
+
+			tc.mockSetup(mockDB, mockTx, mockParser)
+			err := store.MarkDomainDeleted(context.Background(), tc.req)
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error for test case")
+			} else {
+				assert.NoError(t, err, "Did not expect an error for test case")
+			}
+		})
+	}
+}
+
+func TestPurgeDeletedDomains(t *testing.T) {
+	now := time.Now()
+	olderThan := now.Add(-time.Hour)
+	rows := []sqlplugin.DomainRow{
+		{
+			ID:           serialization.MustParseUUID("1a3dc7e2-1e67-41aa-8eaf-6d6e27f7e47c"),
+			Name:         "active-domain",
+			Data:         []byte(`active`),
+			DataEncoding: string(common.EncodingTypeThriftRW),
+		},
+		{
+			ID:           serialization.MustParseUUID("2a3dc7e2-1e67-41aa-8eaf-6d6e27f7e47c"),
+			Name:         "recently-deleted-domain",
+			Data:         []byte(`recent`),
+			DataEncoding: string(common.EncodingTypeThriftRW),
+		},
+		{
+			ID:           serialization.MustParseUUID("3a3dc7e2-1e67-41aa-8eaf-6d6e27f7e47c"),
+			Name:         "deleted-domain",
+			Data:         []byte(`expired`),
+			DataEncoding: string(common.EncodingTypeThriftRW),
+		},
+		{
+			ID:           serialization.MustParseUUID("4a3dc7e2-1e67-41aa-8eaf-6d6e27f7e47c"),
+			Name:         "deleted-status-domain",
+			Data:         []byte(`deleted-status`),
+			DataEncoding: string(common.EncodingTypeThriftRW),
+		},
+	}
+	testCases := []struct {
+		name      string
+		mockSetup func(*sqlplugin.MockDB, *sqlplugin.MockTx, *serialization.MockParser)
+		wantErr   bool
+	}{
+		{
+			name: "Success case - only domains deleted before the window are purged",
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().SelectFromDomain(gomock.Any(), &sqlplugin.DomainFilter{
+					PageSize: common.IntPtr(100),
+				}).Return(rows, nil)
+				mockParser.EXPECT().DomainInfoFromBlob([]byte(`active`), gomock.Any()).Return(&serialization.DomainInfo{
+					Status:               persistence.DomainStatusRegistered,
+					LastUpdatedTimestamp: now.Add(-2 * time.Hour),
+				}, nil)
+				mockParser.EXPECT().DomainInfoFromBlob([]byte(`recent`), gomock.Any()).Return(&serialization.DomainInfo{
+					Data:                 map[string]string{common.DomainDataKeyForSoftDeletedTime: now.Add(-time.Minute).Format(time.RFC3339Nano)},
+					LastUpdatedTimestamp: now.Add(-time.Minute),
+				}, nil)
+				mockParser.EXPECT().DomainInfoFromBlob([]byte(`expired`), gomock.Any()).Return(&serialization.DomainInfo{
+					Data:                 map[string]string{common.DomainDataKeyForSoftDeletedTime: now.Add(-2 * time.Hour).Format(time.RFC3339Nano)},
+					LastUpdatedTimestamp: now.Add(-2 * time.Hour),
+				}, nil)
+				mockParser.EXPECT().DomainInfoFromBlob([]byte(`deleted-status`), gomock.Any()).Return(&serialization.DomainInfo{
+					Status:               persistence.DomainStatusDeleted,
+					LastUpdatedTimestamp: now.Add(-2 * time.Hour),
+				}, nil)
+				mockDB.EXPECT().BeginTx(gomock.Any(), sqlplugin.DbDefaultShard).Return(mockTx, nil)
+				mockTx.EXPECT().DeleteFromDomain(gomock.Any(), &sqlplugin.DomainFilter{
+					ID: serialization.UUIDPtr(serialization.MustParseUUID("3a3dc7e2-1e67-41aa-8eaf-6d6e27f7e47c")),
+				}).Return(&sqlResult{rowsAffected: 1}, nil)
+				mockTx.EXPECT().Commit().Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error case - failed to list domains",
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx, mockParser *serialization.MockParser) {
+				err := errors.New("some error")
+				mockDB.EXPECT().SelectFromDomain(gomock.Any(), gomock.Any()).Return(nil, err)
+				mockDB.EXPECT().IsNotFoundError(err).Return(true)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := sqlplugin.NewMockDB(ctrl)
+			mockTx := sqlplugin.NewMockTx(ctrl)
+			mockParser := serialization.NewMockParser(ctrl)
+			store := &sqlDomainStore{
+				sqlStore:          sqlStore{db: mockDB, parser: mockParser},
+				activeClusterName: "active",
+			}
+
+			tc.mockSetup(mockDB, mockTx, mockParser)
+			err := store.PurgeDeletedDomains(context.Background(), olderThan)
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error for test case")
+			} else {
+				assert.NoError(t, err, "Did not expect an error for test case")
+			}
+		})
+	}
+}
@@ -120,6 +120,12 @@ func (m *sqlExecutionStore) CreateWorkflowExecution(
 	request *p.InternalCreateWorkflowExecutionRequest,
 ) (response *p.CreateWorkflowExecutionResponse, err error) {
 	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(m.shardID, m.db.GetTotalNumDBShards())
+	if request.DedupKey != "" {
+		// workflow requests are not persisted by SQL stores, so the key could not be enforced
+		return nil, &p.InvalidPersistenceRequestError{
+			Msg: "CreateWorkflowExecution with a dedup key is not supported by SQL stores",
+		}
+	}
 
 	err = m.txExecuteShardLockedFn(ctx, dbShardID, "CreateWorkflowExecution", request.RangeID, func(tx sqlplugin.Tx) error {
 		response, err = m.createWorkflowExecutionTx(ctx, tx, request)
@@ -778,6 +784,68 @@ func (m *sqlExecutionStore) IsWorkflowExecutionExists(
 	return nil, &types.InternalServiceError{Message: "Not yet implemented"}
 }
 
+// ListWorkflowRequests is not supported since workflow requests are not persisted by SQL stores
+func (m *sqlExecutionStore) ListWorkflowRequests(
+	_ context.Context,
+	_, _, _ string,
+) ([]*p.WorkflowRequest, error) {
+	return nil, &types.InternalServiceError{Message: "Not yet implemented"}
+}
+
+func (m *sqlExecutionStore) BackfillFirstExecutionRunID(
+	ctx context.Context,
+	request *p.BackfillFirstExecutionRunIDRequest,
+) error {
+	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(m.shardID, m.db.GetTotalNumDBShards())
+	domainID := serialization.MustParseUUID(request.DomainID)
+	runID := serialization.MustParseUUID(request.RunID)
+	return m.txExecuteShardLockedFn(ctx, dbShardID, "BackfillFirstExecutionRunID", request.RangeID, func(tx sqlplugin.Tx) error {
+		if _, err := lockNextEventID(ctx, tx, m.shardID, domainID, request.WorkflowID, runID); err != nil {
+			return err
+		}
+		executions, err := tx.SelectFromExecutions(ctx, &sqlplugin.ExecutionsFilter{
+			ShardID: m.shardID, DomainID: domainID, WorkflowID: request.WorkflowID, RunID: runID})
+		if err != nil {
+			return convertCommonErrors(tx, "BackfillFirstExecutionRunID", "", err)
+		}
+		if len(executions) != 1 {
+			return &types.InternalServiceError{
+				Message: fmt.Sprintf("BackfillFirstExecutionRunID failed. Found %v executions instead of 1.", len(executions)),
+			}
+		}
+
+		row := executions[0]
+		info, err := m.parser.WorkflowExecutionInfoFromBlob(row.Data, row.DataEncoding)
+		if err != nil {
+			return err
+		}
+		info.FirstExecutionRunID = serialization.MustParseUUID(request.FirstExecutionRunID)
+		blob, err := m.parser.WorkflowExecutionInfoToBlob(info)
+		if err != nil {
+			return err
+		}
+		row.Data = blob.Data
+		row.DataEncoding = string(blob.Encoding)
+
+		result, err := tx.UpdateExecutions(ctx, &row)
+		if err != nil {
+			return convertCommonErrors(tx, "BackfillFirstExecutionRunID", "", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return &types.InternalServiceError{
+				Message: fmt.Sprintf("BackfillFirstExecutionRunID failed. Failed to verify number of rows affected. Error: %v", err),
+			}
+		}
+		if rowsAffected != 1 {
+			return &types.EntityNotExistsError{
+				Message: fmt.Sprintf("BackfillFirstExecutionRunID failed. Affected %v rows updated instead of 1.", rowsAffected),
+			}
+		}
+		return nil
+	})
+}
+
 func (m *sqlExecutionStore) ListConcreteExecutions(
 	ctx context.Context,
 	request *p.ListConcreteExecutionsRequest,
@@ -1786,6 +1786,22 @@ func TestCreateWorkflowExecution(t *testing.T) {
 			},
 			want: &persistence.CreateWorkflowExecutionResponse{},
 		},
+		{
+			name: "Error - dedup key is not supported",
+			req: &persistence.InternalCreateWorkflowExecutionRequest{
+				RangeID: 1,
+				Mode:    persistence.CreateWorkflowModeBrandNew,
+				NewWorkflowSnapshot: persistence.InternalWorkflowSnapshot{
+					ExecutionInfo: &persistence.InternalWorkflowExecutionInfo{},
+				},
+				DedupKey: "dedup-key",
+			},
+			wantErr: true,
+			assertErr: func(t *testing.T, err error) {
+				var invalidErr *persistence.InvalidPersistenceRequestError
+				assert.ErrorAs(t, err, &invalidErr)
+			},
+		},
 		{
 			name: "Error - mode state validation failed",
 			req: &persistence.InternalCreateWorkflowExecutionRequest{
@@ -2707,6 +2723,124 @@ func TestCreateFailoverMarkerTasks(t *testing.T) {
 	}
 }
 
+func TestBackfillFirstExecutionRunID(t *testing.T) {
+	domainID := serialization.MustParseUUID("ff9c8a3f-0e4f-4d3e-a4d2-6f5f8f3f7d9d")
+	runID := serialization.MustParseUUID("ee8d7b6e-876c-4b1e-9b6e-5e3e3c6b6b3f")
+	firstRunID := "a2b3c4d5-876c-4b1e-9b6e-5e3e3c6b6b3f"
+	req := &persistence.BackfillFirstExecutionRunIDRequest{
+		RangeID:             1,
+		DomainID:            domainID.String(),
+		WorkflowID:          "test-workflow-id",
+		RunID:               runID.String(),
+		FirstExecutionRunID: firstRunID,
+	}
+	filter := &sqlplugin.ExecutionsFilter{
+		ShardID:    0,
+		DomainID:   domainID,
+		WorkflowID: "test-workflow-id",
+		RunID:      runID,
+	}
+	row := sqlplugin.ExecutionsRow{
+		ShardID:          0,
+		DomainID:         domainID,
+		WorkflowID:       "test-workflow-id",
+		RunID:            runID,
+		NextEventID:      10,
+		LastWriteVersion: 3,
+		Data:             []byte("old data"),
+		DataEncoding:     "thriftrw",
+	}
+	info := &serialization.WorkflowExecutionInfo{
+		TaskList:         "test-task-list",
+		WorkflowTypeName: "test-workflow-type",
+		StartVersion:     1,
+	}
+
+	testCases := []struct {
+		name      string
+		mockSetup func(*sqlplugin.MockTx, *serialization.MockParser)
+		wantErr   bool
+		assertErr func(*testing.T, error)
+	}{
+		{
+			name: "Success case",
+			mockSetup: func(tx *sqlplugin.MockTx, parser *serialization.MockParser) {
+				tx.EXPECT().WriteLockExecutions(gomock.Any(), filter).Return(10, nil)
+				tx.EXPECT().SelectFromExecutions(gomock.Any(), filter).Return([]sqlplugin.ExecutionsRow{row}, nil)
+				parser.EXPECT().WorkflowExecutionInfoFromBlob([]byte("old data"), "thriftrw").Return(info, nil)
+				parser.EXPECT().WorkflowExecutionInfoToBlob(&serialization.WorkflowExecutionInfo{
+					TaskList:            "test-task-list",
+					WorkflowTypeName:    "test-workflow-type",
+					StartVersion:        1,
+					FirstExecutionRunID: serialization.MustParseUUID(firstRunID),
+				}).Return(persistence.DataBlob{
+					Encoding: common.EncodingTypeThriftRW,
+					Data:     []byte("new data"),
+				}, nil)
+				updatedRow := row
+				updatedRow.Data = []byte("new data")
+				tx.EXPECT().UpdateExecutions(gomock.Any(), &updatedRow).Return(&sqlResult{rowsAffected: 1}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error case - workflow not exists",
+			mockSetup: func(tx *sqlplugin.MockTx, parser *serialization.MockParser) {
+				tx.EXPECT().WriteLockExecutions(gomock.Any(), filter).Return(0, sql.ErrNoRows)
+			},
+			wantErr: true,
+			assertErr: func(t *testing.T, err error) {
+				assert.IsType(t, &types.EntityNotExistsError{}, err)
+			},
+		},
+		{
+			name: "Error case - failed to update executions",
+			mockSetup: func(tx *sqlplugin.MockTx, parser *serialization.MockParser) {
+				err := errors.New("some error")
+				tx.EXPECT().WriteLockExecutions(gomock.Any(), filter).Return(10, nil)
+				tx.EXPECT().SelectFromExecutions(gomock.Any(), filter).Return([]sqlplugin.ExecutionsRow{row}, nil)
+				parser.EXPECT().WorkflowExecutionInfoFromBlob(gomock.Any(), gomock.Any()).Return(info, nil)
+				parser.EXPECT().WorkflowExecutionInfoToBlob(gomock.Any()).Return(persistence.DataBlob{}, nil)
+				tx.EXPECT().UpdateExecutions(gomock.Any(), gomock.Any()).Return(nil, err)
+				tx.EXPECT().IsNotFoundError(err).Return(true)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			db := sqlplugin.NewMockDB(ctrl)
+			db.EXPECT().GetTotalNumDBShards().Return(1)
+			tx := sqlplugin.NewMockTx(ctrl)
+			parser := serialization.NewMockParser(ctrl)
+			tc.mockSetup(tx, parser)
+			s := &sqlExecutionStore{
+				shardID: 0,
+				sqlStore: sqlStore{
+					db:     db,
+					logger: testlogger.New(t),
+					parser: parser,
+				},
+				txExecuteShardLockedFn: func(_ context.Context, _ int, _ string, _ int64, fn func(sqlplugin.Tx) error) error {
+					return fn(tx)
+				},
+			}
+
+			err := s.BackfillFirstExecutionRunID(context.Background(), req)
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error for test case")
+				if tc.assertErr != nil {
+					tc.assertErr(t, err)
+				}
+			} else {
+				assert.NoError(t, err, "Did not expect an error for test case")
+			}
+		})
+	}
+}
+
 func TestGetWorkflowExecution(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -24,6 +24,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/uber/cadence/common"
@@ -518,6 +519,87 @@ func (m *sqlHistoryStore) GetAllHistoryTreeBranches(
 	return resp, nil
 }
 
+// TruncateHistoryBranch removes the nodes of a branch with node ID greater than request.LastNodeID
+func (m *sqlHistoryStore) TruncateHistoryBranch(
+	ctx context.Context,
+	request *persistence.InternalTruncateHistoryBranchRequest,
+) error {
+
+	branch := request.BranchInfo
+	minNodeID := request.LastNodeID + 1
+	if beginNodeID := persistenceutils.GetBeginNodeID(branch); minNodeID < beginNodeID {
+		return &persistence.InvalidPersistenceRequestError{
+			Msg: fmt.Sprintf("cannot truncate branch %v before its begin node %v", branch.BranchID, beginNodeID),
+		}
+	}
+
+	treeID := serialization.MustParseUUID(branch.TreeID)
+	branchID := serialization.MustParseUUID(branch.BranchID)
+	dbShardID := sqlplugin.GetDBShardIDFromTreeID(treeID, m.db.GetTotalNumDBShards())
+	return m.txExecute(ctx, dbShardID, "TruncateHistoryBranch", func(tx sqlplugin.Tx) error {
+		// make sure none of the nodes to be removed were written by a newer transaction
+		readMinNodeID := minNodeID
+		maxNodeID := int64(math.MaxInt64)
+		for {
+			rows, err := tx.SelectFromHistoryNode(ctx, &sqlplugin.HistoryNodeFilter{
+				TreeID:    treeID,
+				BranchID:  branchID,
+				MinNodeID: &readMinNodeID,
+				MaxNodeID: &maxNodeID,
+				PageSize:  _defaultHistoryNodeDeleteBatch,
+				ShardID:   request.ShardID,
+			})
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+			for _, row := range rows {
+				if *row.TxnID > request.TransactionID {
+					return &persistence.ConditionFailedError{
+						Msg: fmt.Sprintf("node %v was written by transaction %v which is newer than %v", row.NodeID, *row.TxnID, request.TransactionID),
+					}
+				}
+			}
+			if len(rows) < _defaultHistoryNodeDeleteBatch {
+				break
+			}
+			// rows of the same node are sorted by transaction ID in descending order,
+			// so the rows of the last node which are skipped here have already been covered
+			readMinNodeID = rows[len(rows)-1].NodeID + 1
+		}
+
+		nodeFilter := &sqlplugin.HistoryNodeFilter{
+			TreeID:    treeID,
+			BranchID:  branchID,
+			MinNodeID: &minNodeID,
+			ShardID:   request.ShardID,
+			PageSize:  _defaultHistoryNodeDeleteBatch,
+		}
+		for {
+			result, err := tx.DeleteFromHistoryNode(ctx, nodeFilter)
+			if err != nil {
+				return err
+			}
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if rowsAffected < _defaultHistoryNodeDeleteBatch ||
+				rowsAffected == persistence.UnknownNumRowsAffected ||
+				rowsAffected > _defaultHistoryNodeDeleteBatch {
+				return nil
+			}
+		}
+	})
+}
+
+// BatchDeleteHistoryBranch removes multiple branches, reporting the outcome of each branch individually
+func (m *sqlHistoryStore) BatchDeleteHistoryBranch(
+	ctx context.Context,
+	requests []persistence.InternalDeleteHistoryBranchRequest,
+) (*persistence.InternalBatchDeleteHistoryBranchResponse, error) {
+	return persistenceutils.BatchDeleteHistoryBranchSequentially(ctx, m, requests), nil
+}
+
 // GetHistoryTree returns all branch information of a tree
 func (m *sqlHistoryStore) GetHistoryTree(
 	ctx context.Context,
@@ -526,6 +608,7 @@ func (m *sqlHistoryStore) GetHistoryTree(
 
 	treeID := serialization.MustParseUUID(request.TreeID)
 	branches := make([]*types.HistoryBranch, 0)
+	branchInfos := make(map[string]string)
 
 	treeFilter := &sqlplugin.HistoryTreeFilter{
 		TreeID:  treeID,
@@ -549,9 +632,11 @@ func (m *sqlHistoryStore) GetHistoryTree(
 			Ancestors: treeInfo.Ancestors,
 		}
 		branches = append(branches, br)
+		branchInfos[br.BranchID] = treeInfo.GetInfo()
 	}
 
 	return &persistence.InternalGetHistoryTreeResponse{
-		Branches: branches,
+		Branches:    branches,
+		BranchInfos: branchInfos,
 	}, nil
 }
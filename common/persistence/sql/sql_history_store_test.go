@@ -24,6 +24,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"math"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -72,6 +73,7 @@ func TestGetHistoryTree(t *testing.T) {
 							EndNodeID:   3,
 						},
 					},
+					Info: "branch-info",
 				}, nil)
 			},
 			want: &persistence.InternalGetHistoryTreeResponse{
@@ -88,6 +90,7 @@ func TestGetHistoryTree(t *testing.T) {
 						},
 					},
 				},
+				BranchInfos: map[string]string{"630ec3d3-f74b-423f-a138-3b35494fe691": "branch-info"},
 			},
 			wantErr: false,
 		},
@@ -560,6 +563,108 @@ func TestForkHistoryBranch(t *testing.T) {
 	}
 }
 
+func TestTruncateHistoryBranch(t *testing.T) {
+	treeID := "530ec3d3-f74b-423f-a138-3b35494fe691"
+	branchID := "630ec3d3-f74b-423f-a138-3b35494fe691"
+	request := func(lastNodeID int64) *persistence.InternalTruncateHistoryBranchRequest {
+		return &persistence.InternalTruncateHistoryBranchRequest{
+			BranchInfo: types.HistoryBranch{
+				TreeID:   treeID,
+				BranchID: branchID,
+				Ancestors: []*types.HistoryBranchRange{
+					{
+						BranchID:    "730ec3d3-f74b-423f-a138-3b35494fe691",
+						BeginNodeID: 1,
+						EndNodeID:   5,
+					},
+				},
+			},
+			LastNodeID:    lastNodeID,
+			TransactionID: 100,
+			ShardID:       1,
+		}
+	}
+	testCases := []struct {
+		name      string
+		req       *persistence.InternalTruncateHistoryBranchRequest
+		mockSetup func(*sqlplugin.MockDB, *sqlplugin.MockTx)
+		assertErr func(*testing.T, error)
+	}{
+		{
+			name: "Success case - nodes beyond the cut are removed",
+			req:  request(10),
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {
+				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil)
+				mockTx.EXPECT().SelectFromHistoryNode(gomock.Any(), &sqlplugin.HistoryNodeFilter{
+					TreeID:    serialization.MustParseUUID(treeID),
+					BranchID:  serialization.MustParseUUID(branchID),
+					MinNodeID: common.Int64Ptr(11),
+					MaxNodeID: common.Int64Ptr(math.MaxInt64),
+					PageSize:  _defaultHistoryNodeDeleteBatch,
+					ShardID:   1,
+				}).Return([]sqlplugin.HistoryNodeRow{
+					{NodeID: 11, TxnID: common.Int64Ptr(90)},
+					{NodeID: 13, TxnID: common.Int64Ptr(100)},
+				}, nil)
+				// nodes up to 10 are preserved
+				mockTx.EXPECT().DeleteFromHistoryNode(gomock.Any(), &sqlplugin.HistoryNodeFilter{
+					TreeID:    serialization.MustParseUUID(treeID),
+					BranchID:  serialization.MustParseUUID(branchID),
+					MinNodeID: common.Int64Ptr(11),
+					PageSize:  _defaultHistoryNodeDeleteBatch,
+					ShardID:   1,
+				}).Return(&sqlResult{rowsAffected: 2}, nil)
+				mockTx.EXPECT().Commit().Return(nil)
+			},
+		},
+		{
+			name: "Error case - node written by a newer transaction",
+			req:  request(10),
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {
+				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil)
+				mockTx.EXPECT().SelectFromHistoryNode(gomock.Any(), gomock.Any()).Return([]sqlplugin.HistoryNodeRow{
+					{NodeID: 11, TxnID: common.Int64Ptr(101)},
+				}, nil)
+				mockTx.EXPECT().Rollback().Return(nil)
+			},
+			assertErr: func(t *testing.T, err error) {
+				var conditionFailedErr *persistence.ConditionFailedError
+				assert.ErrorAs(t, err, &conditionFailedErr)
+			},
+		},
+		{
+			name:      "Error case - cut before the begin node of the branch",
+			req:       request(3),
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {},
+			assertErr: func(t *testing.T, err error) {
+				var invalidErr *persistence.InvalidPersistenceRequestError
+				assert.ErrorAs(t, err, &invalidErr)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := sqlplugin.NewMockDB(ctrl)
+			mockTx := sqlplugin.NewMockTx(ctrl)
+			store, err := NewHistoryV2Persistence(mockDB, nil, nil)
+			require.NoError(t, err, "Failed to create sql history store")
+
+			tc.mockSetup(mockDB, mockTx)
+			err = store.TruncateHistoryBranch(context.Background(), tc.req)
+			if tc.assertErr != nil {
+				tc.assertErr(t, err)
+			} else {
+				assert.NoError(t, err, "Did not expect an error for test case")
+			}
+		})
+	}
+}
+
 func TestAppendHistoryNodes(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -78,6 +78,50 @@ func (q *sqlQueueStore) EnqueueMessage(
 	})
 }
 
+func (q *sqlQueueStore) EnqueueMessages(
+	ctx context.Context,
+	messagePayloads [][]byte,
+) ([]int64, error) {
+	if len(messagePayloads) == 0 {
+		return nil, nil
+	}
+
+	var messageIDs []int64
+	err := q.txExecute(ctx, sqlplugin.DbDefaultShard, "EnqueueMessages", func(tx sqlplugin.Tx) error {
+		lastMessageID, err := tx.GetLastEnqueuedMessageIDForUpdate(ctx, q.queueType)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				lastMessageID = -1
+			} else {
+				return err
+			}
+		}
+
+		ackLevels, err := tx.GetAckLevels(ctx, q.queueType, true)
+		if err != nil {
+			return err
+		}
+
+		nextID := getNextID(ackLevels, lastMessageID)
+		rows := make([]sqlplugin.QueueRow, 0, len(messagePayloads))
+		ids := make([]int64, 0, len(messagePayloads))
+		for i, payload := range messagePayloads {
+			row := newQueueRow(q.queueType, nextID+int64(i), payload)
+			rows = append(rows, *row)
+			ids = append(ids, row.MessageID)
+		}
+		if _, err := tx.BatchInsertIntoQueue(ctx, rows); err != nil {
+			return err
+		}
+		messageIDs = ids
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messageIDs, nil
+}
+
 func (q *sqlQueueStore) ReadMessages(
 	ctx context.Context,
 	lastMessageID int64,
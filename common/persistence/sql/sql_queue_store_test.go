@@ -147,6 +147,100 @@ func TestEnqueueMessage(t *testing.T) {
 	}
 }
 
+func TestEnqueueMessages(t *testing.T) {
+	testCases := []struct {
+		name      string
+		payloads  [][]byte
+		mockSetup func(*sqlplugin.MockDB, *sqlplugin.MockTx)
+		wantIDs   []int64
+		wantErr   bool
+	}{
+		{
+			name:     "Success case - IDs assigned in order after the highest ack level",
+			payloads: [][]byte{[]byte("a"), []byte("b"), []byte("c")},
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {
+				mockDB.EXPECT().BeginTx(gomock.Any(), sqlplugin.DbDefaultShard).Return(mockTx, nil)
+				mockTx.EXPECT().GetLastEnqueuedMessageIDForUpdate(gomock.Any(), persistence.DomainReplicationQueueType).Return(int64(5), nil)
+				mockTx.EXPECT().GetAckLevels(gomock.Any(), persistence.DomainReplicationQueueType, true).Return(map[string]int64{"cluster": 9}, nil)
+				mockTx.EXPECT().BatchInsertIntoQueue(gomock.Any(), []sqlplugin.QueueRow{
+					{QueueType: persistence.DomainReplicationQueueType, MessageID: 10, MessagePayload: []byte("a")},
+					{QueueType: persistence.DomainReplicationQueueType, MessageID: 11, MessagePayload: []byte("b")},
+					{QueueType: persistence.DomainReplicationQueueType, MessageID: 12, MessagePayload: []byte("c")},
+				}).Return(nil, nil)
+				mockTx.EXPECT().Commit().Return(nil)
+			},
+			wantIDs: []int64{10, 11, 12},
+		},
+		{
+			name:     "Success case - empty queue",
+			payloads: [][]byte{[]byte("a"), []byte("b")},
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {
+				mockDB.EXPECT().BeginTx(gomock.Any(), sqlplugin.DbDefaultShard).Return(mockTx, nil)
+				mockTx.EXPECT().GetLastEnqueuedMessageIDForUpdate(gomock.Any(), persistence.DomainReplicationQueueType).Return(int64(0), sql.ErrNoRows)
+				mockTx.EXPECT().GetAckLevels(gomock.Any(), persistence.DomainReplicationQueueType, true).Return(nil, nil)
+				mockTx.EXPECT().BatchInsertIntoQueue(gomock.Any(), gomock.Len(2)).Return(nil, nil)
+				mockTx.EXPECT().Commit().Return(nil)
+			},
+			wantIDs: []int64{0, 1},
+		},
+		{
+			name:      "Success case - no payloads",
+			payloads:  nil,
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {},
+			wantIDs:   nil,
+		},
+		{
+			name:     "Error case - failed to get ack levels",
+			payloads: [][]byte{[]byte("a")},
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {
+				mockDB.EXPECT().BeginTx(gomock.Any(), sqlplugin.DbDefaultShard).Return(mockTx, nil)
+				mockTx.EXPECT().GetLastEnqueuedMessageIDForUpdate(gomock.Any(), persistence.DomainReplicationQueueType).Return(int64(0), sql.ErrNoRows)
+				err := errors.New("some error")
+				mockTx.EXPECT().GetAckLevels(gomock.Any(), persistence.DomainReplicationQueueType, true).Return(nil, err)
+				mockTx.EXPECT().Rollback().Return(nil)
+				mockDB.EXPECT().IsNotFoundError(err).Return(true)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "Error case - batch insert fails and nothing is committed",
+			payloads: [][]byte{[]byte("a"), []byte("b")},
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {
+				mockDB.EXPECT().BeginTx(gomock.Any(), sqlplugin.DbDefaultShard).Return(mockTx, nil)
+				mockTx.EXPECT().GetLastEnqueuedMessageIDForUpdate(gomock.Any(), persistence.DomainReplicationQueueType).Return(int64(0), sql.ErrNoRows)
+				mockTx.EXPECT().GetAckLevels(gomock.Any(), persistence.DomainReplicationQueueType, true).Return(nil, nil)
+				err := errors.New("some error")
+				mockTx.EXPECT().BatchInsertIntoQueue(gomock.Any(), gomock.Any()).Return(nil, err)
+				mockTx.EXPECT().Rollback().Return(nil)
+				mockDB.EXPECT().IsNotFoundError(err).Return(true)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := sqlplugin.NewMockDB(ctrl)
+			mockTx := sqlplugin.NewMockTx(ctrl)
+			store, err := newQueueStore(mockDB, nil, persistence.DomainReplicationQueueType)
+			require.NoError(t, err, "Failed to create sql queue store")
+
+			tc.mockSetup(mockDB, mockTx)
+			ids, err := store.EnqueueMessages(context.Background(), tc.payloads)
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error for test case")
+				assert.Nil(t, ids)
+			} else {
+				assert.NoError(t, err, "Did not expect an error for test case")
+				assert.Equal(t, tc.wantIDs, ids)
+			}
+		})
+	}
+}
+
 func TestReadMessages(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -24,6 +24,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/uber/cadence/common"
@@ -187,6 +188,30 @@ func (m *sqlShardStore) UpdateShard(
 	})
 }
 
+func (m *sqlShardStore) ListShardsByOwner(
+	ctx context.Context,
+	owner string,
+) ([]int, error) {
+	var shardIDs []int
+	for dbShardID := 0; dbShardID < m.db.GetTotalNumDBShards(); dbShardID++ {
+		rows, err := m.db.SelectAllFromShards(ctx, dbShardID)
+		if err != nil {
+			return nil, convertCommonErrors(m.db, "ListShardsByOwner", fmt.Sprintf("Failed to scan shards, DBShardID: %v.", dbShardID), err)
+		}
+		for _, row := range rows {
+			shardInfo, err := m.parser.ShardInfoFromBlob(row.Data, row.DataEncoding)
+			if err != nil {
+				return nil, err
+			}
+			if shardInfo.GetOwner() == owner {
+				shardIDs = append(shardIDs, int(row.ShardID))
+			}
+		}
+	}
+	sort.Ints(shardIDs)
+	return shardIDs, nil
+}
+
 // initiated by the owning shard
 func lockShard(ctx context.Context, tx sqlplugin.Tx, shardID int, oldRangeID int64) error {
 	rangeID, err := tx.WriteLockShards(ctx, &sqlplugin.ShardsFilter{ShardID: int64(shardID)})
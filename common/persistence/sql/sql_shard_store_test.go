@@ -590,3 +590,97 @@ func TestReadLockShard(t *testing.T) {
 		})
 	}
 }
+
+func TestListShardsByOwner(t *testing.T) {
+	testCases := []struct {
+		name      string
+		owner     string
+		mockSetup func(*sqlplugin.MockDB, *serialization.MockParser)
+		want      []int
+		wantErr   bool
+	}{
+		{
+			name:  "Success case",
+			owner: "host-a",
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().GetTotalNumDBShards().Return(2).AnyTimes()
+				mockDB.EXPECT().SelectAllFromShards(gomock.Any(), 0).Return([]sqlplugin.ShardsRow{
+					{ShardID: 4, Data: []byte(`shard-4`), DataEncoding: "json"},
+					{ShardID: 2, Data: []byte(`shard-2`), DataEncoding: "json"},
+				}, nil)
+				mockDB.EXPECT().SelectAllFromShards(gomock.Any(), 1).Return([]sqlplugin.ShardsRow{
+					{ShardID: 1, Data: []byte(`shard-1`), DataEncoding: "json"},
+					{ShardID: 3, Data: []byte(`shard-3`), DataEncoding: "json"},
+				}, nil)
+				owners := map[string]string{
+					"shard-1": "host-a",
+					"shard-2": "host-a",
+					"shard-3": "host-b",
+					"shard-4": "host-a",
+				}
+				mockParser.EXPECT().ShardInfoFromBlob(gomock.Any(), "json").DoAndReturn(func(data []byte, _ string) (*serialization.ShardInfo, error) {
+					return &serialization.ShardInfo{Owner: owners[string(data)]}, nil
+				}).Times(4)
+			},
+			want: []int{1, 2, 4},
+		},
+		{
+			name:  "Success case - no shards owned",
+			owner: "host-c",
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().GetTotalNumDBShards().Return(1).AnyTimes()
+				mockDB.EXPECT().SelectAllFromShards(gomock.Any(), 0).Return([]sqlplugin.ShardsRow{
+					{ShardID: 1, Data: []byte(`shard-1`), DataEncoding: "json"},
+				}, nil)
+				mockParser.EXPECT().ShardInfoFromBlob(gomock.Any(), "json").Return(&serialization.ShardInfo{Owner: "host-a"}, nil)
+			},
+			want: nil,
+		},
+		{
+			name:  "Error case - failed to scan shards",
+			owner: "host-a",
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				err := errors.New("some error")
+				mockDB.EXPECT().GetTotalNumDBShards().Return(1).AnyTimes()
+				mockDB.EXPECT().SelectAllFromShards(gomock.Any(), 0).Return(nil, err)
+				mockDB.EXPECT().IsNotFoundError(err).Return(false)
+				mockDB.EXPECT().IsTimeoutError(err).Return(false)
+				mockDB.EXPECT().IsThrottlingError(err).Return(false)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "Error case - failed to decode data",
+			owner: "host-a",
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().GetTotalNumDBShards().Return(1).AnyTimes()
+				mockDB.EXPECT().SelectAllFromShards(gomock.Any(), 0).Return([]sqlplugin.ShardsRow{
+					{ShardID: 1, Data: []byte(`shard-1`), DataEncoding: "json"},
+				}, nil)
+				mockParser.EXPECT().ShardInfoFromBlob(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := sqlplugin.NewMockDB(ctrl)
+			mockParser := serialization.NewMockParser(ctrl)
+			store, err := NewShardPersistence(mockDB, "active", nil, mockParser)
+			require.NoError(t, err, "Failed to create sql shard store")
+
+			tc.mockSetup(mockDB, mockParser)
+			got, err := store.ListShardsByOwner(context.Background(), tc.owner)
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error for test case")
+			} else {
+				assert.NoError(t, err, "Did not expect an error for test case")
+				assert.Equal(t, tc.want, got, "Unexpected result for test case")
+			}
+		})
+	}
+}
@@ -45,6 +45,8 @@ var (
 	stickyTasksListsTTL = time.Hour * 24
 )
 
+const getTaskListsByDomainPageSize = 1000
+
 // newTaskPersistence creates a new instance of TaskManager
 func newTaskPersistence(
 	db sqlplugin.DB,
@@ -78,6 +80,52 @@ func (m *sqlTaskStore) GetTaskListSize(ctx context.Context, request *persistence
 	return &persistence.GetTaskListSizeResponse{Size: size}, nil
 }
 
+func (m *sqlTaskStore) GetTaskIDRange(ctx context.Context, request *persistence.GetTaskIDRangeRequest) (*persistence.GetTaskIDRangeResponse, error) {
+	dbShardID := sqlplugin.GetDBShardIDFromDomainIDAndTasklist(request.DomainID, request.TaskListName, m.db.GetTotalNumDBShards())
+	minTaskID, maxTaskID, err := m.db.GetTaskIDRange(ctx, &sqlplugin.TasksFilter{
+		ShardID:      dbShardID,
+		DomainID:     serialization.MustParseUUID(request.DomainID),
+		TaskListName: request.TaskListName,
+		TaskType:     int64(request.TaskListType),
+	})
+	if err != nil {
+		return nil, convertCommonErrors(m.db, "GetTaskIDRange", "", err)
+	}
+	return &persistence.GetTaskIDRangeResponse{MinTaskID: minTaskID, MaxTaskID: maxTaskID}, nil
+}
+
+func (m *sqlTaskStore) GetTaskListsByDomain(ctx context.Context, request *persistence.GetTaskListsByDomainRequest) (*persistence.GetTaskListsByDomainResponse, error) {
+	resp := &persistence.GetTaskListsByDomainResponse{
+		DecisionTaskLists: make(map[string]int),
+		ActivityTaskLists: make(map[string]int),
+	}
+	var pageToken []byte
+	for {
+		page, err := m.ListTaskList(ctx, &persistence.ListTaskListRequest{
+			PageSize:  getTaskListsByDomainPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			if item.DomainID != request.DomainID {
+				continue
+			}
+			switch item.TaskType {
+			case persistence.TaskListTypeDecision:
+				resp.DecisionTaskLists[item.Name] = item.Kind
+			case persistence.TaskListTypeActivity:
+				resp.ActivityTaskLists[item.Name] = item.Kind
+			}
+		}
+		if len(page.NextPageToken) == 0 {
+			return resp, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
 func (m *sqlTaskStore) LeaseTaskList(
 	ctx context.Context,
 	request *persistence.LeaseTaskListRequest,
@@ -383,9 +431,12 @@ func (m *sqlTaskStore) CreateTasks(
 		tasksRows = make([]sqlplugin.TasksRow, len(request.Tasks))
 	}
 
+	taskIDs := make([]int64, len(request.Tasks))
+
 	dbShardID := sqlplugin.GetDBShardIDFromDomainIDAndTasklist(request.TaskListInfo.DomainID, request.TaskListInfo.Name, m.db.GetTotalNumDBShards())
 
 	for i, v := range request.Tasks {
+		taskIDs[i] = v.TaskID
 		var expiryTime time.Time
 		var ttl time.Duration
 		if v.Data.ScheduleToStartTimeout.Seconds() > 0 {
@@ -456,7 +507,7 @@ func (m *sqlTaskStore) CreateTasks(
 		if err1 != nil {
 			return err1
 		}
-		resp = &persistence.CreateTasksResponse{}
+		resp = &persistence.CreateTasksResponse{TaskIDs: taskIDs}
 		return nil
 	})
 	return resp, err
@@ -525,24 +576,33 @@ func (m *sqlTaskStore) CompleteTasksLessThan(
 	request *persistence.CompleteTasksLessThanRequest,
 ) (*persistence.CompleteTasksLessThanResponse, error) {
 	shardID := sqlplugin.GetDBShardIDFromDomainIDAndTasklist(request.DomainID, request.TaskListName, m.db.GetTotalNumDBShards())
-	result, err := m.db.DeleteFromTasks(ctx, &sqlplugin.TasksFilter{
+	filter := &sqlplugin.TasksFilter{
 		ShardID:              shardID,
 		DomainID:             serialization.MustParseUUID(request.DomainID),
 		TaskListName:         request.TaskListName,
 		TaskType:             int64(request.TaskType),
 		TaskIDLessThanEquals: &request.TaskID,
 		Limit:                &request.Limit,
-	})
-	if err != nil {
-		return nil, convertCommonErrors(m.db, "CompleteTasksLessThan", "", err)
 	}
-	nRows, err := result.RowsAffected()
-	if err != nil {
-		return nil, &types.InternalServiceError{
-			Message: fmt.Sprintf("rowsAffected returned error: %v", err),
+	total := 0
+	for {
+		result, err := m.db.DeleteFromTasks(ctx, filter)
+		if err != nil {
+			return nil, convertCommonErrors(m.db, "CompleteTasksLessThan", "", err)
+		}
+		nRows, err := result.RowsAffected()
+		if err != nil {
+			return nil, &types.InternalServiceError{
+				Message: fmt.Sprintf("rowsAffected returned error: %v", err),
+			}
+		}
+		total += int(nRows)
+		// a short batch means nothing is left below TaskID
+		if !request.ExactCount || int(nRows) < request.Limit {
+			break
 		}
 	}
-	return &persistence.CompleteTasksLessThanResponse{TasksCompleted: int(nRows)}, nil
+	return &persistence.CompleteTasksLessThanResponse{TasksCompleted: total}, nil
 }
 
 // GetOrphanTasks gets tasks from the tasks table that belong to a task_list no longer present
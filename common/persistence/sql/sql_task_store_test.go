@@ -111,6 +111,94 @@ func TestGetTaskListSize(t *testing.T) {
 	}
 }
 
+func TestGetTaskIDRange(t *testing.T) {
+	filter := &sqlplugin.TasksFilter{
+		ShardID:      0,
+		DomainID:     serialization.MustParseUUID("c9488dc7-20b2-44c3-b2e4-bfea5af62ac0"),
+		TaskListName: "tl",
+		TaskType:     0,
+	}
+	testCases := []struct {
+		name      string
+		req       *persistence.GetTaskIDRangeRequest
+		mockSetup func(*sqlplugin.MockDB)
+		want      *persistence.GetTaskIDRangeResponse
+		wantErr   bool
+	}{
+		{
+			name: "Success case - empty task list",
+			req: &persistence.GetTaskIDRangeRequest{
+				DomainID:     "c9488dc7-20b2-44c3-b2e4-bfea5af62ac0",
+				TaskListName: "tl",
+				TaskListType: 0,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().GetTaskIDRange(gomock.Any(), filter).Return(int64(0), int64(0), nil)
+			},
+			want: &persistence.GetTaskIDRangeResponse{
+				MinTaskID: 0,
+				MaxTaskID: 0,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Success case - populated task list",
+			req: &persistence.GetTaskIDRangeRequest{
+				DomainID:     "c9488dc7-20b2-44c3-b2e4-bfea5af62ac0",
+				TaskListName: "tl",
+				TaskListType: 0,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().GetTaskIDRange(gomock.Any(), filter).Return(int64(10), int64(42), nil)
+			},
+			want: &persistence.GetTaskIDRangeResponse{
+				MinTaskID: 10,
+				MaxTaskID: 42,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error case",
+			req: &persistence.GetTaskIDRangeRequest{
+				DomainID:     "c9488dc7-20b2-44c3-b2e4-bfea5af62ac0",
+				TaskListName: "tl",
+				TaskListType: 0,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				err := errors.New("some error")
+				mockDB.EXPECT().GetTaskIDRange(gomock.Any(), gomock.Any()).Return(int64(0), int64(0), err)
+				mockDB.EXPECT().IsNotFoundError(err).Return(true)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := sqlplugin.NewMockDB(ctrl)
+			store := &sqlTaskStore{
+				sqlStore: sqlStore{db: mockDB},
+			}
+
+			tc.mockSetup(mockDB)
+
+			got, err := store.GetTaskIDRange(context.Background(), tc.req)
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error for test case")
+			} else {
+				assert.NoError(t, err, "Did not expect an error for test case")
+				assert.Equal(t, tc.want, got, "Unexpected result for test case")
+			}
+		})
+	}
+}
+
 func TestLeaseTaskList(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -712,6 +800,84 @@ func TestListTaskList(t *testing.T) {
 	}
 }
 
+func TestGetTaskListsByDomain(t *testing.T) {
+	domainID := serialization.MustParseUUID("c9488dc7-20b2-44c3-b2e4-bfea5af62ac0")
+	otherDomainID := serialization.MustParseUUID("d9488dc7-20b2-44c3-b2e4-bfea5af62ac0")
+	testCases := []struct {
+		name      string
+		mockSetup func(*sqlplugin.MockDB, *serialization.MockParser)
+		want      *persistence.GetTaskListsByDomainResponse
+		wantErr   bool
+	}{
+		{
+			name: "Success case",
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().SelectFromTaskLists(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, filter *sqlplugin.TaskListsFilter) ([]sqlplugin.TaskListsRow, error) {
+					assert.Equal(t, 0, filter.ShardID)
+					assert.Equal(t, getTaskListsByDomainPageSize, *filter.PageSize)
+					return []sqlplugin.TaskListsRow{
+						{DomainID: domainID, Name: "decision-tl", TaskType: persistence.TaskListTypeDecision, Data: []byte(`normal`)},
+						{DomainID: domainID, Name: "sticky-tl", TaskType: persistence.TaskListTypeDecision, Data: []byte(`sticky`)},
+						{DomainID: domainID, Name: "activity-tl", TaskType: persistence.TaskListTypeActivity, Data: []byte(`normal`)},
+						{DomainID: otherDomainID, Name: "other-tl", TaskType: persistence.TaskListTypeDecision, Data: []byte(`normal`)},
+					}, nil
+				})
+				mockParser.EXPECT().TaskListInfoFromBlob([]byte(`normal`), gomock.Any()).Return(&serialization.TaskListInfo{
+					Kind: persistence.TaskListKindNormal,
+				}, nil).Times(3)
+				mockParser.EXPECT().TaskListInfoFromBlob([]byte(`sticky`), gomock.Any()).Return(&serialization.TaskListInfo{
+					Kind: persistence.TaskListKindSticky,
+				}, nil)
+			},
+			want: &persistence.GetTaskListsByDomainResponse{
+				DecisionTaskLists: map[string]int{
+					"decision-tl": persistence.TaskListKindNormal,
+					"sticky-tl":   persistence.TaskListKindSticky,
+				},
+				ActivityTaskLists: map[string]int{
+					"activity-tl": persistence.TaskListKindNormal,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error case",
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				err := errors.New("some error")
+				mockDB.EXPECT().SelectFromTaskLists(gomock.Any(), gomock.Any()).Return(nil, err)
+				mockDB.EXPECT().IsNotFoundError(err).Return(true)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := sqlplugin.NewMockDB(ctrl)
+			mockParser := serialization.NewMockParser(ctrl)
+			store := &sqlTaskStore{
+				sqlStore: sqlStore{db: mockDB, parser: mockParser},
+				nShards:  1,
+			}
+
+			tc.mockSetup(mockDB, mockParser)
+
+			got, err := store.GetTaskListsByDomain(context.Background(), &persistence.GetTaskListsByDomainRequest{
+				DomainID: domainID.String(),
+			})
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error for test case")
+			} else {
+				assert.NoError(t, err, "Did not expect an error for test case")
+				assert.Equal(t, tc.want, got, "Unexpected result for test case")
+			}
+		})
+	}
+}
+
 func TestDeleteTaskList(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -838,7 +1004,53 @@ func TestCreateTasks(t *testing.T) {
 				}).Return(int64(9), nil)
 				mockTx.EXPECT().Commit().Return(nil)
 			},
-			want:    &persistence.CreateTasksResponse{},
+			want:    &persistence.CreateTasksResponse{TaskIDs: []int64{999}},
+			wantErr: false,
+		},
+		{
+			name: "Success case - multiple tasks without TTL support",
+			req: &persistence.InternalCreateTasksRequest{
+				TaskListInfo: &persistence.TaskListInfo{
+					Name:     "tl",
+					TaskType: 1,
+					RangeID:  9,
+					DomainID: "c9488dc7-20b2-44c3-b2e4-bfea5af62ac0",
+				},
+				Tasks: []*persistence.InternalCreateTasksInfo{
+					{
+						Data:   &persistence.InternalTaskInfo{DomainID: "c9488dc7-20b2-44c3-b2e4-bfea5af62ac0", TaskID: 12},
+						TaskID: 12,
+					},
+					{
+						Data:   &persistence.InternalTaskInfo{DomainID: "c9488dc7-20b2-44c3-b2e4-bfea5af62ac0", TaskID: 10},
+						TaskID: 10,
+					},
+					{
+						Data:   &persistence.InternalTaskInfo{DomainID: "c9488dc7-20b2-44c3-b2e4-bfea5af62ac0", TaskID: 11},
+						TaskID: 11,
+					},
+				},
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().SupportsTTL().Return(false).Times(5)
+				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockParser.EXPECT().TaskInfoToBlob(gomock.Any()).Return(persistence.DataBlob{
+					Data:     []byte(`tl`),
+					Encoding: common.EncodingType("tl"),
+				}, nil).Times(3)
+				mockDB.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil)
+				mockTx.EXPECT().InsertIntoTasks(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, rows []sqlplugin.TasksRow) (sql.Result, error) {
+						assert.Len(t, rows, 3)
+						assert.Equal(t, int64(12), rows[0].TaskID)
+						assert.Equal(t, int64(10), rows[1].TaskID)
+						assert.Equal(t, int64(11), rows[2].TaskID)
+						return nil, nil
+					})
+				mockTx.EXPECT().LockTaskLists(gomock.Any(), gomock.Any()).Return(int64(9), nil)
+				mockTx.EXPECT().Commit().Return(nil)
+			},
+			want:    &persistence.CreateTasksResponse{TaskIDs: []int64{12, 10, 11}},
 			wantErr: false,
 		},
 		{
@@ -1192,6 +1404,56 @@ func TestCompleteTaskLessThan(t *testing.T) {
 			want:    &persistence.CompleteTasksLessThanResponse{TasksCompleted: 100},
 			wantErr: false,
 		},
+		{
+			name: "Exact count deletes all batches",
+			req: &persistence.CompleteTasksLessThanRequest{
+				DomainID:     "c9488dc7-20b2-44c3-b2e4-bfea5af62ac0",
+				TaskListName: "tl",
+				TaskType:     0,
+				TaskID:       1001,
+				Limit:        100,
+				ExactCount:   true,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				filter := &sqlplugin.TasksFilter{
+					ShardID:              0,
+					DomainID:             serialization.MustParseUUID("c9488dc7-20b2-44c3-b2e4-bfea5af62ac0"),
+					TaskListName:         "tl",
+					TaskType:             0,
+					TaskIDLessThanEquals: common.Int64Ptr(1001),
+					Limit:                common.IntPtr(100),
+				}
+				gomock.InOrder(
+					mockDB.EXPECT().DeleteFromTasks(gomock.Any(), filter).Return(&sqlResult{rowsAffected: 100}, nil),
+					mockDB.EXPECT().DeleteFromTasks(gomock.Any(), filter).Return(&sqlResult{rowsAffected: 100}, nil),
+					mockDB.EXPECT().DeleteFromTasks(gomock.Any(), filter).Return(&sqlResult{rowsAffected: 42}, nil),
+				)
+			},
+			want:    &persistence.CompleteTasksLessThanResponse{TasksCompleted: 242},
+			wantErr: false,
+		},
+		{
+			name: "Exact count error after first batch",
+			req: &persistence.CompleteTasksLessThanRequest{
+				DomainID:     "c9488dc7-20b2-44c3-b2e4-bfea5af62ac0",
+				TaskListName: "tl",
+				TaskType:     0,
+				TaskID:       1001,
+				Limit:        100,
+				ExactCount:   true,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				err := errors.New("some error")
+				gomock.InOrder(
+					mockDB.EXPECT().DeleteFromTasks(gomock.Any(), gomock.Any()).Return(&sqlResult{rowsAffected: 100}, nil),
+					mockDB.EXPECT().DeleteFromTasks(gomock.Any(), gomock.Any()).Return(nil, err),
+				)
+				mockDB.EXPECT().IsNotFoundError(err).Return(true)
+			},
+			wantErr: true,
+		},
 		{
 			name: "Error case",
 			req: &persistence.CompleteTasksLessThanRequest{
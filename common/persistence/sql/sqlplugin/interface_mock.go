@@ -114,6 +114,21 @@ func (m *MocktableCRUD) EXPECT() *MocktableCRUDMockRecorder {
 	return m.recorder
 }
 
+// BatchInsertIntoQueue mocks base method.
+func (m *MocktableCRUD) BatchInsertIntoQueue(ctx context.Context, rows []QueueRow) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchInsertIntoQueue", ctx, rows)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchInsertIntoQueue indicates an expected call of BatchInsertIntoQueue.
+func (mr *MocktableCRUDMockRecorder) BatchInsertIntoQueue(ctx, rows interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchInsertIntoQueue", reflect.TypeOf((*MocktableCRUD)(nil).BatchInsertIntoQueue), ctx, rows)
+}
+
 // DeleteFromActivityInfoMaps mocks base method.
 func (m *MocktableCRUD) DeleteFromActivityInfoMaps(ctx context.Context, filter *ActivityInfoMapsFilter) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -549,6 +564,22 @@ func (mr *MocktableCRUDMockRecorder) GetQueueSize(ctx, queueType interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueSize", reflect.TypeOf((*MocktableCRUD)(nil).GetQueueSize), ctx, queueType)
 }
 
+// GetTaskIDRange mocks base method.
+func (m *MocktableCRUD) GetTaskIDRange(ctx context.Context, filter *TasksFilter) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskIDRange", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTaskIDRange indicates an expected call of GetTaskIDRange.
+func (mr *MocktableCRUDMockRecorder) GetTaskIDRange(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskIDRange", reflect.TypeOf((*MocktableCRUD)(nil).GetTaskIDRange), ctx, filter)
+}
+
 // GetTasksCount mocks base method.
 func (m *MocktableCRUD) GetTasksCount(ctx context.Context, filter *TasksFilter) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1161,6 +1192,21 @@ func (mr *MocktableCRUDMockRecorder) ReplaceIntoVisibility(ctx, row interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceIntoVisibility", reflect.TypeOf((*MocktableCRUD)(nil).ReplaceIntoVisibility), ctx, row)
 }
 
+// SelectAllFromShards mocks base method.
+func (m *MocktableCRUD) SelectAllFromShards(ctx context.Context, dbShardID int) ([]ShardsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAllFromShards", ctx, dbShardID)
+	ret0, _ := ret[0].([]ShardsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAllFromShards indicates an expected call of SelectAllFromShards.
+func (mr *MocktableCRUDMockRecorder) SelectAllFromShards(ctx, dbShardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAllFromShards", reflect.TypeOf((*MocktableCRUD)(nil).SelectAllFromShards), ctx, dbShardID)
+}
+
 // SelectFromActivityInfoMaps mocks base method.
 func (m *MocktableCRUD) SelectFromActivityInfoMaps(ctx context.Context, filter *ActivityInfoMapsFilter) ([]ActivityInfoMapsRow, error) {
 	m.ctrl.T.Helper()
@@ -1891,6 +1937,21 @@ func (m *MockTx) EXPECT() *MockTxMockRecorder {
 	return m.recorder
 }
 
+// BatchInsertIntoQueue mocks base method.
+func (m *MockTx) BatchInsertIntoQueue(ctx context.Context, rows []QueueRow) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchInsertIntoQueue", ctx, rows)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchInsertIntoQueue indicates an expected call of BatchInsertIntoQueue.
+func (mr *MockTxMockRecorder) BatchInsertIntoQueue(ctx, rows interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchInsertIntoQueue", reflect.TypeOf((*MockTx)(nil).BatchInsertIntoQueue), ctx, rows)
+}
+
 // Commit mocks base method.
 func (m *MockTx) Commit() error {
 	m.ctrl.T.Helper()
@@ -2340,6 +2401,22 @@ func (mr *MockTxMockRecorder) GetQueueSize(ctx, queueType interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueSize", reflect.TypeOf((*MockTx)(nil).GetQueueSize), ctx, queueType)
 }
 
+// GetTaskIDRange mocks base method.
+func (m *MockTx) GetTaskIDRange(ctx context.Context, filter *TasksFilter) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskIDRange", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTaskIDRange indicates an expected call of GetTaskIDRange.
+func (mr *MockTxMockRecorder) GetTaskIDRange(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskIDRange", reflect.TypeOf((*MockTx)(nil).GetTaskIDRange), ctx, filter)
+}
+
 // GetTasksCount mocks base method.
 func (m *MockTx) GetTasksCount(ctx context.Context, filter *TasksFilter) (int64, error) {
 	m.ctrl.T.Helper()
@@ -3022,6 +3099,21 @@ func (mr *MockTxMockRecorder) Rollback() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockTx)(nil).Rollback))
 }
 
+// SelectAllFromShards mocks base method.
+func (m *MockTx) SelectAllFromShards(ctx context.Context, dbShardID int) ([]ShardsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAllFromShards", ctx, dbShardID)
+	ret0, _ := ret[0].([]ShardsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAllFromShards indicates an expected call of SelectAllFromShards.
+func (mr *MockTxMockRecorder) SelectAllFromShards(ctx, dbShardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAllFromShards", reflect.TypeOf((*MockTx)(nil).SelectAllFromShards), ctx, dbShardID)
+}
+
 // SelectFromActivityInfoMaps mocks base method.
 func (m *MockTx) SelectFromActivityInfoMaps(ctx context.Context, filter *ActivityInfoMapsFilter) ([]ActivityInfoMapsRow, error) {
 	m.ctrl.T.Helper()
@@ -3582,6 +3674,21 @@ func (m *MockDB) EXPECT() *MockDBMockRecorder {
 	return m.recorder
 }
 
+// BatchInsertIntoQueue mocks base method.
+func (m *MockDB) BatchInsertIntoQueue(ctx context.Context, rows []QueueRow) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchInsertIntoQueue", ctx, rows)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchInsertIntoQueue indicates an expected call of BatchInsertIntoQueue.
+func (mr *MockDBMockRecorder) BatchInsertIntoQueue(ctx, rows interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchInsertIntoQueue", reflect.TypeOf((*MockDB)(nil).BatchInsertIntoQueue), ctx, rows)
+}
+
 // BeginTx mocks base method.
 func (m *MockDB) BeginTx(ctx context.Context, dbShardID int) (Tx, error) {
 	m.ctrl.T.Helper()
@@ -4046,6 +4153,22 @@ func (mr *MockDBMockRecorder) GetQueueSize(ctx, queueType interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueSize", reflect.TypeOf((*MockDB)(nil).GetQueueSize), ctx, queueType)
 }
 
+// GetTaskIDRange mocks base method.
+func (m *MockDB) GetTaskIDRange(ctx context.Context, filter *TasksFilter) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskIDRange", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTaskIDRange indicates an expected call of GetTaskIDRange.
+func (mr *MockDBMockRecorder) GetTaskIDRange(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskIDRange", reflect.TypeOf((*MockDB)(nil).GetTaskIDRange), ctx, filter)
+}
+
 // GetTasksCount mocks base method.
 func (m *MockDB) GetTasksCount(ctx context.Context, filter *TasksFilter) (int64, error) {
 	m.ctrl.T.Helper()
@@ -4742,6 +4865,21 @@ func (mr *MockDBMockRecorder) ReplaceIntoVisibility(ctx, row interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceIntoVisibility", reflect.TypeOf((*MockDB)(nil).ReplaceIntoVisibility), ctx, row)
 }
 
+// SelectAllFromShards mocks base method.
+func (m *MockDB) SelectAllFromShards(ctx context.Context, dbShardID int) ([]ShardsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectAllFromShards", ctx, dbShardID)
+	ret0, _ := ret[0].([]ShardsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectAllFromShards indicates an expected call of SelectAllFromShards.
+func (mr *MockDBMockRecorder) SelectAllFromShards(ctx, dbShardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAllFromShards", reflect.TypeOf((*MockDB)(nil).SelectAllFromShards), ctx, dbShardID)
+}
+
 // SelectFromActivityInfoMaps mocks base method.
 func (m *MockDB) SelectFromActivityInfoMaps(ctx context.Context, filter *ActivityInfoMapsFilter) ([]ActivityInfoMapsRow, error) {
 	m.ctrl.T.Helper()
@@ -598,6 +598,8 @@ type (
 		InsertIntoShards(ctx context.Context, rows *ShardsRow) (sql.Result, error)
 		UpdateShards(ctx context.Context, row *ShardsRow) (sql.Result, error)
 		SelectFromShards(ctx context.Context, filter *ShardsFilter) (*ShardsRow, error)
+		// SelectAllFromShards returns all rows of the shards table stored in the given database shard
+		SelectAllFromShards(ctx context.Context, dbShardID int) ([]ShardsRow, error)
 		ReadLockShards(ctx context.Context, filter *ShardsFilter) (int, error)
 		WriteLockShards(ctx context.Context, filter *ShardsFilter) (int, error)
 
@@ -615,6 +617,8 @@ type (
 		//    - this will delete up to limit number of tasks less than or equal to the given task id
 		DeleteFromTasks(ctx context.Context, filter *TasksFilter) (sql.Result, error)
 		GetTasksCount(ctx context.Context, filter *TasksFilter) (int64, error)
+		// GetTaskIDRange returns the min and max task ID in a task list, both are 0 if there are no tasks
+		GetTaskIDRange(ctx context.Context, filter *TasksFilter) (minTaskID int64, maxTaskID int64, err error)
 		GetOrphanTasks(ctx context.Context, filter *OrphanTasksFilter) ([]TaskKeyRow, error)
 
 		InsertIntoTaskLists(ctx context.Context, row *TaskListsRow) (sql.Result, error)
@@ -801,6 +805,8 @@ type (
 		DeleteFromVisibility(ctx context.Context, filter *VisibilityFilter) (sql.Result, error)
 
 		InsertIntoQueue(ctx context.Context, row *QueueRow) (sql.Result, error)
+		// BatchInsertIntoQueue inserts multiple rows into queue table with a single statement
+		BatchInsertIntoQueue(ctx context.Context, rows []QueueRow) (sql.Result, error)
 		GetLastEnqueuedMessageIDForUpdate(ctx context.Context, queueType persistence.QueueType) (int64, error)
 		GetMessagesFromQueue(ctx context.Context, queueType persistence.QueueType, lastMessageID int64, maxRows int) ([]QueueRow, error)
 		GetMessagesBetween(ctx context.Context, queueType persistence.QueueType, firstMessageID int64, lastMessageID int64, maxRows int) ([]QueueRow, error)
@@ -53,6 +53,15 @@ func (mdb *db) InsertIntoQueue(
 	return mdb.driver.NamedExecContext(ctx, sqlplugin.DbDefaultShard, templateEnqueueMessageQuery, row)
 }
 
+// BatchInsertIntoQueue inserts multiple rows into queue table
+func (mdb *db) BatchInsertIntoQueue(
+	ctx context.Context,
+	rows []sqlplugin.QueueRow,
+) (sql.Result, error) {
+
+	return mdb.driver.NamedExecContext(ctx, sqlplugin.DbDefaultShard, templateEnqueueMessageQuery, rows)
+}
+
 // GetLastEnqueuedMessageIDForUpdate returns the last enqueued message ID
 func (mdb *db) GetLastEnqueuedMessageIDForUpdate(
 	ctx context.Context,
@@ -35,6 +35,10 @@ const (
  shard_id, range_id, data, data_encoding
  FROM shards WHERE shard_id = ?`
 
+	listShardsQry = `SELECT
+ shard_id, range_id, data, data_encoding
+ FROM shards`
+
 	updateShardQry = `UPDATE shards 
  SET range_id = ?, data = ?, data_encoding = ? 
  WHERE shard_id = ?`
@@ -66,6 +70,16 @@ func (mdb *db) SelectFromShards(ctx context.Context, filter *sqlplugin.ShardsFil
 	return &row, err
 }
 
+// SelectAllFromShards reads all rows from shards table in the given database shard
+func (mdb *db) SelectAllFromShards(ctx context.Context, dbShardID int) ([]sqlplugin.ShardsRow, error) {
+	var rows []sqlplugin.ShardsRow
+	err := mdb.driver.SelectContext(ctx, dbShardID, &rows, listShardsQry)
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
 // ReadLockShards acquires a read lock on a single row in shards table
 func (mdb *db) ReadLockShards(ctx context.Context, filter *sqlplugin.ShardsFilter) (int, error) {
 	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
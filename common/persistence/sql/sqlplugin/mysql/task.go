@@ -86,6 +86,10 @@ task_type = :task_type
 		`FROM tasks ` +
 		`WHERE domain_id = ? AND task_list_name = ? AND task_type = ? AND task_id > ?`
 
+	getTaskIDRangeQry = `SELECT COALESCE(MIN(task_id), 0) AS min_task_id, COALESCE(MAX(task_id), 0) AS max_task_id ` +
+		`FROM tasks ` +
+		`WHERE domain_id = ? AND task_list_name = ? AND task_type = ?`
+
 	createTaskQry = `INSERT INTO ` +
 		`tasks(domain_id, task_list_name, task_type, task_id, data, data_encoding) ` +
 		`VALUES(:domain_id, :task_list_name, :task_type, :task_id, :data, :data_encoding)`
@@ -221,6 +225,18 @@ func (mdb *db) GetTasksCount(ctx context.Context, filter *sqlplugin.TasksFilter)
 	return size[0], nil
 }
 
+// GetTaskIDRange returns the min and max task ID in a task list, both are 0 if there are no tasks
+func (mdb *db) GetTaskIDRange(ctx context.Context, filter *sqlplugin.TasksFilter) (int64, int64, error) {
+	var row struct {
+		MinTaskID int64
+		MaxTaskID int64
+	}
+	if err := mdb.driver.GetContext(ctx, filter.ShardID, &row, getTaskIDRangeQry, filter.DomainID, filter.TaskListName, filter.TaskType); err != nil {
+		return 0, 0, err
+	}
+	return row.MinTaskID, row.MaxTaskID, nil
+}
+
 // InsertIntoTasksWithTTL is not supported in MySQL
 func (mdb *db) InsertIntoTasksWithTTL(_ context.Context, _ []sqlplugin.TasksRowWithTTL) (sql.Result, error) {
 	return nil, sqlplugin.ErrTTLNotSupported
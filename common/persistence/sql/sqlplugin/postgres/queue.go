@@ -49,6 +49,11 @@ func (pdb *db) InsertIntoQueue(ctx context.Context, row *sqlplugin.QueueRow) (sq
 	return pdb.driver.NamedExecContext(ctx, sqlplugin.DbDefaultShard, templateEnqueueMessageQuery, row)
 }
 
+// BatchInsertIntoQueue inserts multiple rows into queue table
+func (pdb *db) BatchInsertIntoQueue(ctx context.Context, rows []sqlplugin.QueueRow) (sql.Result, error) {
+	return pdb.driver.NamedExecContext(ctx, sqlplugin.DbDefaultShard, templateEnqueueMessageQuery, rows)
+}
+
 // GetLastEnqueuedMessageIDForUpdate returns the last enqueued message ID
 func (pdb *db) GetLastEnqueuedMessageIDForUpdate(ctx context.Context, queueType persistence.QueueType) (int64, error) {
 	var lastMessageID int64
@@ -35,6 +35,10 @@ const (
  shard_id, range_id, data, data_encoding
  FROM shards WHERE shard_id = $1`
 
+	listShardsQry = `SELECT
+ shard_id, range_id, data, data_encoding
+ FROM shards`
+
 	updateShardQry = `UPDATE shards 
  SET range_id = $1, data = $2, data_encoding = $3 
  WHERE shard_id = $4`
@@ -66,6 +70,16 @@ func (pdb *db) SelectFromShards(ctx context.Context, filter *sqlplugin.ShardsFil
 	return &row, err
 }
 
+// SelectAllFromShards reads all rows from shards table in the given database shard
+func (pdb *db) SelectAllFromShards(ctx context.Context, dbShardID int) ([]sqlplugin.ShardsRow, error) {
+	var rows []sqlplugin.ShardsRow
+	err := pdb.driver.SelectContext(ctx, dbShardID, &rows, listShardsQry)
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
 // ReadLockShards acquires a read lock on a single row in shards table
 func (pdb *db) ReadLockShards(ctx context.Context, filter *sqlplugin.ShardsFilter) (int, error) {
 	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
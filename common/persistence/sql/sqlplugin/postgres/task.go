@@ -86,6 +86,10 @@ task_type = :task_type
 		`FROM tasks ` +
 		`WHERE domain_id = $1 AND task_list_name = $2 AND task_type = $3 AND task_id > $4`
 
+	getTaskIDRangeQry = `SELECT COALESCE(MIN(task_id), 0) AS min_task_id, COALESCE(MAX(task_id), 0) AS max_task_id ` +
+		`FROM tasks ` +
+		`WHERE domain_id = $1 AND task_list_name = $2 AND task_type = $3`
+
 	createTaskQry = `INSERT INTO ` +
 		`tasks(domain_id, task_list_name, task_type, task_id, data, data_encoding) ` +
 		`VALUES(:domain_id, :task_list_name, :task_type, :task_id, :data, :data_encoding)`
@@ -151,6 +155,18 @@ func (pdb *db) GetTasksCount(ctx context.Context, filter *sqlplugin.TasksFilter)
 	return size[0], nil
 }
 
+// GetTaskIDRange returns the min and max task ID in a task list, both are 0 if there are no tasks
+func (pdb *db) GetTaskIDRange(ctx context.Context, filter *sqlplugin.TasksFilter) (int64, int64, error) {
+	var row struct {
+		MinTaskID int64
+		MaxTaskID int64
+	}
+	if err := pdb.driver.GetContext(ctx, filter.ShardID, &row, getTaskIDRangeQry, filter.DomainID, filter.TaskListName, filter.TaskType); err != nil {
+		return 0, 0, err
+	}
+	return row.MinTaskID, row.MaxTaskID, nil
+}
+
 func (pdb *db) GetOrphanTasks(ctx context.Context, filter *sqlplugin.OrphanTasksFilter) ([]sqlplugin.TaskKeyRow, error) {
 	if filter.Limit == nil || *filter.Limit == 0 {
 		return nil, fmt.Errorf("missing limit parameter")
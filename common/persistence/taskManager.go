@@ -73,6 +73,10 @@ func (t *taskManager) GetTaskListSize(ctx context.Context, request *GetTaskListS
 	return t.persistence.GetTaskListSize(ctx, request)
 }
 
+func (t *taskManager) GetTaskIDRange(ctx context.Context, request *GetTaskIDRangeRequest) (*GetTaskIDRangeResponse, error) {
+	return t.persistence.GetTaskIDRange(ctx, request)
+}
+
 func (t *taskManager) CreateTasks(ctx context.Context, request *CreateTasksRequest) (*CreateTasksResponse, error) {
 	var internalCreateTasks []*InternalCreateTasksInfo
 	for _, task := range request.Tasks {
@@ -82,11 +86,11 @@ func (t *taskManager) CreateTasks(ctx context.Context, request *CreateTasksReque
 		TaskListInfo: request.TaskListInfo,
 		Tasks:        internalCreateTasks,
 	}
-	_, err := t.persistence.CreateTasks(ctx, internalRequest)
+	resp, err := t.persistence.CreateTasks(ctx, internalRequest)
 	if err != nil {
 		return nil, err
 	}
-	return &CreateTasksResponse{}, err
+	return &CreateTasksResponse{TaskIDs: resp.TaskIDs}, nil
 }
 
 func (t *taskManager) GetTasks(ctx context.Context, request *GetTasksRequest) (*GetTasksResponse, error) {
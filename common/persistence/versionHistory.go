@@ -24,6 +24,7 @@ import (
 	"bytes"
 	"fmt"
 
+	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/types"
 )
@@ -593,3 +594,105 @@ func (h *VersionHistories) GetCurrentVersionHistory() (*VersionHistory, error) {
 
 	return h.GetVersionHistory(h.GetCurrentVersionHistoryIndex())
 }
+
+// ValidateVersionHistories deserializes the version histories of a mutable state and validates that
+// every version history has monotonically increasing items and a branch token pointing to a distinct branch
+// of the same history tree. Returns nil version histories if the blob is empty, which is the case for
+// workflows without version histories. An *InconsistentVersionHistoriesError is returned if validation fails.
+func ValidateVersionHistories(
+	serializer PayloadSerializer,
+	blob *DataBlob,
+) (*VersionHistories, error) {
+
+	if blob == nil || len(blob.Data) == 0 {
+		return nil, nil
+	}
+	histories, err := serializer.DeserializeVersionHistories(blob)
+	if err != nil {
+		return nil, &InconsistentVersionHistoriesError{Msg: fmt.Sprintf("failed to deserialize version histories: %v", err)}
+	}
+	if len(histories.Histories) == 0 {
+		return nil, &InconsistentVersionHistoriesError{Msg: "version histories is empty"}
+	}
+	currentIndex := int(histories.CurrentVersionHistoryIndex)
+	if currentIndex < 0 || currentIndex >= len(histories.Histories) {
+		return nil, &InconsistentVersionHistoriesError{Msg: fmt.Sprintf(
+			"current version history index %v is out of range, number of version histories: %v",
+			currentIndex, len(histories.Histories),
+		)}
+	}
+
+	for index, history := range histories.Histories {
+		if history == nil {
+			return nil, &InconsistentVersionHistoriesError{Msg: fmt.Sprintf("version history %v is null", index)}
+		}
+		if err := validateVersionHistoryItems(history.Items); err != nil {
+			return nil, &InconsistentVersionHistoriesError{Msg: fmt.Sprintf("version history %v: %v", index, err)}
+		}
+	}
+
+	current := histories.Histories[currentIndex]
+	currentFirstItem, currentLastItem := current.Items[0], current.Items[len(current.Items)-1]
+	treeID := ""
+	branchIDs := make(map[string]int, len(histories.Histories))
+	result := &VersionHistories{CurrentVersionHistoryIndex: currentIndex}
+	for index, history := range histories.Histories {
+		if history.Items[0].Version != currentFirstItem.Version {
+			return nil, &InconsistentVersionHistoriesError{Msg: fmt.Sprintf(
+				"version history %v starts with version %v, current version history starts with version %v",
+				index, history.Items[0].Version, currentFirstItem.Version,
+			)}
+		}
+		if lastItem := history.Items[len(history.Items)-1]; lastItem.Version > currentLastItem.Version {
+			return nil, &InconsistentVersionHistoriesError{Msg: fmt.Sprintf(
+				"version history %v has a higher last version %v than current version history %v",
+				index, lastItem.Version, currentIndex,
+			)}
+		}
+
+		var branch workflow.HistoryBranch
+		if err := internalThriftEncoder.Decode(history.BranchToken, &branch); err != nil {
+			return nil, &InconsistentVersionHistoriesError{Msg: fmt.Sprintf("version history %v has invalid branch token: %v", index, err)}
+		}
+		if branch.GetTreeID() == "" || branch.GetBranchID() == "" {
+			return nil, &InconsistentVersionHistoriesError{Msg: fmt.Sprintf("version history %v has branch token without tree or branch ID", index)}
+		}
+		if treeID == "" {
+			treeID = branch.GetTreeID()
+		} else if treeID != branch.GetTreeID() {
+			return nil, &InconsistentVersionHistoriesError{Msg: fmt.Sprintf(
+				"version history %v points to tree %v, expected tree %v", index, branch.GetTreeID(), treeID,
+			)}
+		}
+		if other, ok := branchIDs[branch.GetBranchID()]; ok {
+			return nil, &InconsistentVersionHistoriesError{Msg: fmt.Sprintf(
+				"version histories %v and %v point to the same branch %v", other, index, branch.GetBranchID(),
+			)}
+		}
+		branchIDs[branch.GetBranchID()] = index
+		result.Histories = append(result.Histories, NewVersionHistoryFromInternalType(history))
+	}
+	return result, nil
+}
+
+func validateVersionHistoryItems(items []*types.VersionHistoryItem) error {
+	if len(items) == 0 {
+		return fmt.Errorf("version history is empty")
+	}
+	for i, item := range items {
+		if item == nil {
+			return fmt.Errorf("item %v is null", i)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := items[i-1]
+		if item.Version <= prev.Version {
+			return fmt.Errorf("item %v has version %v, not greater than previous version %v", i, item.Version, prev.Version)
+		}
+		if item.EventID <= prev.EventID {
+			return fmt.Errorf("item %v has event id %v, not greater than previous event id %v", i, item.EventID, prev.EventID)
+		}
+	}
+	return nil
+}
@@ -703,3 +703,164 @@ func (s *versionHistoriesSuite) TestCurrentVersionHistoryIndexIsInReplay() {
 	s.NoError(err)
 	s.False(isInReplay)
 }
+
+func (s *versionHistoriesSuite) TestValidateVersionHistories() {
+	serializer := NewPayloadSerializer()
+	token := func(treeID, branchID string) []byte {
+		t, err := NewHistoryBranchTokenByBranchID(treeID, branchID)
+		s.NoError(err)
+		return t
+	}
+	items := func(pairs ...int64) []*types.VersionHistoryItem {
+		var result []*types.VersionHistoryItem
+		for i := 0; i < len(pairs); i += 2 {
+			result = append(result, &types.VersionHistoryItem{EventID: pairs[i], Version: pairs[i+1]})
+		}
+		return result
+	}
+
+	testCases := []struct {
+		name      string
+		histories *types.VersionHistories
+		blob      *DataBlob
+		wantErr   bool
+	}{
+		{
+			name: "empty blob",
+			blob: &DataBlob{Encoding: common.EncodingTypeThriftRW},
+		},
+		{
+			name: "valid single history",
+			histories: &types.VersionHistories{
+				Histories: []*types.VersionHistory{
+					{BranchToken: token("tree", "branch"), Items: items(3, 0, 7, 2)},
+				},
+			},
+		},
+		{
+			name: "valid multiple histories",
+			histories: &types.VersionHistories{
+				CurrentVersionHistoryIndex: 1,
+				Histories: []*types.VersionHistory{
+					{BranchToken: token("tree", "branch"), Items: items(3, 0, 7, 2)},
+					{BranchToken: token("tree", "other-branch"), Items: items(3, 0, 5, 2, 9, 4)},
+				},
+			},
+		},
+		{
+			name:    "corrupted blob",
+			blob:    &DataBlob{Encoding: common.EncodingTypeThriftRW, Data: []byte("corrupted")},
+			wantErr: true,
+		},
+		{
+			name: "current index out of range",
+			histories: &types.VersionHistories{
+				CurrentVersionHistoryIndex: 1,
+				Histories: []*types.VersionHistory{
+					{BranchToken: token("tree", "branch"), Items: items(3, 0)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "decreasing event ID",
+			histories: &types.VersionHistories{
+				Histories: []*types.VersionHistory{
+					{BranchToken: token("tree", "branch"), Items: items(7, 0, 3, 2)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "decreasing version",
+			histories: &types.VersionHistories{
+				Histories: []*types.VersionHistory{
+					{BranchToken: token("tree", "branch"), Items: items(3, 2, 7, 0)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty items",
+			histories: &types.VersionHistories{
+				Histories: []*types.VersionHistory{
+					{BranchToken: token("tree", "branch")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid branch token",
+			histories: &types.VersionHistories{
+				Histories: []*types.VersionHistory{
+					{BranchToken: []byte("invalid"), Items: items(3, 0)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "branches of different trees",
+			histories: &types.VersionHistories{
+				Histories: []*types.VersionHistory{
+					{BranchToken: token("tree", "branch"), Items: items(3, 0)},
+					{BranchToken: token("other-tree", "other-branch"), Items: items(3, 0)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "histories share a branch",
+			histories: &types.VersionHistories{
+				Histories: []*types.VersionHistory{
+					{BranchToken: token("tree", "branch"), Items: items(3, 0)},
+					{BranchToken: token("tree", "branch"), Items: items(3, 0)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "current history is not the latest",
+			histories: &types.VersionHistories{
+				Histories: []*types.VersionHistory{
+					{BranchToken: token("tree", "branch"), Items: items(3, 0)},
+					{BranchToken: token("tree", "other-branch"), Items: items(3, 0, 5, 2)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "histories with different roots",
+			histories: &types.VersionHistories{
+				Histories: []*types.VersionHistory{
+					{BranchToken: token("tree", "branch"), Items: items(3, 0)},
+					{BranchToken: token("tree", "other-branch"), Items: items(3, -1)},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			blob := tc.blob
+			if tc.histories != nil {
+				var err error
+				blob, err = serializer.SerializeVersionHistories(tc.histories, common.EncodingTypeThriftRW)
+				s.NoError(err)
+			}
+			result, err := ValidateVersionHistories(serializer, blob)
+			if tc.wantErr {
+				var inconsistentErr *InconsistentVersionHistoriesError
+				s.ErrorAs(err, &inconsistentErr)
+				s.Nil(result)
+				return
+			}
+			s.NoError(err)
+			if tc.histories == nil {
+				s.Nil(result)
+				return
+			}
+			s.Equal(NewVersionHistoriesFromInternalType(tc.histories), result)
+		})
+	}
+}
@@ -137,6 +137,21 @@ func (c *injectorTaskManager) GetOrphanTasks(ctx context.Context, request *persi
 	return
 }
 
+func (c *injectorTaskManager) GetTaskIDRange(ctx context.Context, request *persistence.GetTaskIDRangeRequest) (gp1 *persistence.GetTaskIDRangeResponse, err error) {
+	fakeErr := generateFakeError(c.errorRate)
+	var forwardCall bool
+	if forwardCall = shouldForwardCallToPersistence(fakeErr); forwardCall {
+		gp1, err = c.wrapped.GetTaskIDRange(ctx, request)
+	}
+
+	if fakeErr != nil {
+		logErr(c.logger, "TaskManager.GetTaskIDRange", fakeErr, forwardCall, err)
+		err = fakeErr
+		return
+	}
+	return
+}
+
 func (c *injectorTaskManager) GetTaskListSize(ctx context.Context, request *persistence.GetTaskListSizeRequest) (gp1 *persistence.GetTaskListSizeResponse, err error) {
 	fakeErr := generateFakeError(c.errorRate)
 	var forwardCall bool
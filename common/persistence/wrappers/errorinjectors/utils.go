@@ -318,6 +318,8 @@ func taskManagerTags(op string) *tag.Tag {
 		return &tag.StoreOperationGetOrphanTasks
 	case "TaskManager.GetTaskListSize":
 		return &tag.StoreOperationGetTaskListSize
+	case "TaskManager.GetTaskIDRange":
+		return &tag.StoreOperationGetTaskIDRange
 	case "TaskManager.ListTaskList":
 		return &tag.StoreOperationListTaskList
 	}
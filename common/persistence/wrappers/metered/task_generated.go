@@ -122,6 +122,17 @@ func (c *meteredTaskManager) GetOrphanTasks(ctx context.Context, request *persis
 	return
 }
 
+func (c *meteredTaskManager) GetTaskIDRange(ctx context.Context, request *persistence.GetTaskIDRangeRequest) (gp1 *persistence.GetTaskIDRangeResponse, err error) {
+	op := func() error {
+		gp1, err = c.wrapped.GetTaskIDRange(ctx, request)
+		c.emptyMetric("TaskManager.GetTaskIDRange", request, gp1, err)
+		return err
+	}
+
+	err = c.call(metrics.PersistenceGetTaskIDRangeScope, op, getCustomMetricTags(request)...)
+	return
+}
+
 func (c *meteredTaskManager) GetTaskListSize(ctx context.Context, request *persistence.GetTaskListSizeRequest) (gp1 *persistence.GetTaskListSizeResponse, err error) {
 	op := func() error {
 		gp1, err = c.wrapped.GetTaskListSize(ctx, request)
@@ -29,11 +29,11 @@ import (
 	"strings"
 )
 
-const _CollectionName = "CollectionMutableStateCollectionHistoryCollectionDomainCollectionStale"
+const _CollectionName = "CollectionMutableStateCollectionHistoryCollectionDomainCollectionStaleCollectionOrphanedHistory"
 
-var _CollectionIndex = [...]uint8{0, 22, 39, 55, 70}
+var _CollectionIndex = [...]uint8{0, 22, 39, 55, 70, 95}
 
-const _CollectionLowerName = "collectionmutablestatecollectionhistorycollectiondomaincollectionstale"
+const _CollectionLowerName = "collectionmutablestatecollectionhistorycollectiondomaincollectionstalecollectionorphanedhistory"
 
 func (i Collection) String() string {
 	if i < 0 || i >= Collection(len(_CollectionIndex)-1) {
@@ -50,9 +50,10 @@ func _CollectionNoOp() {
 	_ = x[CollectionHistory-(1)]
 	_ = x[CollectionDomain-(2)]
 	_ = x[CollectionStale-(3)]
+	_ = x[CollectionOrphanedHistory-(4)]
 }
 
-var _CollectionValues = []Collection{CollectionMutableState, CollectionHistory, CollectionDomain, CollectionStale}
+var _CollectionValues = []Collection{CollectionMutableState, CollectionHistory, CollectionDomain, CollectionStale, CollectionOrphanedHistory}
 
 var _CollectionNameToValueMap = map[string]Collection{
 	_CollectionName[0:22]:       CollectionMutableState,
@@ -63,6 +64,8 @@ var _CollectionNameToValueMap = map[string]Collection{
 	_CollectionLowerName[39:55]: CollectionDomain,
 	_CollectionName[55:70]:      CollectionStale,
 	_CollectionLowerName[55:70]: CollectionStale,
+	_CollectionName[70:95]:      CollectionOrphanedHistory,
+	_CollectionLowerName[70:95]: CollectionOrphanedHistory,
 }
 
 var _CollectionNames = []string{
@@ -70,6 +73,7 @@ var _CollectionNames = []string{
 	_CollectionName[22:39],
 	_CollectionName[39:55],
 	_CollectionName[55:70],
+	_CollectionName[70:95],
 }
 
 // CollectionString retrieves an enum value from the enum constants string name.
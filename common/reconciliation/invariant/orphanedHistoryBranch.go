@@ -0,0 +1,295 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package invariant
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/uber/cadence/.gen/go/shared"
+	c "github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/codec"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/reconciliation/entity"
+	"github.com/uber/cadence/common/types"
+)
+
+type (
+	orphanedHistoryBranch struct {
+		pr      persistence.Retryer
+		dc      cache.DomainCache
+		decoder *codec.ThriftRWEncoder
+	}
+)
+
+// NewOrphanedHistoryBranch checks that the history tree of a concrete execution is live
+// and that every branch of that tree is referenced by the execution itself, by the current
+// execution or by another run of the same workflow sharing the tree.
+// Orphaned branches are only reported, fixing them is left to the history scavenger.
+func NewOrphanedHistoryBranch(
+	pr persistence.Retryer, dc cache.DomainCache,
+) Invariant {
+	return &orphanedHistoryBranch{
+		pr:      pr,
+		dc:      dc,
+		decoder: codec.NewThriftRWEncoder(),
+	}
+}
+
+func (o *orphanedHistoryBranch) Check(
+	ctx context.Context,
+	execution interface{},
+) CheckResult {
+	if checkResult := validateCheckContext(ctx, o.Name()); checkResult != nil {
+		return *checkResult
+	}
+
+	concreteExecution, ok := execution.(*entity.ConcreteExecution)
+	if !ok {
+		return o.failed("failed to check: expected concrete execution", "")
+	}
+	domainName, err := o.dc.GetDomainName(concreteExecution.DomainID)
+	if err != nil {
+		return o.failed("failed to check: expected DomainName", err.Error())
+	}
+
+	referenced, stillExists, err := o.referencedBranches(ctx, concreteExecution.DomainID, domainName, concreteExecution.WorkflowID, concreteExecution.RunID)
+	if err != nil {
+		return o.failed("failed to fetch branches referenced by concrete execution", err.Error())
+	}
+	if !stillExists {
+		return CheckResult{
+			CheckResultType: CheckResultTypeHealthy,
+			InvariantName:   o.Name(),
+			Info:            "determined execution was healthy because concrete execution no longer exists",
+		}
+	}
+
+	tree, err := o.pr.GetHistoryTree(ctx, &persistence.GetHistoryTreeRequest{
+		TreeID:     concreteExecution.TreeID,
+		ShardID:    c.IntPtr(concreteExecution.ShardID),
+		DomainName: domainName,
+	})
+	if err != nil {
+		return o.failed("failed to fetch history tree", err.Error())
+	}
+
+	// branches forked from, e.g. the base run of a reset, are still needed by the forked branch
+	referenced = withAncestors(referenced, tree.Branches)
+	var unreferenced []string
+	branchExists := false
+	for _, branch := range tree.Branches {
+		if branch.GetBranchID() == concreteExecution.BranchID {
+			branchExists = true
+		}
+		if _, ok := referenced[branch.GetBranchID()]; !ok {
+			unreferenced = append(unreferenced, branch.GetBranchID())
+		}
+	}
+	if !branchExists {
+		return CheckResult{
+			CheckResultType: CheckResultTypeCorrupted,
+			InvariantName:   o.Name(),
+			Info:            "branch token of concrete execution does not resolve to a live history tree",
+		}
+	}
+	if len(unreferenced) == 0 {
+		return CheckResult{
+			CheckResultType: CheckResultTypeHealthy,
+			InvariantName:   o.Name(),
+		}
+	}
+
+	// other runs sharing the tree, e.g. sibling reset runs, may still own or fork from the remaining branches,
+	// so the branches referenced by all of them are excluded before reporting
+	otherReferenced, checkResult := o.otherRunsReferencedBranches(ctx, concreteExecution, domainName, tree.BranchInfos)
+	if checkResult != nil {
+		return *checkResult
+	}
+	unreferenced = filterBranches(unreferenced, withAncestors(otherReferenced, tree.Branches))
+	if len(unreferenced) == 0 {
+		return CheckResult{
+			CheckResultType: CheckResultTypeHealthy,
+			InvariantName:   o.Name(),
+		}
+	}
+
+	sort.Strings(unreferenced)
+	return CheckResult{
+		CheckResultType: CheckResultTypeCorrupted,
+		InvariantName:   o.Name(),
+		Info:            "history tree contains branches not referenced by any execution of the tree",
+		InfoDetails:     strings.Join(unreferenced, ","),
+	}
+}
+
+func (o *orphanedHistoryBranch) Fix(
+	ctx context.Context,
+	execution interface{},
+) FixResult {
+	if fixResult := validateFixContext(ctx, o.Name()); fixResult != nil {
+		return *fixResult
+	}
+
+	fixResult, checkResult := checkBeforeFix(ctx, o, execution)
+	if fixResult != nil {
+		return *fixResult
+	}
+	return FixResult{
+		FixResultType: FixResultTypeSkipped,
+		InvariantName: o.Name(),
+		CheckResult:   *checkResult,
+		Info:          "skipped fix because orphaned history branches are only reported",
+	}
+}
+
+func (o *orphanedHistoryBranch) Name() Name {
+	return OrphanedHistoryBranch
+}
+
+// referencedBranches returns the IDs of all branches referenced by the version histories of an execution.
+// Returns false if the execution no longer exists.
+func (o *orphanedHistoryBranch) referencedBranches(
+	ctx context.Context,
+	domainID string,
+	domainName string,
+	workflowID string,
+	runID string,
+) (map[string]struct{}, bool, error) {
+	resp, err := o.pr.GetWorkflowExecution(ctx, &persistence.GetWorkflowExecutionRequest{
+		DomainID: domainID,
+		Execution: types.WorkflowExecution{
+			WorkflowID: workflowID,
+			RunID:      runID,
+		},
+		DomainName: domainName,
+	})
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	tokens := [][]byte{resp.State.ExecutionInfo.BranchToken}
+	if resp.State.VersionHistories != nil {
+		for _, history := range resp.State.VersionHistories.Histories {
+			tokens = append(tokens, history.GetBranchToken())
+		}
+	}
+	referenced := make(map[string]struct{}, len(tokens))
+	for _, token := range tokens {
+		if len(token) == 0 {
+			continue
+		}
+		var branch shared.HistoryBranch
+		if err := o.decoder.Decode(token, &branch); err != nil {
+			return nil, false, err
+		}
+		referenced[branch.GetBranchID()] = struct{}{}
+	}
+	return referenced, true, nil
+}
+
+// otherRunsReferencedBranches returns the IDs of all branches referenced by the current execution and by
+// the other runs of the workflow which created a branch of the tree
+func (o *orphanedHistoryBranch) otherRunsReferencedBranches(
+	ctx context.Context,
+	concreteExecution *entity.ConcreteExecution,
+	domainName string,
+	branchInfos map[string]string,
+) (map[string]struct{}, *CheckResult) {
+	runIDs := make(map[string]struct{})
+	current, err := o.pr.GetCurrentExecution(ctx, &persistence.GetCurrentExecutionRequest{
+		DomainID:   concreteExecution.DomainID,
+		WorkflowID: concreteExecution.WorkflowID,
+		DomainName: domainName,
+	})
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); !ok {
+			result := o.failed("failed to fetch current execution", err.Error())
+			return nil, &result
+		}
+	} else {
+		runIDs[current.RunID] = struct{}{}
+	}
+	for _, info := range branchInfos {
+		domainID, workflowID, runID, err := persistence.SplitHistoryGarbageCleanupInfo(info)
+		if err != nil || domainID != concreteExecution.DomainID || workflowID != concreteExecution.WorkflowID {
+			continue
+		}
+		runIDs[runID] = struct{}{}
+	}
+	delete(runIDs, concreteExecution.RunID)
+
+	referenced := make(map[string]struct{})
+	for runID := range runIDs {
+		runReferenced, _, err := o.referencedBranches(ctx, concreteExecution.DomainID, domainName, concreteExecution.WorkflowID, runID)
+		if err != nil {
+			result := o.failed("failed to fetch branches referenced by other runs", err.Error())
+			return nil, &result
+		}
+		for branchID := range runReferenced {
+			referenced[branchID] = struct{}{}
+		}
+	}
+	return referenced, nil
+}
+
+func (o *orphanedHistoryBranch) failed(info string, details string) CheckResult {
+	return CheckResult{
+		CheckResultType: CheckResultTypeFailed,
+		InvariantName:   o.Name(),
+		Info:            info,
+		InfoDetails:     details,
+	}
+}
+
+func filterBranches(branchIDs []string, exclude map[string]struct{}) []string {
+	var result []string
+	for _, branchID := range branchIDs {
+		if _, ok := exclude[branchID]; !ok {
+			result = append(result, branchID)
+		}
+	}
+	return result
+}
+
+// withAncestors adds the ancestor branches of the referenced branches in the tree to the referenced set
+func withAncestors(referenced map[string]struct{}, branches []*shared.HistoryBranch) map[string]struct{} {
+	result := make(map[string]struct{}, len(referenced))
+	for branchID := range referenced {
+		result[branchID] = struct{}{}
+	}
+	for _, branch := range branches {
+		if _, ok := referenced[branch.GetBranchID()]; !ok {
+			continue
+		}
+		for _, ancestor := range branch.Ancestors {
+			result[ancestor.GetBranchID()] = struct{}{}
+		}
+	}
+	return result
+}
@@ -0,0 +1,316 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package invariant
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/uber/cadence/.gen/go/shared"
+	c2 "github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+type OrphanedHistoryBranchSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestOrphanedHistoryBranchSuite(t *testing.T) {
+	suite.Run(t, new(OrphanedHistoryBranchSuite))
+}
+
+func (s *OrphanedHistoryBranchSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *OrphanedHistoryBranchSuite) TestCheck() {
+	const (
+		danglingBranchID = "dangling-branch-id"
+		currentBranchID  = "current-branch-id"
+		baseBranchID     = "base-branch-id"
+		siblingBranchID  = "sibling-branch-id"
+		siblingRunID     = "test-sibling-run-id"
+		deletedRunID     = "test-deleted-run-id"
+	)
+
+	testCases := []struct {
+		name           string
+		getExecErr     error
+		currentRunID   string
+		getCurrentErr  error
+		getTreeResp    *persistence.GetHistoryTreeResponse
+		getTreeErr     error
+		expectedResult CheckResult
+	}{
+		{
+			name:       "execution no longer exists",
+			getExecErr: &types.EntityNotExistsError{},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   OrphanedHistoryBranch,
+				Info:            "determined execution was healthy because concrete execution no longer exists",
+			},
+		},
+		{
+			name:       "failed to fetch execution",
+			getExecErr: errors.New("get execution error"),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   OrphanedHistoryBranch,
+				Info:            "failed to fetch branches referenced by concrete execution",
+				InfoDetails:     "get execution error",
+			},
+		},
+		{
+			name:       "failed to fetch history tree",
+			getTreeErr: errors.New("get tree error"),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   OrphanedHistoryBranch,
+				Info:            "failed to fetch history tree",
+				InfoDetails:     "get tree error",
+			},
+		},
+		{
+			name:        "branch missing from tree",
+			getTreeResp: &persistence.GetHistoryTreeResponse{},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   OrphanedHistoryBranch,
+				Info:            "branch token of concrete execution does not resolve to a live history tree",
+			},
+		},
+		{
+			name: "all branches referenced",
+			getTreeResp: &persistence.GetHistoryTreeResponse{
+				Branches: []*shared.HistoryBranch{
+					historyBranch(branchID, baseBranchID),
+					historyBranch(baseBranchID),
+				},
+			},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   OrphanedHistoryBranch,
+			},
+		},
+		{
+			name:         "dangling branch",
+			currentRunID: runID,
+			getTreeResp: &persistence.GetHistoryTreeResponse{
+				Branches: []*shared.HistoryBranch{
+					historyBranch(branchID),
+					historyBranch(danglingBranchID),
+				},
+			},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   OrphanedHistoryBranch,
+				Info:            "history tree contains branches not referenced by any execution of the tree",
+				InfoDetails:     danglingBranchID,
+			},
+		},
+		{
+			name:         "dangling branch next to branch of current execution",
+			currentRunID: currentRunID,
+			getTreeResp: &persistence.GetHistoryTreeResponse{
+				Branches: []*shared.HistoryBranch{
+					historyBranch(branchID),
+					historyBranch(currentBranchID, branchID),
+					historyBranch(danglingBranchID),
+				},
+			},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   OrphanedHistoryBranch,
+				Info:            "history tree contains branches not referenced by any execution of the tree",
+				InfoDetails:     danglingBranchID,
+			},
+		},
+		{
+			name:         "branch owned by a sibling run sharing the tree",
+			currentRunID: currentRunID,
+			getTreeResp: &persistence.GetHistoryTreeResponse{
+				Branches: []*shared.HistoryBranch{
+					historyBranch(baseBranchID),
+					historyBranch(branchID, baseBranchID),
+					historyBranch(currentBranchID, baseBranchID),
+					historyBranch(siblingBranchID, baseBranchID),
+				},
+				BranchInfos: map[string]string{
+					baseBranchID:    persistence.BuildHistoryGarbageCleanupInfo(domainID, workflowID, deletedRunID),
+					branchID:        persistence.BuildHistoryGarbageCleanupInfo(domainID, workflowID, runID),
+					currentBranchID: persistence.BuildHistoryGarbageCleanupInfo(domainID, workflowID, currentRunID),
+					siblingBranchID: persistence.BuildHistoryGarbageCleanupInfo(domainID, workflowID, siblingRunID),
+				},
+			},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   OrphanedHistoryBranch,
+			},
+		},
+		{
+			name:         "branch owned by a deleted run",
+			currentRunID: currentRunID,
+			getTreeResp: &persistence.GetHistoryTreeResponse{
+				Branches: []*shared.HistoryBranch{
+					historyBranch(branchID),
+					historyBranch(danglingBranchID),
+				},
+				BranchInfos: map[string]string{
+					branchID:         persistence.BuildHistoryGarbageCleanupInfo(domainID, workflowID, runID),
+					danglingBranchID: persistence.BuildHistoryGarbageCleanupInfo(domainID, workflowID, deletedRunID),
+				},
+			},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   OrphanedHistoryBranch,
+				Info:            "history tree contains branches not referenced by any execution of the tree",
+				InfoDetails:     danglingBranchID,
+			},
+		},
+		{
+			name:          "current execution does not exist",
+			getCurrentErr: &types.EntityNotExistsError{},
+			getTreeResp: &persistence.GetHistoryTreeResponse{
+				Branches: []*shared.HistoryBranch{
+					historyBranch(branchID),
+					historyBranch(danglingBranchID),
+				},
+			},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   OrphanedHistoryBranch,
+				Info:            "history tree contains branches not referenced by any execution of the tree",
+				InfoDetails:     danglingBranchID,
+			},
+		},
+		{
+			name:          "failed to fetch current execution",
+			getCurrentErr: errors.New("get current error"),
+			getTreeResp: &persistence.GetHistoryTreeResponse{
+				Branches: []*shared.HistoryBranch{
+					historyBranch(branchID),
+					historyBranch(danglingBranchID),
+				},
+			},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   OrphanedHistoryBranch,
+				Info:            "failed to fetch current execution",
+				InfoDetails:     "get current error",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			ctrl := gomock.NewController(s.T())
+			domainCache := cache.NewMockDomainCache(ctrl)
+			domainCache.EXPECT().GetDomainName(gomock.Any()).Return("test-domain-name", nil).AnyTimes()
+			execManager := &mocks.ExecutionManager{}
+			historyManager := &mocks.HistoryV2Manager{}
+
+			if tc.getExecErr != nil {
+				execManager.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(nil, tc.getExecErr)
+			} else {
+				execManager.On("GetWorkflowExecution", mock.Anything, mock.MatchedBy(func(req *persistence.GetWorkflowExecutionRequest) bool {
+					return req.Execution.RunID == runID
+				})).Return(s.executionWithBranch(branchID), nil)
+				execManager.On("GetWorkflowExecution", mock.Anything, mock.MatchedBy(func(req *persistence.GetWorkflowExecutionRequest) bool {
+					return req.Execution.RunID == currentRunID
+				})).Return(s.executionWithBranch(currentBranchID), nil)
+				execManager.On("GetWorkflowExecution", mock.Anything, mock.MatchedBy(func(req *persistence.GetWorkflowExecutionRequest) bool {
+					return req.Execution.RunID == siblingRunID
+				})).Return(s.executionWithBranch(siblingBranchID), nil)
+				execManager.On("GetWorkflowExecution", mock.Anything, mock.MatchedBy(func(req *persistence.GetWorkflowExecutionRequest) bool {
+					return req.Execution.RunID == deletedRunID
+				})).Return(nil, &types.EntityNotExistsError{})
+			}
+			execManager.On("GetCurrentExecution", mock.Anything, mock.Anything).Return(&persistence.GetCurrentExecutionResponse{
+				RunID: tc.currentRunID,
+			}, tc.getCurrentErr)
+			historyManager.On("GetHistoryTree", mock.Anything, mock.Anything).Return(tc.getTreeResp, tc.getTreeErr)
+
+			i := NewOrphanedHistoryBranch(persistence.NewPersistenceRetryer(execManager, historyManager, c2.CreatePersistenceRetryPolicy()), domainCache)
+			result := i.Check(context.Background(), getOpenConcreteExecution())
+			s.Equal(tc.expectedResult, result)
+		})
+	}
+}
+
+func (s *OrphanedHistoryBranchSuite) TestFix_SkipsCorruptedExecution() {
+	ctrl := gomock.NewController(s.T())
+	domainCache := cache.NewMockDomainCache(ctrl)
+	domainCache.EXPECT().GetDomainName(gomock.Any()).Return("test-domain-name", nil).AnyTimes()
+	execManager := &mocks.ExecutionManager{}
+	historyManager := &mocks.HistoryV2Manager{}
+	execManager.On("GetWorkflowExecution", mock.Anything, mock.Anything).Return(s.executionWithBranch(branchID), nil)
+	execManager.On("GetCurrentExecution", mock.Anything, mock.Anything).Return(&persistence.GetCurrentExecutionResponse{RunID: runID}, nil)
+	historyManager.On("GetHistoryTree", mock.Anything, mock.Anything).Return(&persistence.GetHistoryTreeResponse{
+		Branches: []*shared.HistoryBranch{
+			historyBranch(branchID),
+			historyBranch("dangling-branch-id"),
+		},
+	}, nil)
+
+	i := NewOrphanedHistoryBranch(persistence.NewPersistenceRetryer(execManager, historyManager, c2.CreatePersistenceRetryPolicy()), domainCache)
+	result := i.Fix(context.Background(), getOpenConcreteExecution())
+	s.Equal(FixResultTypeSkipped, result.FixResultType)
+	s.Equal(CheckResultTypeCorrupted, result.CheckResult.CheckResultType)
+	historyManager.AssertNotCalled(s.T(), "DeleteHistoryBranch", mock.Anything, mock.Anything)
+}
+
+func (s *OrphanedHistoryBranchSuite) executionWithBranch(branch string) *persistence.GetWorkflowExecutionResponse {
+	token, err := persistence.NewHistoryBranchTokenByBranchID(treeID, branch)
+	s.NoError(err)
+	return &persistence.GetWorkflowExecutionResponse{
+		State: &persistence.WorkflowMutableState{
+			ExecutionInfo: &persistence.WorkflowExecutionInfo{
+				BranchToken: token,
+			},
+		},
+	}
+}
+
+func historyBranch(branch string, ancestors ...string) *shared.HistoryBranch {
+	result := &shared.HistoryBranch{
+		TreeID:   c2.StringPtr(treeID),
+		BranchID: c2.StringPtr(branch),
+	}
+	for _, ancestor := range ancestors {
+		result.Ancestors = append(result.Ancestors, &shared.HistoryBranchRange{
+			BranchID: c2.StringPtr(ancestor),
+		})
+	}
+	return result
+}
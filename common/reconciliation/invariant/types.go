@@ -57,6 +57,9 @@ const (
 	// implying a failed cleanup / lost timers / etc of some kind.
 	StaleWorkflow Name = "stale_workflow"
 
+	// OrphanedHistoryBranch asserts that every branch in the history tree of an execution is referenced by an execution
+	OrphanedHistoryBranch Name = "orphaned_history_branch"
+
 	// CollectionMutableState is the collection of invariants relating to mutable state
 	CollectionMutableState Collection = 0
 	// CollectionHistory is the collection  of invariants relating to history
@@ -65,6 +68,8 @@ const (
 	CollectionDomain Collection = 2
 	// CollectionStale contains the stale workflow scanner
 	CollectionStale Collection = 3
+	// CollectionOrphanedHistory contains the orphaned history branch invariant
+	CollectionOrphanedHistory Collection = 4
 )
 
 type (
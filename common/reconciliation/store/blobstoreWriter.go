@@ -26,6 +26,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -40,11 +41,16 @@ const (
 	maxRetryDelay     = 30 * time.Second // Maximum delay between retries
 )
 
+// ErrWriteBudgetExceeded is returned by blobstore clients which limit the amount of written bytes,
+// writes failed with it are not retried.
+var ErrWriteBudgetExceeded = errors.New("blobstore write budget is exceeded")
+
 type (
 	blobstoreWriter struct {
-		writer    pagination.Writer
-		uuid      string
-		extension Extension
+		writer      pagination.Writer
+		uuid        string
+		extension   Extension
+		flushedKeys *Keys
 	}
 )
 
@@ -54,6 +60,27 @@ func NewBlobstoreWriter(
 	extension Extension,
 	client blobstore.Client,
 	flushThreshold int,
+) ExecutionWriter {
+	return newBlobstoreWriter(uuid, extension, client, flushThreshold, nil)
+}
+
+// NewBlobstoreWriterFromKeys constructs a blobstore writer which continues writing after the pages of flushedKeys,
+// flushedKeys are expected to be written by a previous writer which did not finish.
+// FlushedKeys of the returned writer cover both the pages of flushedKeys and the pages it flushed itself.
+func NewBlobstoreWriterFromKeys(
+	flushedKeys Keys,
+	client blobstore.Client,
+	flushThreshold int,
+) ExecutionWriter {
+	return newBlobstoreWriter(flushedKeys.UUID, flushedKeys.Extension, client, flushThreshold, &flushedKeys)
+}
+
+func newBlobstoreWriter(
+	uuid string,
+	extension Extension,
+	client blobstore.Client,
+	flushThreshold int,
+	flushedKeys *Keys,
 ) ExecutionWriter {
 	// Set a longer expiration interval than timeout for the entire retry process
 	totalRetryDuration := 2 * Timeout
@@ -68,13 +95,19 @@ func NewBlobstoreWriter(
 	throttlePolicy.SetMaximumInterval(maxRetryDelay)
 	throttlePolicy.SetExpirationInterval(totalRetryDuration)
 
+	startingPage := 0
+	if flushedKeys != nil {
+		startingPage = flushedKeys.MaxPage + 1
+	}
+
 	return &blobstoreWriter{
 		writer: pagination.NewWriter(
 			getBlobstoreWriteFn(uuid, extension, client, retryPolicy, throttlePolicy),
 			getBlobstoreShouldFlushFn(flushThreshold),
-			0),
-		uuid:      uuid,
-		extension: extension,
+			startingPage),
+		uuid:        uuid,
+		extension:   extension,
+		flushedKeys: flushedKeys,
 	}
 }
 
@@ -93,11 +126,19 @@ func (bw *blobstoreWriter) Flush() error {
 // Returns nil if no keys have been flushed.
 func (bw *blobstoreWriter) FlushedKeys() *Keys {
 	if len(bw.writer.FlushedPages()) == 0 {
-		return nil
+		if bw.flushedKeys == nil {
+			return nil
+		}
+		keys := *bw.flushedKeys
+		return &keys
+	}
+	minPage := bw.writer.FirstFlushedPage().(int)
+	if bw.flushedKeys != nil {
+		minPage = bw.flushedKeys.MinPage
 	}
 	return &Keys{
 		UUID:      bw.uuid,
-		MinPage:   bw.writer.FirstFlushedPage().(int),
+		MinPage:   minPage,
 		MaxPage:   bw.writer.LastFlushedPage().(int),
 		Extension: bw.extension,
 	}
@@ -141,7 +182,8 @@ func getBlobstoreWriteFn(
 			backoff.WithRetryPolicy(retryPolicy),
 			backoff.WithThrottlePolicy(throttlePolicy),
 			backoff.WithRetryableError(func(err error) bool {
-				return true // assuming all errors are retryable
+				// assuming all errors are retryable, except for exceeded budget which will not change on retry
+				return !errors.Is(err, ErrWriteBudgetExceeded)
 			}),
 		)
 
@@ -27,6 +27,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/uber/cadence/common/blobstore"
@@ -96,3 +97,35 @@ func TestBlobstoreWriter(t *testing.T) {
 		})
 	}
 }
+
+func TestBlobstoreWriter_WriteBudgetExceededIsNotRetried(t *testing.T) {
+	blobstoreClient := &blobstore.MockClient{}
+	blobstoreClient.On("Put", mock.Anything, mock.Anything).Return(nil, ErrWriteBudgetExceeded).Once()
+
+	blobstoreWriter := NewBlobstoreWriter("test-uuid", Extension("test"), blobstoreClient, 10)
+	require.NoError(t, blobstoreWriter.Add("one"))
+	err := blobstoreWriter.Flush()
+	assert.ErrorIs(t, err, ErrWriteBudgetExceeded)
+	assert.Nil(t, blobstoreWriter.FlushedKeys())
+	blobstoreClient.AssertExpectations(t)
+}
+
+func TestBlobstoreWriterFromKeys(t *testing.T) {
+	blobstoreClient, err := filestore.NewFilestoreClient(&config.FileBlobstore{OutputDirectory: t.TempDir()})
+	require.NoError(t, err)
+
+	writer := NewBlobstoreWriter("test-uuid", Extension("test"), blobstoreClient, 10)
+	require.NoError(t, writer.Add("one"))
+	require.NoError(t, writer.Flush())
+	require.Equal(t, &Keys{UUID: "test-uuid", MinPage: 0, MaxPage: 0, Extension: Extension("test")}, writer.FlushedKeys())
+
+	resumedWriter := NewBlobstoreWriterFromKeys(*writer.FlushedKeys(), blobstoreClient, 10)
+	assert.Equal(t, writer.FlushedKeys(), resumedWriter.FlushedKeys())
+	require.NoError(t, resumedWriter.Add("two"))
+	require.NoError(t, resumedWriter.Flush())
+	assert.Equal(t, &Keys{UUID: "test-uuid", MinPage: 0, MaxPage: 1, Extension: Extension("test")}, resumedWriter.FlushedKeys())
+
+	resp, err := blobstoreClient.Get(context.Background(), &blobstore.GetRequest{Key: pageNumberToKey("test-uuid", Extension("test"), 1)})
+	require.NoError(t, err)
+	assert.Equal(t, "\"two\"\r\n", string(resp.Blob.Body))
+}
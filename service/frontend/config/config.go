@@ -100,6 +100,10 @@ type Config struct {
 	DomainFailoverRefreshInterval               dynamicconfig.DurationPropertyFn
 	DomainFailoverRefreshTimerJitterCoefficient dynamicconfig.FloatPropertyFn
 
+	// Cluster redirection circuit breaker
+	RedirectionCircuitBreakerFailureThreshold dynamicconfig.IntPropertyFn
+	RedirectionCircuitBreakerOpenDuration     dynamicconfig.DurationPropertyFn
+
 	// ValidSearchAttributes is legal indexed keys that can be used in list APIs
 	ValidSearchAttributes             dynamicconfig.MapPropertyFn
 	SearchAttributesNumberOfKeysLimit dynamicconfig.IntPropertyFnWithDomainFilter
@@ -175,6 +179,8 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, isAdvancedVis
 		EnableGracefulFailover:                      dc.GetBoolProperty(dynamicconfig.EnableGracefulFailover),
 		DomainFailoverRefreshInterval:               dc.GetDurationProperty(dynamicconfig.DomainFailoverRefreshInterval),
 		DomainFailoverRefreshTimerJitterCoefficient: dc.GetFloat64Property(dynamicconfig.DomainFailoverRefreshTimerJitterCoefficient),
+		RedirectionCircuitBreakerFailureThreshold:   dc.GetIntProperty(dynamicconfig.FrontendRedirectionCircuitBreakerFailureThreshold),
+		RedirectionCircuitBreakerOpenDuration:       dc.GetDurationProperty(dynamicconfig.FrontendRedirectionCircuitBreakerOpenDuration),
 		EnableClientVersionCheck:                    dc.GetBoolProperty(dynamicconfig.EnableClientVersionCheck),
 		EnableQueryAttributeValidation:              dc.GetBoolProperty(dynamicconfig.EnableQueryAttributeValidation),
 		ValidSearchAttributes:                       dc.GetMapProperty(dynamicconfig.ValidSearchAttributes),
@@ -91,6 +91,8 @@ func TestNewConfig(t *testing.T) {
 		"EnableGracefulFailover":                      {dynamicconfig.EnableGracefulFailover, false},
 		"DomainFailoverRefreshInterval":               {dynamicconfig.DomainFailoverRefreshInterval, time.Duration(33)},
 		"DomainFailoverRefreshTimerJitterCoefficient": {dynamicconfig.DomainFailoverRefreshTimerJitterCoefficient, 34.0},
+		"RedirectionCircuitBreakerFailureThreshold":   {dynamicconfig.FrontendRedirectionCircuitBreakerFailureThreshold, 45},
+		"RedirectionCircuitBreakerOpenDuration":       {dynamicconfig.FrontendRedirectionCircuitBreakerOpenDuration, time.Duration(46)},
 		"EnableClientVersionCheck":                    {dynamicconfig.EnableClientVersionCheck, true},
 		"EnableQueryAttributeValidation":              {dynamicconfig.EnableQueryAttributeValidation, false},
 		"ValidSearchAttributes":                       {dynamicconfig.ValidSearchAttributes, map[string]interface{}{"foo": "bar"}},
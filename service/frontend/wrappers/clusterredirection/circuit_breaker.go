@@ -0,0 +1,137 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package clusterredirection
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/yarpc/yarpcerrors"
+
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/dynamicconfig"
+	"github.com/uber/cadence/common/types"
+)
+
+type (
+	// clusterCircuitBreaker short-circuits calls forwarded to a cluster after it failed
+	// failureThreshold consecutive times. Once openDuration has passed, a single probe call
+	// is let through (half-open), and its outcome either closes or re-opens the breaker.
+	clusterCircuitBreaker struct {
+		timeSource       clock.TimeSource
+		failureThreshold dynamicconfig.IntPropertyFn
+		openDuration     dynamicconfig.DurationPropertyFn
+
+		mu     sync.Mutex
+		states map[string]*circuitState
+	}
+
+	circuitState struct {
+		consecutiveFailures int
+		open                bool
+		openedAt            time.Time
+		probing             bool
+	}
+)
+
+func newClusterCircuitBreaker(
+	timeSource clock.TimeSource,
+	failureThreshold dynamicconfig.IntPropertyFn,
+	openDuration dynamicconfig.DurationPropertyFn,
+) *clusterCircuitBreaker {
+	return &clusterCircuitBreaker{
+		timeSource:       timeSource,
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+		states:           make(map[string]*circuitState),
+	}
+}
+
+// allow returns a retryable error if calls to the cluster are currently short-circuited
+func (b *clusterCircuitBreaker) allow(clusterName string) error {
+	if b.failureThreshold() <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.states[clusterName]
+	if !ok || !state.open {
+		return nil
+	}
+	if !state.probing && b.timeSource.Now().Sub(state.openedAt) >= b.openDuration() {
+		state.probing = true
+		return nil
+	}
+	return &types.ServiceBusyError{
+		Message: fmt.Sprintf("forwarding to cluster %v is short-circuited after repeated failures", clusterName),
+	}
+}
+
+// record updates the state of the cluster's breaker with the outcome of a forwarded call.
+// A call whose context is done by the time it returns says nothing about the cluster, so the state
+// is left unchanged, except that another probe is let through if the call was one.
+func (b *clusterCircuitBreaker) record(ctx context.Context, clusterName string, err error) {
+	threshold := b.failureThreshold()
+	if threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ctx.Err() != nil {
+		if state, ok := b.states[clusterName]; ok {
+			state.probing = false
+		}
+		return
+	}
+	if !isTransportFailure(err) {
+		delete(b.states, clusterName)
+		return
+	}
+
+	state, ok := b.states[clusterName]
+	if !ok {
+		state = &circuitState{}
+		b.states[clusterName] = state
+	}
+	state.consecutiveFailures++
+	if state.probing || state.consecutiveFailures >= threshold {
+		state.open = true
+		state.openedAt = b.timeSource.Now()
+		state.probing = false
+	}
+}
+
+// isTransportFailure returns true if the forwarded call got no response from the target cluster,
+// as opposed to an error returned by the cluster for the request itself
+func isTransportFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	return err == context.DeadlineExceeded || yarpcerrors.IsDeadlineExceeded(err) || yarpcerrors.IsUnavailable(err)
+}
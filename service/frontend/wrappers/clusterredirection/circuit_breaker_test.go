@@ -0,0 +1,181 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2017-2020 Uber Technologies Inc.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package clusterredirection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/yarpc/yarpcerrors"
+
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/dynamicconfig"
+	"github.com/uber/cadence/common/types"
+)
+
+const testOpenDuration = time.Minute
+
+func TestClusterCircuitBreaker(t *testing.T) {
+	unavailable := yarpcerrors.UnavailableErrorf("cluster is down")
+
+	tests := []struct {
+		name      string
+		threshold int
+		// outcomes of the calls that are let through, in order
+		calls []error
+		// time to advance after the calls
+		advance   time.Duration
+		wantAllow bool
+	}{
+		{
+			name:      "disabled breaker never opens",
+			threshold: 0,
+			calls:     []error{unavailable, unavailable, unavailable},
+			wantAllow: true,
+		},
+		{
+			name:      "failures below threshold keep the breaker closed",
+			threshold: 3,
+			calls:     []error{unavailable, unavailable},
+			wantAllow: true,
+		},
+		{
+			name:      "success resets consecutive failures",
+			threshold: 2,
+			calls:     []error{unavailable, nil, unavailable},
+			wantAllow: true,
+		},
+		{
+			name:      "errors from a healthy cluster are not failures",
+			threshold: 1,
+			calls:     []error{&types.EntityNotExistsError{}, &types.BadRequestError{}},
+			wantAllow: true,
+		},
+		{
+			name:      "errors returned by the cluster for its own timeouts are not failures",
+			threshold: 1,
+			calls:     []error{&types.InternalServiceError{Message: context.DeadlineExceeded.Error()}},
+			wantAllow: true,
+		},
+		{
+			name:      "consecutive failures open the breaker",
+			threshold: 2,
+			calls:     []error{unavailable, context.DeadlineExceeded},
+			wantAllow: false,
+		},
+		{
+			name:      "open breaker lets a probe through after the open duration",
+			threshold: 2,
+			calls:     []error{unavailable, unavailable},
+			advance:   testOpenDuration,
+			wantAllow: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			timeSource := clock.NewMockedTimeSource()
+			breaker := newClusterCircuitBreaker(
+				timeSource,
+				dynamicconfig.GetIntPropertyFn(tc.threshold),
+				dynamicconfig.GetDurationPropertyFn(testOpenDuration),
+			)
+
+			for _, err := range tc.calls {
+				assert.NoError(t, breaker.allow("cluster"))
+				breaker.record(context.Background(), "cluster", err)
+			}
+			timeSource.Advance(tc.advance)
+
+			err := breaker.allow("cluster")
+			if tc.wantAllow {
+				assert.NoError(t, err)
+			} else {
+				assert.IsType(t, &types.ServiceBusyError{}, err)
+			}
+			// other clusters are never affected
+			assert.NoError(t, breaker.allow("other-cluster"))
+		})
+	}
+}
+
+func TestClusterCircuitBreaker_Probe(t *testing.T) {
+	unavailable := yarpcerrors.UnavailableErrorf("cluster is down")
+	timeSource := clock.NewMockedTimeSource()
+	breaker := newClusterCircuitBreaker(
+		timeSource,
+		dynamicconfig.GetIntPropertyFn(1),
+		dynamicconfig.GetDurationPropertyFn(testOpenDuration),
+	)
+
+	breaker.record(context.Background(), "cluster", unavailable)
+	assert.Error(t, breaker.allow("cluster"))
+
+	// only a single probe is let through while half-open
+	timeSource.Advance(testOpenDuration)
+	assert.NoError(t, breaker.allow("cluster"))
+	assert.Error(t, breaker.allow("cluster"))
+
+	// a failed probe re-opens the breaker for another open duration
+	breaker.record(context.Background(), "cluster", unavailable)
+	assert.Error(t, breaker.allow("cluster"))
+	timeSource.Advance(testOpenDuration / 2)
+	assert.Error(t, breaker.allow("cluster"))
+
+	// a successful probe closes it
+	timeSource.Advance(testOpenDuration / 2)
+	assert.NoError(t, breaker.allow("cluster"))
+	breaker.record(context.Background(), "cluster", nil)
+	assert.NoError(t, breaker.allow("cluster"))
+	assert.NoError(t, breaker.allow("cluster"))
+}
+
+func TestClusterCircuitBreaker_CallerContextDone(t *testing.T) {
+	unavailable := yarpcerrors.UnavailableErrorf("cluster is down")
+	timeSource := clock.NewMockedTimeSource()
+	breaker := newClusterCircuitBreaker(
+		timeSource,
+		dynamicconfig.GetIntPropertyFn(2),
+		dynamicconfig.GetDurationPropertyFn(testOpenDuration),
+	)
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the caller giving up neither counts as a failure nor resets the consecutive failures
+	breaker.record(context.Background(), "cluster", unavailable)
+	breaker.record(cancelledCtx, "cluster", context.Canceled)
+	breaker.record(cancelledCtx, "cluster", context.DeadlineExceeded)
+	assert.NoError(t, breaker.allow("cluster"))
+	breaker.record(context.Background(), "cluster", unavailable)
+	assert.Error(t, breaker.allow("cluster"))
+
+	// a probe given up by its caller lets another probe through, without closing the breaker
+	timeSource.Advance(testOpenDuration)
+	assert.NoError(t, breaker.allow("cluster"))
+	assert.Error(t, breaker.allow("cluster"))
+	breaker.record(cancelledCtx, "cluster", context.Canceled)
+	assert.NoError(t, breaker.allow("cluster"))
+	assert.Error(t, breaker.allow("cluster"))
+}
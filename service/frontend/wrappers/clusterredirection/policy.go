@@ -25,6 +25,7 @@ import (
 	"fmt"
 
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/types"
@@ -95,6 +96,7 @@ type (
 		allDomainAPIs      bool
 		selectedAPIs       map[string]struct{}
 		targetCluster      string
+		circuitBreaker     *clusterCircuitBreaker
 	}
 )
 
@@ -185,6 +187,11 @@ func newSelectedOrAllAPIsForwardingPolicy(currentClusterName string, config *fro
 		allDomainAPIs:      allDoaminAPIs,
 		selectedAPIs:       selectedAPIs,
 		targetCluster:      targetCluster,
+		circuitBreaker: newClusterCircuitBreaker(
+			clock.NewRealTimeSource(),
+			config.RedirectionCircuitBreakerFailureThreshold,
+			config.RedirectionCircuitBreakerOpenDuration,
+		),
 	}
 }
 
@@ -225,13 +232,26 @@ func (policy *selectedOrAllAPIsForwardingRedirectionPolicy) WithDomainNameRedire
 func (policy *selectedOrAllAPIsForwardingRedirectionPolicy) withRedirect(ctx context.Context, domainEntry *cache.DomainCacheEntry, apiName string, call func(string) error) error {
 	targetDC, enableDomainNotActiveForwarding := policy.getTargetClusterAndIsDomainNotActiveAutoForwarding(ctx, domainEntry, apiName)
 
-	err := call(targetDC)
+	err := policy.callCluster(ctx, targetDC, call)
 
 	targetDC, ok := policy.isDomainNotActiveError(err)
 	if !ok || !enableDomainNotActiveForwarding {
 		return err
 	}
-	return call(targetDC)
+	return policy.callCluster(ctx, targetDC, call)
+}
+
+// callCluster makes the call against the target cluster, guarded by the circuit breaker if the call is forwarded
+func (policy *selectedOrAllAPIsForwardingRedirectionPolicy) callCluster(ctx context.Context, targetDC string, call func(string) error) error {
+	if targetDC == policy.currentClusterName {
+		return call(targetDC)
+	}
+	if err := policy.circuitBreaker.allow(targetDC); err != nil {
+		return err
+	}
+	err := call(targetDC)
+	policy.circuitBreaker.record(ctx, targetDC, err)
+	return err
 }
 
 func (policy *selectedOrAllAPIsForwardingRedirectionPolicy) isDomainNotActiveError(err error) (string, bool) {
@@ -24,12 +24,15 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/yarpc/yarpcerrors"
 
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/log/testlogger"
@@ -409,6 +412,45 @@ func (s *selectedAPIsForwardingRedirectionPolicySuite) TestGetTargetDataCenter_G
 	s.Equal(0, alternativeClustercallCount)
 }
 
+func (s *selectedAPIsForwardingRedirectionPolicySuite) TestWithDomainRedirect_GlobalDomain_Forwarding_CircuitBreaker() {
+	s.setupGlobalDomainWithTwoReplicationCluster(true, false)
+	timeSource := clock.NewMockedTimeSource()
+	s.policy.circuitBreaker = newClusterCircuitBreaker(
+		timeSource,
+		dynamicconfig.GetIntPropertyFn(2),
+		dynamicconfig.GetDurationPropertyFn(time.Minute),
+	)
+
+	apiName := "StartWorkflowExecution"
+	callCount := 0
+	var callErr error
+	callFn := func(targetCluster string) error {
+		callCount++
+		s.Equal(s.alternativeClusterName, targetCluster)
+		return callErr
+	}
+
+	// consecutive failures open the breaker
+	callErr = yarpcerrors.UnavailableErrorf("cluster is down")
+	for i := 0; i < 2; i++ {
+		err := s.policy.WithDomainNameRedirect(context.Background(), s.domainName, apiName, callFn)
+		s.True(yarpcerrors.IsUnavailable(err))
+	}
+	s.Equal(2, callCount)
+
+	// forwarded calls are short-circuited while the breaker is open
+	err := s.policy.WithDomainNameRedirect(context.Background(), s.domainName, apiName, callFn)
+	s.IsType(&types.ServiceBusyError{}, err)
+	s.Equal(2, callCount)
+
+	// a successful probe after the open duration closes the breaker
+	timeSource.Advance(time.Minute)
+	callErr = nil
+	s.NoError(s.policy.WithDomainNameRedirect(context.Background(), s.domainName, apiName, callFn))
+	s.NoError(s.policy.WithDomainNameRedirect(context.Background(), s.domainName, apiName, callFn))
+	s.Equal(4, callCount)
+}
+
 func (s *selectedAPIsForwardingRedirectionPolicySuite) setupLocalDomain() {
 	domainEntry := cache.NewLocalDomainCacheEntryForTest(
 		&persistence.DomainInfo{ID: s.domainID, Name: s.domainName},
@@ -267,6 +267,24 @@ func (m *TestTaskManager) GetTasks(
 	}, nil
 }
 
+func (m *TestTaskManager) GetTaskIDRange(_ context.Context, request *persistence.GetTaskIDRangeRequest) (*persistence.GetTaskIDRangeResponse, error) {
+	tlm := m.getTaskListManager(NewTestTaskListID(m.t, request.DomainID, request.TaskListName, request.TaskListType))
+	tlm.Lock()
+	defer tlm.Unlock()
+	resp := &persistence.GetTaskIDRangeResponse{}
+	it := tlm.tasks.Iterator()
+	for it.Next() {
+		taskID := it.Key().(int64)
+		if resp.MinTaskID == 0 || taskID < resp.MinTaskID {
+			resp.MinTaskID = taskID
+		}
+		if taskID > resp.MaxTaskID {
+			resp.MaxTaskID = taskID
+		}
+	}
+	return resp, nil
+}
+
 func (m *TestTaskManager) GetTaskListSize(_ context.Context, request *persistence.GetTaskListSizeRequest) (*persistence.GetTaskListSizeResponse, error) {
 	tlm := m.getTaskListManager(NewTestTaskListID(m.t, request.DomainID, request.TaskListName, request.TaskListType))
 	tlm.Lock()
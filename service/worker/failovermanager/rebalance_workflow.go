@@ -73,11 +73,12 @@ func RebalanceWorkflow(ctx workflow.Context, params *RebalanceParams) (*Rebalanc
 			BatchFailoverSize:              params.BatchFailoverSize,
 			BatchFailoverWaitTimeInSeconds: params.BatchFailoverWaitTimeInSeconds,
 		}
-		successDomains, failedDomains := failoverDomainsByBatch(
+		successDomains, failedDomains, _ := failoverDomainsByBatch(
 			ctx,
 			domains,
 			failoverParams,
 			func() {},
+			func() bool { return false },
 			false,
 		)
 		result.SuccessDomains = append(result.SuccessDomains, successDomains...)
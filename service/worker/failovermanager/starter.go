@@ -100,6 +100,9 @@ func (s *FailoverManager) Start() error {
 	failoverWorker.RegisterWorkflowWithOptions(FailoverWorkflow, workflow.RegisterOptions{Name: FailoverWorkflowTypeName})
 	failoverWorker.RegisterWorkflowWithOptions(RebalanceWorkflow, workflow.RegisterOptions{Name: RebalanceWorkflowTypeName})
 	failoverWorker.RegisterActivityWithOptions(FailoverActivity, activity.RegisterOptions{Name: failoverActivityName})
+	failoverWorker.RegisterActivityWithOptions(VerifyFailoverActivity, activity.RegisterOptions{Name: verifyFailoverActivityName})
+	failoverWorker.RegisterActivityWithOptions(GetReplicationBacklogActivity, activity.RegisterOptions{Name: getReplicationBacklogActivityName})
+	failoverWorker.RegisterActivityWithOptions(RecordFailoverAuditActivity, activity.RegisterOptions{Name: recordFailoverAuditActivityName})
 	failoverWorker.RegisterActivityWithOptions(GetDomainsActivity, activity.RegisterOptions{Name: getDomainsActivityName})
 	failoverWorker.RegisterActivityWithOptions(GetDomainsForRebalanceActivity, activity.RegisterOptions{Name: getRebalanceDomainsActivityName})
 	s.worker = failoverWorker
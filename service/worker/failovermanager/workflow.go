@@ -35,6 +35,7 @@ import (
 
 	"github.com/uber/cadence/client/frontend"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/types"
 )
 
@@ -51,20 +52,25 @@ const (
 	// RebalanceWorkflowTypeName is rebalance workflow type name
 	RebalanceWorkflowTypeName = "cadence-sys-rebalance-workflow"
 	// WorkflowID will be reused to ensure only one workflow running
-	FailoverWorkflowID              = "cadence-failover-manager"
-	RebalanceWorkflowID             = "cadence-rebalance-workflow"
-	DrillWorkflowID                 = FailoverWorkflowID + "-drill"
-	failoverActivityName            = "cadence-sys-failover-activity"
-	getDomainsActivityName          = "cadence-sys-getDomains-activity"
-	getRebalanceDomainsActivityName = "cadence-sys-getRebalanceDomains-activity"
+	FailoverWorkflowID                = "cadence-failover-manager"
+	RebalanceWorkflowID               = "cadence-rebalance-workflow"
+	DrillWorkflowID                   = FailoverWorkflowID + "-drill"
+	failoverActivityName              = "cadence-sys-failover-activity"
+	verifyFailoverActivityName        = "cadence-sys-verifyFailover-activity"
+	getDomainsActivityName            = "cadence-sys-getDomains-activity"
+	getRebalanceDomainsActivityName   = "cadence-sys-getRebalanceDomains-activity"
+	getReplicationBacklogActivityName = "cadence-sys-getReplicationBacklog-activity"
+	recordFailoverAuditActivityName   = "cadence-sys-recordFailoverAudit-activity"
 
 	defaultBatchFailoverSize              = 20
 	defaultBatchFailoverWaitTimeInSeconds = 30
+	replicationDrainPollInterval          = 10 * time.Second
 
 	errMsgParamsIsNil                 = "params is nil"
 	errMsgTargetClusterIsEmpty        = "targetCluster is empty"
 	errMsgSourceClusterIsEmpty        = "sourceCluster is empty"
 	errMsgTargetClusterIsSameAsSource = "targetCluster is same as sourceCluster"
+	errMsgDomainsNotManaged           = "domains are not managed by cadence failover"
 
 	// QueryType for failover workflow
 	QueryType = "state"
@@ -72,6 +78,8 @@ const (
 	PauseSignal = "pause"
 	// ResumeSignal signal name for resume
 	ResumeSignal = "resume"
+	// CancelSignal signal name for cancel, the batch in progress is completed but no further batches are failed over
+	CancelSignal = "cancel"
 
 	// workflow states for query
 
@@ -79,6 +87,8 @@ const (
 	WorkflowInitialized = "initialized"
 	// WorkflowRunning state
 	WorkflowRunning = "running"
+	// WorkflowDrainingReplication state
+	WorkflowDrainingReplication = "draining_replication"
 	// WorkflowPaused state
 	WorkflowPaused = "paused"
 	// WorkflowCompleted state
@@ -106,21 +116,44 @@ type (
 		DrillWaitTime time.Duration
 		// GracefulFailoverTimeoutInSeconds
 		GracefulFailoverTimeoutInSeconds *int32
+		// SkipPollerCheck skips validating that the target cluster has pollers before failover.
+		// This is useful for cold failover where workers only start after the switch.
+		SkipPollerCheck bool
+		// Reason is recorded together with the operator into the data of each domain failed over by a non-drill failover
+		Reason string
+		// FailOnUnmanagedDomains fails the workflow if any of the explicitly listed Domains
+		// is not managed by Cadence, instead of only skipping it with a warning.
+		FailOnUnmanagedDomains bool
+		// VerifyFailover re-describes successfully failed over domains and reclassifies
+		// the ones whose active cluster is not the target cluster as failed.
+		VerifyFailover bool
+		// VerifyAfterFailover verifies the failover the same way as VerifyFailover, but reports the
+		// domains whose active cluster is not the target cluster in UnverifiedDomains instead of
+		// FailedDomains, as they may still converge once the domain update is replicated.
+		VerifyAfterFailover bool
+		// ReplicationDrainTimeout bounds how long a graceful failover waits for the replication backlog
+		// of the failed over domains in the source cluster to drain before completing. Zero disables the wait.
+		ReplicationDrainTimeout time.Duration
+		// ReplicationDrainThreshold is the replication backlog, in pending shards, at or below which
+		// the replication is considered drained.
+		ReplicationDrainThreshold int
 	}
 
 	// FailoverResult is workflow result
 	FailoverResult struct {
 		SuccessDomains      []string
 		FailedDomains       []string
+		UnverifiedDomains   []string
 		SuccessResetDomains []string
 		FailedResetDomains  []string
 	}
 
 	// GetDomainsActivityParams params for activity
 	GetDomainsActivityParams struct {
-		TargetCluster string
-		SourceCluster string
-		Domains       []string
+		TargetCluster          string
+		SourceCluster          string
+		Domains                []string
+		FailOnUnmanagedDomains bool
 	}
 
 	// FailoverActivityParams params for activity
@@ -128,6 +161,14 @@ type (
 		Domains                          []string
 		TargetCluster                    string
 		GracefulFailoverTimeoutInSeconds *int32
+		SkipPollerCheck                  bool
+	}
+
+	// RecordFailoverAuditActivityParams params for activity
+	RecordFailoverAuditActivityParams struct {
+		Domains  []string
+		Operator string
+		Reason   string
 	}
 
 	// FailoverActivityResult result for failover activity
@@ -136,6 +177,24 @@ type (
 		FailedDomains  []string
 	}
 
+	// VerifyFailoverActivityParams params for verify failover activity
+	VerifyFailoverActivityParams struct {
+		Domains       []string
+		TargetCluster string
+	}
+
+	// GetReplicationBacklogActivityParams params for get replication backlog activity
+	GetReplicationBacklogActivityParams struct {
+		Domains       []string
+		SourceCluster string
+	}
+
+	// VerifyFailoverActivityResult result for verify failover activity
+	VerifyFailoverActivityResult struct {
+		VerifiedDomains   []string
+		UnverifiedDomains []string
+	}
+
 	// QueryResult for failover progress
 	QueryResult struct {
 		TotalDomains        int
@@ -146,8 +205,10 @@ type (
 		SourceCluster       string
 		SuccessDomains      []string // SuccessDomains are guaranteed succeed processed
 		FailedDomains       []string // FailedDomains contains false positive
+		UnverifiedDomains   []string // UnverifiedDomains are failed over domains not observed as active in the target cluster
 		SuccessResetDomains []string // SuccessResetDomains are domains successfully reset in drill mode
 		FailedResetDomains  []string // FailedResetDomains contains false positive in drill mode
+		ReplicationBacklog  int      // ReplicationBacklog is the last observed number of source cluster shards pending graceful failover
 		Operator            string
 	}
 )
@@ -162,9 +223,11 @@ func FailoverWorkflow(ctx workflow.Context, params *FailoverParams) (*FailoverRe
 	// define query properties
 	var failedDomains []string
 	var successDomains []string
+	var unverifiedDomains []string
 	var successResetDomains []string
 	var failedResetDomains []string
 	var totalNumOfDomains int
+	var replicationBacklog int
 	wfState := WorkflowInitialized
 	operator := getOperator(ctx)
 	err = workflow.SetQueryHandler(ctx, QueryType, func(input []byte) (*QueryResult, error) {
@@ -177,8 +240,10 @@ func FailoverWorkflow(ctx workflow.Context, params *FailoverParams) (*FailoverRe
 			SourceCluster:       params.SourceCluster,
 			SuccessDomains:      successDomains,
 			FailedDomains:       failedDomains,
+			UnverifiedDomains:   unverifiedDomains,
 			SuccessResetDomains: successResetDomains,
 			FailedResetDomains:  failedResetDomains,
+			ReplicationBacklog:  replicationBacklog,
 			Operator:            operator,
 		}, nil
 	})
@@ -189,9 +254,10 @@ func FailoverWorkflow(ctx workflow.Context, params *FailoverParams) (*FailoverRe
 	// get target domains
 	ao := workflow.WithActivityOptions(ctx, getGetDomainsActivityOptions())
 	getDomainsParams := &GetDomainsActivityParams{
-		TargetCluster: params.TargetCluster,
-		SourceCluster: params.SourceCluster,
-		Domains:       params.Domains,
+		TargetCluster:          params.TargetCluster,
+		SourceCluster:          params.SourceCluster,
+		Domains:                params.Domains,
+		FailOnUnmanagedDomains: params.FailOnUnmanagedDomains,
 	}
 	var domains []string
 	err = workflow.ExecuteActivity(ao, GetDomainsActivity, getDomainsParams).Get(ctx, &domains)
@@ -202,50 +268,130 @@ func FailoverWorkflow(ctx workflow.Context, params *FailoverParams) (*FailoverRe
 
 	pauseCh := workflow.GetSignalChannel(ctx, PauseSignal)
 	resumeCh := workflow.GetSignalChannel(ctx, ResumeSignal)
+	cancelCh := workflow.GetSignalChannel(ctx, CancelSignal)
 	var shouldPause bool
+	var cancelled bool
 	checkPauseSignal := func() {
 		shouldPause = pauseCh.ReceiveAsync(nil)
 		if shouldPause {
 			wfState = WorkflowPaused
-			resumeCh.Receive(ctx, nil)
+			// a paused failover can be cancelled without resuming it first
+			selector := workflow.NewSelector(ctx)
+			selector.AddReceive(resumeCh, func(c workflow.Channel, more bool) { c.Receive(ctx, nil) })
+			selector.AddReceive(cancelCh, func(c workflow.Channel, more bool) {
+				c.Receive(ctx, nil)
+				cancelled = true
+			})
+			selector.Select(ctx)
 			// clean up all pending pause signal
 			cleanupChannel(pauseCh)
 		}
 		wfState = WorkflowRunning
 	}
+	checkCancelSignal := func() bool {
+		if !cancelled {
+			cancelled = cancelCh.ReceiveAsync(nil)
+		}
+		return cancelled
+	}
 
 	// failover in batch
-	successDomains, failedDomains = failoverDomainsByBatch(ctx, domains, params, checkPauseSignal, false)
+	successDomains, failedDomains, unverifiedDomains = failoverDomainsByBatch(ctx, domains, params, checkPauseSignal, checkCancelSignal, false)
+
+	if cancelled && params.DrillWaitTime == 0 {
+		workflow.GetLogger(ctx).Info("Failover is cancelled, remaining domains are not failed over",
+			zap.Int("successDomains", len(successDomains)), zap.Int("totalDomains", totalNumOfDomains))
+		recordFailoverAudit(ctx, successDomains, operator, params.Reason)
+		wfState = WorkflowAborted
+		return &FailoverResult{
+			SuccessDomains:    successDomains,
+			FailedDomains:     failedDomains,
+			UnverifiedDomains: unverifiedDomains,
+		}, nil
+	}
+
+	if !cancelled && shouldWaitForReplicationDrain(params, successDomains) {
+		wfState = WorkflowDrainingReplication
+		waitForReplicationDrain(ctx, successDomains, params, func(backlog int) { replicationBacklog = backlog })
+		wfState = WorkflowRunning
+	}
 
 	if params.DrillWaitTime == 0 {
 		// This is a normal failover
+		recordFailoverAudit(ctx, successDomains, operator, params.Reason)
 		wfState = WorkflowCompleted
 		return &FailoverResult{
-			SuccessDomains: successDomains,
-			FailedDomains:  failedDomains,
+			SuccessDomains:    successDomains,
+			FailedDomains:     failedDomains,
+			UnverifiedDomains: unverifiedDomains,
 		}, nil
 	}
 
-	workflow.Sleep(ctx, params.DrillWaitTime)
-	// Reset domains to original cluster
-	successResetDomains, failedResetDomains = failoverDomainsByBatch(ctx, domains, params, checkPauseSignal, true)
+	if cancelled {
+		workflow.GetLogger(ctx).Info("Failover drill is cancelled, remaining domains are not failed over",
+			zap.Int("successDomains", len(successDomains)), zap.Int("totalDomains", totalNumOfDomains))
+	} else {
+		// a cancel during the drill wait time only cuts the wait short, the domains still have to be reset
+		waitCtx, cancelWait := workflow.WithCancel(ctx)
+		selector := workflow.NewSelector(ctx)
+		selector.AddFuture(workflow.NewTimer(waitCtx, params.DrillWaitTime), func(f workflow.Future) {})
+		selector.AddReceive(cancelCh, func(c workflow.Channel, more bool) {
+			c.Receive(ctx, nil)
+			cancelled = true
+		})
+		selector.Select(ctx)
+		cancelWait()
+	}
+
+	// Reset domains to original cluster. Domains which were not attempted in the failover phase are still
+	// active in the source cluster, while failed ones are reset as well as they may have been failed over.
+	// The reset cannot be cancelled, otherwise the drill would leave domains active in the target cluster.
+	workflow.GetLogger(ctx).Info("Resetting domains to the source cluster after failover drill",
+		zap.String("sourceCluster", params.SourceCluster), tag.FailoverTypeDrill.Field())
+	resetDomains := getAttemptedDomains(domains, successDomains, failedDomains, unverifiedDomains)
+	var unverifiedResetDomains []string
+	successResetDomains, failedResetDomains, unverifiedResetDomains = failoverDomainsByBatch(ctx, resetDomains, params, checkPauseSignal, func() bool { return false }, true)
+	// there is no separate bucket for resets, so domains not reset as observed from the source cluster are treated as failed
+	failedResetDomains = append(failedResetDomains, unverifiedResetDomains...)
 	wfState = WorkflowCompleted
+	if cancelled {
+		wfState = WorkflowAborted
+	}
 
 	return &FailoverResult{
 		SuccessDomains:      successDomains,
 		FailedDomains:       failedDomains,
+		UnverifiedDomains:   unverifiedDomains,
 		SuccessResetDomains: successResetDomains,
 		FailedResetDomains:  failedResetDomains,
 	}, nil
 }
 
+// getAttemptedDomains returns the domains, in their original order, which are present in any of the results
+func getAttemptedDomains(domains []string, results ...[]string) []string {
+	attempted := make(map[string]struct{})
+	for _, result := range results {
+		for _, domain := range result {
+			attempted[domain] = struct{}{}
+		}
+	}
+	var attemptedDomains []string
+	for _, domain := range domains {
+		if _, ok := attempted[domain]; ok {
+			attemptedDomains = append(attemptedDomains, domain)
+		}
+	}
+	return attemptedDomains
+}
+
 func failoverDomainsByBatch(
 	ctx workflow.Context,
 	domains []string,
 	params *FailoverParams,
 	pauseSignalHandler func(),
+	cancelSignalHandler func() bool,
 	reverseFailover bool,
-) (successDomains []string, failedDomains []string) {
+) (successDomains []string, failedDomains []string, unverifiedDomains []string) {
 
 	totalNumOfDomains := len(domains)
 	batchSize := params.BatchFailoverSize
@@ -257,11 +403,15 @@ func failoverDomainsByBatch(
 	}
 	for i := 0; i < times; i++ {
 		pauseSignalHandler()
+		if cancelSignalHandler() {
+			break
+		}
 
 		failoverActivityParams := &FailoverActivityParams{
 			Domains:                          domains[i*batchSize : common.MinInt((i+1)*batchSize, totalNumOfDomains)],
 			TargetCluster:                    targetCluster,
 			GracefulFailoverTimeoutInSeconds: params.GracefulFailoverTimeoutInSeconds,
+			SkipPollerCheck:                  params.SkipPollerCheck,
 		}
 		var actResult FailoverActivityResult
 		err := workflow.ExecuteActivity(ao, FailoverActivity, failoverActivityParams).Get(ctx, &actResult)
@@ -274,13 +424,102 @@ func failoverDomainsByBatch(
 			failedDomains = append(failedDomains, actResult.FailedDomains...)
 		}
 
-		if i != times-1 {
+		if i != times-1 && !cancelSignalHandler() {
 			workflow.Sleep(ctx, time.Duration(params.BatchFailoverWaitTimeInSeconds)*time.Second)
 		}
 	}
+
+	if params.VerifyFailover || params.VerifyAfterFailover {
+		successDomains, unverifiedDomains = verifyFailoverByBatch(ctx, successDomains, targetCluster, batchSize)
+		if !params.VerifyAfterFailover {
+			failedDomains = append(failedDomains, unverifiedDomains...)
+			unverifiedDomains = nil
+		}
+	}
 	return
 }
 
+func verifyFailoverByBatch(
+	ctx workflow.Context,
+	domains []string,
+	targetCluster string,
+	batchSize int,
+) (verifiedDomains []string, unverifiedDomains []string) {
+
+	ao := workflow.WithActivityOptions(ctx, getFailoverActivityOptions())
+	for start := 0; start < len(domains); start += batchSize {
+		verifyActivityParams := &VerifyFailoverActivityParams{
+			Domains:       domains[start:common.MinInt(start+batchSize, len(domains))],
+			TargetCluster: targetCluster,
+		}
+		var actResult VerifyFailoverActivityResult
+		err := workflow.ExecuteActivity(ao, VerifyFailoverActivity, verifyActivityParams).Get(ctx, &actResult)
+		if err != nil {
+			// Domains in failed verify activity may well be failed over, but we cannot confirm it.
+			unverifiedDomains = append(unverifiedDomains, verifyActivityParams.Domains...)
+		} else {
+			verifiedDomains = append(verifiedDomains, actResult.VerifiedDomains...)
+			unverifiedDomains = append(unverifiedDomains, actResult.UnverifiedDomains...)
+		}
+	}
+	return
+}
+
+// recordFailoverAudit records the failover audit data with a separate domain update after the failover,
+// as data cannot be updated together with the active cluster. Failing to record it does not fail the failover.
+func recordFailoverAudit(ctx workflow.Context, domains []string, operator string, reason string) {
+	if len(domains) == 0 {
+		return
+	}
+	ao := workflow.WithActivityOptions(ctx, getRecordFailoverAuditActivityOptions())
+	auditParams := &RecordFailoverAuditActivityParams{
+		Domains:  domains,
+		Operator: operator,
+		Reason:   reason,
+	}
+	if err := workflow.ExecuteActivity(ao, RecordFailoverAuditActivity, auditParams).Get(ctx, nil); err != nil {
+		workflow.GetLogger(ctx).Warn("Failed to record failover audit data", zap.Error(err))
+	}
+}
+
+func shouldWaitForReplicationDrain(params *FailoverParams, successDomains []string) bool {
+	return params.GracefulFailoverTimeoutInSeconds != nil && params.ReplicationDrainTimeout > 0 && len(successDomains) > 0
+}
+
+// waitForReplicationDrain polls the replication backlog of the domains in the source cluster
+// until it is at or below the threshold or the drain timeout expires.
+func waitForReplicationDrain(
+	ctx workflow.Context,
+	domains []string,
+	params *FailoverParams,
+	backlogHandler func(int),
+) {
+
+	ao := workflow.WithActivityOptions(ctx, getFailoverActivityOptions())
+	backlogParams := &GetReplicationBacklogActivityParams{
+		Domains:       domains,
+		SourceCluster: params.SourceCluster,
+	}
+	deadline := workflow.Now(ctx).Add(params.ReplicationDrainTimeout)
+	for {
+		var backlog int
+		err := workflow.ExecuteActivity(ao, GetReplicationBacklogActivity, backlogParams).Get(ctx, &backlog)
+		if err == nil {
+			backlogHandler(backlog)
+			if backlog <= params.ReplicationDrainThreshold {
+				return
+			}
+		}
+		remaining := deadline.Sub(workflow.Now(ctx))
+		if remaining <= 0 {
+			workflow.GetLogger(ctx).Warn("Timed out waiting for replication to drain after failover",
+				zap.String("sourceCluster", params.SourceCluster), zap.Int("threshold", params.ReplicationDrainThreshold))
+			return
+		}
+		workflow.Sleep(ctx, common.MinDuration(replicationDrainPollInterval, remaining))
+	}
+}
+
 func getOperator(ctx workflow.Context) string {
 	memo := workflow.GetInfo(ctx).Memo
 	if memo == nil || len(memo.Fields) == 0 {
@@ -311,7 +550,8 @@ func getGetDomainsActivityOptions() workflow.ActivityOptions {
 				errMsgParamsIsNil,
 				errMsgTargetClusterIsEmpty,
 				errMsgSourceClusterIsEmpty,
-				errMsgTargetClusterIsSameAsSource},
+				errMsgTargetClusterIsSameAsSource,
+				errMsgDomainsNotManaged},
 		},
 	}
 }
@@ -323,6 +563,20 @@ func getFailoverActivityOptions() workflow.ActivityOptions {
 	}
 }
 
+func getRecordFailoverAuditActivityOptions() workflow.ActivityOptions {
+	return workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Second,
+		StartToCloseTimeout:    20 * time.Second,
+		// the domain update is rejected until the failover cool down since the failover has passed
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    30 * time.Second,
+			ExpirationInterval: 5 * time.Minute,
+		},
+	}
+}
+
 func validateParams(params *FailoverParams) error {
 	if params == nil {
 		return errors.New(errMsgParamsIsNil)
@@ -346,6 +600,9 @@ func GetDomainsActivity(ctx context.Context, params *GetDomainsActivityParams) (
 	if err != nil {
 		return nil, err
 	}
+	if err := validateDomainsManagedByCadence(ctx, domains, params); err != nil {
+		return nil, err
+	}
 	var res []string
 	for _, domain := range domains {
 		if shouldFailover(domain, params.SourceCluster) {
@@ -356,6 +613,29 @@ func GetDomainsActivity(ctx context.Context, params *GetDomainsActivityParams) (
 	return res, nil
 }
 
+// validateDomainsManagedByCadence checks explicitly listed domains for the managed failover flag,
+// as those are silently skipped by failover otherwise.
+func validateDomainsManagedByCadence(ctx context.Context, domains []*types.DescribeDomainResponse, params *GetDomainsActivityParams) error {
+	if len(params.Domains) == 0 {
+		return nil
+	}
+	var unmanagedDomains []string
+	for _, domain := range domains {
+		if !isDomainFailoverManagedByCadence(domain) {
+			unmanagedDomains = append(unmanagedDomains, domain.GetDomainInfo().GetName())
+		}
+	}
+	if len(unmanagedDomains) == 0 {
+		return nil
+	}
+	if params.FailOnUnmanagedDomains {
+		return cadence.NewCustomError(errMsgDomainsNotManaged, unmanagedDomains)
+	}
+	activity.GetLogger(ctx).Warn("Domains are not managed by cadence failover and will be skipped",
+		zap.Strings("domains", unmanagedDomains))
+	return nil
+}
+
 func validateGetDomainsActivityParams(params *GetDomainsActivityParams) error {
 	if params == nil {
 		return errors.New(errMsgParamsIsNil)
@@ -448,14 +728,20 @@ func FailoverActivity(ctx context.Context, params *FailoverActivityParams) (*Fai
 	logger := activity.GetLogger(ctx)
 	frontendClient := getClient(ctx)
 	domains := params.Domains
+	failoverType := tag.FailoverTypeForce
+	if params.GracefulFailoverTimeoutInSeconds != nil {
+		failoverType = tag.FailoverTypeGraceful
+	}
 	var successDomains []string
 	var failedDomains []string
 	for _, domain := range domains {
 		// Check if poller exist
-		if err := validateTaskListPollerInfo(ctx, params.TargetCluster, domain); err != nil {
-			logger.Error("Failed to validate task list poller info", zap.Error(err))
-			failedDomains = append(failedDomains, domain)
-			continue
+		if !params.SkipPollerCheck {
+			if err := validateTaskListPollerInfo(ctx, params.TargetCluster, domain); err != nil {
+				logger.Error("Failed to validate task list poller info", zap.Error(err))
+				failedDomains = append(failedDomains, domain)
+				continue
+			}
 		}
 		updateRequest := &types.UpdateDomainRequest{
 			Name:              domain,
@@ -467,6 +753,7 @@ func FailoverActivity(ctx context.Context, params *FailoverActivityParams) (*Fai
 
 		_, err := frontendClient.UpdateDomain(ctx, updateRequest)
 		if err != nil {
+			logger.Error("Failed to failover domain", zap.String("domain", domain), failoverType.Field(), zap.Error(err))
 			failedDomains = append(failedDomains, domain)
 		} else {
 			successDomains = append(successDomains, domain)
@@ -478,6 +765,83 @@ func FailoverActivity(ctx context.Context, params *FailoverActivityParams) (*Fai
 	}, nil
 }
 
+// VerifyFailoverActivity activity def
+func VerifyFailoverActivity(ctx context.Context, params *VerifyFailoverActivityParams) (*VerifyFailoverActivityResult, error) {
+
+	logger := activity.GetLogger(ctx)
+	// describe against the target cluster to observe the failover where it is expected to take effect
+	frontendClient := getRemoteClient(ctx, params.TargetCluster)
+	var verifiedDomains []string
+	var unverifiedDomains []string
+	for _, domain := range params.Domains {
+		resp, err := frontendClient.DescribeDomain(ctx, &types.DescribeDomainRequest{Name: common.StringPtr(domain)})
+		if err != nil {
+			logger.Error("Failed to describe domain for failover verification", zap.String("domain", domain), zap.Error(err))
+			unverifiedDomains = append(unverifiedDomains, domain)
+			continue
+		}
+		if activeCluster := resp.ReplicationConfiguration.GetActiveClusterName(); activeCluster != params.TargetCluster {
+			logger.Warn("Domain active cluster is not the failover target cluster",
+				zap.String("domain", domain),
+				zap.String("activeCluster", activeCluster),
+				zap.String("targetCluster", params.TargetCluster))
+			unverifiedDomains = append(unverifiedDomains, domain)
+			continue
+		}
+		verifiedDomains = append(verifiedDomains, domain)
+	}
+	return &VerifyFailoverActivityResult{
+		VerifiedDomains:   verifiedDomains,
+		UnverifiedDomains: unverifiedDomains,
+	}, nil
+}
+
+// GetReplicationBacklogActivity activity def, returns the number of shards in the source cluster
+// which have not yet processed the graceful failover of the domains
+func GetReplicationBacklogActivity(ctx context.Context, params *GetReplicationBacklogActivityParams) (int, error) {
+	frontendClient := getRemoteClient(ctx, params.SourceCluster)
+	backlog := 0
+	for _, domain := range params.Domains {
+		resp, err := frontendClient.DescribeDomain(ctx, &types.DescribeDomainRequest{Name: common.StringPtr(domain)})
+		if err != nil {
+			return 0, err
+		}
+		backlog += len(resp.GetFailoverInfo().GetPendingShards())
+	}
+	return backlog, nil
+}
+
+// RecordFailoverAuditActivity activity def, records the operator and reason of the failover into the data
+// of the failed over domains
+func RecordFailoverAuditActivity(ctx context.Context, params *RecordFailoverAuditActivityParams) error {
+	frontendClient := getClient(ctx)
+	data := getFailoverAuditData(params.Operator, params.Reason)
+	for _, domain := range params.Domains {
+		_, err := frontendClient.UpdateDomain(ctx, &types.UpdateDomainRequest{
+			Name: domain,
+			Data: data,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getFailoverAuditData(operator, reason string) map[string]string {
+	if operator == "" {
+		operator = unknownOperator
+	}
+	data := map[string]string{
+		common.DomainDataKeyForLastFailoverOperator: operator,
+	}
+	// an empty reason would overwrite the reason of a previous failover with no value
+	if reason != "" {
+		data[common.DomainDataKeyForLastFailoverReason] = reason
+	}
+	return data
+}
+
 func cleanupChannel(channel workflow.Channel) {
 	for {
 		if hasValue := channel.ReceiveAsync(nil); !hasValue {
@@ -29,6 +29,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence"
 	"go.uber.org/cadence/activity"
 	"go.uber.org/cadence/testsuite"
 	"go.uber.org/cadence/worker"
@@ -66,8 +67,14 @@ func (s *failoverWorkflowTestSuite) SetupTest() {
 	s.workflowEnv.RegisterWorkflowWithOptions(FailoverWorkflow, workflow.RegisterOptions{Name: FailoverWorkflowTypeName})
 	s.workflowEnv.RegisterActivityWithOptions(FailoverActivity, activity.RegisterOptions{Name: failoverActivityName})
 	s.workflowEnv.RegisterActivityWithOptions(GetDomainsActivity, activity.RegisterOptions{Name: getDomainsActivityName})
+	s.workflowEnv.RegisterActivityWithOptions(VerifyFailoverActivity, activity.RegisterOptions{Name: verifyFailoverActivityName})
+	s.workflowEnv.RegisterActivityWithOptions(GetReplicationBacklogActivity, activity.RegisterOptions{Name: getReplicationBacklogActivityName})
+	s.workflowEnv.RegisterActivityWithOptions(RecordFailoverAuditActivity, activity.RegisterOptions{Name: recordFailoverAuditActivityName})
 	s.activityEnv.RegisterActivityWithOptions(FailoverActivity, activity.RegisterOptions{Name: failoverActivityName})
 	s.activityEnv.RegisterActivityWithOptions(GetDomainsActivity, activity.RegisterOptions{Name: getDomainsActivityName})
+	s.activityEnv.RegisterActivityWithOptions(VerifyFailoverActivity, activity.RegisterOptions{Name: verifyFailoverActivityName})
+	s.activityEnv.RegisterActivityWithOptions(GetReplicationBacklogActivity, activity.RegisterOptions{Name: getReplicationBacklogActivityName})
+	s.activityEnv.RegisterActivityWithOptions(RecordFailoverAuditActivity, activity.RegisterOptions{Name: recordFailoverAuditActivityName})
 }
 
 func (s *failoverWorkflowTestSuite) TearDownTest() {
@@ -128,6 +135,7 @@ func (s *failoverWorkflowTestSuite) TestWorkflow_Success() {
 	}
 	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
 	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, mock.Anything).Return(mockFailoverActivityResult, nil)
+	s.workflowEnv.OnActivity(recordFailoverAuditActivityName, mock.Anything, mock.Anything).Return(nil).Once()
 	params := &FailoverParams{
 		TargetCluster: "t",
 		SourceCluster: "s",
@@ -172,6 +180,7 @@ func (s *failoverWorkflowTestSuite) TestWorkflow_Success_Batches() {
 	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
 	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, expectFailoverActivityParams1).Return(mockFailoverActivityResult1, nil).Once()
 	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, expectFailoverActivityParams2).Return(mockFailoverActivityResult2, nil).Once()
+	s.workflowEnv.OnActivity(recordFailoverAuditActivityName, mock.Anything, mock.Anything).Return(nil).Once()
 
 	params := &FailoverParams{
 		TargetCluster:     "t",
@@ -187,6 +196,167 @@ func (s *failoverWorkflowTestSuite) TestWorkflow_Success_Batches() {
 	s.Equal(mockFailoverActivityResult2.FailedDomains, result.FailedDomains)
 }
 
+func (s *failoverWorkflowTestSuite) TestWorkflow_VerifyFailover() {
+	domains := []string{"d1", "d2", "d3"}
+	mockFailoverActivityResult := &FailoverActivityResult{
+		SuccessDomains: []string{"d1", "d2"},
+		FailedDomains:  []string{"d3"},
+	}
+	expectVerifyActivityParams := &VerifyFailoverActivityParams{
+		Domains:       []string{"d1", "d2"},
+		TargetCluster: "t",
+	}
+	mockVerifyActivityResult := &VerifyFailoverActivityResult{
+		VerifiedDomains:   []string{"d1"},
+		UnverifiedDomains: []string{"d2"},
+	}
+	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, mock.Anything).Return(mockFailoverActivityResult, nil).Once()
+	s.workflowEnv.OnActivity(recordFailoverAuditActivityName, mock.Anything, mock.Anything).Return(nil).Once()
+	s.workflowEnv.OnActivity(verifyFailoverActivityName, mock.Anything, expectVerifyActivityParams).Return(mockVerifyActivityResult, nil).Once()
+	params := &FailoverParams{
+		TargetCluster:  "t",
+		SourceCluster:  "s",
+		VerifyFailover: true,
+	}
+	s.workflowEnv.ExecuteWorkflow(FailoverWorkflowTypeName, params)
+	var result FailoverResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal([]string{"d1"}, result.SuccessDomains)
+	s.Equal([]string{"d3", "d2"}, result.FailedDomains)
+}
+
+func (s *failoverWorkflowTestSuite) TestWorkflow_VerifyFailoverActivityError() {
+	domains := []string{"d1"}
+	mockFailoverActivityResult := &FailoverActivityResult{
+		SuccessDomains: []string{"d1"},
+	}
+	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, mock.Anything).Return(mockFailoverActivityResult, nil).Once()
+	s.workflowEnv.OnActivity(verifyFailoverActivityName, mock.Anything, mock.Anything).Return(nil, errors.New("mock err")).Once()
+	params := &FailoverParams{
+		TargetCluster:  "t",
+		SourceCluster:  "s",
+		VerifyFailover: true,
+	}
+	s.workflowEnv.ExecuteWorkflow(FailoverWorkflowTypeName, params)
+	var result FailoverResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(0, len(result.SuccessDomains))
+	s.Equal(domains, result.FailedDomains)
+}
+
+func (s *failoverWorkflowTestSuite) TestWorkflow_VerifyAfterFailover() {
+	domains := []string{"d1", "d2", "d3"}
+	mockFailoverActivityResult := &FailoverActivityResult{
+		SuccessDomains: []string{"d1", "d2"},
+		FailedDomains:  []string{"d3"},
+	}
+	expectVerifyActivityParams := &VerifyFailoverActivityParams{
+		Domains:       []string{"d1", "d2"},
+		TargetCluster: "t",
+	}
+	mockVerifyActivityResult := &VerifyFailoverActivityResult{
+		VerifiedDomains:   []string{"d1"},
+		UnverifiedDomains: []string{"d2"},
+	}
+	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, mock.Anything).Return(mockFailoverActivityResult, nil).Once()
+	s.workflowEnv.OnActivity(recordFailoverAuditActivityName, mock.Anything, mock.Anything).Return(nil).Once()
+	s.workflowEnv.OnActivity(verifyFailoverActivityName, mock.Anything, expectVerifyActivityParams).Return(mockVerifyActivityResult, nil).Once()
+	params := &FailoverParams{
+		TargetCluster:       "t",
+		SourceCluster:       "s",
+		VerifyAfterFailover: true,
+	}
+	s.workflowEnv.ExecuteWorkflow(FailoverWorkflowTypeName, params)
+	var result FailoverResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal([]string{"d1"}, result.SuccessDomains)
+	s.Equal([]string{"d3"}, result.FailedDomains)
+	s.Equal([]string{"d2"}, result.UnverifiedDomains)
+}
+
+func (s *failoverWorkflowTestSuite) TestWorkflow_VerifyAfterFailoverActivityError() {
+	domains := []string{"d1"}
+	mockFailoverActivityResult := &FailoverActivityResult{
+		SuccessDomains: []string{"d1"},
+	}
+	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, mock.Anything).Return(mockFailoverActivityResult, nil).Once()
+	s.workflowEnv.OnActivity(verifyFailoverActivityName, mock.Anything, mock.Anything).Return(nil, errors.New("mock err")).Once()
+	params := &FailoverParams{
+		TargetCluster:       "t",
+		SourceCluster:       "s",
+		VerifyAfterFailover: true,
+	}
+	s.workflowEnv.ExecuteWorkflow(FailoverWorkflowTypeName, params)
+	var result FailoverResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(0, len(result.SuccessDomains))
+	s.Equal(0, len(result.FailedDomains))
+	s.Equal(domains, result.UnverifiedDomains)
+}
+
+func (s *failoverWorkflowTestSuite) TestWorkflow_VerifyAfterFailover_AllVerified() {
+	domains := []string{"d1", "d2"}
+	mockFailoverActivityResult := &FailoverActivityResult{
+		SuccessDomains: domains,
+	}
+	mockVerifyActivityResult := &VerifyFailoverActivityResult{
+		VerifiedDomains: domains,
+	}
+	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, mock.Anything).Return(mockFailoverActivityResult, nil).Once()
+	s.workflowEnv.OnActivity(recordFailoverAuditActivityName, mock.Anything, mock.Anything).Return(nil).Once()
+	s.workflowEnv.OnActivity(verifyFailoverActivityName, mock.Anything, mock.Anything).Return(mockVerifyActivityResult, nil).Once()
+	params := &FailoverParams{
+		TargetCluster:       "t",
+		SourceCluster:       "s",
+		VerifyAfterFailover: true,
+	}
+	s.workflowEnv.ExecuteWorkflow(FailoverWorkflowTypeName, params)
+	var result FailoverResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(domains, result.SuccessDomains)
+	s.Equal(0, len(result.FailedDomains))
+	s.Equal(0, len(result.UnverifiedDomains))
+}
+
+func (s *failoverWorkflowTestSuite) TestWorkflow_OperatorAndReason() {
+	operator := "testOperator"
+	s.workflowEnv.SetMemoOnStart(map[string]interface{}{
+		common.MemoKeyForOperator: operator,
+	})
+	domains := []string{"d1"}
+	expectFailoverActivityParams := &FailoverActivityParams{
+		Domains:       domains,
+		TargetCluster: "t",
+	}
+	mockFailoverActivityResult := &FailoverActivityResult{
+		SuccessDomains: domains,
+	}
+	expectRecordFailoverAuditActivityParams := &RecordFailoverAuditActivityParams{
+		Domains:  domains,
+		Operator: operator,
+		Reason:   "testReason",
+	}
+	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, expectFailoverActivityParams).Return(mockFailoverActivityResult, nil).Once()
+	s.workflowEnv.OnActivity(recordFailoverAuditActivityName, mock.Anything, expectRecordFailoverAuditActivityParams).Return(nil).Once()
+
+	params := &FailoverParams{
+		TargetCluster: "t",
+		SourceCluster: "s",
+		Reason:        "testReason",
+	}
+	s.workflowEnv.ExecuteWorkflow(FailoverWorkflowTypeName, params)
+
+	var result FailoverResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(domains, result.SuccessDomains)
+}
+
 func (s *failoverWorkflowTestSuite) TestWorkflow_Pause() {
 	domains := []string{"d1"}
 	mockFailoverActivityResult := &FailoverActivityResult{
@@ -194,6 +364,7 @@ func (s *failoverWorkflowTestSuite) TestWorkflow_Pause() {
 	}
 	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
 	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, mock.Anything).Return(mockFailoverActivityResult, nil).Once()
+	s.workflowEnv.OnActivity(recordFailoverAuditActivityName, mock.Anything, mock.Anything).Return(nil).Once()
 
 	s.workflowEnv.RegisterDelayedCallback(func() {
 		s.workflowEnv.SignalWorkflow(PauseSignal, nil)
@@ -221,6 +392,139 @@ func (s *failoverWorkflowTestSuite) TestWorkflow_Pause() {
 	s.Equal(mockFailoverActivityResult.SuccessDomains, result.SuccessDomains)
 }
 
+func (s *failoverWorkflowTestSuite) TestWorkflow_Cancel() {
+	domains := []string{"d1", "d2", "d3"}
+	expectFailoverActivityParams := &FailoverActivityParams{
+		Domains:       []string{"d1"},
+		TargetCluster: "t",
+	}
+	mockFailoverActivityResult := &FailoverActivityResult{
+		SuccessDomains: []string{"d1"},
+	}
+	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
+	// only the first batch is failed over, the remaining batches are skipped after cancel
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, expectFailoverActivityParams).Return(mockFailoverActivityResult, nil).Once()
+	s.workflowEnv.OnActivity(recordFailoverAuditActivityName, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// cancel while waiting between the first and second batch
+	s.workflowEnv.RegisterDelayedCallback(func() {
+		s.workflowEnv.SignalWorkflow(CancelSignal, nil)
+	}, 10*time.Second)
+
+	params := &FailoverParams{
+		TargetCluster:     "t",
+		SourceCluster:     "s",
+		BatchFailoverSize: 1,
+		Domains:           domains,
+	}
+	s.workflowEnv.ExecuteWorkflow(FailoverWorkflowTypeName, params)
+
+	var result FailoverResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal([]string{"d1"}, result.SuccessDomains)
+	s.Empty(result.FailedDomains)
+
+	queryResult, err := s.workflowEnv.QueryWorkflow(QueryType)
+	s.NoError(err)
+	var res QueryResult
+	s.NoError(queryResult.Get(&res))
+	s.Equal(WorkflowAborted, res.State)
+	s.Equal(len(domains), res.TotalDomains)
+	s.Equal(1, res.Success)
+}
+
+func (s *failoverWorkflowTestSuite) TestWorkflow_CancelWhilePaused() {
+	domains := []string{"d1"}
+	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
+
+	s.workflowEnv.RegisterDelayedCallback(func() {
+		s.workflowEnv.SignalWorkflow(PauseSignal, nil)
+	}, time.Millisecond*0)
+	s.workflowEnv.RegisterDelayedCallback(func() {
+		s.workflowEnv.SignalWorkflow(CancelSignal, nil)
+	}, time.Millisecond*100)
+
+	params := &FailoverParams{
+		TargetCluster: "t",
+		SourceCluster: "s",
+		Domains:       domains,
+	}
+	s.workflowEnv.ExecuteWorkflow(FailoverWorkflowTypeName, params)
+
+	var result FailoverResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Empty(result.SuccessDomains)
+	s.assertQueryState(s.workflowEnv, WorkflowAborted)
+}
+
+func (s *failoverWorkflowTestSuite) TestWorkflow_WithDrillWaitTime_Cancel() {
+	domains := []string{"d1", "d2", "d3"}
+	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
+	// only the first batch is failed over and reset, the remaining batches are skipped after cancel
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, &FailoverActivityParams{
+		Domains:       []string{"d1"},
+		TargetCluster: "t",
+	}).Return(&FailoverActivityResult{SuccessDomains: []string{"d1"}}, nil).Once()
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, &FailoverActivityParams{
+		Domains:       []string{"d1"},
+		TargetCluster: "s",
+	}).Return(&FailoverActivityResult{SuccessDomains: []string{"d1"}}, nil).Once()
+
+	// cancel while waiting between the first and second batch
+	s.workflowEnv.RegisterDelayedCallback(func() {
+		s.workflowEnv.SignalWorkflow(CancelSignal, nil)
+	}, 10*time.Second)
+
+	params := &FailoverParams{
+		TargetCluster:     "t",
+		SourceCluster:     "s",
+		BatchFailoverSize: 1,
+		Domains:           domains,
+		DrillWaitTime:     time.Hour,
+	}
+	s.workflowEnv.ExecuteWorkflow(FailoverWorkflowTypeName, params)
+
+	var result FailoverResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal([]string{"d1"}, result.SuccessDomains)
+	s.Equal([]string{"d1"}, result.SuccessResetDomains)
+	s.Empty(result.FailedResetDomains)
+	s.assertQueryState(s.workflowEnv, WorkflowAborted)
+}
+
+func (s *failoverWorkflowTestSuite) TestWorkflow_WithDrillWaitTime_CancelDuringWait() {
+	domains := []string{"d1"}
+	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, &FailoverActivityParams{
+		Domains:       domains,
+		TargetCluster: "t",
+	}).Return(&FailoverActivityResult{SuccessDomains: domains}, nil).Once()
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, &FailoverActivityParams{
+		Domains:       domains,
+		TargetCluster: "s",
+	}).Return(&FailoverActivityResult{SuccessDomains: domains}, nil).Once()
+
+	// cancel during the drill wait time, the domains are reset right away
+	s.workflowEnv.RegisterDelayedCallback(func() {
+		s.workflowEnv.SignalWorkflow(CancelSignal, nil)
+	}, time.Minute)
+
+	params := &FailoverParams{
+		TargetCluster: "t",
+		SourceCluster: "s",
+		Domains:       domains,
+		DrillWaitTime: time.Hour,
+	}
+	s.workflowEnv.ExecuteWorkflow(FailoverWorkflowTypeName, params)
+
+	var result FailoverResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(domains, result.SuccessDomains)
+	s.Equal(domains, result.SuccessResetDomains)
+	s.Empty(result.FailedResetDomains)
+	s.assertQueryState(s.workflowEnv, WorkflowAborted)
+}
+
 func (s *failoverWorkflowTestSuite) TestWorkflow_WithDrillWaitTime_Success() {
 	domains := []string{"d1"}
 	mockFailoverActivityResult := &FailoverActivityResult{
@@ -267,6 +571,74 @@ func (s *failoverWorkflowTestSuite) TestWorkflow_WithDrillWaitTime_Success() {
 	s.Equal(0, len(res.FailedResetDomains))
 }
 
+func (s *failoverWorkflowTestSuite) TestWorkflow_GracefulFailover_WaitForReplicationDrain() {
+	domains := []string{"d1", "d2"}
+	mockFailoverActivityResult := &FailoverActivityResult{
+		SuccessDomains: []string{"d1"},
+		FailedDomains:  []string{"d2"},
+	}
+	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, mock.Anything).Return(mockFailoverActivityResult, nil)
+	s.workflowEnv.OnActivity(recordFailoverAuditActivityName, mock.Anything, mock.Anything).Return(nil).Once()
+	backlogParams := &GetReplicationBacklogActivityParams{Domains: []string{"d1"}, SourceCluster: "s"}
+	s.workflowEnv.OnActivity(getReplicationBacklogActivityName, mock.Anything, backlogParams).Return(8, nil).Once()
+	s.workflowEnv.OnActivity(getReplicationBacklogActivityName, mock.Anything, backlogParams).Return(4, nil).Once()
+	s.workflowEnv.OnActivity(getReplicationBacklogActivityName, mock.Anything, backlogParams).Return(2, nil).Once()
+
+	s.workflowEnv.RegisterDelayedCallback(func() {
+		s.assertQueryState(s.workflowEnv, WorkflowDrainingReplication)
+	}, replicationDrainPollInterval/2)
+
+	params := &FailoverParams{
+		TargetCluster:                    "t",
+		SourceCluster:                    "s",
+		GracefulFailoverTimeoutInSeconds: common.Int32Ptr(60),
+		ReplicationDrainTimeout:          time.Minute,
+		ReplicationDrainThreshold:        2,
+	}
+	s.workflowEnv.ExecuteWorkflow(FailoverWorkflowTypeName, params)
+	var result FailoverResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(mockFailoverActivityResult.SuccessDomains, result.SuccessDomains)
+	s.Equal(mockFailoverActivityResult.FailedDomains, result.FailedDomains)
+
+	queryResult, err := s.workflowEnv.QueryWorkflow(QueryType)
+	s.NoError(err)
+	var res QueryResult
+	s.NoError(queryResult.Get(&res))
+	s.Equal(WorkflowCompleted, res.State)
+	s.Equal(2, res.ReplicationBacklog)
+}
+
+func (s *failoverWorkflowTestSuite) TestWorkflow_GracefulFailover_WaitForReplicationDrain_Timeout() {
+	domains := []string{"d1"}
+	mockFailoverActivityResult := &FailoverActivityResult{
+		SuccessDomains: []string{"d1"},
+	}
+	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, mock.Anything).Return(mockFailoverActivityResult, nil)
+	s.workflowEnv.OnActivity(recordFailoverAuditActivityName, mock.Anything, mock.Anything).Return(nil).Once()
+	// polled at 0s, 10s and 15s before the drain timeout expires
+	s.workflowEnv.OnActivity(getReplicationBacklogActivityName, mock.Anything, mock.Anything).Return(5, nil).Times(3)
+
+	params := &FailoverParams{
+		TargetCluster:                    "t",
+		SourceCluster:                    "s",
+		GracefulFailoverTimeoutInSeconds: common.Int32Ptr(60),
+		ReplicationDrainTimeout:          15 * time.Second,
+	}
+	s.workflowEnv.ExecuteWorkflow(FailoverWorkflowTypeName, params)
+	var result FailoverResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(mockFailoverActivityResult.SuccessDomains, result.SuccessDomains)
+
+	queryResult, err := s.workflowEnv.QueryWorkflow(QueryType)
+	s.NoError(err)
+	var res QueryResult
+	s.NoError(queryResult.Get(&res))
+	s.Equal(5, res.ReplicationBacklog)
+}
+
 func (s *failoverWorkflowTestSuite) TestShouldFailover() {
 
 	tests := []struct {
@@ -409,6 +781,68 @@ func (s *failoverWorkflowTestSuite) TestGetDomainsActivity_WithTargetDomains() {
 	s.Equal([]string{"d1"}, result) // d3 filtered out because not managed
 }
 
+func (s *failoverWorkflowTestSuite) TestGetDomainsActivity_UnmanagedTargetDomains() {
+	domains := &types.ListDomainsResponse{
+		Domains: []*types.DescribeDomainResponse{
+			{
+				DomainInfo: &types.DomainInfo{
+					Name: "d1",
+					Data: map[string]string{common.DomainDataKeyForManagedFailover: "true"},
+				},
+				ReplicationConfiguration: &types.DomainReplicationConfiguration{
+					ActiveClusterName: "c1",
+					Clusters:          clusters,
+				},
+				IsGlobalDomain: true,
+			},
+			{
+				DomainInfo: &types.DomainInfo{
+					Name: "d2",
+				},
+				ReplicationConfiguration: &types.DomainReplicationConfiguration{
+					ActiveClusterName: "c1",
+					Clusters:          clusters,
+				},
+				IsGlobalDomain: true,
+			},
+		},
+	}
+
+	s.Run("warn", func() {
+		env, mockResource := s.prepareTestActivityEnv()
+		mockResource.FrontendClient.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+		params := &GetDomainsActivityParams{
+			TargetCluster: "c2",
+			SourceCluster: "c1",
+			Domains:       []string{"d1", "d2"},
+		}
+		actResult, err := env.ExecuteActivity(getDomainsActivityName, params)
+		s.NoError(err)
+		var result []string
+		s.NoError(actResult.Get(&result))
+		s.Equal([]string{"d1"}, result)
+	})
+
+	s.Run("strict", func() {
+		env, mockResource := s.prepareTestActivityEnv()
+		mockResource.FrontendClient.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(domains, nil)
+		params := &GetDomainsActivityParams{
+			TargetCluster:          "c2",
+			SourceCluster:          "c1",
+			Domains:                []string{"d1", "d2"},
+			FailOnUnmanagedDomains: true,
+		}
+		_, err := env.ExecuteActivity(getDomainsActivityName, params)
+		s.Error(err)
+		var customErr *cadence.CustomError
+		s.True(errors.As(err, &customErr))
+		s.Equal(errMsgDomainsNotManaged, customErr.Reason())
+		var unmanagedDomains []string
+		s.NoError(customErr.Details(&unmanagedDomains))
+		s.Equal([]string{"d2"}, unmanagedDomains)
+	})
+}
+
 func (s *failoverWorkflowTestSuite) TestFailoverActivity_ForceFailover_Success() {
 	env, mockResource := s.prepareTestActivityEnv()
 
@@ -577,6 +1011,132 @@ func (s *failoverWorkflowTestSuite) TestFailoverActivity_NoPoller_Error() {
 	s.Equal([]string{"d1", "d2"}, result.FailedDomains)
 }
 
+func (s *failoverWorkflowTestSuite) TestFailoverActivity_NoPoller_SkipPollerCheck_Success() {
+	env, mockResource := s.prepareTestActivityEnv()
+
+	domains := []string{"d1", "d2"}
+	targetCluster := "c2"
+
+	mockResource.FrontendClient.EXPECT().UpdateDomain(gomock.Any(), gomock.Any()).Return(nil, nil).Times(len(domains))
+	mockResource.FrontendClient.EXPECT().GetTaskListsByDomain(gomock.Any(), gomock.Any()).Times(0)
+	mockResource.RemoteFrontendClient.EXPECT().GetTaskListsByDomain(gomock.Any(), gomock.Any()).Times(0)
+
+	params := &FailoverActivityParams{
+		Domains:         domains,
+		TargetCluster:   targetCluster,
+		SkipPollerCheck: true,
+	}
+
+	actResult, err := env.ExecuteActivity(failoverActivityName, params)
+	s.NoError(err)
+	var result FailoverActivityResult
+	s.NoError(actResult.Get(&result))
+	s.Equal(domains, result.SuccessDomains)
+	s.Equal(0, len(result.FailedDomains))
+}
+
+func (s *failoverWorkflowTestSuite) TestVerifyFailoverActivity() {
+	env, mockResource := s.prepareTestActivityEnv()
+
+	mockResource.RemoteFrontendClient.EXPECT().DescribeDomain(gomock.Any(), &types.DescribeDomainRequest{Name: common.StringPtr("d1")}).
+		Return(&types.DescribeDomainResponse{
+			ReplicationConfiguration: &types.DomainReplicationConfiguration{ActiveClusterName: "c2"},
+		}, nil)
+	mockResource.RemoteFrontendClient.EXPECT().DescribeDomain(gomock.Any(), &types.DescribeDomainRequest{Name: common.StringPtr("d2")}).
+		Return(&types.DescribeDomainResponse{
+			ReplicationConfiguration: &types.DomainReplicationConfiguration{ActiveClusterName: "c1"},
+		}, nil)
+	mockResource.RemoteFrontendClient.EXPECT().DescribeDomain(gomock.Any(), &types.DescribeDomainRequest{Name: common.StringPtr("d3")}).
+		Return(nil, errors.New("mock err"))
+
+	params := &VerifyFailoverActivityParams{
+		Domains:       []string{"d1", "d2", "d3"},
+		TargetCluster: "c2",
+	}
+	actResult, err := env.ExecuteActivity(verifyFailoverActivityName, params)
+	s.NoError(err)
+	var result VerifyFailoverActivityResult
+	s.NoError(actResult.Get(&result))
+	s.Equal([]string{"d1"}, result.VerifiedDomains)
+	s.Equal([]string{"d2", "d3"}, result.UnverifiedDomains)
+}
+
+func (s *failoverWorkflowTestSuite) TestGetReplicationBacklogActivity() {
+	env, mockResource := s.prepareTestActivityEnv()
+
+	mockResource.RemoteFrontendClient.EXPECT().DescribeDomain(gomock.Any(), &types.DescribeDomainRequest{Name: common.StringPtr("d1")}).
+		Return(&types.DescribeDomainResponse{
+			FailoverInfo: &types.FailoverInfo{PendingShards: []int32{1, 2, 3}},
+		}, nil)
+	mockResource.RemoteFrontendClient.EXPECT().DescribeDomain(gomock.Any(), &types.DescribeDomainRequest{Name: common.StringPtr("d2")}).
+		Return(&types.DescribeDomainResponse{}, nil)
+
+	params := &GetReplicationBacklogActivityParams{
+		Domains:       []string{"d1", "d2"},
+		SourceCluster: "c1",
+	}
+	actResult, err := env.ExecuteActivity(getReplicationBacklogActivityName, params)
+	s.NoError(err)
+	var backlog int
+	s.NoError(actResult.Get(&backlog))
+	s.Equal(3, backlog)
+}
+
+func (s *failoverWorkflowTestSuite) TestRecordFailoverAuditActivity() {
+	env, mockResource := s.prepareTestActivityEnv()
+
+	expectedData := map[string]string{
+		common.DomainDataKeyForLastFailoverOperator: "testOperator",
+		common.DomainDataKeyForLastFailoverReason:   "testReason",
+	}
+	mockResource.FrontendClient.EXPECT().UpdateDomain(gomock.Any(), &types.UpdateDomainRequest{Name: "d1", Data: expectedData}).Return(nil, nil).Times(1)
+	mockResource.FrontendClient.EXPECT().UpdateDomain(gomock.Any(), &types.UpdateDomainRequest{Name: "d2", Data: expectedData}).Return(nil, nil).Times(1)
+
+	params := &RecordFailoverAuditActivityParams{
+		Domains:  []string{"d1", "d2"},
+		Operator: "testOperator",
+		Reason:   "testReason",
+	}
+	_, err := env.ExecuteActivity(recordFailoverAuditActivityName, params)
+	s.NoError(err)
+}
+
+func (s *failoverWorkflowTestSuite) TestRecordFailoverAuditActivity_Error() {
+	env, mockResource := s.prepareTestActivityEnv()
+
+	expectedData := map[string]string{
+		common.DomainDataKeyForLastFailoverOperator: unknownOperator,
+	}
+	mockResource.FrontendClient.EXPECT().UpdateDomain(gomock.Any(), &types.UpdateDomainRequest{Name: "d1", Data: expectedData}).
+		Return(nil, &types.BadRequestError{Message: "Domain update too frequent."}).Times(1)
+
+	params := &RecordFailoverAuditActivityParams{
+		Domains: []string{"d1", "d2"},
+	}
+	_, err := env.ExecuteActivity(recordFailoverAuditActivityName, params)
+	s.Error(err)
+}
+
+func (s *failoverWorkflowTestSuite) TestWorkflow_RecordFailoverAuditError() {
+	domains := []string{"d1"}
+	mockFailoverActivityResult := &FailoverActivityResult{
+		SuccessDomains: domains,
+	}
+	s.workflowEnv.OnActivity(getDomainsActivityName, mock.Anything, mock.Anything).Return(domains, nil)
+	s.workflowEnv.OnActivity(failoverActivityName, mock.Anything, mock.Anything).Return(mockFailoverActivityResult, nil).Once()
+	s.workflowEnv.OnActivity(recordFailoverAuditActivityName, mock.Anything, mock.Anything).Return(errors.New("mock err"))
+
+	params := &FailoverParams{
+		TargetCluster: "t",
+		SourceCluster: "s",
+	}
+	s.workflowEnv.ExecuteWorkflow(FailoverWorkflowTypeName, params)
+
+	var result FailoverResult
+	s.NoError(s.workflowEnv.GetWorkflowResult(&result))
+	s.Equal(domains, result.SuccessDomains)
+}
+
 func (s *failoverWorkflowTestSuite) TestGetOperator() {
 	operator := "testOperator"
 	s.workflowEnv.SetMemoOnStart(map[string]interface{}{
@@ -178,6 +178,9 @@ func concreteExecutionCustomScannerConfig(ctx shardscanner.ScannerContext) shard
 	if ctx.Config.DynamicCollection.GetBoolProperty(dynamicconfig.ConcreteExecutionsScannerInvariantCollectionStale)() {
 		res[invariant.CollectionStale.String()] = strconv.FormatBool(true)
 	}
+	if ctx.Config.DynamicCollection.GetBoolProperty(dynamicconfig.ConcreteExecutionsScannerInvariantCollectionOrphanedHistory)() {
+		res[invariant.CollectionOrphanedHistory.String()] = strconv.FormatBool(true)
+	}
 
 	return res
 }
@@ -196,6 +199,9 @@ func concreteExecutionCustomFixerConfig(ctx shardscanner.FixerContext) shardscan
 	res[invariant.CollectionStale.String()] = strconv.FormatBool(
 		ctx.Config.DynamicCollection.GetBoolProperty(dynamicconfig.ConcreteExecutionsFixerInvariantCollectionStale)(),
 	)
+	res[invariant.CollectionOrphanedHistory.String()] = strconv.FormatBool(
+		ctx.Config.DynamicCollection.GetBoolProperty(dynamicconfig.ConcreteExecutionsFixerInvariantCollectionOrphanedHistory)(),
+	)
 
 	return res
 }
@@ -206,13 +212,15 @@ func ConcreteExecutionConfig(dc *dynamicconfig.Collection) *shardscanner.Scanner
 		ScannerWFTypeName: ConcreteExecutionsScannerWFTypeName,
 		FixerWFTypeName:   ConcreteExecutionsFixerWFTypeName,
 		DynamicParams: shardscanner.DynamicParams{
-			ScannerEnabled:          dc.GetBoolProperty(dynamicconfig.ConcreteExecutionsScannerEnabled),
-			FixerEnabled:            dc.GetBoolProperty(dynamicconfig.ConcreteExecutionFixerEnabled),
-			Concurrency:             dc.GetIntProperty(dynamicconfig.ConcreteExecutionsScannerConcurrency),
-			PageSize:                dc.GetIntProperty(dynamicconfig.ConcreteExecutionsScannerPersistencePageSize),
-			BlobstoreFlushThreshold: dc.GetIntProperty(dynamicconfig.ConcreteExecutionsScannerBlobstoreFlushThreshold),
-			ActivityBatchSize:       dc.GetIntProperty(dynamicconfig.ConcreteExecutionsScannerActivityBatchSize),
-			AllowDomain:             dc.GetBoolPropertyFilteredByDomain(dynamicconfig.ConcreteExecutionFixerDomainAllow),
+			ScannerEnabled:            dc.GetBoolProperty(dynamicconfig.ConcreteExecutionsScannerEnabled),
+			FixerEnabled:              dc.GetBoolProperty(dynamicconfig.ConcreteExecutionFixerEnabled),
+			Concurrency:               dc.GetIntProperty(dynamicconfig.ConcreteExecutionsScannerConcurrency),
+			PageSize:                  dc.GetIntProperty(dynamicconfig.ConcreteExecutionsScannerPersistencePageSize),
+			BlobstoreFlushThreshold:   dc.GetIntProperty(dynamicconfig.ConcreteExecutionsScannerBlobstoreFlushThreshold),
+			BlobstoreWriteBudgetBytes: dc.GetIntProperty(dynamicconfig.ConcreteExecutionsScannerBlobstoreWriteBudgetBytes),
+			ShardConcurrency:          dc.GetIntProperty(dynamicconfig.ConcreteExecutionsScannerShardConcurrency),
+			ActivityBatchSize:         dc.GetIntProperty(dynamicconfig.ConcreteExecutionsScannerActivityBatchSize),
+			AllowDomain:               dc.GetBoolPropertyFilteredByDomain(dynamicconfig.ConcreteExecutionFixerDomainAllow),
 		},
 		DynamicCollection: dc,
 		ScannerHooks:      concreteExecutionScannerHooks,
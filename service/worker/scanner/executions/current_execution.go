@@ -164,13 +164,15 @@ func CurrentExecutionConfig(dc *dynamicconfig.Collection) *shardscanner.ScannerC
 		FixerWFTypeName:   CurrentExecutionsFixerWFTypeName,
 		DynamicCollection: dc,
 		DynamicParams: shardscanner.DynamicParams{
-			ScannerEnabled:          dc.GetBoolProperty(dynamicconfig.CurrentExecutionsScannerEnabled),
-			FixerEnabled:            dc.GetBoolProperty(dynamicconfig.CurrentExecutionFixerEnabled),
-			Concurrency:             dc.GetIntProperty(dynamicconfig.CurrentExecutionsScannerConcurrency),
-			PageSize:                dc.GetIntProperty(dynamicconfig.CurrentExecutionsScannerPersistencePageSize),
-			BlobstoreFlushThreshold: dc.GetIntProperty(dynamicconfig.CurrentExecutionsScannerBlobstoreFlushThreshold),
-			ActivityBatchSize:       dc.GetIntProperty(dynamicconfig.CurrentExecutionsScannerActivityBatchSize),
-			AllowDomain:             dc.GetBoolPropertyFilteredByDomain(dynamicconfig.CurrentExecutionFixerDomainAllow),
+			ScannerEnabled:            dc.GetBoolProperty(dynamicconfig.CurrentExecutionsScannerEnabled),
+			FixerEnabled:              dc.GetBoolProperty(dynamicconfig.CurrentExecutionFixerEnabled),
+			Concurrency:               dc.GetIntProperty(dynamicconfig.CurrentExecutionsScannerConcurrency),
+			PageSize:                  dc.GetIntProperty(dynamicconfig.CurrentExecutionsScannerPersistencePageSize),
+			BlobstoreFlushThreshold:   dc.GetIntProperty(dynamicconfig.CurrentExecutionsScannerBlobstoreFlushThreshold),
+			BlobstoreWriteBudgetBytes: dc.GetIntProperty(dynamicconfig.CurrentExecutionsScannerBlobstoreWriteBudgetBytes),
+			ShardConcurrency:          dc.GetIntProperty(dynamicconfig.CurrentExecutionsScannerShardConcurrency),
+			ActivityBatchSize:         dc.GetIntProperty(dynamicconfig.CurrentExecutionsScannerActivityBatchSize),
+			AllowDomain:               dc.GetBoolPropertyFilteredByDomain(dynamicconfig.CurrentExecutionFixerDomainAllow),
 		},
 		ScannerHooks: currentExecutionScannerHooks,
 		FixerHooks:   currentExecutionFixerHooks,
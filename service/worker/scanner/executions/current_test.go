@@ -56,6 +56,7 @@ func (s *currentExectionsWorkflowsSuite) TestScannerWorkflow_Success() {
 		GenericScannerConfig: shardscanner.GenericScannerConfig{
 			Enabled:           true,
 			Concurrency:       3,
+			ShardConcurrency:  1,
 			ActivityBatchSize: 5,
 		},
 	}, nil)
@@ -118,6 +119,7 @@ func (s *currentExectionsWorkflowsSuite) TestScannerWorkflow_Success() {
 		env.OnActivity(shardscanner.ActivityScanShard, mock.Anything, shardscanner.ScanShardActivityParams{
 			Shards:        batch,
 			ScannerConfig: customc,
+			Concurrency:   1,
 		}).Return(reports, nil)
 	}
 
@@ -109,6 +109,8 @@ func (st ScanType) ToInvariants(collections []invariant.Collection, logger *zap.
 				})
 			case invariant.CollectionMutableState:
 				fns = append(fns, invariant.NewOpenCurrentExecution)
+			case invariant.CollectionOrphanedHistory:
+				fns = append(fns, invariant.NewOrphanedHistoryBranch)
 			}
 		}
 		return fns
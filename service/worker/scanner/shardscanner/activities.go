@@ -27,13 +27,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"go.uber.org/cadence"
+	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
 	"go.uber.org/cadence/.gen/go/shared"
 	"go.uber.org/cadence/activity"
+	"golang.org/x/sync/errgroup"
 
 	c "github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
 	"github.com/uber/cadence/common/log/tag"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
@@ -53,6 +58,8 @@ const (
 	ActivityFixerCorruptedKeys = "cadence-sys-shardscanner-corruptedkeys-activity"
 	// ActivityFixShard is the activity name for fixShardActivity
 	ActivityFixShard = "cadence-sys-shardscanner-fixshard-activity"
+	// ActivityScanReportExport is the activity name for scanReportExportActivity
+	ActivityScanReportExport = "cadence-sys-shardscanner-export-report-activity"
 	// ShardCorruptKeysQuery is the query name for the query used to get all completed shards with at least one corruption
 	ShardCorruptKeysQuery = "shard_corrupt_keys"
 )
@@ -70,11 +77,13 @@ func scannerConfigActivity(
 
 	result := ResolvedScannerWorkflowConfig{
 		GenericScannerConfig: GenericScannerConfig{
-			Enabled:                 dc.ScannerEnabled(),
-			Concurrency:             dc.Concurrency(),
-			PageSize:                dc.PageSize(),
-			BlobstoreFlushThreshold: dc.BlobstoreFlushThreshold(),
-			ActivityBatchSize:       dc.ActivityBatchSize(),
+			Enabled:                   dc.ScannerEnabled(),
+			Concurrency:               dc.Concurrency(),
+			PageSize:                  dc.PageSize(),
+			BlobstoreFlushThreshold:   dc.BlobstoreFlushThreshold(),
+			ActivityBatchSize:         dc.ActivityBatchSize(),
+			BlobstoreWriteBudgetBytes: dc.BlobstoreWriteBudgetBytes(),
+			ShardConcurrency:          dc.ShardConcurrency(),
 		},
 	}
 
@@ -99,6 +108,12 @@ func scannerConfigActivity(
 	if overwrites.ActivityBatchSize != nil {
 		result.GenericScannerConfig.ActivityBatchSize = *overwrites.ActivityBatchSize
 	}
+	if overwrites.BlobstoreWriteBudgetBytes != nil {
+		result.GenericScannerConfig.BlobstoreWriteBudgetBytes = *overwrites.BlobstoreWriteBudgetBytes
+	}
+	if overwrites.ShardConcurrency != nil {
+		result.GenericScannerConfig.ShardConcurrency = *overwrites.ShardConcurrency
+	}
 
 	if params.Overwrites.CustomScannerConfig != nil {
 		result.CustomScannerConfig = *params.Overwrites.CustomScannerConfig
@@ -108,6 +123,8 @@ func scannerConfigActivity(
 }
 
 // scanShardActivity will scan a collection of shards for invariant violations.
+// Up to params.Concurrency shards are scanned in parallel. Heartbeat details only advance over the
+// contiguous prefix of completed shards, so a retry never re-scans a shard whose report was recorded.
 func scanShardActivity(
 	activityCtx context.Context,
 	params ScanShardActivityParams,
@@ -127,17 +144,54 @@ func scanShardActivity(
 			return nil, err
 		}
 	}
+
+	budget := newBlobstoreWriteBudget(params.BlobstoreWriteBudgetBytes, heartbeatDetails.Reports)
+
+	var mu sync.Mutex
+	var scanErr error
+	completed := make([]*ScanReport, len(params.Shards))
+	recordHeartbeat := func() {
+		mu.Lock()
+		details := heartbeatDetails
+		mu.Unlock()
+		activity.RecordHeartbeat(activityCtx, details)
+	}
+
+	g := &errgroup.Group{}
+	g.SetLimit(c.MaxInt(params.Concurrency, 1))
 	for i := heartbeatDetails.LastShardIndexHandled + 1; i < len(params.Shards); i++ {
-		currentShardID := params.Shards[i]
-		shardReport, err := scanShard(activityCtx, params, currentShardID, heartbeatDetails)
-		if err != nil {
-			ctx.Logger.Error("scanning shard", tag.Error(err))
-			return nil, err
-		}
-		heartbeatDetails = ScanShardHeartbeatDetails{
-			LastShardIndexHandled: i,
-			Reports:               append(heartbeatDetails.Reports, *shardReport),
+		mu.Lock()
+		failed := scanErr != nil
+		mu.Unlock()
+		if failed {
+			break
 		}
+
+		idx := i
+		g.Go(func() error {
+			shardReport, err := scanShard(activityCtx, params, params.Shards[idx], budget, recordHeartbeat)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				ctx.Logger.Error("scanning shard", tag.Error(err))
+				if scanErr == nil {
+					scanErr = err
+				}
+				return err
+			}
+			completed[idx] = shardReport
+			for next := heartbeatDetails.LastShardIndexHandled + 1; next < len(params.Shards) && completed[next] != nil; next++ {
+				heartbeatDetails = ScanShardHeartbeatDetails{
+					LastShardIndexHandled: next,
+					Reports:               append(heartbeatDetails.Reports, *completed[next]),
+				}
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
 	}
 	return heartbeatDetails.Reports, nil
 }
@@ -146,7 +200,8 @@ func scanShard(
 	activityCtx context.Context,
 	params ScanShardActivityParams,
 	shardID int,
-	heartbeatDetails ScanShardHeartbeatDetails,
+	budget *blobstoreWriteBudget,
+	recordHeartbeat func(),
 ) (*ScanReport, error) {
 	ctx, err := GetScannerContext(activityCtx)
 	if err != nil {
@@ -166,6 +221,19 @@ func scanShard(
 		return nil, cadence.NewCustomError(ErrMissingHooks)
 	}
 
+	if budget.exhausted() {
+		scope.IncCounter(metrics.CadenceFailures)
+		return &ScanReport{
+			ShardID: shardID,
+			Result: ScanResult{
+				ControlFlowFailure: &ControlFlowFailure{
+					Info:        "shard skipped",
+					InfoDetails: store.ErrWriteBudgetExceeded.Error(),
+				},
+			},
+		}, nil
+	}
+
 	resources := ctx.Resource
 	execManager, err := resources.GetExecutionManager(shardID)
 	if err != nil {
@@ -174,24 +242,46 @@ func scanShard(
 	}
 
 	pr := persistence.NewPersistenceRetryer(execManager, resources.GetHistoryManager(), c.CreatePersistenceRetryPolicy())
+	blobstoreClient := newBudgetedBlobstoreClient(resources.GetBlobstoreClient(), budget)
 
 	scanner := NewScanner(
 		shardID,
 		ctx.Hooks.Iterator(activityCtx, pr, params),
-		resources.GetBlobstoreClient(),
+		blobstoreClient,
 		params.BlobstoreFlushThreshold,
 		ctx.Hooks.Manager(activityCtx, pr, params, resources.GetDomainCache()),
-		func() { activity.RecordHeartbeat(activityCtx, heartbeatDetails) },
+		recordHeartbeat,
 		scope,
 		resources.GetDomainCache(),
 	)
+	start := time.Now()
 	report := scanner.Scan(activityCtx)
+	report.ScanDuration = time.Since(start)
+	report.BlobstoreBytesWritten = blobstoreClient.bytesWritten()
+	scope.Tagged(metrics.ShardScannerShardSizeBucket(shardSizeBucket(report.Stats.EntitiesCount))).
+		RecordHistogramDuration(metrics.ScannerShardScanDurationHistogram, report.ScanDuration)
 	if report.Result.ControlFlowFailure != nil {
 		scope.IncCounter(metrics.CadenceFailures)
 	}
 	return &report, nil
 }
 
+// shardSizeBucket returns a coarse, order of magnitude bucket of the number of entities in a shard
+func shardSizeBucket(entitiesCount int64) string {
+	switch {
+	case entitiesCount < 1000:
+		return "lt_1k"
+	case entitiesCount < 10000:
+		return "lt_10k"
+	case entitiesCount < 100000:
+		return "lt_100k"
+	case entitiesCount < 1000000:
+		return "lt_1m"
+	default:
+		return "gte_1m"
+	}
+}
+
 // fixerCorruptedKeysActivity will fetch the keys of blobs from shards with corruptions from a completed scan workflow.
 // If scan workflow is not closed or if query fails activity will return an error.
 // Accepts as input the shard to start query at and returns a next page token, therefore this activity can
@@ -206,97 +296,295 @@ func fixerCorruptedKeysActivity(
 	}
 
 	client := ctx.Resource.GetSDKClient()
+	if len(params.ScannerWorkflowRunIDs) > 0 {
+		return corruptedKeysFromRuns(activityCtx, client, params)
+	}
 	if params.ScannerWorkflowRunID == "" {
-		listResp, err := client.ListClosedWorkflowExecutions(activityCtx, &shared.ListClosedWorkflowExecutionsRequest{
-			Domain:          c.StringPtr(c.SystemLocalDomainName),
-			MaximumPageSize: c.Int32Ptr(10),
-			NextPageToken:   nil,
-			StartTimeFilter: &shared.StartTimeFilter{
-				EarliestTime: c.Int64Ptr(0),
-				LatestTime:   c.Int64Ptr(time.Now().UnixNano()),
-			},
-			ExecutionFilter: &shared.WorkflowExecutionFilter{
-				WorkflowId: c.StringPtr(params.ScannerWorkflowWorkflowID),
-			},
+		params.ScannerWorkflowRunID, err = findScannerRunID(activityCtx, client, params.ScannerWorkflowWorkflowID, params.AcceptedCloseStatuses)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	queryResult, err := queryCorruptedKeys(activityCtx, client, params.ScannerWorkflowWorkflowID, params.ScannerWorkflowRunID, params.StartingShardID)
+	if err != nil {
+		return nil, err
+	}
+	var corrupted []CorruptedKeysEntry
+	for sid, keys := range queryResult.Result {
+		corrupted = append(corrupted, CorruptedKeysEntry{
+			ShardID:       sid,
+			CorruptedKeys: keys,
 		})
+	}
+	return newFixerCorruptedKeysActivityResult(corrupted, queryResult.ShardQueryPaginationToken), nil
+}
+
+// findScannerRunID returns the run ID of the most recent closed scanner workflow with one of the accepted close statuses.
+// Nil acceptedCloseStatuses defaults to defaultAcceptedCloseStatuses.
+func findScannerRunID(
+	activityCtx context.Context,
+	client workflowserviceclient.Interface,
+	workflowID string,
+	acceptedCloseStatuses []shared.WorkflowExecutionCloseStatus,
+) (string, error) {
+	if acceptedCloseStatuses == nil {
+		acceptedCloseStatuses = defaultAcceptedCloseStatuses
+	}
+	if len(acceptedCloseStatuses) == 0 {
+		return "", cadence.NewCustomError(ErrInvalidAcceptedCloseStatuses)
+	}
+	listResp, err := client.ListClosedWorkflowExecutions(activityCtx, &shared.ListClosedWorkflowExecutionsRequest{
+		Domain:          c.StringPtr(c.SystemLocalDomainName),
+		MaximumPageSize: c.Int32Ptr(10),
+		NextPageToken:   nil,
+		StartTimeFilter: &shared.StartTimeFilter{
+			EarliestTime: c.Int64Ptr(0),
+			LatestTime:   c.Int64Ptr(time.Now().UnixNano()),
+		},
+		ExecutionFilter: &shared.WorkflowExecutionFilter{
+			WorkflowId: c.StringPtr(workflowID),
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(listResp.Executions) > 10 {
+		return "", errors.New("got unexpected number of executions back from list")
+	}
+	// ListClosedWorkflowExecutions API doesn't support querying by workflow ID and status filter at the same time,
+	// and we want to avoid using a scan result with Terminated state.
+	for _, executionInfo := range listResp.Executions {
+		for _, status := range acceptedCloseStatuses {
+			if executionInfo.GetCloseStatus() == status {
+				return executionInfo.Execution.GetRunId(), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("failed to find a recent scanner workflow execution with close status in %v", acceptedCloseStatuses)
+}
+
+// corruptedKeysFromRuns queries the given scanner runs and merges their corrupted keys.
+// Keys are only returned up to the smallest next shard across the runs, so that no shard is returned twice by
+// subsequent pages.
+func corruptedKeysFromRuns(
+	activityCtx context.Context,
+	client workflowserviceclient.Interface,
+	params FixerCorruptedKeysActivityParams,
+) (*FixerCorruptedKeysActivityResult, error) {
+	results := make([]*ShardCorruptKeysQueryResult, 0, len(params.ScannerWorkflowRunIDs))
+	paginationToken := ShardQueryPaginationToken{IsDone: true}
+	for _, runID := range params.ScannerWorkflowRunIDs {
+		queryResult, err := queryCorruptedKeys(activityCtx, client, params.ScannerWorkflowWorkflowID, runID, params.StartingShardID)
 		if err != nil {
 			return nil, err
 		}
-		if len(listResp.Executions) > 10 {
-			return nil, errors.New("got unexpected number of executions back from list")
+		results = append(results, queryResult)
+		nextShardID := queryResult.ShardQueryPaginationToken.NextShardID
+		if queryResult.ShardQueryPaginationToken.IsDone || nextShardID == nil {
+			continue
 		}
-		// ListClosedWorkflowExecutions API doesn't support querying by workflow ID and status filter at the same time,
-		// and we want to avoid using a scan result with Terminated state.
-		for _, executionInfo := range listResp.Executions {
-			if *executionInfo.CloseStatus == shared.WorkflowExecutionCloseStatusContinuedAsNew {
-				params.ScannerWorkflowRunID = *executionInfo.Execution.RunId
-				break
-			}
+		if paginationToken.NextShardID == nil || *paginationToken.NextShardID > *nextShardID {
+			paginationToken = ShardQueryPaginationToken{NextShardID: nextShardID}
 		}
-		if len(params.ScannerWorkflowRunID) == 0 {
-			return nil, errors.New("failed to find a recent scanner workflow execution with ContinuedAsNew status")
+	}
+
+	// a shard corrupted in several runs is returned once, as fix reports are aggregated by shard
+	entries := make(map[int]*CorruptedKeysEntry)
+	for _, queryResult := range results {
+		for sid, keys := range queryResult.Result {
+			if paginationToken.NextShardID != nil && sid >= *paginationToken.NextShardID {
+				continue
+			}
+			if entry, ok := entries[sid]; ok {
+				entry.MergedCorruptedKeys = append(entry.MergedCorruptedKeys, keys)
+				continue
+			}
+			entries[sid] = &CorruptedKeysEntry{
+				ShardID:       sid,
+				CorruptedKeys: keys,
+			}
 		}
 	}
+	corrupted := make([]CorruptedKeysEntry, 0, len(entries))
+	for _, entry := range entries {
+		corrupted = append(corrupted, *entry)
+	}
+	sort.Slice(corrupted, func(i, j int) bool { return corrupted[i].ShardID < corrupted[j].ShardID })
+	return newFixerCorruptedKeysActivityResult(corrupted, paginationToken), nil
+}
 
+// queryCorruptedKeys returns a page of corrupted keys from a closed scanner workflow run
+func queryCorruptedKeys(
+	activityCtx context.Context,
+	client workflowserviceclient.Interface,
+	workflowID string,
+	runID string,
+	startingShardID *int,
+) (*ShardCorruptKeysQueryResult, error) {
+	queryArgs := PaginatedShardQueryRequest{
+		StartingShardID: startingShardID,
+	}
+	queryResult := &ShardCorruptKeysQueryResult{}
+	if err := queryClosedScanner(activityCtx, client, workflowID, runID, ShardCorruptKeysQuery, queryArgs, &queryResult); err != nil {
+		return nil, err
+	}
+	return queryResult, nil
+}
+
+// queryClosedScanner runs the given query against a closed scanner workflow run and decodes the result.
+// A nil queryArgs sends the query without arguments.
+func queryClosedScanner(
+	activityCtx context.Context,
+	client workflowserviceclient.Interface,
+	workflowID string,
+	runID string,
+	queryType string,
+	queryArgs interface{},
+	queryResult interface{},
+) error {
 	descResp, err := client.DescribeWorkflowExecution(activityCtx, &shared.DescribeWorkflowExecutionRequest{
 		Domain: c.StringPtr(c.SystemLocalDomainName),
 		Execution: &shared.WorkflowExecution{
-			WorkflowId: c.StringPtr(params.ScannerWorkflowWorkflowID),
-			RunId:      c.StringPtr(params.ScannerWorkflowRunID),
+			WorkflowId: c.StringPtr(workflowID),
+			RunId:      c.StringPtr(runID),
 		},
 	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if descResp.WorkflowExecutionInfo.CloseStatus == nil {
-		return nil, cadence.NewCustomError(ErrScanWorkflowNotClosed)
-	}
-	queryArgs := PaginatedShardQueryRequest{
-		StartingShardID: params.StartingShardID,
+		return cadence.NewCustomError(ErrScanWorkflowNotClosed)
 	}
-	queryArgsBytes, err := json.Marshal(queryArgs)
-	if err != nil {
-		return nil, cadence.NewCustomError(ErrSerialization)
+	var queryArgsBytes []byte
+	if queryArgs != nil {
+		if queryArgsBytes, err = json.Marshal(queryArgs); err != nil {
+			return cadence.NewCustomError(ErrSerialization)
+		}
 	}
 	queryResp, err := client.QueryWorkflow(activityCtx, &shared.QueryWorkflowRequest{
 		Domain: c.StringPtr(c.SystemLocalDomainName),
 		Execution: &shared.WorkflowExecution{
-			WorkflowId: c.StringPtr(params.ScannerWorkflowWorkflowID),
-			RunId:      c.StringPtr(params.ScannerWorkflowRunID),
+			WorkflowId: c.StringPtr(workflowID),
+			RunId:      c.StringPtr(runID),
 		},
 		Query: &shared.WorkflowQuery{
-			QueryType: c.StringPtr(ShardCorruptKeysQuery),
+			QueryType: c.StringPtr(queryType),
 			QueryArgs: queryArgsBytes,
 		},
 	})
 	if err != nil {
-		return nil, err
+		return err
 	}
-	queryResult := &ShardCorruptKeysQueryResult{}
-	if err := json.Unmarshal(queryResp.QueryResult, &queryResult); err != nil {
-		return nil, cadence.NewCustomError(ErrSerialization)
+	if err := json.Unmarshal(queryResp.QueryResult, queryResult); err != nil {
+		return cadence.NewCustomError(ErrSerialization)
 	}
-	var corrupted []CorruptedKeysEntry
+	return nil
+}
+
+// scanReportExportActivity aggregates the reports of a completed scan workflow into a single JSON
+// document and writes it to the blobstore under the given key, so the results can be inspected
+// without querying the scanner workflow. It is scheduled by the fixer workflow when
+// FixerWorkflowParams.ScanReportBlobstoreKey is set.
+func scanReportExportActivity(
+	activityCtx context.Context,
+	params ScanReportExportActivityParams,
+) error {
+	ctx, err := GetFixerContext(activityCtx)
+	if err != nil {
+		return err
+	}
+	if params.BlobstoreKey == "" {
+		return errors.New("blobstore key is required to export scan report")
+	}
+
+	client := ctx.Resource.GetSDKClient()
+	if params.ScannerWorkflowRunID == "" {
+		params.ScannerWorkflowRunID, err = findScannerRunID(activityCtx, client, params.ScannerWorkflowWorkflowID, params.AcceptedCloseStatuses)
+		if err != nil {
+			return err
+		}
+	}
+	var aggregate AggregateScanReportResult
+	if err := queryClosedScanner(
+		activityCtx,
+		client,
+		params.ScannerWorkflowWorkflowID,
+		params.ScannerWorkflowRunID,
+		AggregateReportQuery,
+		nil,
+		&aggregate,
+	); err != nil {
+		return err
+	}
+
+	report := ScanJSONReport{
+		ScannerWorkflowWorkflowID: params.ScannerWorkflowWorkflowID,
+		ScannerWorkflowRunID:      params.ScannerWorkflowRunID,
+		EntitiesCount:             aggregate.EntitiesCount,
+		CorruptedCount:            aggregate.CorruptedCount,
+		CheckFailedCount:          aggregate.CheckFailedCount,
+		CorruptionByType:          aggregate.CorruptionByType,
+	}
+	var startingShardID *int
+	for {
+		queryResult, err := queryCorruptedKeys(activityCtx, client, params.ScannerWorkflowWorkflowID, params.ScannerWorkflowRunID, startingShardID)
+		if err != nil {
+			return err
+		}
+		shardIDs := make([]int, 0, len(queryResult.Result))
+		for sid := range queryResult.Result {
+			shardIDs = append(shardIDs, sid)
+		}
+		sort.Ints(shardIDs)
+		for _, sid := range shardIDs {
+			report.AffectedShards = append(report.AffectedShards, sid)
+			if len(report.SampleCorruptedKeys) < maxSampleCorruptedKeys {
+				report.SampleCorruptedKeys = append(report.SampleCorruptedKeys, CorruptedKeysEntry{
+					ShardID:       sid,
+					CorruptedKeys: queryResult.Result[sid],
+				})
+			}
+		}
+		startingShardID = queryResult.ShardQueryPaginationToken.NextShardID
+		if queryResult.ShardQueryPaginationToken.IsDone || startingShardID == nil {
+			break
+		}
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return cadence.NewCustomError(ErrSerialization)
+	}
+	_, err = ctx.Resource.GetBlobstoreClient().Put(activityCtx, &blobstore.PutRequest{
+		Key: params.BlobstoreKey,
+		Blob: blobstore.Blob{
+			Body: data,
+		},
+	})
+	return err
+}
+
+func newFixerCorruptedKeysActivityResult(
+	corrupted []CorruptedKeysEntry,
+	paginationToken ShardQueryPaginationToken,
+) *FixerCorruptedKeysActivityResult {
 	var minShardID *int
 	var maxShardID *int
-	for sid, keys := range queryResult.Result {
-		if minShardID == nil || *minShardID > sid {
-			minShardID = c.IntPtr(sid)
+	for _, entry := range corrupted {
+		if minShardID == nil || *minShardID > entry.ShardID {
+			minShardID = c.IntPtr(entry.ShardID)
 		}
-		if maxShardID == nil || *maxShardID < sid {
-			maxShardID = c.IntPtr(sid)
+		if maxShardID == nil || *maxShardID < entry.ShardID {
+			maxShardID = c.IntPtr(entry.ShardID)
 		}
-		corrupted = append(corrupted, CorruptedKeysEntry{
-			ShardID:       sid,
-			CorruptedKeys: keys,
-		})
 	}
 	return &FixerCorruptedKeysActivityResult{
 		CorruptedKeys:             corrupted,
 		MinShard:                  minShardID,
 		MaxShard:                  maxShardID,
-		ShardQueryPaginationToken: queryResult.ShardQueryPaginationToken,
-	}, nil
+		ShardQueryPaginationToken: paginationToken,
+	}
 }
 
 type (
@@ -351,7 +639,7 @@ func fixShardActivity(
 	}
 	for i := heartbeatDetails.LastShardIndexHandled + 1; i < len(params.CorruptedKeysEntries); i++ {
 		currentShardID := params.CorruptedKeysEntries[i].ShardID
-		currentKeys := params.CorruptedKeysEntries[i].CorruptedKeys
+		currentKeys := append([]store.Keys{params.CorruptedKeysEntries[i].CorruptedKeys}, params.CorruptedKeysEntries[i].MergedCorruptedKeys...)
 		shardReport, err := fixShard(activityCtx, params, currentShardID, currentKeys, heartbeatDetails)
 		if err != nil {
 			ctx.Logger.Error("fixing shard", tag.Error(err))
@@ -359,6 +647,7 @@ func fixShardActivity(
 		}
 		heartbeatDetails = FixShardHeartbeatDetails{
 			LastShardIndexHandled: i,
+			CurrentShardProgress:  FixProgress{},
 			Reports:               append(heartbeatDetails.Reports, *shardReport),
 		}
 	}
@@ -369,7 +658,7 @@ func fixShard(
 	activityCtx context.Context,
 	params FixShardActivityParams,
 	shardID int,
-	corruptedKeys store.Keys,
+	corruptedKeys []store.Keys,
 	heartbeatDetails FixShardHeartbeatDetails,
 ) (*FixReport, error) {
 	ctx, err := GetFixerContext(activityCtx)
@@ -396,27 +685,139 @@ func fixShard(
 		return nil, err
 	}
 
-	pr := persistence.NewPersistenceRetryer(execManager, resource.GetHistoryManager(), c.CreatePersistenceRetryPolicy())
+	var pr persistence.Retryer = persistence.NewPersistenceRetryer(execManager, resource.GetHistoryManager(), c.CreatePersistenceRetryPolicy())
+	if params.DryRun {
+		pr = dryRunRetryer{retryer: pr}
+	}
+
+	iterators := make([]store.ScanOutputIterator, 0, len(corruptedKeys))
+	for _, keys := range corruptedKeys {
+		iterators = append(iterators, ctx.Hooks.Iterator(activityCtx, resource.GetBlobstoreClient(), keys, params))
+	}
 
 	fixer := NewFixer(
 		activityCtx,
 		shardID,
 		ctx.Hooks.InvariantManager(activityCtx, pr, params, resource.GetDomainCache()),
-		ctx.Hooks.Iterator(activityCtx, resource.GetBlobstoreClient(), corruptedKeys, params),
+		&concatScanOutputIterator{iterators: iterators},
+		heartbeatDetails.CurrentShardProgress,
 		resource.GetBlobstoreClient(),
 		params.ResolvedFixerWorkflowConfig.BlobstoreFlushThreshold,
-		func() { activity.RecordHeartbeat(activityCtx, heartbeatDetails) },
+		func(progress FixProgress) {
+			heartbeatDetails.CurrentShardProgress = progress
+			activity.RecordHeartbeat(activityCtx, heartbeatDetails)
+		},
 		resource.GetDomainCache(),
 		ctx.Config.DynamicParams.AllowDomain,
 		scope,
 	)
 	report := fixer.Fix()
+	report.DryRun = params.DryRun
 	if report.Result.ControlFlowFailure != nil {
 		scope.IncCounter(metrics.CadenceFailures)
 	}
 	return &report, nil
 }
 
+// concatScanOutputIterator iterates over the entities of several iterators one after another.
+// It must hold at least one iterator.
+type concatScanOutputIterator struct {
+	iterators []store.ScanOutputIterator
+}
+
+func (i *concatScanOutputIterator) Next() (*store.ScanOutputEntity, error) {
+	i.HasNext()
+	return i.iterators[0].Next()
+}
+
+func (i *concatScanOutputIterator) HasNext() bool {
+	for len(i.iterators) > 1 && !i.iterators[0].HasNext() {
+		i.iterators = i.iterators[1:]
+	}
+	return i.iterators[0].HasNext()
+}
+
+// dryRunRetryer passes reads through to the wrapped Retryer and drops every write,
+// so invariants still report what they would fix without touching persistence.
+// It does not embed the Retryer, so a new method has to be classified here before it compiles.
+type dryRunRetryer struct {
+	retryer persistence.Retryer
+}
+
+var _ persistence.Retryer = dryRunRetryer{}
+
+func (r dryRunRetryer) ListConcreteExecutions(
+	ctx context.Context,
+	request *persistence.ListConcreteExecutionsRequest,
+) (*persistence.ListConcreteExecutionsResponse, error) {
+	return r.retryer.ListConcreteExecutions(ctx, request)
+}
+
+func (r dryRunRetryer) ListCurrentExecutions(
+	ctx context.Context,
+	request *persistence.ListCurrentExecutionsRequest,
+) (*persistence.ListCurrentExecutionsResponse, error) {
+	return r.retryer.ListCurrentExecutions(ctx, request)
+}
+
+func (r dryRunRetryer) GetWorkflowExecution(
+	ctx context.Context,
+	request *persistence.GetWorkflowExecutionRequest,
+) (*persistence.GetWorkflowExecutionResponse, error) {
+	return r.retryer.GetWorkflowExecution(ctx, request)
+}
+
+func (r dryRunRetryer) GetCurrentExecution(
+	ctx context.Context,
+	request *persistence.GetCurrentExecutionRequest,
+) (*persistence.GetCurrentExecutionResponse, error) {
+	return r.retryer.GetCurrentExecution(ctx, request)
+}
+
+func (r dryRunRetryer) IsWorkflowExecutionExists(
+	ctx context.Context,
+	request *persistence.IsWorkflowExecutionExistsRequest,
+) (*persistence.IsWorkflowExecutionExistsResponse, error) {
+	return r.retryer.IsWorkflowExecutionExists(ctx, request)
+}
+
+func (r dryRunRetryer) ReadHistoryBranch(
+	ctx context.Context,
+	request *persistence.ReadHistoryBranchRequest,
+) (*persistence.ReadHistoryBranchResponse, error) {
+	return r.retryer.ReadHistoryBranch(ctx, request)
+}
+
+func (r dryRunRetryer) GetHistoryTree(
+	ctx context.Context,
+	request *persistence.GetHistoryTreeRequest,
+) (*persistence.GetHistoryTreeResponse, error) {
+	return r.retryer.GetHistoryTree(ctx, request)
+}
+
+func (r dryRunRetryer) GetShardID() int {
+	return r.retryer.GetShardID()
+}
+
+func (r dryRunRetryer) GetTimerIndexTasks(
+	ctx context.Context,
+	request *persistence.GetTimerIndexTasksRequest,
+) (*persistence.GetTimerIndexTasksResponse, error) {
+	return r.retryer.GetTimerIndexTasks(ctx, request)
+}
+
+func (dryRunRetryer) DeleteWorkflowExecution(context.Context, *persistence.DeleteWorkflowExecutionRequest) error {
+	return nil
+}
+
+func (dryRunRetryer) DeleteCurrentWorkflowExecution(context.Context, *persistence.DeleteCurrentWorkflowExecutionRequest) error {
+	return nil
+}
+
+func (dryRunRetryer) CompleteTimerTask(context.Context, *persistence.CompleteTimerTaskRequest) error {
+	return nil
+}
+
 // scannerEmitMetricsActivity will emit metrics for a complete run of ShardScanner
 func scannerEmitMetricsActivity(
 	activityCtx context.Context,
@@ -450,5 +851,9 @@ func scannerEmitMetricsActivity(
 	scope.UpdateGauge(metrics.ScannerShardSizeSeventyFiveGauge, float64(shardStats.P75))
 	scope.UpdateGauge(metrics.ScannerShardSizeTwentyFiveGauge, float64(shardStats.P25))
 	scope.UpdateGauge(metrics.ScannerShardSizeTenGauge, float64(shardStats.P10))
+	durationStats := params.ShardScanDurationStats
+	scope.UpdateGauge(metrics.ScannerShardScanDurationMinGauge, durationStats.Min.Seconds())
+	scope.UpdateGauge(metrics.ScannerShardScanDurationMedianGauge, durationStats.Median.Seconds())
+	scope.UpdateGauge(metrics.ScannerShardScanDurationNinetyGauge, durationStats.P90.Seconds())
 	return nil
 }
@@ -25,6 +25,9 @@ package shardscanner
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -164,6 +167,161 @@ func (s *activitiesSuite) TestScanShardActivity() {
 	}
 }
 
+func (s *activitiesSuite) TestScanShardActivity_Concurrency() {
+	shards := []int{3, 1, 2, 0}
+
+	var started sync.WaitGroup
+	started.Add(len(shards))
+	allStarted := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(allStarted)
+	}()
+
+	managerHook := func(ctx context.Context, pr persistence.Retryer, params ScanShardActivityParams, cache cache.DomainCache) invariant.Manager {
+		return invariant.NewMockManager(s.controller)
+	}
+	itHook := func(ctx context.Context, pr persistence.Retryer, params ScanShardActivityParams) pagination.Iterator {
+		// every shard must be in flight at the same time to get past this point
+		started.Done()
+		select {
+		case <-allStarted:
+		case <-time.After(5 * time.Second):
+			s.Fail("shards were not scanned in parallel")
+		}
+		it := pagination.NewMockIterator(s.controller)
+		it.EXPECT().HasNext().Return(false).AnyTimes()
+		return it
+	}
+
+	env := s.NewTestActivityEnvironment()
+	hooks, _ := NewScannerHooks(managerHook, itHook, func(scanner ScannerContext) CustomScannerConfig {
+		return nil // no config overrides
+	})
+	sc := NewShardScannerContext(s.mockResource, &ScannerConfig{
+		ScannerHooks: func() *ScannerHooks { return hooks },
+	})
+	env.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: NewScannerContext(context.Background(), testWorkflowName, sc),
+	})
+
+	result, err := env.ExecuteActivity(scanShardActivity, ScanShardActivityParams{
+		Shards:      shards,
+		Concurrency: len(shards),
+	})
+	s.NoError(err)
+	var reports []ScanReport
+	s.NoError(result.Get(&reports))
+	s.Len(reports, len(shards))
+	for i, report := range reports {
+		s.Equal(shards[i], report.ShardID)
+	}
+}
+
+func (s *activitiesSuite) TestScanShardActivity_RecordsScanDuration() {
+	const scanTime = 10 * time.Millisecond
+	managerHook := func(ctx context.Context, pr persistence.Retryer, params ScanShardActivityParams, cache cache.DomainCache) invariant.Manager {
+		return invariant.NewMockManager(s.controller)
+	}
+	itHook := func(ctx context.Context, pr persistence.Retryer, params ScanShardActivityParams) pagination.Iterator {
+		it := pagination.NewMockIterator(s.controller)
+		it.EXPECT().HasNext().DoAndReturn(func() bool {
+			time.Sleep(scanTime)
+			return false
+		}).AnyTimes()
+		return it
+	}
+
+	env := s.NewTestActivityEnvironment()
+	hooks, _ := NewScannerHooks(managerHook, itHook, func(scanner ScannerContext) CustomScannerConfig {
+		return nil // no config overrides
+	})
+	sc := NewShardScannerContext(s.mockResource, &ScannerConfig{
+		ScannerHooks: func() *ScannerHooks { return hooks },
+	})
+	env.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: NewScannerContext(context.Background(), testWorkflowName, sc),
+	})
+
+	result, err := env.ExecuteActivity(scanShardActivity, ScanShardActivityParams{
+		Shards: []int{0},
+	})
+	s.NoError(err)
+	var reports []ScanReport
+	s.NoError(result.Get(&reports))
+	s.Len(reports, 1)
+	s.GreaterOrEqual(reports[0].ScanDuration, scanTime)
+
+	recorded := false
+	for _, h := range s.mockResource.MetricsScope.Snapshot().Histograms() {
+		if h.Name() != "test.scanner_shard_scan_duration" {
+			continue
+		}
+		recorded = true
+		s.Equal("lt_1k", h.Tags()["shardscanner_shard_size_bucket"])
+		var samples int64
+		for _, count := range h.Durations() {
+			samples += count
+		}
+		s.Equal(int64(1), samples)
+	}
+	s.True(recorded)
+}
+
+func (s *activitiesSuite) TestScanShardActivity_BlobstoreWriteBudget() {
+	managerHook := func(ctx context.Context, pr persistence.Retryer, params ScanShardActivityParams, cache cache.DomainCache) invariant.Manager {
+		manager := invariant.NewMockManager(s.controller)
+		manager.EXPECT().RunChecks(gomock.Any(), gomock.Any()).Return(invariant.ManagerCheckResult{
+			CheckResultType:          invariant.CheckResultTypeCorrupted,
+			DeterminingInvariantType: invariant.NamePtr(invariant.HistoryExists),
+		}).AnyTimes()
+		return manager
+	}
+	itHook := func(ctx context.Context, pr persistence.Retryer, params ScanShardActivityParams) pagination.Iterator {
+		it := pagination.NewMockIterator(s.controller)
+		it.EXPECT().HasNext().Return(true).AnyTimes()
+		it.EXPECT().Next().Return(&entity.ConcreteExecution{
+			Execution: entity.Execution{DomainID: "test_domain"},
+		}, nil).AnyTimes()
+		return it
+	}
+	domainCache := cache.NewMockDomainCache(s.controller)
+	domainCache.EXPECT().GetDomainName(gomock.Any()).Return("test-domain", nil).AnyTimes()
+	s.mockResource.DomainCache = domainCache
+
+	env := s.NewTestActivityEnvironment()
+	hooks, _ := NewScannerHooks(managerHook, itHook, func(scanner ScannerContext) CustomScannerConfig {
+		return nil // no config overrides
+	})
+	sc := NewShardScannerContext(s.mockResource, &ScannerConfig{
+		ScannerHooks: func() *ScannerHooks { return hooks },
+	})
+	env.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: NewScannerContext(context.Background(), testWorkflowName, sc),
+	})
+
+	result, err := env.ExecuteActivity(scanShardActivity, ScanShardActivityParams{
+		Shards:                    []int{0, 1},
+		BlobstoreFlushThreshold:   1,
+		BlobstoreWriteBudgetBytes: common.Int64Ptr(1),
+	})
+	s.NoError(err)
+	var reports []ScanReport
+	s.NoError(result.Get(&reports))
+	s.Len(reports, 2)
+	// first corrupted entity does not fit into the budget, so nothing is flushed
+	s.Equal(&ControlFlowFailure{
+		Info:        "blobstore add failed for corrupted execution check",
+		InfoDetails: store.ErrWriteBudgetExceeded.Error(),
+	}, reports[0].Result.ControlFlowFailure)
+	s.Zero(reports[0].BlobstoreBytesWritten)
+	s.Equal(&ControlFlowFailure{
+		Info:        "shard skipped",
+		InfoDetails: store.ErrWriteBudgetExceeded.Error(),
+	}, reports[1].Result.ControlFlowFailure)
+	s.mockResource.BlobstoreClient.AssertNotCalled(s.T(), "Put", mock.Anything, mock.Anything)
+}
+
 func (s *activitiesSuite) TestFixShardActivity() {
 
 	testCases := []struct {
@@ -260,6 +418,236 @@ func (s *activitiesSuite) TestFixShardActivity() {
 
 }
 
+func (s *activitiesSuite) TestConcatScanOutputIterator() {
+	newIterator := func(domainIDs ...string) store.ScanOutputIterator {
+		it := store.NewMockScanOutputIterator(s.controller)
+		it.EXPECT().HasNext().DoAndReturn(func() bool { return len(domainIDs) > 0 }).AnyTimes()
+		it.EXPECT().Next().DoAndReturn(func() (*store.ScanOutputEntity, error) {
+			if len(domainIDs) == 0 {
+				return nil, pagination.ErrIteratorFinished
+			}
+			domainID := domainIDs[0]
+			domainIDs = domainIDs[1:]
+			return &store.ScanOutputEntity{
+				Execution: &entity.ConcreteExecution{Execution: entity.Execution{DomainID: domainID}},
+			}, nil
+		}).AnyTimes()
+		return it
+	}
+
+	it := &concatScanOutputIterator{iterators: []store.ScanOutputIterator{
+		newIterator("d1", "d2"),
+		newIterator(),
+		newIterator("d3"),
+	}}
+	var domainIDs []string
+	for it.HasNext() {
+		soe, err := it.Next()
+		s.NoError(err)
+		domainIDs = append(domainIDs, soe.Execution.(*entity.ConcreteExecution).DomainID)
+	}
+	s.Equal([]string{"d1", "d2", "d3"}, domainIDs)
+	_, err := it.Next()
+	s.ErrorIs(err, pagination.ErrIteratorFinished)
+}
+
+func (s *activitiesSuite) TestDryRunRetryer() {
+	// every Retryer method has to be classified, a method which is not would reach persistence in a dry run
+	isWrite := map[string]bool{
+		"ListConcreteExecutions":         false,
+		"ListCurrentExecutions":          false,
+		"GetWorkflowExecution":           false,
+		"GetCurrentExecution":            false,
+		"IsWorkflowExecutionExists":      false,
+		"ReadHistoryBranch":              false,
+		"GetHistoryTree":                 false,
+		"GetShardID":                     false,
+		"GetTimerIndexTasks":             false,
+		"DeleteWorkflowExecution":        true,
+		"DeleteCurrentWorkflowExecution": true,
+		"CompleteTimerTask":              true,
+	}
+	retryer := persistence.NewMockRetryer(s.controller)
+	recorder := reflect.ValueOf(retryer.EXPECT())
+	dryRun := reflect.ValueOf(dryRunRetryer{retryer: retryer})
+
+	retryerType := reflect.TypeOf((*persistence.Retryer)(nil)).Elem()
+	for i := 0; i < retryerType.NumMethod(); i++ {
+		method := retryerType.Method(i)
+		write, ok := isWrite[method.Name]
+		s.True(ok, "Retryer method %v is not classified as a read or a write", method.Name)
+
+		args := make([]reflect.Value, method.Type.NumIn())
+		matchers := make([]reflect.Value, method.Type.NumIn())
+		for j := range args {
+			args[j] = reflect.Zero(method.Type.In(j))
+			matchers[j] = reflect.ValueOf(gomock.Any())
+		}
+		if !write {
+			// reads are passed through, writes fail the test if they reach the mock
+			recorder.MethodByName(method.Name).Call(matchers)[0].Interface().(*gomock.Call).Times(1)
+		}
+		dryRun.MethodByName(method.Name).Call(args)
+	}
+}
+
+func (s *activitiesSuite) TestFixShardActivity_ResumesFromHeartbeatOffset() {
+	s.mockResource.BlobstoreClient.
+		Mock.On("Put", mock.Anything, mock.Anything).
+		Return(&blobstore.PutResponse{}, nil)
+	domainCache := cache.NewMockDomainCache(s.controller)
+	domainCache.EXPECT().GetDomainName(gomock.Any()).Return("test-domain", nil).AnyTimes()
+	s.mockResource.DomainCache = domainCache
+
+	var fixed []string
+	cfg := &ScannerConfig{
+		DynamicParams: DynamicParams{
+			AllowDomain: dynamicconfig.GetBoolPropertyFnFilteredByDomain(true),
+		},
+		FixerHooks: func() *FixerHooks {
+			return &FixerHooks{
+				InvariantManager: func(ctx context.Context, pr persistence.Retryer, p FixShardActivityParams, cache cache.DomainCache) invariant.Manager {
+					manager := invariant.NewMockManager(s.controller)
+					manager.EXPECT().RunFixes(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, e interface{}) invariant.ManagerFixResult {
+							fixed = append(fixed, e.(*entity.ConcreteExecution).WorkflowID)
+							return invariant.ManagerFixResult{FixResultType: invariant.FixResultTypeFixed}
+						},
+					).AnyTimes()
+					return manager
+				},
+				Iterator: func(ctx context.Context, client blobstore.Client, k store.Keys, params FixShardActivityParams) store.ScanOutputIterator {
+					it := store.NewMockScanOutputIterator(s.controller)
+					calls := 0
+					it.EXPECT().HasNext().DoAndReturn(func() bool { return calls < 5 }).AnyTimes()
+					it.EXPECT().Next().DoAndReturn(func() (*store.ScanOutputEntity, error) {
+						defer func() { calls++ }()
+						return &store.ScanOutputEntity{
+							Execution: &entity.ConcreteExecution{
+								Execution: entity.Execution{
+									DomainID:   "test_domain",
+									WorkflowID: fmt.Sprintf("%v-%v", k.UUID, calls),
+								},
+							},
+						}, nil
+					}).AnyTimes()
+					return it
+				},
+			}
+		},
+	}
+	fc := NewShardFixerContext(s.mockResource, cfg)
+	env := s.NewTestActivityEnvironment()
+	env.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: NewFixerContext(context.Background(), testWorkflowName, fc),
+	})
+	// previous attempt failed after handling the first three keys of the first shard
+	env.SetHeartbeatDetails(FixShardHeartbeatDetails{
+		LastShardIndexHandled: -1,
+		CurrentShardProgress: FixProgress{
+			Offset:      3,
+			Stats:       FixStats{EntitiesCount: 3, FixedCount: 3},
+			DomainStats: map[string]*FixStats{"test_domain": {EntitiesCount: 3, FixedCount: 3}},
+		},
+	})
+
+	report, err := env.ExecuteActivity(fixShardActivity, FixShardActivityParams{
+		CorruptedKeysEntries: []CorruptedKeysEntry{
+			{ShardID: 1, CorruptedKeys: store.Keys{UUID: "first"}},
+			{ShardID: 2, CorruptedKeys: store.Keys{UUID: "second"}},
+		},
+	})
+	s.NoError(err)
+	var reports []FixReport
+	s.NoError(report.Get(&reports))
+	s.Len(reports, 2)
+	s.Equal(FixStats{EntitiesCount: 5, FixedCount: 5}, reports[0].Stats)
+	s.Equal(map[string]*FixStats{"test_domain": {EntitiesCount: 5, FixedCount: 5}}, reports[0].DomainStats)
+	s.Equal(FixStats{EntitiesCount: 5, FixedCount: 5}, reports[1].Stats)
+	s.Equal([]string{
+		"first-3", "first-4",
+		"second-0", "second-1", "second-2", "second-3", "second-4",
+	}, fixed)
+}
+
+func (s *activitiesSuite) TestFixShardActivity_DryRun() {
+	s.mockResource.BlobstoreClient.
+		Mock.On("Put", mock.Anything, mock.Anything).
+		Return(&blobstore.PutResponse{}, nil)
+	domainCache := cache.NewMockDomainCache(s.controller)
+	domainCache.EXPECT().GetDomainName(gomock.Any()).Return("test-domain", nil).AnyTimes()
+	s.mockResource.DomainCache = domainCache
+
+	cfg := &ScannerConfig{
+		DynamicParams: DynamicParams{
+			AllowDomain: dynamicconfig.GetBoolPropertyFnFilteredByDomain(true),
+		},
+		FixerHooks: func() *FixerHooks {
+			return &FixerHooks{
+				InvariantManager: func(ctx context.Context, pr persistence.Retryer, p FixShardActivityParams, cache cache.DomainCache) invariant.Manager {
+					manager := invariant.NewMockManager(s.controller)
+					manager.EXPECT().RunFixes(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, e interface{}) invariant.ManagerFixResult {
+							execution := e.(*entity.ConcreteExecution)
+							s.NoError(pr.DeleteCurrentWorkflowExecution(ctx, &persistence.DeleteCurrentWorkflowExecutionRequest{
+								DomainID:   execution.DomainID,
+								WorkflowID: execution.WorkflowID,
+								RunID:      execution.RunID,
+							}))
+							s.NoError(pr.DeleteWorkflowExecution(ctx, &persistence.DeleteWorkflowExecutionRequest{
+								DomainID:   execution.DomainID,
+								WorkflowID: execution.WorkflowID,
+								RunID:      execution.RunID,
+							}))
+							return invariant.ManagerFixResult{FixResultType: invariant.FixResultTypeFixed}
+						},
+					).Times(3)
+					return manager
+				},
+				Iterator: func(ctx context.Context, client blobstore.Client, k store.Keys, params FixShardActivityParams) store.ScanOutputIterator {
+					it := store.NewMockScanOutputIterator(s.controller)
+					calls := 0
+					it.EXPECT().HasNext().DoAndReturn(func() bool { return calls < 3 }).AnyTimes()
+					it.EXPECT().Next().DoAndReturn(func() (*store.ScanOutputEntity, error) {
+						defer func() { calls++ }()
+						return &store.ScanOutputEntity{
+							Execution: &entity.ConcreteExecution{
+								Execution: entity.Execution{
+									DomainID:   "test_domain",
+									WorkflowID: fmt.Sprintf("wid-%v", calls),
+									RunID:      fmt.Sprintf("rid-%v", calls),
+								},
+							},
+						}, nil
+					}).AnyTimes()
+					return it
+				},
+			}
+		},
+	}
+	fc := NewShardFixerContext(s.mockResource, cfg)
+	env := s.NewTestActivityEnvironment()
+	env.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: NewFixerContext(context.Background(), testWorkflowName, fc),
+	})
+
+	report, err := env.ExecuteActivity(fixShardActivity, FixShardActivityParams{
+		CorruptedKeysEntries: []CorruptedKeysEntry{
+			{ShardID: 1, CorruptedKeys: store.Keys{UUID: "first"}},
+		},
+		DryRun: true,
+	})
+	s.NoError(err)
+	var reports []FixReport
+	s.NoError(report.Get(&reports))
+	s.Len(reports, 1)
+	s.True(reports[0].DryRun)
+	s.Equal(int64(3), reports[0].Stats.EntitiesCount)
+	s.Equal(int64(3), reports[0].Stats.FixedCount)
+	s.mockResource.ExecutionMgr.AssertNotCalled(s.T(), "DeleteWorkflowExecution", mock.Anything, mock.Anything)
+	s.mockResource.ExecutionMgr.AssertNotCalled(s.T(), "DeleteCurrentWorkflowExecution", mock.Anything, mock.Anything)
+}
+
 func (s *activitiesSuite) TestScannerConfigActivity() {
 	testCases := []struct {
 		dynamicParams *DynamicParams
@@ -269,11 +657,13 @@ func (s *activitiesSuite) TestScannerConfigActivity() {
 	}{
 		{
 			dynamicParams: &DynamicParams{
-				ScannerEnabled:          dynamicconfig.GetBoolPropertyFn(true),
-				Concurrency:             dynamicconfig.GetIntPropertyFn(10),
-				PageSize:                dynamicconfig.GetIntPropertyFn(100),
-				ActivityBatchSize:       dynamicconfig.GetIntPropertyFn(10),
-				BlobstoreFlushThreshold: dynamicconfig.GetIntPropertyFn(1000),
+				ScannerEnabled:            dynamicconfig.GetBoolPropertyFn(true),
+				Concurrency:               dynamicconfig.GetIntPropertyFn(10),
+				PageSize:                  dynamicconfig.GetIntPropertyFn(100),
+				ActivityBatchSize:         dynamicconfig.GetIntPropertyFn(10),
+				BlobstoreFlushThreshold:   dynamicconfig.GetIntPropertyFn(1000),
+				BlobstoreWriteBudgetBytes: dynamicconfig.GetIntPropertyFn(0),
+				ShardConcurrency:          dynamicconfig.GetIntPropertyFn(1),
 			},
 			params: ScannerConfigActivityParams{
 				Overwrites: ScannerWorkflowConfigOverwrites{},
@@ -286,6 +676,7 @@ func (s *activitiesSuite) TestScannerConfigActivity() {
 					ActivityBatchSize:       10,
 					PageSize:                100,
 					BlobstoreFlushThreshold: 1000,
+					ShardConcurrency:        1,
 				},
 				CustomScannerConfig: CustomScannerConfig{
 					"test-key": "test-value",
@@ -294,11 +685,13 @@ func (s *activitiesSuite) TestScannerConfigActivity() {
 		},
 		{
 			dynamicParams: &DynamicParams{
-				ScannerEnabled:          dynamicconfig.GetBoolPropertyFn(true),
-				Concurrency:             dynamicconfig.GetIntPropertyFn(10),
-				PageSize:                dynamicconfig.GetIntPropertyFn(100),
-				ActivityBatchSize:       dynamicconfig.GetIntPropertyFn(10),
-				BlobstoreFlushThreshold: dynamicconfig.GetIntPropertyFn(1000),
+				ScannerEnabled:            dynamicconfig.GetBoolPropertyFn(true),
+				Concurrency:               dynamicconfig.GetIntPropertyFn(10),
+				PageSize:                  dynamicconfig.GetIntPropertyFn(100),
+				ActivityBatchSize:         dynamicconfig.GetIntPropertyFn(10),
+				BlobstoreFlushThreshold:   dynamicconfig.GetIntPropertyFn(1000),
+				BlobstoreWriteBudgetBytes: dynamicconfig.GetIntPropertyFn(0),
+				ShardConcurrency:          dynamicconfig.GetIntPropertyFn(1),
 			},
 			params: ScannerConfigActivityParams{
 				Overwrites: ScannerWorkflowConfigOverwrites{},
@@ -310,23 +703,28 @@ func (s *activitiesSuite) TestScannerConfigActivity() {
 					ActivityBatchSize:       10,
 					PageSize:                100,
 					BlobstoreFlushThreshold: 1000,
+					ShardConcurrency:        1,
 				},
 			},
 		},
 		{
 			dynamicParams: &DynamicParams{
-				ScannerEnabled:          dynamicconfig.GetBoolPropertyFn(true),
-				Concurrency:             dynamicconfig.GetIntPropertyFn(10),
-				ActivityBatchSize:       dynamicconfig.GetIntPropertyFn(100),
-				PageSize:                dynamicconfig.GetIntPropertyFn(100),
-				BlobstoreFlushThreshold: dynamicconfig.GetIntPropertyFn(1000),
+				ScannerEnabled:            dynamicconfig.GetBoolPropertyFn(true),
+				Concurrency:               dynamicconfig.GetIntPropertyFn(10),
+				ActivityBatchSize:         dynamicconfig.GetIntPropertyFn(100),
+				PageSize:                  dynamicconfig.GetIntPropertyFn(100),
+				BlobstoreFlushThreshold:   dynamicconfig.GetIntPropertyFn(1000),
+				BlobstoreWriteBudgetBytes: dynamicconfig.GetIntPropertyFn(0),
+				ShardConcurrency:          dynamicconfig.GetIntPropertyFn(1),
 			},
 			params: ScannerConfigActivityParams{
 				Overwrites: ScannerWorkflowConfigOverwrites{
 					GenericScannerConfig: GenericScannerConfigOverwrites{
-						Enabled:                 common.BoolPtr(false),
-						ActivityBatchSize:       common.IntPtr(1),
-						BlobstoreFlushThreshold: common.IntPtr(100),
+						Enabled:                   common.BoolPtr(false),
+						ActivityBatchSize:         common.IntPtr(1),
+						BlobstoreFlushThreshold:   common.IntPtr(100),
+						BlobstoreWriteBudgetBytes: common.IntPtr(1 << 20),
+						ShardConcurrency:          common.IntPtr(4),
 					},
 					CustomScannerConfig: &CustomScannerConfig{
 						"test": "test",
@@ -335,11 +733,13 @@ func (s *activitiesSuite) TestScannerConfigActivity() {
 			},
 			resolved: ResolvedScannerWorkflowConfig{
 				GenericScannerConfig: GenericScannerConfig{
-					Enabled:                 false,
-					Concurrency:             10,
-					ActivityBatchSize:       1,
-					PageSize:                100,
-					BlobstoreFlushThreshold: 100,
+					Enabled:                   false,
+					Concurrency:               10,
+					ActivityBatchSize:         1,
+					PageSize:                  100,
+					BlobstoreFlushThreshold:   100,
+					BlobstoreWriteBudgetBytes: 1 << 20,
+					ShardConcurrency:          4,
 				},
 				CustomScannerConfig: CustomScannerConfig{
 					"test": "test",
@@ -459,6 +859,66 @@ func (s *activitiesSuite) TestFixerCorruptedKeysActivity() {
 	})
 }
 
+func (s *activitiesSuite) TestFixerCorruptedKeysActivity_ExplicitRunIDs() {
+	queryResults := map[string]*ShardCorruptKeysQueryResult{
+		"first-run": {
+			Result: map[int]store.Keys{
+				1: {UUID: "first-run-1"},
+				5: {UUID: "first-run-5"},
+			},
+			ShardQueryPaginationToken: ShardQueryPaginationToken{
+				NextShardID: common.IntPtr(6),
+				IsDone:      false,
+			},
+		},
+		"second-run": {
+			Result: map[int]store.Keys{
+				2: {UUID: "second-run-2"},
+				5: {UUID: "second-run-5"},
+				8: {UUID: "second-run-8"},
+			},
+			ShardQueryPaginationToken: ShardQueryPaginationToken{
+				NextShardID: common.IntPtr(9),
+				IsDone:      false,
+			},
+		},
+	}
+	s.mockResource.SDKClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(&shared.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &shared.WorkflowExecutionInfo{
+			CloseStatus: shared.WorkflowExecutionCloseStatusTerminated.Ptr(),
+		},
+	}, nil).Times(2)
+	s.mockResource.SDKClient.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *shared.QueryWorkflowRequest, _ ...interface{}) (*shared.QueryWorkflowResponse, error) {
+			s.Equal("test-wid", request.Execution.GetWorkflowId())
+			data, err := json.Marshal(queryResults[request.Execution.GetRunId()])
+			s.NoError(err)
+			return &shared.QueryWorkflowResponse{QueryResult: data}, nil
+		}).Times(2)
+
+	env := s.getFixerActivityEnvironment()
+	fixerResultValue, err := env.ExecuteActivity(fixerCorruptedKeysActivity, FixerCorruptedKeysActivityParams{
+		ScannerWorkflowWorkflowID: "test-wid",
+		ScannerWorkflowRunIDs:     []string{"first-run", "second-run"},
+	})
+	s.NoError(err)
+	fixerResult := &FixerCorruptedKeysActivityResult{}
+	s.NoError(fixerResultValue.Get(&fixerResult))
+	s.Equal(1, *fixerResult.MinShard)
+	s.Equal(5, *fixerResult.MaxShard)
+	// shard 8 of the second run is left for the next page, which starts at the first run's next shard
+	s.Equal(ShardQueryPaginationToken{
+		NextShardID: common.IntPtr(6),
+		IsDone:      false,
+	}, fixerResult.ShardQueryPaginationToken)
+	// shard 5 is corrupted in both runs and returned once with the keys of both runs
+	s.Equal([]CorruptedKeysEntry{
+		{ShardID: 1, CorruptedKeys: store.Keys{UUID: "first-run-1"}},
+		{ShardID: 2, CorruptedKeys: store.Keys{UUID: "second-run-2"}},
+		{ShardID: 5, CorruptedKeys: store.Keys{UUID: "first-run-5"}, MergedCorruptedKeys: []store.Keys{{UUID: "second-run-5"}}},
+	}, fixerResult.CorruptedKeys)
+}
+
 func (s *activitiesSuite) TestFixerCorruptedKeysActivity_Fails_WhenNoSuitableExecutionsAreFound() {
 	response := &shared.ListClosedWorkflowExecutionsResponse{
 		Executions: []*shared.WorkflowExecutionInfo{
@@ -484,7 +944,243 @@ func (s *activitiesSuite) TestFixerCorruptedKeysActivity_Fails_WhenNoSuitableExe
 	env := s.getFixerActivityEnvironment()
 	fixerResultValue, err := env.ExecuteActivity(fixerCorruptedKeysActivity, FixerCorruptedKeysActivityParams{})
 	s.Nil(fixerResultValue)
-	s.EqualError(err, "failed to find a recent scanner workflow execution with ContinuedAsNew status")
+	s.EqualError(err, "failed to find a recent scanner workflow execution with close status in [CONTINUED_AS_NEW]")
+}
+
+func (s *activitiesSuite) TestFixerCorruptedKeysActivity_AcceptedCloseStatuses() {
+	completedRunID := uuid.New()
+	s.mockResource.SDKClient.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any(), gomock.Any()).Return(&shared.ListClosedWorkflowExecutionsResponse{
+		Executions: []*shared.WorkflowExecutionInfo{
+			{
+				Execution:   &shared.WorkflowExecution{WorkflowId: common.StringPtr("test-wid"), RunId: common.StringPtr(uuid.New())},
+				CloseStatus: shared.WorkflowExecutionCloseStatusTerminated.Ptr(),
+			},
+			{
+				Execution:   &shared.WorkflowExecution{WorkflowId: common.StringPtr("test-wid"), RunId: common.StringPtr(completedRunID)},
+				CloseStatus: shared.WorkflowExecutionCloseStatusCompleted.Ptr(),
+			},
+		},
+	}, nil)
+	s.mockResource.SDKClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(&shared.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &shared.WorkflowExecutionInfo{
+			CloseStatus: shared.WorkflowExecutionCloseStatusCompleted.Ptr(),
+		},
+	}, nil)
+	queryResultData, err := json.Marshal(&ShardCorruptKeysQueryResult{
+		Result:                    map[int]store.Keys{1: {UUID: "first"}},
+		ShardQueryPaginationToken: ShardQueryPaginationToken{IsDone: true},
+	})
+	s.NoError(err)
+	s.mockResource.SDKClient.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *shared.QueryWorkflowRequest, _ ...interface{}) (*shared.QueryWorkflowResponse, error) {
+			s.Equal(completedRunID, request.Execution.GetRunId())
+			return &shared.QueryWorkflowResponse{QueryResult: queryResultData}, nil
+		})
+
+	env := s.getFixerActivityEnvironment()
+	fixerResultValue, err := env.ExecuteActivity(fixerCorruptedKeysActivity, FixerCorruptedKeysActivityParams{
+		ScannerWorkflowWorkflowID: "test-wid",
+		AcceptedCloseStatuses:     []shared.WorkflowExecutionCloseStatus{shared.WorkflowExecutionCloseStatusCompleted},
+	})
+	s.NoError(err)
+	fixerResult := &FixerCorruptedKeysActivityResult{}
+	s.NoError(fixerResultValue.Get(&fixerResult))
+	s.Equal([]CorruptedKeysEntry{{ShardID: 1, CorruptedKeys: store.Keys{UUID: "first"}}}, fixerResult.CorruptedKeys)
+}
+
+func (s *activitiesSuite) TestFixerCorruptedKeysActivity_AcceptedCloseStatuses_RejectsTerminated() {
+	s.mockResource.SDKClient.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any(), gomock.Any()).Return(&shared.ListClosedWorkflowExecutionsResponse{
+		Executions: []*shared.WorkflowExecutionInfo{
+			{
+				Execution:   &shared.WorkflowExecution{WorkflowId: common.StringPtr("test-wid"), RunId: common.StringPtr(uuid.New())},
+				CloseStatus: shared.WorkflowExecutionCloseStatusTerminated.Ptr(),
+			},
+		},
+	}, nil)
+
+	env := s.getFixerActivityEnvironment()
+	fixerResultValue, err := env.ExecuteActivity(fixerCorruptedKeysActivity, FixerCorruptedKeysActivityParams{
+		ScannerWorkflowWorkflowID: "test-wid",
+		AcceptedCloseStatuses: []shared.WorkflowExecutionCloseStatus{
+			shared.WorkflowExecutionCloseStatusCompleted,
+			shared.WorkflowExecutionCloseStatusContinuedAsNew,
+		},
+	})
+	s.Nil(fixerResultValue)
+	s.EqualError(err, "failed to find a recent scanner workflow execution with close status in [COMPLETED CONTINUED_AS_NEW]")
+}
+
+func (s *activitiesSuite) TestFixerCorruptedKeysActivity_EmptyAcceptedCloseStatuses() {
+	env := s.getFixerActivityEnvironment()
+	fixerResultValue, err := env.ExecuteActivity(fixerCorruptedKeysActivity, FixerCorruptedKeysActivityParams{
+		ScannerWorkflowWorkflowID: "test-wid",
+		AcceptedCloseStatuses:     []shared.WorkflowExecutionCloseStatus{},
+	})
+	s.Nil(fixerResultValue)
+	s.EqualError(err, ErrInvalidAcceptedCloseStatuses)
+}
+
+func (s *activitiesSuite) TestScanReportExportActivity() {
+	s.mockResource.SDKClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(&shared.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &shared.WorkflowExecutionInfo{
+			CloseStatus: shared.WorkflowExecutionCloseStatusContinuedAsNew.Ptr(),
+		},
+	}, nil).Times(3)
+	pages := []*ShardCorruptKeysQueryResult{
+		{
+			Result: map[int]store.Keys{
+				3: {UUID: "third"},
+				1: {UUID: "first"},
+			},
+			ShardQueryPaginationToken: ShardQueryPaginationToken{NextShardID: common.IntPtr(4)},
+		},
+		{
+			Result: map[int]store.Keys{
+				7: {UUID: "seventh", MinPage: 0, MaxPage: 2, Extension: store.CorruptedExtension},
+			},
+			ShardQueryPaginationToken: ShardQueryPaginationToken{IsDone: true},
+		},
+	}
+	s.mockResource.SDKClient.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *shared.QueryWorkflowRequest, _ ...interface{}) (*shared.QueryWorkflowResponse, error) {
+			s.Equal("test-wid", request.Execution.GetWorkflowId())
+			s.Equal("test-rid", request.Execution.GetRunId())
+			var result interface{}
+			switch request.Query.GetQueryType() {
+			case AggregateReportQuery:
+				s.Nil(request.Query.QueryArgs)
+				result = AggregateScanReportResult{
+					EntitiesCount:    100,
+					CorruptedCount:   4,
+					CheckFailedCount: 1,
+					CorruptionByType: map[invariant.Name]int64{
+						invariant.HistoryExists:        3,
+						invariant.OpenCurrentExecution: 1,
+					},
+				}
+			case ShardCorruptKeysQuery:
+				var args PaginatedShardQueryRequest
+				s.NoError(json.Unmarshal(request.Query.QueryArgs, &args))
+				if args.StartingShardID == nil {
+					result = pages[0]
+				} else {
+					s.Equal(4, *args.StartingShardID)
+					result = pages[1]
+				}
+			default:
+				s.Failf("unexpected query", "query type %v", request.Query.GetQueryType())
+			}
+			data, err := json.Marshal(result)
+			s.NoError(err)
+			return &shared.QueryWorkflowResponse{QueryResult: data}, nil
+		}).Times(3)
+
+	var written *blobstore.PutRequest
+	s.mockResource.BlobstoreClient.On("Put", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		written = args.Get(1).(*blobstore.PutRequest)
+	}).Return(&blobstore.PutResponse{}, nil).Once()
+
+	env := s.getFixerActivityEnvironment()
+	_, err := env.ExecuteActivity(scanReportExportActivity, ScanReportExportActivityParams{
+		ScannerWorkflowWorkflowID: "test-wid",
+		ScannerWorkflowRunID:      "test-rid",
+		BlobstoreKey:              "reports/test-rid.json",
+	})
+	s.NoError(err)
+	s.Require().NotNil(written)
+	s.Equal("reports/test-rid.json", written.Key)
+
+	var report map[string]interface{}
+	s.NoError(json.Unmarshal(written.Blob.Body, &report))
+	s.Equal(map[string]interface{}{
+		"ScannerWorkflowWorkflowID": "test-wid",
+		"ScannerWorkflowRunID":      "test-rid",
+		"EntitiesCount":             float64(100),
+		"CorruptedCount":            float64(4),
+		"CheckFailedCount":          float64(1),
+		"CorruptionByType": map[string]interface{}{
+			string(invariant.HistoryExists):        float64(3),
+			string(invariant.OpenCurrentExecution): float64(1),
+		},
+		"AffectedShards": []interface{}{float64(1), float64(3), float64(7)},
+		"SampleCorruptedKeys": []interface{}{
+			map[string]interface{}{
+				"ShardID":       float64(1),
+				"CorruptedKeys": map[string]interface{}{"UUID": "first", "MinPage": float64(0), "MaxPage": float64(0), "Extension": ""},
+			},
+			map[string]interface{}{
+				"ShardID":       float64(3),
+				"CorruptedKeys": map[string]interface{}{"UUID": "third", "MinPage": float64(0), "MaxPage": float64(0), "Extension": ""},
+			},
+			map[string]interface{}{
+				"ShardID":       float64(7),
+				"CorruptedKeys": map[string]interface{}{"UUID": "seventh", "MinPage": float64(0), "MaxPage": float64(2), "Extension": string(store.CorruptedExtension)},
+			},
+		},
+	}, report)
+}
+
+func (s *activitiesSuite) TestScanReportExportActivity_ScanNotClosed() {
+	s.mockResource.SDKClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(&shared.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &shared.WorkflowExecutionInfo{},
+	}, nil)
+
+	env := s.getFixerActivityEnvironment()
+	_, err := env.ExecuteActivity(scanReportExportActivity, ScanReportExportActivityParams{
+		ScannerWorkflowWorkflowID: "test-wid",
+		ScannerWorkflowRunID:      "test-rid",
+		BlobstoreKey:              "reports/test-rid.json",
+	})
+	s.ErrorContains(err, ErrScanWorkflowNotClosed)
+	s.mockResource.BlobstoreClient.AssertNotCalled(s.T(), "Put", mock.Anything, mock.Anything)
+}
+
+func (s *activitiesSuite) TestScanReportExportActivity_FindsScannerRun() {
+	s.mockResource.SDKClient.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any(), gomock.Any()).Return(&shared.ListClosedWorkflowExecutionsResponse{
+		Executions: []*shared.WorkflowExecutionInfo{
+			{
+				Execution:   &shared.WorkflowExecution{WorkflowId: common.StringPtr("test-wid"), RunId: common.StringPtr("terminated-rid")},
+				CloseStatus: shared.WorkflowExecutionCloseStatusTerminated.Ptr(),
+			},
+			{
+				Execution:   &shared.WorkflowExecution{WorkflowId: common.StringPtr("test-wid"), RunId: common.StringPtr("found-rid")},
+				CloseStatus: shared.WorkflowExecutionCloseStatusContinuedAsNew.Ptr(),
+			},
+		},
+	}, nil)
+	s.mockResource.SDKClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(&shared.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &shared.WorkflowExecutionInfo{
+			CloseStatus: shared.WorkflowExecutionCloseStatusContinuedAsNew.Ptr(),
+		},
+	}, nil).Times(2)
+	s.mockResource.SDKClient.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *shared.QueryWorkflowRequest, _ ...interface{}) (*shared.QueryWorkflowResponse, error) {
+			s.Equal("found-rid", request.Execution.GetRunId())
+			var result interface{} = AggregateScanReportResult{EntitiesCount: 10}
+			if request.Query.GetQueryType() == ShardCorruptKeysQuery {
+				result = ShardCorruptKeysQueryResult{ShardQueryPaginationToken: ShardQueryPaginationToken{IsDone: true}}
+			}
+			data, err := json.Marshal(result)
+			s.NoError(err)
+			return &shared.QueryWorkflowResponse{QueryResult: data}, nil
+		}).Times(2)
+
+	var written *blobstore.PutRequest
+	s.mockResource.BlobstoreClient.On("Put", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		written = args.Get(1).(*blobstore.PutRequest)
+	}).Return(&blobstore.PutResponse{}, nil).Once()
+
+	env := s.getFixerActivityEnvironment()
+	_, err := env.ExecuteActivity(scanReportExportActivity, ScanReportExportActivityParams{
+		ScannerWorkflowWorkflowID: "test-wid",
+		BlobstoreKey:              "reports/latest.json",
+	})
+	s.NoError(err)
+	s.Require().NotNil(written)
+	var report ScanJSONReport
+	s.NoError(json.Unmarshal(written.Blob.Body, &report))
+	s.Equal("found-rid", report.ScannerWorkflowRunID)
+	s.Equal(int64(10), report.EntitiesCount)
 }
 
 func (s *activitiesSuite) getFixerActivityEnvironment() *testsuite.TestActivityEnvironment {
@@ -25,6 +25,7 @@ package shardscanner
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/uber/cadence/common/reconciliation/invariant"
 	"github.com/uber/cadence/common/reconciliation/store"
@@ -79,9 +80,12 @@ type (
 	}
 
 	// CorruptedKeysEntry is a pair of shardID and corrupted keys
+	// MergedCorruptedKeys holds the corrupted keys of the same shard from further scanner runs,
+	// they are fixed after CorruptedKeys.
 	CorruptedKeysEntry struct {
-		ShardID       int
-		CorruptedKeys store.Keys
+		ShardID             int
+		CorruptedKeys       store.Keys
+		MergedCorruptedKeys []store.Keys `json:",omitempty"`
 	}
 
 	// ScanShardHeartbeatDetails is the heartbeat details for scan shard
@@ -91,8 +95,11 @@ type (
 	}
 
 	// FixShardHeartbeatDetails is the heartbeat details for the fix shard
+	// CurrentShardProgress is the progress within the shard after LastShardIndexHandled,
+	// so that a retried activity can resume mid-shard without losing the stats and output of handled keys.
 	FixShardHeartbeatDetails struct {
 		LastShardIndexHandled int
+		CurrentShardProgress  FixProgress
 		Reports               []FixReport
 	}
 
@@ -164,6 +171,14 @@ type (
 		P10    int64
 	}
 
+	// ShardScanDurationStats contains stats on the distribution of shard scan durations.
+	// It is used by the ScannerEmitMetricsActivityParams.
+	ShardScanDurationStats struct {
+		Min    time.Duration
+		Median time.Duration
+		P90    time.Duration
+	}
+
 	// ShardFixResultAggregator is used to keep aggregated fix metrics
 	ShardFixResultAggregator struct {
 		minShard int
@@ -187,6 +202,7 @@ type (
 		statusSummary  ShardStatusSummaryResult
 		aggregation    AggregateScanReportResult
 		shardSizes     ShardSizeQueryResult
+		scanDurations  []time.Duration
 		corruptionKeys map[int]store.Keys
 	}
 
@@ -465,6 +481,9 @@ func (a *ShardScanResultAggregator) AddReport(report ScanReport) {
 	if report.DomainStats != nil {
 		a.updateDomainStats(report)
 	}
+	if report.ScanDuration > 0 {
+		a.insertReportIntoDurations(report)
+	}
 }
 
 func (a *ShardScanResultAggregator) updateDomainStats(report ScanReport) {
@@ -516,6 +535,27 @@ func (a *ShardScanResultAggregator) GetShardDistributionStats() ShardDistributio
 	}
 }
 
+func (a *ShardScanResultAggregator) insertReportIntoDurations(report ScanReport) {
+	insertIndex := sort.Search(len(a.scanDurations), func(i int) bool {
+		return a.scanDurations[i] >= report.ScanDuration
+	})
+	a.scanDurations = append(a.scanDurations, 0)
+	copy(a.scanDurations[insertIndex+1:], a.scanDurations[insertIndex:])
+	a.scanDurations[insertIndex] = report.ScanDuration
+}
+
+// GetShardScanDurationStats returns aggregated scan duration statistics
+func (a *ShardScanResultAggregator) GetShardScanDurationStats() ShardScanDurationStats {
+	if len(a.scanDurations) == 0 {
+		return ShardScanDurationStats{}
+	}
+	return ShardScanDurationStats{
+		Min:    a.scanDurations[0],
+		Median: a.scanDurations[int(float64(len(a.scanDurations))*.5)],
+		P90:    a.scanDurations[int(float64(len(a.scanDurations))*.9)],
+	}
+}
+
 // GetReport returns a report for a single shard.
 func (a *ShardScanResultAggregator) GetReport(shardID int) (*ScanReport, error) {
 	if _, ok := a.status[shardID]; !ok {
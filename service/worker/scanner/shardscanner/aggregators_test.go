@@ -25,6 +25,7 @@ package shardscanner
 import (
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
@@ -665,3 +666,23 @@ func (s *aggregatorsSuite) TestInsertReportIntoSizes() {
 		s.GreaterOrEqual(agg.shardSizes[i].EntitiesCount, agg.shardSizes[i+1].EntitiesCount)
 	}
 }
+
+func (s *aggregatorsSuite) TestGetShardScanDurationStats() {
+	agg := NewShardScanResultAggregator([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 0, 10)
+	s.Equal(ShardScanDurationStats{}, agg.GetShardScanDurationStats())
+
+	// reports are added out of order and one report has no duration recorded
+	for shardID, seconds := range []int{5, 3, 9, 1, 7, 10, 2, 8, 4, 6} {
+		agg.AddReport(ScanReport{
+			ShardID:      shardID,
+			ScanDuration: time.Duration(seconds) * time.Second,
+		})
+	}
+	agg.AddReport(ScanReport{ShardID: 10})
+
+	s.Equal(ShardScanDurationStats{
+		Min:    1 * time.Second,
+		Median: 6 * time.Second,
+		P90:    10 * time.Second,
+	}, agg.GetShardScanDurationStats())
+}
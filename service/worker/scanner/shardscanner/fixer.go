@@ -53,11 +53,13 @@ type (
 		ctx              context.Context
 		shardID          int
 		itr              store.ScanOutputIterator
+		startingProgress FixProgress
 		skippedWriter    store.ExecutionWriter
 		failedWriter     store.ExecutionWriter
 		fixedWriter      store.ExecutionWriter
 		invariantManager invariant.Manager
-		progressReportFn func()
+		progressReportFn func(progress FixProgress)
+		progressInterval int
 		domainCache      cache.DomainCache
 		allowDomain      dynamicconfig.BoolPropertyFnWithDomainFilter
 		scope            metrics.Scope
@@ -65,29 +67,51 @@ type (
 )
 
 // NewFixer constructs a new shard fixer.
+// The first startingProgress.Offset entities of iterator are skipped, they are expected to be handled by a previous attempt
+// whose stats and flushed keys are carried over from startingProgress.
+// progressReportFn is called every blobstoreFlushThreshold handled entities, once they are flushed to blobstore,
+// with the progress so far, including the skipped entities.
 func NewFixer(
 	ctx context.Context,
 	shardID int,
 	manager invariant.Manager,
 	iterator store.ScanOutputIterator,
+	startingProgress FixProgress,
 	blobstoreClient blobstore.Client,
 	blobstoreFlushThreshold int,
-	progressReportFn func(),
+	progressReportFn func(progress FixProgress),
 	domainCache cache.DomainCache,
 	allowDomain dynamicconfig.BoolPropertyFnWithDomainFilter,
 	scope metrics.Scope,
 ) *ShardFixer {
 	id := uuid.New()
+	for _, keys := range []*store.Keys{
+		startingProgress.FlushedKeys.Skipped,
+		startingProgress.FlushedKeys.Failed,
+		startingProgress.FlushedKeys.Fixed,
+	} {
+		if keys != nil {
+			id = keys.UUID
+		}
+	}
+	newWriter := func(extension store.Extension, flushedKeys *store.Keys) store.ExecutionWriter {
+		if flushedKeys != nil {
+			return store.NewBlobstoreWriterFromKeys(*flushedKeys, blobstoreClient, blobstoreFlushThreshold)
+		}
+		return store.NewBlobstoreWriter(id, extension, blobstoreClient, blobstoreFlushThreshold)
+	}
 
 	return &ShardFixer{
 		ctx:              ctx,
 		shardID:          shardID,
 		itr:              iterator,
-		skippedWriter:    store.NewBlobstoreWriter(id, store.SkippedExtension, blobstoreClient, blobstoreFlushThreshold),
-		failedWriter:     store.NewBlobstoreWriter(id, store.FailedExtension, blobstoreClient, blobstoreFlushThreshold),
-		fixedWriter:      store.NewBlobstoreWriter(id, store.FixedExtension, blobstoreClient, blobstoreFlushThreshold),
+		startingProgress: startingProgress,
+		skippedWriter:    newWriter(store.SkippedExtension, startingProgress.FlushedKeys.Skipped),
+		failedWriter:     newWriter(store.FailedExtension, startingProgress.FlushedKeys.Failed),
+		fixedWriter:      newWriter(store.FixedExtension, startingProgress.FlushedKeys.Fixed),
 		invariantManager: manager,
 		progressReportFn: progressReportFn,
+		progressInterval: blobstoreFlushThreshold,
 		domainCache:      domainCache,
 		allowDomain:      allowDomain,
 		scope:            scope,
@@ -99,11 +123,22 @@ func (f *ShardFixer) Fix() FixReport {
 
 	result := FixReport{
 		ShardID:     f.shardID,
-		DomainStats: map[string]*FixStats{},
+		Stats:       f.startingProgress.Stats,
+		DomainStats: copyDomainFixStats(f.startingProgress.DomainStats),
+	}
+
+	offset := 0
+	for ; offset < f.startingProgress.Offset && f.itr.HasNext(); offset++ {
+		if _, err := f.itr.Next(); err != nil {
+			result.Result.ControlFlowFailure = &ControlFlowFailure{
+				Info:        "blobstore iterator returned error",
+				InfoDetails: err.Error(),
+			}
+			return result
+		}
 	}
 
-	for f.itr.HasNext() {
-		f.progressReportFn()
+	for ; f.itr.HasNext(); offset++ {
 		soe, err := f.itr.Next()
 		if err != nil {
 			result.Result.ControlFlowFailure = &ControlFlowFailure{
@@ -188,32 +223,65 @@ func (f *ShardFixer) Fix() FixReport {
 		default:
 			panic(fmt.Sprintf("unknown FixResultType: %v", fixResult.FixResultType))
 		}
+		// progress is only reported once the handled entities are flushed,
+		// otherwise a retried attempt would skip entities whose output was never written
+		if f.progressInterval > 0 && (offset+1-f.startingProgress.Offset)%f.progressInterval == 0 {
+			if failure := f.flush(); failure != nil {
+				result.Result.ControlFlowFailure = failure
+				return result
+			}
+			f.progressReportFn(FixProgress{
+				Offset:      offset + 1,
+				Stats:       result.Stats,
+				DomainStats: copyDomainFixStats(result.DomainStats),
+				FlushedKeys: f.flushedKeys(),
+			})
+		}
+	}
+	if failure := f.flush(); failure != nil {
+		result.Result.ControlFlowFailure = failure
+		return result
 	}
+	keys := f.flushedKeys()
+	result.Result.ShardFixKeys = &keys
+	return result
+}
+
+func (f *ShardFixer) flush() *ControlFlowFailure {
 	if err := f.fixedWriter.Flush(); err != nil {
-		result.Result.ControlFlowFailure = &ControlFlowFailure{
+		return &ControlFlowFailure{
 			Info:        "failed to flush for fixed execution fixes",
 			InfoDetails: err.Error(),
 		}
-		return result
 	}
 	if err := f.skippedWriter.Flush(); err != nil {
-		result.Result.ControlFlowFailure = &ControlFlowFailure{
+		return &ControlFlowFailure{
 			Info:        "failed to flush for skipped execution fixes",
 			InfoDetails: err.Error(),
 		}
-		return result
 	}
 	if err := f.failedWriter.Flush(); err != nil {
-		result.Result.ControlFlowFailure = &ControlFlowFailure{
+		return &ControlFlowFailure{
 			Info:        "failed to flush for failed execution fixes",
 			InfoDetails: err.Error(),
 		}
-		return result
 	}
-	result.Result.ShardFixKeys = &FixKeys{
+	return nil
+}
+
+func (f *ShardFixer) flushedKeys() FixKeys {
+	return FixKeys{
 		Fixed:   f.fixedWriter.FlushedKeys(),
 		Failed:  f.failedWriter.FlushedKeys(),
 		Skipped: f.skippedWriter.FlushedKeys(),
 	}
+}
+
+func copyDomainFixStats(domainStats map[string]*FixStats) map[string]*FixStats {
+	result := make(map[string]*FixStats, len(domainStats))
+	for domainID, stats := range domainStats {
+		statsCopy := *stats
+		result[domainID] = &statsCopy
+	}
 	return result
 }
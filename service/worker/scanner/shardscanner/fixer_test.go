@@ -23,6 +23,9 @@
 package shardscanner
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
@@ -31,7 +34,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/blobstore/filestore"
 	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
@@ -70,7 +76,7 @@ func (s *FixerSuite) TestFix_Failure_FirstIteratorError() {
 	fixer := &ShardFixer{
 		shardID:          0,
 		itr:              mockItr,
-		progressReportFn: func() {},
+		progressReportFn: func(FixProgress) {},
 	}
 	result := fixer.Fix()
 	s.Equal(FixReport{
@@ -119,7 +125,7 @@ func (s *FixerSuite) TestFix_Failure_NonFirstError() {
 		itr:              mockItr,
 		invariantManager: mockInvariantManager,
 		fixedWriter:      fixedWriter,
-		progressReportFn: func() {},
+		progressReportFn: func(FixProgress) {},
 		domainCache:      domainCache,
 		allowDomain:      dynamicconfig.GetBoolPropertyFnFilteredByDomain(true),
 		scope:            metrics.NoopScope(metrics.Worker),
@@ -171,7 +177,7 @@ func (s *FixerSuite) TestFix_Failure_SkippedWriterError() {
 		itr:              mockItr,
 		skippedWriter:    skippedWriter,
 		invariantManager: mockInvariantManager,
-		progressReportFn: func() {},
+		progressReportFn: func(FixProgress) {},
 		domainCache:      domainCache,
 		allowDomain:      dynamicconfig.GetBoolPropertyFnFilteredByDomain(true),
 		scope:            metrics.NoopScope(metrics.Worker),
@@ -222,7 +228,7 @@ func (s *FixerSuite) TestFix_Failure_FailedWriterError() {
 		itr:              mockItr,
 		failedWriter:     failedWriter,
 		invariantManager: mockInvariantManager,
-		progressReportFn: func() {},
+		progressReportFn: func(FixProgress) {},
 		domainCache:      domainCache,
 		allowDomain:      dynamicconfig.GetBoolPropertyFnFilteredByDomain(true),
 		scope:            metrics.NoopScope(metrics.Worker),
@@ -273,7 +279,7 @@ func (s *FixerSuite) TestFix_Failure_FixedWriterError() {
 		itr:              mockItr,
 		fixedWriter:      fixedWriter,
 		invariantManager: mockInvariantManager,
-		progressReportFn: func() {},
+		progressReportFn: func(FixProgress) {},
 		domainCache:      domainCache,
 		allowDomain:      dynamicconfig.GetBoolPropertyFnFilteredByDomain(true),
 		scope:            metrics.NoopScope(metrics.Worker),
@@ -310,7 +316,7 @@ func (s *FixerSuite) TestFix_Failure_FixedWriterFlushError() {
 		shardID:          0,
 		itr:              mockItr,
 		fixedWriter:      fixedWriter,
-		progressReportFn: func() {},
+		progressReportFn: func(FixProgress) {},
 	}
 	result := fixer.Fix()
 	s.Equal(FixReport{
@@ -337,7 +343,7 @@ func (s *FixerSuite) TestFix_Failure_SkippedWriterFlushError() {
 		itr:              mockItr,
 		fixedWriter:      fixedWriter,
 		skippedWriter:    skippedWriter,
-		progressReportFn: func() {},
+		progressReportFn: func(FixProgress) {},
 	}
 	result := fixer.Fix()
 	s.Equal(FixReport{
@@ -367,7 +373,7 @@ func (s *FixerSuite) TestFix_Failure_FailedWriterFlushError() {
 		fixedWriter:      fixedWriter,
 		skippedWriter:    skippedWriter,
 		failedWriter:     failedWriter,
-		progressReportFn: func() {},
+		progressReportFn: func(FixProgress) {},
 	}
 	result := fixer.Fix()
 	s.Equal(FixReport{
@@ -382,6 +388,155 @@ func (s *FixerSuite) TestFix_Failure_FailedWriterFlushError() {
 	}, result)
 }
 
+func (s *FixerSuite) TestFix_SkipsStartingOffset() {
+	mockItr := store.NewMockScanOutputIterator(s.controller)
+	iteratorCallNumber := 0
+	mockItr.EXPECT().HasNext().DoAndReturn(func() bool {
+		return iteratorCallNumber < 4
+	}).AnyTimes()
+	mockItr.EXPECT().Next().DoAndReturn(func() (*store.ScanOutputEntity, error) {
+		defer func() {
+			iteratorCallNumber++
+		}()
+		return &store.ScanOutputEntity{
+			Execution: &entity.ConcreteExecution{
+				Execution: entity.Execution{
+					DomainID:   "test_domain",
+					WorkflowID: fmt.Sprintf("workflow-%v", iteratorCallNumber),
+				},
+			},
+		}, nil
+	}).Times(4)
+	mockInvariantManager := invariant.NewMockManager(s.controller)
+	mockInvariantManager.EXPECT().RunFixes(gomock.Any(), &entity.ConcreteExecution{
+		Execution: entity.Execution{
+			DomainID:   "test_domain",
+			WorkflowID: "workflow-3",
+		},
+	}).Return(invariant.ManagerFixResult{
+		FixResultType: invariant.FixResultTypeFixed,
+	}).Times(1)
+	mockFixedWriter := store.NewMockExecutionWriter(s.controller)
+	mockFixedWriter.EXPECT().Add(gomock.Any()).Return(nil).Times(1)
+	mockFixedWriter.EXPECT().Flush().Return(nil).Times(2)
+	mockFixedWriter.EXPECT().FlushedKeys().Return(&store.Keys{UUID: "fixed_keys_uuid"}).Times(2)
+	mockSkippedWriter := store.NewMockExecutionWriter(s.controller)
+	mockSkippedWriter.EXPECT().Flush().Return(nil).Times(2)
+	mockSkippedWriter.EXPECT().FlushedKeys().Return(nil).Times(2)
+	mockFailedWriter := store.NewMockExecutionWriter(s.controller)
+	mockFailedWriter.EXPECT().Flush().Return(nil).Times(2)
+	mockFailedWriter.EXPECT().FlushedKeys().Return(nil).Times(2)
+	domainCache := cache.NewMockDomainCache(s.controller)
+	domainCache.EXPECT().GetDomainName("test_domain").Return("test_domain", nil).Times(1)
+
+	var reported []FixProgress
+	fixer := &ShardFixer{
+		shardID:          0,
+		invariantManager: mockInvariantManager,
+		skippedWriter:    mockSkippedWriter,
+		failedWriter:     mockFailedWriter,
+		fixedWriter:      mockFixedWriter,
+		itr:              mockItr,
+		startingProgress: FixProgress{
+			Offset:      3,
+			Stats:       FixStats{EntitiesCount: 3, FixedCount: 2, SkippedCount: 1},
+			DomainStats: map[string]*FixStats{"test_domain": {EntitiesCount: 3, FixedCount: 2, SkippedCount: 1}},
+		},
+		progressReportFn: func(progress FixProgress) { reported = append(reported, progress) },
+		progressInterval: 1,
+		domainCache:      domainCache,
+		allowDomain:      func(string) bool { return true },
+		scope:            metrics.NoopScope(metrics.Worker),
+	}
+	result := fixer.Fix()
+	s.Nil(result.Result.ControlFlowFailure)
+	s.Equal(FixStats{EntitiesCount: 4, FixedCount: 3, SkippedCount: 1}, result.Stats)
+	s.Equal(map[string]*FixStats{"test_domain": {EntitiesCount: 4, FixedCount: 3, SkippedCount: 1}}, result.DomainStats)
+	s.Equal([]FixProgress{{
+		Offset:      4,
+		Stats:       FixStats{EntitiesCount: 4, FixedCount: 3, SkippedCount: 1},
+		DomainStats: map[string]*FixStats{"test_domain": {EntitiesCount: 4, FixedCount: 3, SkippedCount: 1}},
+		FlushedKeys: FixKeys{Fixed: &store.Keys{UUID: "fixed_keys_uuid"}},
+	}}, reported)
+}
+
+func (s *FixerSuite) TestFix_ResumesFlushedKeys() {
+	blobstoreClient, err := filestore.NewFilestoreClient(&config.FileBlobstore{OutputDirectory: s.T().TempDir()})
+	s.NoError(err)
+	newIterator := func(failAt int) store.ScanOutputIterator {
+		mockItr := store.NewMockScanOutputIterator(s.controller)
+		iteratorCallNumber := 0
+		mockItr.EXPECT().HasNext().DoAndReturn(func() bool {
+			return iteratorCallNumber < 4
+		}).AnyTimes()
+		mockItr.EXPECT().Next().DoAndReturn(func() (*store.ScanOutputEntity, error) {
+			defer func() {
+				iteratorCallNumber++
+			}()
+			if iteratorCallNumber == failAt {
+				return nil, errors.New("iterator error")
+			}
+			return &store.ScanOutputEntity{
+				Execution: &entity.ConcreteExecution{
+					Execution: entity.Execution{
+						DomainID:   "test_domain",
+						WorkflowID: fmt.Sprintf("workflow-%v", iteratorCallNumber),
+					},
+				},
+			}, nil
+		}).AnyTimes()
+		return mockItr
+	}
+	mockInvariantManager := invariant.NewMockManager(s.controller)
+	mockInvariantManager.EXPECT().RunFixes(gomock.Any(), gomock.Any()).Return(invariant.ManagerFixResult{
+		FixResultType: invariant.FixResultTypeFixed,
+	}).Times(5) // workflow-2 is fixed by both attempts as its output was not flushed by the first one
+	domainCache := cache.NewMockDomainCache(s.controller)
+	domainCache.EXPECT().GetDomainName("test_domain").Return("test_domain", nil).AnyTimes()
+	allowDomain := func(string) bool { return true }
+
+	var progress FixProgress
+	fixer := NewFixer(context.Background(), 0, mockInvariantManager, newIterator(3), FixProgress{}, blobstoreClient, 2,
+		func(p FixProgress) { progress = p }, domainCache, allowDomain, metrics.NoopScope(metrics.Worker))
+	result := fixer.Fix()
+	s.NotNil(result.Result.ControlFlowFailure)
+	s.Equal(2, progress.Offset)
+	s.Equal(FixStats{EntitiesCount: 2, FixedCount: 2}, progress.Stats)
+	s.NotNil(progress.FlushedKeys.Fixed)
+
+	fixer = NewFixer(context.Background(), 0, mockInvariantManager, newIterator(-1), progress, blobstoreClient, 2,
+		func(p FixProgress) { progress = p }, domainCache, allowDomain, metrics.NoopScope(metrics.Worker))
+	result = fixer.Fix()
+	s.Nil(result.Result.ControlFlowFailure)
+	s.Equal(FixStats{EntitiesCount: 4, FixedCount: 4}, result.Stats)
+	s.Nil(result.Result.ShardFixKeys.Skipped)
+	s.Nil(result.Result.ShardFixKeys.Failed)
+	s.Equal(&store.Keys{
+		UUID:      progress.FlushedKeys.Fixed.UUID,
+		MinPage:   0,
+		MaxPage:   1,
+		Extension: store.FixedExtension,
+	}, result.Result.ShardFixKeys.Fixed)
+
+	var workflowIDs []string
+	for page := 0; page <= 1; page++ {
+		resp, err := blobstoreClient.Get(context.Background(), &blobstore.GetRequest{
+			Key: fmt.Sprintf("%v_%v.%v", progress.FlushedKeys.Fixed.UUID, page, store.FixedExtension),
+		})
+		s.NoError(err)
+		for _, data := range bytes.Split(resp.Blob.Body, store.SeparatorToken) {
+			if len(data) == 0 {
+				continue
+			}
+			var foe store.FixOutputEntity
+			foe.Execution = &entity.ConcreteExecution{}
+			s.NoError(json.Unmarshal(data, &foe))
+			workflowIDs = append(workflowIDs, foe.Execution.(*entity.ConcreteExecution).WorkflowID)
+		}
+	}
+	s.Equal([]string{"workflow-0", "workflow-1", "workflow-2", "workflow-3"}, workflowIDs)
+}
+
 func (s *FixerSuite) TestFix_Success() {
 	mockItr := store.NewMockScanOutputIterator(s.controller)
 	iteratorCallNumber := 0
@@ -714,7 +869,7 @@ func (s *FixerSuite) TestFix_Success() {
 		failedWriter:     mockFailedWriter,
 		fixedWriter:      mockFixedWriter,
 		itr:              mockItr,
-		progressReportFn: func() {},
+		progressReportFn: func(FixProgress) {},
 		domainCache:      domainCache,
 		allowDomain:      allowDomain,
 		scope:            metrics.NoopScope(metrics.Worker),
@@ -102,6 +102,10 @@ func NewFixerWorkflow(
 		return nil, errors.New("workflow name is not provided")
 	}
 
+	if params.ScanReportBlobstoreKey != "" && len(params.ScannerWorkflowRunIDs) > 0 {
+		return nil, errors.New("scan report export is not supported when fixing multiple scanner runs")
+	}
+
 	wf := FixerWorkflow{
 		Params: params,
 	}
@@ -147,6 +151,18 @@ func (fx *FixerWorkflow) Start(ctx workflow.Context) error {
 		enabled = out.EnabledInvariants
 	}
 
+	if fx.Params.ScanReportBlobstoreKey != "" {
+		activityCtx := getShortActivityContext(ctx)
+		if err := workflow.ExecuteActivity(activityCtx, ActivityScanReportExport, ScanReportExportActivityParams{
+			ScannerWorkflowWorkflowID: fx.Params.ScannerWorkflowWorkflowID,
+			ScannerWorkflowRunID:      fx.Params.ScannerWorkflowRunID,
+			AcceptedCloseStatuses:     fx.Params.AcceptedCloseStatuses,
+			BlobstoreKey:              fx.Params.ScanReportBlobstoreKey,
+		}).Get(activityCtx, nil); err != nil {
+			return err
+		}
+	}
+
 	shardReportChan := workflow.GetSignalChannel(ctx, fixShardReportChan)
 
 	for i := 0; i < resolvedConfig.Concurrency; i++ {
@@ -160,6 +176,7 @@ func (fx *FixerWorkflow) Start(ctx workflow.Context) error {
 					CorruptedKeysEntries:        batch,
 					ResolvedFixerWorkflowConfig: resolvedConfig,
 					EnabledInvariants:           enabled,
+					DryRun:                      fx.Params.DryRun,
 				}).Get(ctx, &reports); err != nil {
 					errStr := err.Error()
 					shardReportChan.Send(ctx, FixReportError{
@@ -25,6 +25,7 @@ package shardscanner
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/pborman/uuid"
 
@@ -215,3 +216,76 @@ func (s *ShardScanner) getDomainIDFromEntity(e interface{}) (*string, error) {
 
 	return nil, fmt.Errorf("unknown entity type in scanner: %T", e)
 }
+
+// blobstoreWriteBudget is the number of bytes which can still be written to blobstore,
+// shared by all shards scanned within an activity. A nil budget is unlimited.
+type blobstoreWriteBudget struct {
+	sync.Mutex
+	remaining int64
+}
+
+// newBlobstoreWriteBudget creates the budget of an activity, bytes written by already reported shards are not available.
+func newBlobstoreWriteBudget(budgetBytes *int64, reports []ScanReport) *blobstoreWriteBudget {
+	if budgetBytes == nil {
+		return nil
+	}
+	remaining := *budgetBytes
+	for _, report := range reports {
+		remaining -= report.BlobstoreBytesWritten
+	}
+	return &blobstoreWriteBudget{remaining: remaining}
+}
+
+func (b *blobstoreWriteBudget) take(size int64) bool {
+	if b == nil {
+		return true
+	}
+	b.Lock()
+	defer b.Unlock()
+	if size > b.remaining {
+		b.remaining = 0
+		return false
+	}
+	b.remaining -= size
+	return true
+}
+
+func (b *blobstoreWriteBudget) exhausted() bool {
+	if b == nil {
+		return false
+	}
+	b.Lock()
+	defer b.Unlock()
+	return b.remaining <= 0
+}
+
+// budgetedBlobstoreClient fails writes once the budget is exceeded and counts bytes written by a single shard.
+type budgetedBlobstoreClient struct {
+	blobstore.Client
+	budget  *blobstoreWriteBudget
+	written int64
+}
+
+func newBudgetedBlobstoreClient(client blobstore.Client, budget *blobstoreWriteBudget) *budgetedBlobstoreClient {
+	return &budgetedBlobstoreClient{
+		Client: client,
+		budget: budget,
+	}
+}
+
+// Put writes the blob if it fits into the remaining budget.
+func (c *budgetedBlobstoreClient) Put(ctx context.Context, req *blobstore.PutRequest) (*blobstore.PutResponse, error) {
+	size := int64(len(req.Blob.Body))
+	if !c.budget.take(size) {
+		return nil, store.ErrWriteBudgetExceeded
+	}
+	resp, err := c.Client.Put(ctx, req)
+	if err == nil {
+		c.written += size
+	}
+	return resp, err
+}
+
+func (c *budgetedBlobstoreClient) bytesWritten() int64 {
+	return c.written
+}
@@ -29,9 +29,12 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/pagination"
@@ -528,3 +531,35 @@ func (s *ScannerSuite) TestGetDomainIDFromEntity() {
 	s.Nil(result)
 	s.Error(err)
 }
+
+func (s *ScannerSuite) TestBudgetedBlobstoreClient() {
+	blobstoreClient := &blobstore.MockClient{}
+	blobstoreClient.On("Put", mock.Anything, mock.Anything).Return(&blobstore.PutResponse{}, nil).Once()
+	budget := newBlobstoreWriteBudget(common.Int64Ptr(15), []ScanReport{{BlobstoreBytesWritten: 5}})
+	client := newBudgetedBlobstoreClient(blobstoreClient, budget)
+
+	_, err := client.Put(context.Background(), &blobstore.PutRequest{Blob: blobstore.Blob{Body: make([]byte, 6)}})
+	s.NoError(err)
+	s.False(budget.exhausted())
+
+	_, err = client.Put(context.Background(), &blobstore.PutRequest{Blob: blobstore.Blob{Body: make([]byte, 6)}})
+	s.Equal(store.ErrWriteBudgetExceeded, err)
+	s.True(budget.exhausted())
+	s.Equal(int64(6), client.bytesWritten())
+	blobstoreClient.AssertExpectations(s.T())
+}
+
+func (s *ScannerSuite) TestBudgetedBlobstoreClient_Unlimited() {
+	blobstoreClient := &blobstore.MockClient{}
+	blobstoreClient.On("Put", mock.Anything, mock.Anything).Return(&blobstore.PutResponse{}, nil).Twice()
+	budget := newBlobstoreWriteBudget(nil, nil)
+	client := newBudgetedBlobstoreClient(blobstoreClient, budget)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Put(context.Background(), &blobstore.PutRequest{Blob: blobstore.Blob{Body: make([]byte, 6)}})
+		s.NoError(err)
+	}
+	s.False(budget.exhausted())
+	s.Equal(int64(12), client.bytesWritten())
+	blobstoreClient.AssertExpectations(s.T())
+}
@@ -56,6 +56,11 @@ const (
 	// troubleshooting purposes.
 	AllResultsQuery = "all_results"
 
+	// PauseSignal is the signal name used to pause a running scan, shard batches which are already started are not interrupted
+	PauseSignal = "pause"
+	// ResumeSignal is the signal name used to resume a paused scan
+	ResumeSignal = "resume"
+
 	scanShardReportChan = "scanShardReportChan"
 )
 
@@ -136,19 +141,57 @@ func (wf *ScannerWorkflow) Start(ctx workflow.Context) error {
 		return nil
 	}
 
+	paused := false
+	pauseCh := workflow.GetSignalChannel(ctx, PauseSignal)
+	resumeCh := workflow.GetSignalChannel(ctx, ResumeSignal)
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		selector := workflow.NewSelector(ctx)
+		selector.AddReceive(pauseCh, func(c workflow.Channel, more bool) {
+			c.Receive(ctx, nil)
+			paused = true
+		})
+		selector.AddReceive(resumeCh, func(c workflow.Channel, more bool) {
+			c.Receive(ctx, nil)
+			paused = false
+		})
+		for {
+			selector.Select(ctx)
+		}
+	})
+
+	// blobstore write budget is enforced by activities, batches running in parallel may overshoot it
+	var blobstoreBytesWritten int64
+	remainingBlobstoreWriteBudget := func() *int64 {
+		if resolvedConfig.GenericScannerConfig.BlobstoreWriteBudgetBytes <= 0 {
+			return nil
+		}
+		remaining := int64(resolvedConfig.GenericScannerConfig.BlobstoreWriteBudgetBytes) - blobstoreBytesWritten
+		return &remaining
+	}
+
 	shardReportChan := workflow.GetSignalChannel(ctx, scanShardReportChan)
 	for i := 0; i < resolvedConfig.GenericScannerConfig.Concurrency; i++ {
 		idx := i
 		workflow.Go(ctx, func(ctx workflow.Context) {
 			batches := getShardBatches(resolvedConfig.GenericScannerConfig.ActivityBatchSize, resolvedConfig.GenericScannerConfig.Concurrency, wf.Shards, idx)
 			for _, batch := range batches {
+				if err := workflow.Await(ctx, func() bool { return !paused }); err != nil {
+					errStr := err.Error()
+					shardReportChan.Send(ctx, ScanReportError{
+						Reports:  nil,
+						ErrorStr: &errStr,
+					})
+					return
+				}
 				activityCtx = getLongActivityContext(ctx)
 				var reports []ScanReport
 				if err := workflow.ExecuteActivity(activityCtx, ActivityScanShard, ScanShardActivityParams{
-					Shards:                  batch,
-					PageSize:                resolvedConfig.GenericScannerConfig.PageSize,
-					BlobstoreFlushThreshold: resolvedConfig.GenericScannerConfig.BlobstoreFlushThreshold,
-					ScannerConfig:           resolvedConfig.CustomScannerConfig,
+					Shards:                    batch,
+					PageSize:                  resolvedConfig.GenericScannerConfig.PageSize,
+					BlobstoreFlushThreshold:   resolvedConfig.GenericScannerConfig.BlobstoreFlushThreshold,
+					ScannerConfig:             resolvedConfig.CustomScannerConfig,
+					Concurrency:               resolvedConfig.GenericScannerConfig.ShardConcurrency,
+					BlobstoreWriteBudgetBytes: remainingBlobstoreWriteBudget(),
 				}).Get(ctx, &reports); err != nil {
 					errStr := err.Error()
 					shardReportChan.Send(ctx, ScanReportError{
@@ -175,6 +218,7 @@ func (wf *ScannerWorkflow) Start(ctx workflow.Context) error {
 		}
 		for _, report := range reportErr.Reports {
 			wf.Aggregator.AddReport(report)
+			blobstoreBytesWritten += report.BlobstoreBytesWritten
 			i++
 		}
 	}
@@ -187,6 +231,7 @@ func (wf *ScannerWorkflow) Start(ctx workflow.Context) error {
 		ShardControlFlowFailureCount: summary[ShardStatusControlFlowFailure],
 		AggregateReportResult:        wf.Aggregator.GetAggregateReport(),
 		ShardDistributionStats:       wf.Aggregator.GetShardDistributionStats(),
+		ShardScanDurationStats:       wf.Aggregator.GetShardScanDurationStats(),
 	}).Get(ctx, nil)
 
 }
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"go.uber.org/cadence"
+	"go.uber.org/cadence/.gen/go/shared"
 	"go.uber.org/cadence/activity"
 	"go.uber.org/cadence/client"
 	"go.uber.org/cadence/workflow"
@@ -49,8 +50,15 @@ const (
 	ErrSerialization = "encountered serialization error"
 	// ErrMissingHooks indicates scanner is not providing hooks to Invariant manager or Iterator
 	ErrMissingHooks = "hooks are not provided for this scanner"
+	// ErrInvalidAcceptedCloseStatuses indicates an empty list of accepted scanner workflow close statuses was given
+	ErrInvalidAcceptedCloseStatuses = "accepted close statuses must not be empty"
+
+	maxSampleCorruptedKeys = 10
 )
 
+// defaultAcceptedCloseStatuses are the close statuses of scanner runs the fixer discovers by default.
+var defaultAcceptedCloseStatuses = []shared.WorkflowExecutionCloseStatus{shared.WorkflowExecutionCloseStatusContinuedAsNew}
+
 type (
 	contextKey string
 
@@ -78,6 +86,7 @@ type (
 		ShardControlFlowFailureCount int
 		AggregateReportResult        AggregateScanReportResult
 		ShardDistributionStats       ShardDistributionStats
+		ShardScanDurationStats       ShardScanDurationStats
 	}
 
 	// ShardRange identifies a set of shards based on min (inclusive) and max (exclusive)
@@ -110,13 +119,27 @@ type (
 		PageSize                int
 		BlobstoreFlushThreshold int
 		ScannerConfig           CustomScannerConfig
+		// Concurrency is the max number of shards scanned in parallel within the activity.
+		// Zero (e.g. from params serialized before this field existed) scans shards sequentially.
+		Concurrency int
+		// BlobstoreWriteBudgetBytes is the number of bytes the shards of the activity may write to blobstore.
+		// Nil means unlimited.
+		BlobstoreWriteBudgetBytes *int64
 	}
 
 	// FixerWorkflowParams are the parameters to the fix workflow
 	FixerWorkflowParams struct {
 		ScannerWorkflowWorkflowID     string
 		ScannerWorkflowRunID          string
+		ScannerWorkflowRunIDs         []string
 		FixerWorkflowConfigOverwrites FixerWorkflowConfigOverwrites
+		// AcceptedCloseStatuses, see FixerCorruptedKeysActivityParams.AcceptedCloseStatuses.
+		AcceptedCloseStatuses []shared.WorkflowExecutionCloseStatus
+		// DryRun runs the fixer without mutating persistence, see FixShardActivityParams.DryRun.
+		DryRun bool
+		// ScanReportBlobstoreKey, when set, makes the fixer export the report of the scanner workflow
+		// to this blobstore key, see scanReportExportActivity. It cannot be used with ScannerWorkflowRunIDs.
+		ScanReportBlobstoreKey string
 	}
 
 	// ScanReport is the report of running Scan on a single shard.
@@ -125,6 +148,10 @@ type (
 		Stats       ScanStats
 		Result      ScanResult
 		DomainStats map[string]*ScanStats
+		// ScanDuration is the wall-clock time it took to scan the shard
+		ScanDuration time.Duration
+		// BlobstoreBytesWritten is the number of bytes written to blobstore while scanning the shard
+		BlobstoreBytesWritten int64
 	}
 
 	// DomainStats is the report of stats for one domain
@@ -167,6 +194,8 @@ type (
 		Stats       FixStats
 		Result      FixResult
 		DomainStats map[string]*FixStats
+		// DryRun is true when the report holds what would have been fixed rather than what was fixed.
+		DryRun bool
 	}
 
 	// FixStats indicates the stats of executions that were handled by shard Fix.
@@ -177,6 +206,16 @@ type (
 		FailedCount   int64
 	}
 
+	// FixProgress indicates how far shard Fix got within a shard.
+	// Offset is the number of entities handled, Stats and DomainStats are the stats of those entities
+	// and FlushedKeys are the keys of the blobs those entities were written to.
+	FixProgress struct {
+		Offset      int
+		Stats       FixStats
+		DomainStats map[string]*FixStats
+		FlushedKeys FixKeys
+	}
+
 	// FixResult indicates the result of running fix on a shard.
 	// Exactly one of ControlFlowFailure or FixKeys will be non-nil.
 	FixResult struct {
@@ -205,7 +244,37 @@ type (
 	FixerCorruptedKeysActivityParams struct {
 		ScannerWorkflowWorkflowID string
 		ScannerWorkflowRunID      string
-		StartingShardID           *int
+		// ScannerWorkflowRunIDs are scanner runs to fix corrupted keys from, merging keys across the runs.
+		// When empty, ScannerWorkflowRunID or the most recent ContinuedAsNew scanner run is used.
+		ScannerWorkflowRunIDs []string
+		StartingShardID       *int
+		// AcceptedCloseStatuses are the close statuses a discovered scanner run may have when no run ID is given.
+		// Defaults to ContinuedAsNew when nil, and must not be empty otherwise.
+		AcceptedCloseStatuses []shared.WorkflowExecutionCloseStatus
+	}
+
+	// ScanReportExportActivityParams is the parameter for scanReportExportActivity
+	ScanReportExportActivityParams struct {
+		ScannerWorkflowWorkflowID string
+		ScannerWorkflowRunID      string
+		// AcceptedCloseStatuses, see FixerCorruptedKeysActivityParams.AcceptedCloseStatuses.
+		AcceptedCloseStatuses []shared.WorkflowExecutionCloseStatus
+		// BlobstoreKey is the key the JSON report is written to
+		BlobstoreKey string
+	}
+
+	// ScanJSONReport is the consolidated report of a completed scan workflow written by scanReportExportActivity.
+	// AffectedShards lists every shard with at least one corruption, SampleCorruptedKeys holds the blob keys of
+	// up to maxSampleCorruptedKeys of those shards.
+	ScanJSONReport struct {
+		ScannerWorkflowWorkflowID string
+		ScannerWorkflowRunID      string
+		EntitiesCount             int64
+		CorruptedCount            int64
+		CheckFailedCount          int64
+		CorruptionByType          map[invariant.Name]int64
+		AffectedShards            []int
+		SampleCorruptedKeys       []CorruptedKeysEntry
 	}
 
 	// FixShardActivityParams is the parameter for fixShardActivity
@@ -220,6 +289,10 @@ type (
 		// and the historical list of invariants should be used.  This should be a one-time event
 		// after upgrading.
 		EnabledInvariants CustomScannerConfig
+
+		// DryRun runs all invariant fixes but skips every persistence write, so the resulting
+		// reports count the executions that would have been fixed.
+		DryRun bool
 	}
 
 	// CustomScannerConfig is used to pass key/value parameters between shardscanner activity and scanner/fixer implementations.
@@ -237,15 +310,22 @@ type (
 		PageSize                int
 		BlobstoreFlushThreshold int
 		ActivityBatchSize       int
+		// BlobstoreWriteBudgetBytes is the total number of bytes a scan may write to blobstore, zero means unlimited
+		BlobstoreWriteBudgetBytes int
+		// ShardConcurrency is the number of shards scanned in parallel by each activity,
+		// on top of the Concurrency activities run in parallel by the workflow
+		ShardConcurrency int
 	}
 
 	// GenericScannerConfigOverwrites allows to override generic params
 	GenericScannerConfigOverwrites struct {
-		Enabled                 *bool
-		Concurrency             *int
-		PageSize                *int
-		BlobstoreFlushThreshold *int
-		ActivityBatchSize       *int
+		Enabled                   *bool
+		Concurrency               *int
+		PageSize                  *int
+		BlobstoreFlushThreshold   *int
+		ActivityBatchSize         *int
+		BlobstoreWriteBudgetBytes *int
+		ShardConcurrency          *int
 	}
 
 	// ResolvedScannerWorkflowConfig is the resolved config after reading dynamic config
@@ -265,13 +345,15 @@ type (
 
 	// DynamicParams is the dynamic config for scanner workflow.
 	DynamicParams struct {
-		ScannerEnabled          dynamicconfig.BoolPropertyFn
-		FixerEnabled            dynamicconfig.BoolPropertyFn
-		Concurrency             dynamicconfig.IntPropertyFn
-		PageSize                dynamicconfig.IntPropertyFn
-		BlobstoreFlushThreshold dynamicconfig.IntPropertyFn
-		ActivityBatchSize       dynamicconfig.IntPropertyFn
-		AllowDomain             dynamicconfig.BoolPropertyFnWithDomainFilter
+		ScannerEnabled            dynamicconfig.BoolPropertyFn
+		FixerEnabled              dynamicconfig.BoolPropertyFn
+		Concurrency               dynamicconfig.IntPropertyFn
+		PageSize                  dynamicconfig.IntPropertyFn
+		BlobstoreFlushThreshold   dynamicconfig.IntPropertyFn
+		ActivityBatchSize         dynamicconfig.IntPropertyFn
+		BlobstoreWriteBudgetBytes dynamicconfig.IntPropertyFn
+		ShardConcurrency          dynamicconfig.IntPropertyFn
+		AllowDomain               dynamicconfig.BoolPropertyFnWithDomainFilter
 	}
 
 	// ScannerConfig is the  config for ShardScanner workflow
@@ -311,7 +393,7 @@ func getShortActivityContext(ctx workflow.Context) workflow.Context {
 			InitialInterval:          time.Second,
 			BackoffCoefficient:       1.7,
 			ExpirationInterval:       10 * time.Minute,
-			NonRetriableErrorReasons: []string{ErrScanWorkflowNotClosed, ErrSerialization, ErrMissingHooks},
+			NonRetriableErrorReasons: []string{ErrScanWorkflowNotClosed, ErrSerialization, ErrMissingHooks, ErrInvalidAcceptedCloseStatuses},
 		},
 	}
 	return workflow.WithActivityOptions(ctx, activityOptions)
@@ -340,7 +422,9 @@ func GetCorruptedKeys(
 	fixerCorruptedKeysActivityParams := FixerCorruptedKeysActivityParams{
 		ScannerWorkflowWorkflowID: params.ScannerWorkflowWorkflowID,
 		ScannerWorkflowRunID:      params.ScannerWorkflowRunID,
+		ScannerWorkflowRunIDs:     params.ScannerWorkflowRunIDs,
 		StartingShardID:           nil,
+		AcceptedCloseStatuses:     params.AcceptedCloseStatuses,
 	}
 	var minShardID *int
 	var maxShardID *int
@@ -32,4 +32,5 @@ func init() {
 	activity.RegisterWithOptions(fixerConfigActivity, activity.RegisterOptions{Name: ActivityFixerConfig})
 	activity.RegisterWithOptions(fixerCorruptedKeysActivity, activity.RegisterOptions{Name: ActivityFixerCorruptedKeys})
 	activity.RegisterWithOptions(fixShardActivity, activity.RegisterOptions{Name: ActivityFixShard})
+	activity.RegisterWithOptions(scanReportExportActivity, activity.RegisterOptions{Name: ActivityScanReportExport})
 }
@@ -25,9 +25,11 @@ package shardscanner
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence"
 	"go.uber.org/cadence/testsuite"
 	"go.uber.org/cadence/workflow"
 
@@ -58,6 +60,7 @@ func (s *workflowsSuite) TestScannerWorkflow_Failure_ScanShard() {
 		GenericScannerConfig: GenericScannerConfig{
 			Enabled:           true,
 			Concurrency:       3,
+			ShardConcurrency:  2,
 			ActivityBatchSize: 5,
 		},
 	}, nil)
@@ -101,7 +104,8 @@ func (s *workflowsSuite) TestScannerWorkflow_Failure_ScanShard() {
 			}
 		}
 		s.env.OnActivity(ActivityScanShard, mock.Anything, ScanShardActivityParams{
-			Shards: batch,
+			Shards:      batch,
+			Concurrency: 2,
 		}).Return(reports, err)
 	}
 	s.env.ExecuteWorkflow(NewTestWorkflow, "test-workflow", ScannerWorkflowParams{
@@ -111,6 +115,54 @@ func (s *workflowsSuite) TestScannerWorkflow_Failure_ScanShard() {
 	s.Equal("scan shard activity got error", s.env.GetWorkflowError().Error())
 }
 
+func (s *workflowsSuite) TestScannerWorkflow_PauseAndResume() {
+	s.env.OnActivity(ActivityScannerConfig, mock.Anything, mock.Anything).Return(ResolvedScannerWorkflowConfig{
+		GenericScannerConfig: GenericScannerConfig{
+			Enabled:           true,
+			Concurrency:       1,
+			ShardConcurrency:  1,
+			ActivityBatchSize: 1,
+		},
+	}, nil)
+	var startTimes []time.Time
+	for shardID := 0; shardID < 2; shardID++ {
+		s.env.OnActivity(ActivityScanShard, mock.Anything, ScanShardActivityParams{
+			Shards:      []int{shardID},
+			Concurrency: 1,
+		}).Run(func(args mock.Arguments) {
+			startTimes = append(startTimes, s.env.Now())
+		}).After(time.Minute).Return([]ScanReport{{
+			ShardID: shardID,
+			Result: ScanResult{
+				ShardScanKeys: &ScanKeys{},
+			},
+		}}, nil).Once()
+	}
+	s.env.OnActivity(ActivityScannerEmitMetrics, mock.Anything, mock.Anything).Return(nil)
+
+	// pause while the first batch is running, resume well after it has finished
+	s.env.RegisterDelayedCallback(func() {
+		s.env.SignalWorkflow(PauseSignal, nil)
+	}, 30*time.Second)
+	s.env.RegisterDelayedCallback(func() {
+		s.Len(startTimes, 1)
+		s.env.SignalWorkflow(ResumeSignal, nil)
+	}, time.Hour)
+
+	s.env.ExecuteWorkflow(NewTestWorkflow, "test-workflow", ScannerWorkflowParams{
+		Shards: Shards{
+			Range: &ShardRange{
+				Min: 0,
+				Max: 2,
+			},
+		},
+	})
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	s.Len(startTimes, 2)
+	s.True(startTimes[1].Sub(startTimes[0]) >= time.Hour)
+}
+
 func (s *workflowsSuite) TestScannerWorkflow_Failure_ScannerConfigActivity() {
 	s.env.OnActivity(ActivityScannerConfig, mock.Anything, mock.Anything).Return(ResolvedScannerWorkflowConfig{}, errors.New("got error getting config"))
 	s.env.ExecuteWorkflow(NewTestWorkflow, "test-workflow", ScannerWorkflowParams{
@@ -354,6 +406,59 @@ func (s *workflowsSuite) TestFixerWorkflow_Success() {
 	s.Equal(15, *status.ShardQueryPaginationToken.NextShardID)
 }
 
+func (s *workflowsSuite) TestFixerWorkflow_ExportsScanReport() {
+	s.env.OnActivity(ActivityFixerCorruptedKeys, mock.Anything, mock.Anything).Return(&FixerCorruptedKeysActivityResult{
+		CorruptedKeys:             []CorruptedKeysEntry{{ShardID: 1}},
+		MinShard:                  common.IntPtr(1),
+		MaxShard:                  common.IntPtr(1),
+		ShardQueryPaginationToken: ShardQueryPaginationToken{IsDone: true},
+	}, nil)
+	s.env.OnActivity(ActivityFixerConfig, mock.Anything, FixShardConfigParams{}).Return(&FixShardConfigResults{}, nil)
+	s.env.OnActivity(ActivityScanReportExport, mock.Anything, ScanReportExportActivityParams{
+		ScannerWorkflowWorkflowID: "test-wid",
+		ScannerWorkflowRunID:      "test-rid",
+		BlobstoreKey:              "reports/test-rid.json",
+	}).Return(nil).Once()
+	s.env.OnActivity(ActivityFixShard, mock.Anything, mock.Anything).Return([]FixReport{{ShardID: 1}}, nil)
+
+	s.env.ExecuteWorkflow(NewTestFixerWorkflow, FixerWorkflowParams{
+		ScannerWorkflowWorkflowID: "test-wid",
+		ScannerWorkflowRunID:      "test-rid",
+		ScanReportBlobstoreKey:    "reports/test-rid.json",
+	})
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	s.env.AssertExpectations(s.T())
+}
+
+func (s *workflowsSuite) TestFixerWorkflow_ScanReportExportError() {
+	s.env.OnActivity(ActivityFixerCorruptedKeys, mock.Anything, mock.Anything).Return(&FixerCorruptedKeysActivityResult{
+		CorruptedKeys:             []CorruptedKeysEntry{{ShardID: 1}},
+		MinShard:                  common.IntPtr(1),
+		MaxShard:                  common.IntPtr(1),
+		ShardQueryPaginationToken: ShardQueryPaginationToken{IsDone: true},
+	}, nil)
+	s.env.OnActivity(ActivityFixerConfig, mock.Anything, FixShardConfigParams{}).Return(&FixShardConfigResults{}, nil)
+	s.env.OnActivity(ActivityScanReportExport, mock.Anything, mock.Anything).Return(cadence.NewCustomError(ErrSerialization))
+
+	s.env.ExecuteWorkflow(NewTestFixerWorkflow, FixerWorkflowParams{
+		ScannerWorkflowWorkflowID: "test-wid",
+		ScanReportBlobstoreKey:    "reports/latest.json",
+	})
+	s.True(s.env.IsWorkflowCompleted())
+	s.ErrorContains(s.env.GetWorkflowError(), ErrSerialization)
+}
+
+func (s *workflowsSuite) TestFixerWorkflow_ScanReportExportWithMultipleRuns() {
+	s.env.ExecuteWorkflow(NewTestFixerWorkflow, FixerWorkflowParams{
+		ScannerWorkflowWorkflowID: "test-wid",
+		ScannerWorkflowRunIDs:     []string{"rid-1", "rid-2"},
+		ScanReportBlobstoreKey:    "reports/test.json",
+	})
+	s.True(s.env.IsWorkflowCompleted())
+	s.ErrorContains(s.env.GetWorkflowError(), "scan report export is not supported when fixing multiple scanner runs")
+}
+
 func (s *workflowsSuite) TestGetCorruptedKeys_Success() {
 	s.env.OnActivity(ActivityFixerCorruptedKeys, mock.Anything, FixerCorruptedKeysActivityParams{
 		ScannerWorkflowWorkflowID: "test_wid",
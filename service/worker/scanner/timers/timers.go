@@ -178,13 +178,15 @@ func ScannerConfig(dc *dynamicconfig.Collection) *shardscanner.ScannerConfig {
 		ScannerWFTypeName: ScannerWFTypeName,
 		FixerWFTypeName:   FixerWFTypeName,
 		DynamicParams: shardscanner.DynamicParams{
-			ScannerEnabled:          dc.GetBoolProperty(dynamicconfig.TimersScannerEnabled),
-			FixerEnabled:            dc.GetBoolProperty(dynamicconfig.TimersFixerEnabled),
-			Concurrency:             dc.GetIntProperty(dynamicconfig.TimersScannerConcurrency),
-			PageSize:                dc.GetIntProperty(dynamicconfig.TimersScannerPersistencePageSize),
-			BlobstoreFlushThreshold: dc.GetIntProperty(dynamicconfig.TimersScannerBlobstoreFlushThreshold),
-			ActivityBatchSize:       dc.GetIntProperty(dynamicconfig.TimersScannerActivityBatchSize),
-			AllowDomain:             dc.GetBoolPropertyFilteredByDomain(dynamicconfig.TimersFixerDomainAllow),
+			ScannerEnabled:            dc.GetBoolProperty(dynamicconfig.TimersScannerEnabled),
+			FixerEnabled:              dc.GetBoolProperty(dynamicconfig.TimersFixerEnabled),
+			Concurrency:               dc.GetIntProperty(dynamicconfig.TimersScannerConcurrency),
+			PageSize:                  dc.GetIntProperty(dynamicconfig.TimersScannerPersistencePageSize),
+			BlobstoreFlushThreshold:   dc.GetIntProperty(dynamicconfig.TimersScannerBlobstoreFlushThreshold),
+			BlobstoreWriteBudgetBytes: dc.GetIntProperty(dynamicconfig.TimersScannerBlobstoreWriteBudgetBytes),
+			ShardConcurrency:          dc.GetIntProperty(dynamicconfig.TimersScannerShardConcurrency),
+			ActivityBatchSize:         dc.GetIntProperty(dynamicconfig.TimersScannerActivityBatchSize),
+			AllowDomain:               dc.GetBoolPropertyFilteredByDomain(dynamicconfig.TimersFixerDomainAllow),
 		},
 		DynamicCollection: dc,
 		ScannerHooks:      ScannerHooks,
@@ -74,6 +74,7 @@ func (s *timersWorkflowsSuite) TestScannerWorkflow_Success() {
 		GenericScannerConfig: shardscanner.GenericScannerConfig{
 			Enabled:           true,
 			Concurrency:       3,
+			ShardConcurrency:  1,
 			ActivityBatchSize: 5,
 		},
 		CustomScannerConfig: cconfig,
@@ -135,6 +136,7 @@ func (s *timersWorkflowsSuite) TestScannerWorkflow_Success() {
 		env.OnActivity(shardscanner.ActivityScanShard, mock.Anything, shardscanner.ScanShardActivityParams{
 			Shards:        batch,
 			ScannerConfig: cconfig,
+			Concurrency:   1,
 		}).Return(reports, nil)
 	}
 
@@ -85,6 +85,44 @@ func newAdminWorkflowCommands() []cli.Command {
 			},
 			Action: AdminDescribeWorkflow,
 		},
+		{
+			Name:    "get-version-histories",
+			Aliases: []string{"gvh"},
+			Usage:   "Show version histories of a workflow execution from its mutable state",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID",
+				},
+				getFormatFlag(),
+			},
+			Action: AdminGetVersionHistories,
+		},
+		{
+			Name:    "diff-clusters",
+			Aliases: []string{"dc"},
+			Usage:   "Compare the mutable state of a workflow execution in the current cluster with a remote cluster",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID, default to the current run in the current cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagDestinationAddress,
+					Usage: "Remote cadence-frontend address in <host>:<port> format",
+				},
+				getFormatFlag(),
+			},
+			Action: AdminDiffWorkflowAcrossClusters,
+		},
 		{
 			Name:    "refresh-tasks",
 			Aliases: []string{"rt"},
@@ -159,6 +197,7 @@ func newAdminShardManagementCommands() []cli.Command {
 					Name:  FlagShardID,
 					Usage: "The Id of the shard to describe",
 				},
+				getFormatFlag(),
 			),
 			Action: AdminDescribeShard,
 		},
@@ -252,13 +291,15 @@ func newAdminShardManagementCommands() []cli.Command {
 					Value: 500,
 				},
 				cli.StringFlag{
-					Name:  FlagStartDate,
-					Usage: "start date",
+					Name: FlagStartDate,
+					Usage: "start date, supported formats are '2006-01-02T15:04:05+07:00', raw UnixNano and " +
+						"time range (N<duration>), e.g. '2h' implies two hours ago",
 					Value: time.Now().UTC().Format(time.RFC3339),
 				},
 				cli.StringFlag{
-					Name:  FlagEndDate,
-					Usage: "end date",
+					Name: FlagEndDate,
+					Usage: "end date, supported formats are '2006-01-02T15:04:05+07:00', raw UnixNano and " +
+						"time range (N<duration>), e.g. '2h' implies two hours ago",
 					Value: time.Now().UTC().Add(24 * time.Hour).Format(time.RFC3339),
 				},
 				cli.StringFlag{
@@ -407,39 +448,22 @@ func newAdminDomainCommands() []cli.Command {
 			Name:    "list",
 			Aliases: []string{"l"},
 			Usage:   "List all domains in the cluster",
-			Flags: []cli.Flag{
-				cli.IntFlag{
-					Name:  FlagPageSizeWithAlias,
-					Value: 10,
-					Usage: "Result page size",
-				},
-				cli.BoolFlag{
-					Name:  FlagAllWithAlias,
-					Usage: "List all domains, by default only domains in REGISTERED status are listed",
-				},
-				cli.BoolFlag{
-					Name:  FlagDeprecatedWithAlias,
-					Usage: "List deprecated domains only, by default only domains in REGISTERED status are listed",
-				},
-				cli.StringFlag{
-					Name:  FlagPrefix,
-					Usage: "List domains that are matching to the given prefix",
-					Value: "",
-				},
-				cli.BoolFlag{
-					Name:  FlagPrintFullyDetailWithAlias,
-					Usage: "Print full domain detail",
-				},
-				cli.BoolFlag{
-					Name:  FlagPrintJSONWithAlias,
-					Usage: "Print in raw json format (DEPRECATED: instead use --format json)",
-				},
-				getFormatFlag(),
-			},
+			Flags:   listDomainFlags,
 			Action: func(c *cli.Context) {
 				newDomainCLI(c, false).ListDomains(c)
 			},
 		},
+		{
+			Name:  "list-deleted",
+			Usage: "List soft-deleted domains which are not purged yet, with their deletion time",
+			Flags: append(getDBFlags(),
+				cli.IntFlag{
+					Name:  FlagPageSizeWithAlias,
+					Value: defaultPageSizeForList,
+					Usage: "Page size used to read domains from the database",
+				}),
+			Action: AdminListDeletedDomains,
+		},
 	}
 }
 
@@ -505,6 +529,14 @@ func newAdminKafkaCommands() []cli.Command {
 					Name:  FlagRunIDWithAlias,
 					Usage: "RunID",
 				},
+				cli.Int64Flag{
+					Name:  FlagMinEventID,
+					Usage: "MinEventID Optional, default to the first event",
+				},
+				cli.Int64Flag{
+					Name:  FlagStartEventVersion,
+					Usage: "Version of the event before MinEventID, required if MinEventID is specified",
+				},
 				cli.Int64Flag{
 					Name:  FlagMaxEventID,
 					Usage: "MaxEventID Optional, default to all events",
@@ -639,6 +671,26 @@ func newAdminTaskListCommands() []cli.Command {
 			},
 			Action: AdminDescribeTaskList,
 		},
+		{
+			Name:  "describe-partition",
+			Usage: "Describe pollers and status information of a single tasklist partition, without aggregating over partitions",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskListWithAlias,
+					Usage: "TaskList name, either the root name or a full partition name like /__cadence_sys/<name>/<partition>",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskListTypeWithAlias,
+					Value: "decision",
+					Usage: "Optional TaskList type [decision|activity]",
+				},
+				cli.IntFlag{
+					Name:  FlagTaskListPartition,
+					Usage: "Partition ID, where 0 is the root partition. Required unless a full partition name is given",
+				},
+			},
+			Action: AdminDescribeTaskListPartition,
+		},
 		{
 			Name:    "list",
 			Aliases: []string{"l"},
@@ -651,6 +703,39 @@ func newAdminTaskListCommands() []cli.Command {
 			},
 			Action: AdminListTaskList,
 		},
+		{
+			Name:  "drain",
+			Usage: "Delete the persisted backlog of a tasklist up to its highest task ID when the command starts",
+			Flags: append(getDBFlags(),
+				cli.StringFlag{
+					Name:  FlagTaskListWithAlias,
+					Usage: "TaskList name",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskListTypeWithAlias,
+					Value: "decision",
+					Usage: "Optional TaskList type [decision|activity]",
+				},
+				cli.IntFlag{
+					Name:  FlagMaxTaskCount,
+					Usage: "Maximum number of tasks to drain. Required",
+				},
+				cli.IntFlag{
+					Name:  FlagBatchSizeWithAlias,
+					Value: defaultTaskListDrainBatchSize,
+					Usage: "Number of tasks deleted per persistence call",
+				},
+				cli.BoolFlag{
+					Name:  FlagDryRun + ", dry-run",
+					Usage: "Only report the persisted backlog of the tasklist",
+				},
+				cli.BoolFlag{
+					Name:  FlagYes,
+					Usage: "Optional flag to disable confirmation prompt",
+				},
+			),
+			Action: AdminDrainTaskList,
+		},
 	}
 }
 
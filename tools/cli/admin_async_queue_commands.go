@@ -49,12 +49,12 @@ func AdminGetAsyncWFConfig(c *cli.Context) {
 	}
 
 	if resp == nil || resp.Configuration == nil {
-		fmt.Printf("Async workflow queue config not found for domain %s\n", domainName)
+		fmt.Fprintf(getOutputWriter(c), "Async workflow queue config not found for domain %s\n", domainName)
 		return
 	}
 
-	fmt.Printf("Async workflow queue config for domain %s:\n", domainName)
-	prettyPrintJSONObject(resp.Configuration)
+	fmt.Fprintf(getOutputWriter(c), "Async workflow queue config for domain %s:\n", domainName)
+	prettyPrintJSONObject(getOutputWriter(c), resp.Configuration)
 }
 
 func AdminUpdateAsyncWFConfig(c *cli.Context) {
@@ -82,5 +82,5 @@ func AdminUpdateAsyncWFConfig(c *cli.Context) {
 		ErrorAndExit("Failed to update async workflow queue config", err)
 	}
 
-	fmt.Printf("Successfully updated async workflow queue config for domain %s\n", domainName)
+	fmt.Fprintf(getOutputWriter(c), "Successfully updated async workflow queue config for domain %s\n", domainName)
 }
@@ -69,7 +69,7 @@ func AdminAddSearchAttribute(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("Add search attribute failed.", err)
 	}
-	fmt.Println("Success. Note that for a multil-node Cadence cluster, DynamicConfig MUST be updated separately to whitelist the new attributes.")
+	fmt.Fprintln(getOutputWriter(c), "Success. Note that for a multil-node Cadence cluster, DynamicConfig MUST be updated separately to whitelist the new attributes.")
 }
 
 // AdminDescribeCluster is used to dump information about the cluster
@@ -83,7 +83,7 @@ func AdminDescribeCluster(c *cli.Context) {
 		ErrorAndExit("Operation DescribeCluster failed.", err)
 	}
 
-	prettyPrintJSONObject(response)
+	prettyPrintJSONObject(getOutputWriter(c), response)
 }
 
 func AdminRebalanceStart(c *cli.Context) {
@@ -128,9 +128,9 @@ func AdminRebalanceStart(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("Failed to start failover workflow", err)
 	}
-	fmt.Println("Rebalance workflow started")
-	fmt.Println("wid: " + workflowID)
-	fmt.Println("rid: " + resp.GetRunID())
+	fmt.Fprintln(getOutputWriter(c), "Rebalance workflow started")
+	fmt.Fprintln(getOutputWriter(c), "wid: "+workflowID)
+	fmt.Fprintln(getOutputWriter(c), "rid: "+resp.GetRunID())
 }
 
 func AdminRebalanceList(c *cli.Context) {
@@ -31,6 +31,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client/admin"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/codec"
 	"github.com/uber/cadence/common/persistence"
@@ -89,7 +90,7 @@ func AdminShowWorkflow(c *cli.Context) {
 	totalSize := 0
 	for idx, b := range history {
 		totalSize += len(b.Data)
-		fmt.Printf("======== batch %v, blob len: %v ======\n", idx+1, len(b.Data))
+		fmt.Fprintf(getOutputWriter(c), "======== batch %v, blob len: %v ======\n", idx+1, len(b.Data))
 		internalHistoryBatch, err := serializer.DeserializeBatchEvents(b)
 		if err != nil {
 			ErrorAndExit("DeserializeBatchEvents err", err)
@@ -101,10 +102,10 @@ func AdminShowWorkflow(c *cli.Context) {
 			if err != nil {
 				ErrorAndExit("json.Marshal err", err)
 			}
-			fmt.Println(string(jsonstr))
+			fmt.Fprintln(getOutputWriter(c), string(jsonstr))
 		}
 	}
-	fmt.Printf("======== total batches %v, total blob len: %v ======\n", len(history), totalSize)
+	fmt.Fprintf(getOutputWriter(c), "======== total batches %v, total blob len: %v ======\n", len(history), totalSize)
 
 	if outputFileName != "" {
 		data, err := json.Marshal(allEvents.Events)
@@ -121,7 +122,7 @@ func AdminShowWorkflow(c *cli.Context) {
 func AdminDescribeWorkflow(c *cli.Context) {
 
 	resp := describeMutableState(c)
-	prettyPrintJSONObject(resp)
+	prettyPrintJSONObject(getOutputWriter(c), resp)
 
 	if resp != nil {
 		msStr := resp.GetMutableStateInDatabase()
@@ -146,18 +147,129 @@ func AdminDescribeWorkflow(c *cli.Context) {
 		if err != nil {
 			ErrorAndExit("thriftrwEncoder.Decode err", err)
 		}
-		prettyPrintJSONObject(branchInfo)
+		prettyPrintJSONObject(getOutputWriter(c), branchInfo)
 		if ms.ExecutionInfo.AutoResetPoints != nil {
-			fmt.Println("auto-reset-points:")
+			fmt.Fprintln(getOutputWriter(c), "auto-reset-points:")
 			for _, p := range ms.ExecutionInfo.AutoResetPoints.Points {
 				createT := time.Unix(0, p.GetCreatedTimeNano())
 				expireT := time.Unix(0, p.GetExpiringTimeNano())
-				fmt.Println(p.GetBinaryChecksum(), p.GetRunID(), p.GetFirstDecisionCompletedID(), p.GetResettable(), createT, expireT)
+				fmt.Fprintln(getOutputWriter(c), p.GetBinaryChecksum(), p.GetRunID(), p.GetFirstDecisionCompletedID(), p.GetResettable(), createT, expireT)
 			}
 		}
 	}
 }
 
+// VersionHistoryRow is a row of version histories table, one per version history item
+type VersionHistoryRow struct {
+	Index    int    `header:"Index"`
+	Current  bool   `header:"Current"`
+	TreeID   string `header:"TreeID"`
+	BranchID string `header:"BranchID"`
+	EventID  int64  `header:"EventID"`
+	Version  int64  `header:"Version"`
+}
+
+// AdminGetVersionHistories shows version histories of a workflow execution
+func AdminGetVersionHistories(c *cli.Context) {
+	resp := describeMutableState(c)
+
+	ms := persistence.WorkflowMutableState{}
+	if err := json.Unmarshal([]byte(resp.GetMutableStateInDatabase()), &ms); err != nil {
+		ErrorAndExit("json.Unmarshal err", err)
+	}
+	if ms.VersionHistories == nil {
+		ErrorAndExit("Workflow execution has no version histories", nil)
+		return
+	}
+
+	table := []VersionHistoryRow{}
+	thriftrwEncoder := codec.NewThriftRWEncoder()
+	for index, history := range ms.VersionHistories.Histories {
+		branchInfo := shared.HistoryBranch{}
+		if err := thriftrwEncoder.Decode(history.GetBranchToken(), &branchInfo); err != nil {
+			ErrorAndExit(fmt.Sprintf("Failed to decode branch token of version history %d", index), err)
+		}
+		for _, item := range history.Items {
+			table = append(table, VersionHistoryRow{
+				Index:    index,
+				Current:  index == ms.VersionHistories.CurrentVersionHistoryIndex,
+				TreeID:   branchInfo.GetTreeID(),
+				BranchID: branchInfo.GetBranchID(),
+				EventID:  item.EventID,
+				Version:  item.Version,
+			})
+		}
+	}
+	Render(c, table, RenderOptions{DefaultTemplate: templateTable, Color: true})
+}
+
+// MutableStateDiffRow is a row of the mutable state diff table, one per differing field
+type MutableStateDiffRow struct {
+	Field   string `header:"Field"`
+	Current string `header:"Current"`
+	Remote  string `header:"Remote"`
+}
+
+// AdminDiffWorkflowAcrossClusters compares the mutable state of a workflow execution in the current and a remote cluster
+func AdminDiffWorkflowAcrossClusters(c *cli.Context) {
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	getRequiredOption(c, FlagDestinationAddress)
+
+	current := getMutableStateFromDatabase(c, cFactory.ServerAdminClient(c), domain, wid, c.String(FlagRunID))
+	// pin the remote cluster to the run described in the current cluster
+	rid := c.String(FlagRunID)
+	if rid == "" && current.ExecutionInfo != nil {
+		rid = current.ExecutionInfo.RunID
+	}
+	remote := getMutableStateFromDatabase(c, cFactory.ServerAdminClientForMigration(c), domain, wid, rid)
+
+	diffs := persistence.DiffWorkflowMutableState(current, remote)
+	if len(diffs) == 0 {
+		fmt.Fprintln(getOutputWriter(c), "Mutable states are identical.")
+		return
+	}
+	table := make([]MutableStateDiffRow, 0, len(diffs))
+	for _, diff := range diffs {
+		table = append(table, MutableStateDiffRow{
+			Field:   diff.Path,
+			Current: diff.A,
+			Remote:  diff.B,
+		})
+	}
+	Render(c, table, RenderOptions{DefaultTemplate: templateTable, Color: true})
+}
+
+func getMutableStateFromDatabase(
+	c *cli.Context,
+	adminClient admin.Client,
+	domain string,
+	wid string,
+	rid string,
+) *persistence.WorkflowMutableState {
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.DescribeWorkflowExecution(
+		ctx,
+		&types.AdminDescribeWorkflowExecutionRequest{
+			Domain: domain,
+			Execution: &types.WorkflowExecution{
+				WorkflowID: wid,
+				RunID:      rid,
+			},
+		},
+	)
+	if err != nil {
+		ErrorAndExit("Get workflow mutableState failed", err)
+	}
+	ms := &persistence.WorkflowMutableState{}
+	if err := json.Unmarshal([]byte(resp.GetMutableStateInDatabase()), ms); err != nil {
+		ErrorAndExit("json.Unmarshal err", err)
+	}
+	return ms
+}
+
 func describeMutableState(c *cli.Context) *types.AdminDescribeWorkflowExecutionResponse {
 	adminClient := cFactory.ServerAdminClient(c)
 
@@ -277,8 +389,8 @@ func AdminDeleteWorkflow(c *cli.Context) {
 		if err != nil {
 			ErrorAndExit("thriftrwEncoder.Decode err", err)
 		}
-		fmt.Println("deleting history events for ...")
-		prettyPrintJSONObject(branchInfo)
+		fmt.Fprintln(getOutputWriter(c), "deleting history events for ...")
+		prettyPrintJSONObject(getOutputWriter(c), branchInfo)
 		err = histV2.DeleteHistoryBranch(ctx, &persistence.DeleteHistoryBranchRequest{
 			BranchToken: branchToken,
 			ShardID:     &shardIDInt,
@@ -286,7 +398,7 @@ func AdminDeleteWorkflow(c *cli.Context) {
 		})
 		if err != nil {
 			if skipError {
-				fmt.Println("failed to delete history, ", err)
+				fmt.Fprintln(getOutputWriter(c), "failed to delete history, ", err)
 			} else {
 				ErrorAndExit("DeleteHistoryBranch err", err)
 			}
@@ -303,12 +415,12 @@ func AdminDeleteWorkflow(c *cli.Context) {
 	err = exeStore.DeleteWorkflowExecution(ctx, req)
 	if err != nil {
 		if skipError {
-			fmt.Println("delete mutableState row failed, ", err)
+			fmt.Fprintln(getOutputWriter(c), "delete mutableState row failed, ", err)
 		} else {
 			ErrorAndExit("delete mutableState row failed", err)
 		}
 	}
-	fmt.Println("delete mutableState row successfully")
+	fmt.Fprintln(getOutputWriter(c), "delete mutableState row successfully")
 
 	deleteCurrentReq := &persistence.DeleteCurrentWorkflowExecutionRequest{
 		DomainID:   domainID,
@@ -319,12 +431,12 @@ func AdminDeleteWorkflow(c *cli.Context) {
 	err = exeStore.DeleteCurrentWorkflowExecution(ctx, deleteCurrentReq)
 	if err != nil {
 		if skipError {
-			fmt.Println("delete current row failed, ", err)
+			fmt.Fprintln(getOutputWriter(c), "delete current row failed, ", err)
 		} else {
 			ErrorAndExit("delete current row failed", err)
 		}
 	}
-	fmt.Println("delete current row successfully")
+	fmt.Fprintln(getOutputWriter(c), "delete current row successfully")
 }
 
 // AdminGetDomainIDOrName map domain
@@ -344,16 +456,65 @@ func AdminGetDomainIDOrName(c *cli.Context) {
 		if err != nil {
 			ErrorAndExit("SelectDomain error", err)
 		}
-		fmt.Printf("domainName for domainID %v is %v \n", domainID, domain.Info.Name)
+		fmt.Fprintf(getOutputWriter(c), "domainName for domainID %v is %v \n", domainID, domain.Info.Name)
 	} else {
 		domain, err := domainManager.GetDomain(ctx, &persistence.GetDomainRequest{Name: domainName})
 		if err != nil {
 			ErrorAndExit("SelectDomain error", err)
 		}
-		fmt.Printf("domainID for domainName %v is %v \n", domain.Info.ID, domainID)
+		fmt.Fprintf(getOutputWriter(c), "domainID for domainName %v is %v \n", domain.Info.ID, domainID)
 	}
 }
 
+// DeletedDomainRow is a row in the output of admin domain list-deleted
+type DeletedDomainRow struct {
+	Name      string    `header:"Name"`
+	DomainID  string    `header:"UUID"`
+	DeletedAt time.Time `header:"Deleted At"`
+}
+
+// AdminListDeletedDomains lists domains which were soft-deleted but not purged yet
+func AdminListDeletedDomains(c *cli.Context) {
+	domainManager := initializeDomainManager(c)
+	pageSize := c.Int(FlagPageSize)
+	if pageSize <= 0 {
+		pageSize = defaultPageSizeForList
+	}
+
+	table := []DeletedDomainRow{}
+	var token []byte
+	for {
+		ctx, cancel := newContext(c)
+		resp, err := domainManager.ListDomains(ctx, &persistence.ListDomainsRequest{
+			PageSize:       pageSize,
+			NextPageToken:  token,
+			IncludeDeleted: true,
+		})
+		cancel()
+		if err != nil {
+			ErrorAndExit("Failed to list domains", err)
+			return
+		}
+		for _, domain := range resp.Domains {
+			deletedAt, ok := domain.Info.SoftDeletedTime()
+			if !ok {
+				continue
+			}
+			table = append(table, DeletedDomainRow{
+				Name:      domain.Info.Name,
+				DomainID:  domain.Info.ID,
+				DeletedAt: deletedAt,
+			})
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		token = resp.NextPageToken
+	}
+
+	Render(c, table, RenderOptions{DefaultTemplate: templateTable, Color: true, PrintDateTime: true})
+}
+
 // AdminGetShardID get shardID
 func AdminGetShardID(c *cli.Context) {
 	wid := getRequiredOption(c, FlagWorkflowID)
@@ -364,7 +525,7 @@ func AdminGetShardID(c *cli.Context) {
 		return
 	}
 	shardID := common.WorkflowIDToHistoryShard(wid, numberOfShards)
-	fmt.Printf("ShardID for workflowID: %v is %v \n", wid, shardID)
+	fmt.Fprintf(getOutputWriter(c), "ShardID for workflowID: %v is %v \n", wid, shardID)
 }
 
 // AdminRemoveTask describes history host
@@ -411,7 +572,72 @@ func AdminDescribeShard(c *cli.Context) {
 		ErrorAndExit("Failed to describe shard.", err)
 	}
 
-	prettyPrintJSONObject(shard)
+	Render(c, newShardDescriptionRow(shard.ShardInfo), RenderOptions{
+		DefaultTemplate: templateShard,
+		Color:           true,
+		PrintDateTime:   true,
+	})
+}
+
+var templateShard = `ShardID: {{.ShardID}}
+Owner: {{.Owner}}
+RangeID: {{.RangeID}}
+StolenSinceRenew: {{.StolenSinceRenew}}
+UpdatedAt: {{.UpdatedAt}}
+TransferAckLevel: {{.TransferAckLevel}}
+TimerAckLevel: {{.TimerAckLevel}}
+ReplicationAckLevel: {{.ReplicationAckLevel}}
+ReplicationDLQAckLevel: {{.ReplicationDLQAckLevel}}
+ClusterTransferAckLevel: {{.ClusterTransferAckLevel}}
+ClusterTimerAckLevel: {{.ClusterTimerAckLevel}}
+ClusterReplicationLevel: {{.ClusterReplicationLevel}}
+DomainNotificationVersion: {{.DomainNotificationVersion}}
+PendingFailoverMarkers: {{.PendingFailoverMarkers}}
+TransferProcessingQueueStates: {{json .TransferProcessingQueueStates}}
+TimerProcessingQueueStates: {{json .TimerProcessingQueueStates}}
+`
+
+// ShardDescriptionRow is the output of admin shard describe
+type ShardDescriptionRow struct {
+	ShardID                   int
+	Owner                     string
+	RangeID                   int64
+	StolenSinceRenew          int
+	UpdatedAt                 time.Time
+	TransferAckLevel          int64
+	TimerAckLevel             time.Time
+	ReplicationAckLevel       int64
+	ReplicationDLQAckLevel    map[string]int64
+	ClusterTransferAckLevel   map[string]int64
+	ClusterTimerAckLevel      map[string]time.Time
+	ClusterReplicationLevel   map[string]int64
+	DomainNotificationVersion int64
+	// PendingFailoverMarkers is the number of failover markers not yet processed by the shard
+	PendingFailoverMarkers        int
+	TransferProcessingQueueStates *types.ProcessingQueueStates
+	TimerProcessingQueueStates    *types.ProcessingQueueStates
+}
+
+func newShardDescriptionRow(info *persistence.ShardInfo) ShardDescriptionRow {
+	return ShardDescriptionRow{
+		ShardID:                   info.ShardID,
+		Owner:                     info.Owner,
+		RangeID:                   info.RangeID,
+		StolenSinceRenew:          info.StolenSinceRenew,
+		UpdatedAt:                 info.UpdatedAt,
+		TransferAckLevel:          info.TransferAckLevel,
+		TimerAckLevel:             info.TimerAckLevel,
+		ReplicationAckLevel:       info.ReplicationAckLevel,
+		ReplicationDLQAckLevel:    info.ReplicationDLQAckLevel,
+		ClusterTransferAckLevel:   info.ClusterTransferAckLevel,
+		ClusterTimerAckLevel:      info.ClusterTimerAckLevel,
+		ClusterReplicationLevel:   info.ClusterReplicationLevel,
+		DomainNotificationVersion: info.DomainNotificationVersion,
+		PendingFailoverMarkers:    len(info.PendingFailoverMarkers),
+
+		TransferProcessingQueueStates: info.TransferProcessingQueueStates,
+		TimerProcessingQueueStates:    info.TimerProcessingQueueStates,
+	}
 }
 
 // AdminSetShardRangeID set shard rangeID by shard id
@@ -442,7 +668,7 @@ func AdminSetShardRangeID(c *cli.Context) {
 		ErrorAndExit("Failed to reset shard rangeID.", err)
 	}
 
-	fmt.Printf("Successfully updated rangeID from %v to %v for shard %v.\n", previousRangeID, rid, sid)
+	fmt.Fprintf(getOutputWriter(c), "Successfully updated rangeID from %v to %v for shard %v.\n", previousRangeID, rid, sid)
 }
 
 // AdminCloseShard closes shard by shard id
@@ -484,8 +710,8 @@ func AdminDescribeShardDistribution(c *cli.Context) {
 		ErrorAndExit("Shard list failed", err)
 	}
 
-	fmt.Printf("Total Number of Shards: %d \n", resp.NumberOfShards)
-	fmt.Printf("Number of Shards Returned: %d \n", len(resp.Shards))
+	fmt.Fprintf(getOutputWriter(c), "Total Number of Shards: %d \n", resp.NumberOfShards)
+	fmt.Fprintf(getOutputWriter(c), "Number of Shards Returned: %d \n", len(resp.Shards))
 
 	if len(resp.Shards) == 0 {
 		return
@@ -546,7 +772,7 @@ func AdminDescribeHistoryHost(c *cli.Context) {
 	if !printFully {
 		resp.ShardIDs = nil
 	}
-	prettyPrintJSONObject(resp)
+	prettyPrintJSONObject(getOutputWriter(c), resp)
 }
 
 // AdminRefreshWorkflowTasks refreshes all the tasks of a workflow
@@ -570,7 +796,7 @@ func AdminRefreshWorkflowTasks(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("Refresh workflow task failed", err)
 	} else {
-		fmt.Println("Refresh workflow task succeeded.")
+		fmt.Fprintln(getOutputWriter(c), "Refresh workflow task succeeded.")
 	}
 }
 
@@ -595,7 +821,7 @@ func AdminResetQueue(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("Failed to reset queue", err)
 	}
-	fmt.Println("Reset queue state succeeded")
+	fmt.Fprintln(getOutputWriter(c), "Reset queue state succeeded")
 }
 
 // AdminDescribeQueue describes task processing queue states
@@ -621,6 +847,6 @@ func AdminDescribeQueue(c *cli.Context) {
 	}
 
 	for _, state := range resp.ProcessingQueueStates {
-		fmt.Println(state)
+		fmt.Fprintln(getOutputWriter(c), state)
 	}
 }
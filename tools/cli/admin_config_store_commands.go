@@ -69,17 +69,17 @@ func AdminGetDynamicConfig(c *cli.Context) {
 		}
 
 		if val == nil || val.Entries == nil || len(val.Entries) == 0 {
-			fmt.Printf("No dynamic config values stored to list.\n")
+			fmt.Fprintf(getOutputWriter(c), "No dynamic config values stored to list.\n")
 		} else {
 			cliEntries := make([]*cliEntry, 0, len(val.Entries))
 			for _, dcEntry := range val.Entries {
 				cliEntry, err := convertToInputEntry(dcEntry)
 				if err != nil {
-					fmt.Printf("Cannot parse list response.\n")
+					fmt.Fprintf(getOutputWriter(c), "Cannot parse list response.\n")
 				}
 				cliEntries = append(cliEntries, cliEntry)
 			}
-			prettyPrintJSONObject(cliEntries)
+			prettyPrintJSONObject(getOutputWriter(c), cliEntries)
 		}
 	} else {
 		parsedFilters, err := parseInputFilterArray(filters)
@@ -104,9 +104,9 @@ func AdminGetDynamicConfig(c *cli.Context) {
 		}
 
 		if umVal == nil {
-			fmt.Printf("No values stored for specified dynamic config.\n")
+			fmt.Fprintf(getOutputWriter(c), "No values stored for specified dynamic config.\n")
 		} else {
-			prettyPrintJSONObject(umVal)
+			prettyPrintJSONObject(getOutputWriter(c), umVal)
 		}
 	}
 }
@@ -151,7 +151,7 @@ func AdminUpdateDynamicConfig(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("Failed to update dynamic config value", err)
 	}
-	fmt.Printf("Dynamic Config %q updated with %s \n", dcName, dcValues)
+	fmt.Fprintf(getOutputWriter(c), "Dynamic Config %q updated with %s \n", dcName, dcValues)
 }
 
 // AdminRestoreDynamicConfig removes values of specified dynamic config parameter matching specified filter
@@ -178,7 +178,7 @@ func AdminRestoreDynamicConfig(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("Failed to restore dynamic config value", err)
 	}
-	fmt.Printf("Dynamic Config %q restored\n", dcName)
+	fmt.Fprintf(getOutputWriter(c), "Dynamic Config %q restored\n", dcName)
 }
 
 // AdminListDynamicConfig lists all values associated with specified dynamic config parameter or all values for all dc parameter if none is specified.
@@ -198,17 +198,17 @@ func AdminListDynamicConfig(c *cli.Context) {
 	}
 
 	if val == nil || val.Entries == nil || len(val.Entries) == 0 {
-		fmt.Printf("No dynamic config values stored to list.\n")
+		fmt.Fprintf(getOutputWriter(c), "No dynamic config values stored to list.\n")
 	} else {
 		cliEntries := make([]*cliEntry, 0, len(val.Entries))
 		for _, dcEntry := range val.Entries {
 			cliEntry, err := convertToInputEntry(dcEntry)
 			if err != nil {
-				fmt.Printf("Cannot parse list response.\n")
+				fmt.Fprintf(getOutputWriter(c), "Cannot parse list response.\n")
 			}
 			cliEntries = append(cliEntries, cliEntry)
 		}
-		prettyPrintJSONObject(cliEntries)
+		prettyPrintJSONObject(getOutputWriter(c), cliEntries)
 	}
 }
 
@@ -113,7 +113,7 @@ func AdminDBClean(c *cli.Context) {
 			continue
 		}
 
-		fmt.Println(string(data))
+		fmt.Fprintln(getOutputWriter(c), string(data))
 	}
 }
 
@@ -118,7 +118,7 @@ func AdminDBScan(c *cli.Context) {
 			continue
 		}
 
-		fmt.Println(string(data))
+		fmt.Fprintln(getOutputWriter(c), string(data))
 	}
 }
 
@@ -168,7 +168,7 @@ func AdminDBScanUnsupportedWorkflow(c *cli.Context) {
 	defer outputFile.Close()
 	for i := startShardID; i <= endShardID; i++ {
 		listExecutionsByShardID(c, i, outputFile)
-		fmt.Printf("Shard %v scan operation is completed.\n", i)
+		fmt.Fprintf(getOutputWriter(c), "Shard %v scan operation is completed.\n", i)
 	}
 }
 
@@ -83,7 +83,7 @@ func AdminCountDLQMessages(c *cli.Context) {
 	}
 
 	if c.String(FlagDLQType) == "domain" {
-		fmt.Println(response.Domain)
+		fmt.Fprintln(getOutputWriter(c), response.Domain)
 		return
 	}
 
@@ -252,11 +252,11 @@ func AdminPurgeDLQMessages(c *cli.Context) {
 		})
 		cancel()
 		if err != nil {
-			fmt.Printf("Failed to purge DLQ message in shard %v with error: %v.\n", shardID, err)
+			fmt.Fprintf(getOutputWriter(c), "Failed to purge DLQ message in shard %v with error: %v.\n", shardID, err)
 			continue
 		}
 		time.Sleep(10 * time.Millisecond)
-		fmt.Printf("Successfully purge DLQ Messages in shard %v.\n", shardID)
+		fmt.Fprintf(getOutputWriter(c), "Successfully purge DLQ Messages in shard %v.\n", shardID)
 	}
 }
 
@@ -285,7 +285,7 @@ ShardIDLoop:
 			response, err := adminClient.MergeDLQMessages(ctx, request)
 			cancel()
 			if err != nil {
-				fmt.Printf("Failed to merge DLQ message in shard %v with error: %v.\n", shardID, err)
+				fmt.Fprintf(getOutputWriter(c), "Failed to merge DLQ message in shard %v with error: %v.\n", shardID, err)
 				continue ShardIDLoop
 			}
 
@@ -295,7 +295,7 @@ ShardIDLoop:
 
 			request.NextPageToken = response.NextPageToken
 		}
-		fmt.Printf("Successfully merged all messages in shard %v.\n", shardID)
+		fmt.Fprintf(getOutputWriter(c), "Successfully merged all messages in shard %v.\n", shardID)
 	}
 }
 
@@ -314,7 +314,7 @@ func generateShardRangeFromFlags(c *cli.Context) chan int {
 	go func() {
 		shardRange, err := parseIntMultiRange(c.String(FlagShards))
 		if err != nil {
-			fmt.Printf("failed to parse shard range: %q\n", c.String(FlagShards))
+			fmt.Fprintf(getOutputWriter(c), "failed to parse shard range: %q\n", c.String(FlagShards))
 		} else {
 			for _, shard := range shardRange {
 				shards <- shard
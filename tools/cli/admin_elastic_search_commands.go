@@ -337,7 +337,7 @@ func GenerateReport(c *cli.Context) {
 	}
 
 	// Show result to terminal
-	table := tablewriter.NewWriter(os.Stdout)
+	table := tablewriter.NewWriter(getOutputWriter(c))
 	var headers []string
 	var groupby, bucket map[string]interface{}
 	var buckets []interface{}
@@ -347,7 +347,7 @@ func GenerateReport(c *cli.Context) {
 	}
 	buckets = groupby["buckets"].([]interface{})
 	if len(buckets) == 0 {
-		fmt.Println("no matching bucket")
+		fmt.Fprintln(getOutputWriter(c), "no matching bucket")
 		return
 	}
 
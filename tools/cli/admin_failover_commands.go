@@ -77,7 +77,7 @@ func AdminFailoverPause(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("Failed to pause failover workflow", err)
 	}
-	fmt.Println("Failover paused on " + getFailoverWorkflowID(c))
+	fmt.Fprintln(getOutputWriter(c), "Failover paused on "+getFailoverWorkflowID(c))
 }
 
 // AdminFailoverResume resume a paused failover workflow
@@ -86,7 +86,7 @@ func AdminFailoverResume(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("Failed to resume failover workflow", err)
 	}
-	fmt.Println("Failover resumed on " + getFailoverWorkflowID(c))
+	fmt.Fprintln(getOutputWriter(c), "Failover resumed on "+getFailoverWorkflowID(c))
 }
 
 // AdminFailoverQuery query a failover workflow
@@ -112,7 +112,7 @@ func AdminFailoverQuery(c *cli.Context) {
 	if isWorkflowTerminated(descResp) {
 		result.State = failovermanager.WorkflowAborted
 	}
-	prettyPrintJSONObject(result)
+	prettyPrintJSONObject(getOutputWriter(c), result)
 }
 
 // AdminFailoverAbort abort a failover workflow
@@ -141,7 +141,7 @@ func AdminFailoverAbort(c *cli.Context) {
 		ErrorAndExit("Failed to abort failover workflow", err)
 	}
 
-	fmt.Println("Failover aborted")
+	fmt.Fprintln(getOutputWriter(c), "Failover aborted")
 }
 
 // AdminFailoverRollback rollback a failover run
@@ -175,6 +175,7 @@ func AdminFailoverRollback(c *cli.Context) {
 	// rollback includes both success and failed domains to make sure no leftover domains
 	rollbackDomains = append(rollbackDomains, queryResult.SuccessDomains...)
 	rollbackDomains = append(rollbackDomains, queryResult.FailedDomains...)
+	rollbackDomains = append(rollbackDomains, queryResult.UnverifiedDomains...)
 
 	params := &startParams{
 		targetCluster:                  queryResult.SourceCluster,
@@ -299,7 +300,7 @@ func failoverStart(c *cli.Context, params *startParams) {
 				ErrorAndExit("Failed to send pase signal to drill workflow", err)
 			}
 		}
-		fmt.Println("The failover drill workflow is paused. Please run 'cadence admin cluster failover resume --fd'" +
+		fmt.Fprintln(getOutputWriter(c), "The failover drill workflow is paused. Please run 'cadence admin cluster failover resume --fd'"+
 			" to resume the drill workflow.")
 	}
 
@@ -321,9 +322,9 @@ func failoverStart(c *cli.Context, params *startParams) {
 	if err != nil {
 		ErrorAndExit("Failed to start failover workflow", err)
 	}
-	fmt.Println("Failover workflow started")
-	fmt.Println("wid: " + workflowID)
-	fmt.Println("rid: " + wf.GetRunID())
+	fmt.Fprintln(getOutputWriter(c), "Failover workflow started")
+	fmt.Fprintln(getOutputWriter(c), "wid: "+workflowID)
+	fmt.Fprintln(getOutputWriter(c), "rid: "+wf.GetRunID())
 }
 
 func getFailoverWorkflowID(c *cli.Context) string {
@@ -129,7 +129,7 @@ func AdminKafkaParse(c *cli.Context) {
 	<-doneCh
 
 	if skipErrMode {
-		fmt.Printf("%v messages were skipped due to errors in parsing", atomic.LoadInt32(&skippedCount))
+		fmt.Fprintf(getOutputWriter(c), "%v messages were skipped due to errors in parsing", atomic.LoadInt32(&skippedCount))
 	}
 }
 
@@ -452,6 +452,8 @@ func doRereplicate(
 	domainID string,
 	wid string,
 	rid string,
+	startEventID *int64,
+	startEventVersion *int64,
 	endEventID *int64,
 	endEventVersion *int64,
 	sourceCluster string,
@@ -465,6 +467,8 @@ func doRereplicate(
 			WorkflowID:    wid,
 			RunID:         rid,
 			RemoteCluster: sourceCluster,
+			StartEventID:  startEventID,
+			StartVersion:  startEventVersion,
 			EndEventID:    endEventID,
 			EndVersion:    endEventVersion,
 		},
@@ -479,7 +483,16 @@ func AdminRereplicate(c *cli.Context) {
 	sourceCluster := getRequiredOption(c, FlagSourceCluster)
 
 	adminClient := cFactory.ServerAdminClient(c)
-	var endEventID, endVersion *int64
+	// the start and end of the range are exclusive in the request
+	var startEventID, startVersion, endEventID, endVersion *int64
+	if c.IsSet(FlagMinEventID) {
+		if !c.IsSet(FlagStartEventVersion) {
+			ErrorAndExit(fmt.Sprintf("Option %s is required if %s is specified.", FlagStartEventVersion, FlagMinEventID), nil)
+			return
+		}
+		startEventID = common.Int64Ptr(c.Int64(FlagMinEventID) - 1)
+		startVersion = common.Int64Ptr(c.Int64(FlagStartEventVersion))
+	}
 	if c.IsSet(FlagMaxEventID) {
 		endEventID = common.Int64Ptr(c.Int64(FlagMaxEventID) + 1)
 	}
@@ -502,6 +515,8 @@ func AdminRereplicate(c *cli.Context) {
 		domainID,
 		wid,
 		rid,
+		startEventID,
+		startVersion,
 		endEventID,
 		endVersion,
 		sourceCluster,
@@ -22,11 +22,15 @@ package cli
 
 import (
 	"fmt"
-	"os"
+	"io"
 	"strings"
 
+	"github.com/fatih/color"
 	"github.com/urfave/cli"
 
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/sql"
 	"github.com/uber/cadence/common/types"
 )
 
@@ -47,9 +51,34 @@ type (
 
 // AdminDescribeTaskList displays poller and status information of task list.
 func AdminDescribeTaskList(c *cli.Context) {
+	adminDescribeTaskList(c, getRequiredOption(c, FlagTaskList))
+}
+
+// AdminDescribeTaskListPartition displays poller and status information of a single task list partition.
+func AdminDescribeTaskListPartition(c *cli.Context) {
+	taskList := getRequiredOption(c, FlagTaskList)
+	if !strings.HasPrefix(taskList, common.ReservedTaskListPrefix) {
+		partition := getRequiredIntOption(c, FlagTaskListPartition)
+		if partition < 0 {
+			ErrorAndExit(fmt.Sprintf("Option %s must not be negative", FlagTaskListPartition), nil)
+		}
+		taskList = getTaskListPartitionName(taskList, partition)
+	}
+	fmt.Fprintln(getOutputWriter(c), "Task list partition: "+taskList)
+	adminDescribeTaskList(c, taskList)
+}
+
+// getTaskListPartitionName returns the name matching uses for the given partition of a task list
+func getTaskListPartitionName(taskList string, partition int) string {
+	if partition == 0 {
+		return taskList
+	}
+	return fmt.Sprintf("%v%v/%v", common.ReservedTaskListPrefix, taskList, partition)
+}
+
+func adminDescribeTaskList(c *cli.Context, taskList string) {
 	frontendClient := cFactory.ServerFrontendClient(c)
 	domain := getRequiredGlobalOption(c, FlagDomain)
-	taskList := getRequiredOption(c, FlagTaskList)
 	taskListType := types.TaskListTypeDecision
 	if strings.ToLower(c.String(FlagTaskListType)) == "activity" {
 		taskListType = types.TaskListTypeActivity
@@ -73,14 +102,14 @@ func AdminDescribeTaskList(c *cli.Context) {
 	if taskListStatus == nil {
 		ErrorAndExit(colorMagenta("No tasklist status information."), nil)
 	}
-	printTaskListStatus(taskListStatus)
-	fmt.Printf("\n")
+	printTaskListStatus(getOutputWriter(c), taskListStatus)
+	fmt.Fprintf(getOutputWriter(c), "\n")
 
 	pollers := response.Pollers
 	if len(pollers) == 0 {
 		ErrorAndExit(colorMagenta("No poller for tasklist: "+taskList), nil)
 	}
-	printTaskListPollers(pollers, taskListType)
+	printTaskListPollers(getOutputWriter(c), pollers, taskListType)
 }
 
 // AdminListTaskList displays all task lists under a domain.
@@ -99,7 +128,7 @@ func AdminListTaskList(c *cli.Context) {
 		ErrorAndExit("Operation GetTaskListByDomain failed.", err)
 	}
 
-	fmt.Println("Task Lists for domain " + domain + ":")
+	fmt.Fprintln(getOutputWriter(c), "Task Lists for domain "+domain+":")
 	table := []TaskListRow{}
 	for name, taskList := range response.GetDecisionTaskListMap() {
 		table = append(table, TaskListRow{name, "Decision", len(taskList.GetPollers())})
@@ -107,10 +136,124 @@ func AdminListTaskList(c *cli.Context) {
 	for name, taskList := range response.GetActivityTaskListMap() {
 		table = append(table, TaskListRow{name, "Activity", len(taskList.GetPollers())})
 	}
-	RenderTable(os.Stdout, table, RenderOptions{Color: true, Border: true})
+	RenderTable(getOutputWriter(c), table, RenderOptions{Color: true, Border: true})
+}
+
+// AdminDrainTaskList deletes up to a maximum number of persisted tasks of a task list.
+// Only tasks up to the highest persisted task ID when the command starts are deleted,
+// tasks written afterwards are kept.
+func AdminDrainTaskList(c *cli.Context) {
+	frontendClient := cFactory.ServerFrontendClient(c)
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	taskList := getRequiredOption(c, FlagTaskList)
+	maxCount := getRequiredIntOption(c, FlagMaxTaskCount)
+	if maxCount <= 0 {
+		ErrorAndExit(fmt.Sprintf("Option %s must be positive.", FlagMaxTaskCount), nil)
+	}
+	batchSize := c.Int(FlagBatchSize)
+	if batchSize <= 0 {
+		ErrorAndExit(fmt.Sprintf("Option %s must be positive.", FlagBatchSize), nil)
+	}
+	taskListType := types.TaskListTypeDecision
+	if strings.ToLower(c.String(FlagTaskListType)) == "activity" {
+		taskListType = types.TaskListTypeActivity
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	domainID := getDomainID(ctx, domain, frontendClient)
+	taskManager := initializeTaskManager(c)
+	defer taskManager.Close()
+	// stores which do not count deleted rows (e.g. Cassandra) also ignore the limit and delete in one call
+	limitSupported := sql.PluginRegistered(taskManager.GetName())
+
+	idRange, err := taskManager.GetTaskIDRange(ctx, &persistence.GetTaskIDRangeRequest{
+		DomainID:     domainID,
+		TaskListName: taskList,
+		TaskListType: int(taskListType),
+	})
+	if err != nil {
+		ErrorAndExit("Failed to get task ID range of tasklist.", err)
+	}
+	maxTaskID := idRange.MaxTaskID
+	if maxTaskID <= 0 {
+		fmt.Fprintf(getOutputWriter(c), "Tasklist %s has no persisted tasks, nothing to drain.\n", taskList)
+		return
+	}
+	size, err := taskManager.GetTaskListSize(ctx, &persistence.GetTaskListSizeRequest{
+		DomainID:     domainID,
+		DomainName:   domain,
+		TaskListName: taskList,
+		TaskListType: int(taskListType),
+	})
+	if err != nil {
+		ErrorAndExit("Failed to get size of tasklist.", err)
+	}
+
+	if c.Bool(FlagDryRun) {
+		if limitSupported {
+			fmt.Fprintf(getOutputWriter(c), "Tasklist %s has %d persisted tasks with ID up to %d, %d of them would be drained.\n",
+				taskList, size.Size, maxTaskID, common.MinInt64(size.Size, int64(maxCount)))
+		} else {
+			fmt.Fprintf(getOutputWriter(c), "Tasklist %s has %d persisted tasks with ID up to %d, all of them would be drained as the %s task store cannot limit deletes.\n",
+				taskList, size.Size, maxTaskID, taskManager.GetName())
+		}
+		return
+	}
+
+	if !c.Bool(FlagYes) {
+		if limitSupported {
+			promptFn(fmt.Sprintf("Are you sure to delete up to %d tasks with ID up to %d of tasklist [%s] in domain [%s]? Y/N",
+				maxCount, maxTaskID, color.YellowString(taskList), color.YellowString(domain)))
+		} else {
+			promptFn(fmt.Sprintf("The %s task store cannot limit deletes, %s is ignored. Are you sure to delete ALL tasks with ID up to %d of tasklist [%s] in domain [%s]? Y/N",
+				taskManager.GetName(), FlagMaxTaskCount, maxTaskID, color.YellowString(taskList), color.YellowString(domain)))
+		}
+	}
+
+	completeTasks := func(drained, limit int, exactCount bool) int {
+		resp, err := taskManager.CompleteTasksLessThan(ctx, &persistence.CompleteTasksLessThanRequest{
+			DomainID:     domainID,
+			TaskListName: taskList,
+			TaskType:     int(taskListType),
+			TaskID:       maxTaskID,
+			Limit:        limit,
+			DomainName:   domain,
+			ExactCount:   exactCount,
+		})
+		if err != nil {
+			ErrorAndExit(fmt.Sprintf("Failed to drain tasklist after %d tasks.", drained), err)
+		}
+		return resp.TasksCompleted
+	}
+
+	drained := 0
+	if size.Size <= int64(maxCount) {
+		// all persisted tasks fit in the limit, the store deletes them in batches and counts every deleted row
+		drained = completeTasks(drained, batchSize, true)
+	} else {
+		for drained < maxCount {
+			limit := common.MinInt(batchSize, maxCount-drained)
+			completed := completeTasks(drained, limit, false)
+			if completed == persistence.UnknownNumRowsAffected {
+				drained = completed
+				break
+			}
+			drained += completed
+			if completed < limit {
+				break
+			}
+		}
+	}
+	if drained == persistence.UnknownNumRowsAffected {
+		fmt.Fprintf(getOutputWriter(c), "Drained all tasks with ID up to %d of tasklist %s, the store does not report how many.\n", maxTaskID, taskList)
+		return
+	}
+	fmt.Fprintf(getOutputWriter(c), "Drained %d tasks of tasklist %s.\n", drained, taskList)
 }
 
-func printTaskListStatus(taskListStatus *types.TaskListStatus) {
+func printTaskListStatus(w io.Writer, taskListStatus *types.TaskListStatus) {
 	table := []TaskListStatusRow{{
 		ReadLevel: taskListStatus.GetReadLevel(),
 		AckLevel:  taskListStatus.GetAckLevel(),
@@ -118,5 +261,5 @@ func printTaskListStatus(taskListStatus *types.TaskListStatus) {
 		StartID:   taskListStatus.GetTaskIDBlock().GetStartID(),
 		EndID:     taskListStatus.GetTaskIDBlock().GetEndID(),
 	}}
-	RenderTable(os.Stdout, table, RenderOptions{Color: true})
+	RenderTable(w, table, RenderOptions{Color: true})
 }
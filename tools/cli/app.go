@@ -22,6 +22,7 @@ package cli
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/urfave/cli"
 
@@ -64,6 +65,10 @@ func NewCliApp() *cli.App {
 			Usage:  "optional timeout for context of RPC call in seconds",
 			EnvVar: "CADENCE_CONTEXT_TIMEOUT",
 		},
+		cli.StringFlag{
+			Name:  FlagFormat,
+			Usage: "Output format [table|json|yaml] for commands which support it; a command level --format takes precedence",
+		},
 		cli.StringFlag{
 			Name:   FlagJWT,
 			Usage:  "optional JWT for authorization. Either this or --jwt-private-key is needed for jwt authorization",
@@ -79,12 +84,34 @@ func NewCliApp() *cli.App {
 			Usage:  "optional argument for transport protocol format, either 'grpc' or 'tchannel'. Defaults to tchannel if not provided",
 			EnvVar: "CADENCE_CLI_TRANSPORT_PROTOCOL",
 		},
+		cli.DurationFlag{
+			Name:   FlagGRPCKeepaliveTime,
+			Usage:  "optional interval of gRPC keepalive pings on an idle connection, e.g. 5m. Values below 10s are raised to 10s. Keepalive is disabled if not provided. Only used with grpc transport",
+			EnvVar: "CADENCE_CLI_GRPC_KEEPALIVE_TIME",
+		},
+		cli.DurationFlag{
+			Name:   FlagGRPCKeepaliveTimeout,
+			Value:  defaultGRPCKeepaliveTimeout,
+			Usage:  "optional time to wait for a gRPC keepalive ping ack before closing the connection",
+			EnvVar: "CADENCE_CLI_GRPC_KEEPALIVE_TIMEOUT",
+		},
+		cli.BoolFlag{
+			Name:   FlagGRPCKeepalivePermitWithoutStream,
+			Usage:  "optional flag to send gRPC keepalive pings even without active requests",
+			EnvVar: "CADENCE_CLI_GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM",
+		},
 		cli.StringFlag{
 			Name:   FlagTLSCertPathWithAlias,
 			Usage:  "optional argument for path to TLS certificate. Defaults to an empty string if not provided",
 			EnvVar: "CADENCE_CLI_TLS_CERT_PATH",
 		},
+		cli.StringFlag{
+			Name:  FlagOutputPath,
+			Usage: "optional path of a file to write command output to instead of stdout",
+		},
 	}
+	app.Before = redirectOutput
+	app.After = restoreOutput
 	app.Commands = []cli.Command{
 		{
 			Name:        "domain",
@@ -212,3 +239,28 @@ func NewCliApp() *cli.App {
 	}
 	return app
 }
+
+// redirectOutput points the writer of the app to the file given by the output path flag,
+// so that the result of any command can be saved
+func redirectOutput(c *cli.Context) error {
+	path := c.String(FlagOutputPath)
+	if path == "" {
+		return nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create output file %s: %w", path, err)
+	}
+	c.App.Writer = f
+	return nil
+}
+
+// restoreOutput closes the output file and points the writer of the app back to stdout
+func restoreOutput(c *cli.Context) error {
+	f, ok := c.App.Writer.(*os.File)
+	if !ok || f == os.Stdout {
+		return nil
+	}
+	c.App.Writer = os.Stdout
+	return f.Close()
+}
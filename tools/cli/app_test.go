@@ -21,21 +21,34 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/golang/mock/gomock"
 	"github.com/olekukonko/tablewriter"
 	"github.com/olivere/elastic"
 	"github.com/pborman/uuid"
 	"github.com/stretchr/testify/suite"
 	"github.com/urfave/cli"
+	clientshared "go.uber.org/cadence/.gen/go/shared"
+	"go.uber.org/yarpc"
+	"gopkg.in/yaml.v2"
 
 	"github.com/uber/cadence/client/admin"
 	"github.com/uber/cadence/client/frontend"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/client"
+	"github.com/uber/cadence/common/persistence/sql/sqlplugin/mysql"
 	"github.com/uber/cadence/common/types"
 )
 
@@ -45,11 +58,14 @@ type cliAppSuite struct {
 	mockCtrl             *gomock.Controller
 	serverFrontendClient *frontend.MockClient
 	serverAdminClient    *admin.MockClient
+	// serverAdminClientForMigration is the admin client of the remote cluster
+	serverAdminClientForMigration *admin.MockClient
 }
 
 type clientFactoryMock struct {
-	serverFrontendClient frontend.Client
-	serverAdminClient    admin.Client
+	serverFrontendClient          frontend.Client
+	serverAdminClient             admin.Client
+	serverAdminClientForMigration admin.Client
 }
 
 func (m *clientFactoryMock) ServerFrontendClient(c *cli.Context) frontend.Client {
@@ -65,7 +81,7 @@ func (m *clientFactoryMock) ServerFrontendClientForMigration(c *cli.Context) fro
 }
 
 func (m *clientFactoryMock) ServerAdminClientForMigration(c *cli.Context) admin.Client {
-	panic("not implemented")
+	return m.serverAdminClientForMigration
 }
 
 func (m *clientFactoryMock) ElasticSearchClient(c *cli.Context) *elastic.Client {
@@ -98,9 +114,11 @@ func (s *cliAppSuite) SetupTest() {
 
 	s.serverFrontendClient = frontend.NewMockClient(s.mockCtrl)
 	s.serverAdminClient = admin.NewMockClient(s.mockCtrl)
+	s.serverAdminClientForMigration = admin.NewMockClient(s.mockCtrl)
 	SetFactory(&clientFactoryMock{
-		serverFrontendClient: s.serverFrontendClient,
-		serverAdminClient:    s.serverAdminClient,
+		serverFrontendClient:          s.serverFrontendClient,
+		serverAdminClient:             s.serverAdminClient,
+		serverAdminClientForMigration: s.serverAdminClientForMigration,
 	})
 }
 
@@ -267,6 +285,240 @@ func (s *cliAppSuite) TestDomainDescribe() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestDomainDescribe_OutputToFile() {
+	resp := describeDomainResponseServer
+	s.serverFrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).Return(resp, nil)
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "domain", "describe"})
+	s.Nil(err)
+	s.Equal(os.Stdout, s.app.Writer)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Contains(string(content), resp.DomainInfo.Name)
+}
+
+func (s *cliAppSuite) TestDomainDescribe_ShowAsyncWorkflowConfig() {
+	resp := &types.DescribeDomainResponse{
+		DomainInfo: describeDomainResponseServer.DomainInfo,
+		Configuration: &types.DomainConfiguration{
+			AsyncWorkflowConfig: &types.AsyncWorkflowConfiguration{
+				Enabled:   true,
+				QueueType: "kafka",
+				QueueConfig: &types.DataBlob{
+					EncodingType: types.EncodingTypeJSON.Ptr(),
+					Data:         []byte(`{"topic":"async-wf-topic"}`),
+				},
+			},
+		},
+		ReplicationConfiguration: describeDomainResponseServer.ReplicationConfiguration,
+	}
+	s.serverFrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).Return(resp, nil).Times(2)
+
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "domain", "describe", "--show-async-wf-config"})
+	s.Nil(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Contains(string(content), "Async workflow config:")
+	s.Contains(string(content), "kafka")
+	s.Contains(string(content), `{"topic":"async-wf-topic"}`)
+
+	path = filepath.Join(s.T().TempDir(), "output.txt")
+	err = s.app.Run([]string{"", "--do", domainName, "--output_path", path, "domain", "describe"})
+	s.Nil(err)
+	content, err = os.ReadFile(path)
+	s.NoError(err)
+	s.NotContains(string(content), "Async workflow config:")
+}
+
+func (s *cliAppSuite) TestDomainDescribe_GlobalFormat() {
+	resp := describeDomainResponseServer
+	for _, format := range []string{"json", "yaml"} {
+		s.serverFrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).Return(resp, nil)
+		path := filepath.Join(s.T().TempDir(), "output."+format)
+		err := s.app.Run([]string{"", "--do", domainName, "--format", format, "--output_path", path, "domain", "describe"})
+		s.Nil(err)
+		content, err := os.ReadFile(path)
+		s.NoError(err)
+		var decoded map[string]interface{}
+		if format == "json" {
+			s.NoError(json.Unmarshal(content, &decoded))
+			s.Equal(resp.DomainInfo.Name, decoded["Name"])
+		} else {
+			s.NoError(yaml.Unmarshal(content, &decoded))
+			s.Equal(resp.DomainInfo.Name, decoded["name"])
+		}
+	}
+}
+
+func newListDomainsTestDomain(name, activeCluster string, isGlobal bool) *types.DescribeDomainResponse {
+	return &types.DescribeDomainResponse{
+		DomainInfo: &types.DomainInfo{
+			Name:   name,
+			Status: types.DomainStatusRegistered.Ptr(),
+		},
+		Configuration: &types.DomainConfiguration{},
+		ReplicationConfiguration: &types.DomainReplicationConfiguration{
+			ActiveClusterName: activeCluster,
+		},
+		IsGlobalDomain: isGlobal,
+	}
+}
+
+func (s *cliAppSuite) runDomainListNames(args ...string) []string {
+	s.serverFrontendClient.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(&types.ListDomainsResponse{
+		Domains: []*types.DescribeDomainResponse{
+			newListDomainsTestDomain("global-a", "cluster-a", true),
+			newListDomainsTestDomain("global-b", "cluster-b", true),
+			newListDomainsTestDomain("local-a", "cluster-a", false),
+		},
+	}, nil)
+	path := filepath.Join(s.T().TempDir(), "output.json")
+	err := s.app.Run(append([]string{"", "--format", "json", "--output_path", path, "domain", "list"}, args...))
+	s.Nil(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	var rows []map[string]interface{}
+	s.NoError(json.Unmarshal(content, &rows))
+	var names []string
+	for _, row := range rows {
+		names = append(names, row["Name"].(string))
+	}
+	return names
+}
+
+func (s *cliAppSuite) TestDomainList() {
+	s.Equal([]string{"global-a", "global-b", "local-a"}, s.runDomainListNames())
+}
+
+func (s *cliAppSuite) TestDomainList_ActiveClusterFilter() {
+	s.Equal([]string{"global-a", "local-a"}, s.runDomainListNames("--active-cluster", "cluster-a"))
+}
+
+func (s *cliAppSuite) TestDomainList_GlobalOnlyFilter() {
+	s.Equal([]string{"global-a", "global-b"}, s.runDomainListNames("--global-only"))
+	s.Equal([]string{"global-a"}, s.runDomainListNames("--global-only", "--active-cluster", "cluster-a"))
+}
+
+func (s *cliAppSuite) TestAdminDomainListDeleted() {
+	deletedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	domainManager := persistence.NewMockDomainManager(s.mockCtrl)
+	factory := client.NewMockFactory(s.mockCtrl)
+	factory.EXPECT().NewDomainManager().Return(domainManager, nil)
+	oldFactory := persistenceFactory
+	persistenceFactory = factory
+	defer func() { persistenceFactory = oldFactory }()
+
+	domainManager.EXPECT().ListDomains(gomock.Any(), &persistence.ListDomainsRequest{
+		PageSize:       10,
+		IncludeDeleted: true,
+	}).Return(&persistence.ListDomainsResponse{
+		Domains: []*persistence.GetDomainResponse{
+			{Info: &persistence.DomainInfo{ID: "id-1", Name: "deleted-1", Data: map[string]string{
+				common.DomainDataKeyForSoftDeletedTime: deletedAt.Format(time.RFC3339Nano),
+			}}},
+			{Info: &persistence.DomainInfo{ID: "id-2", Name: "registered", Status: persistence.DomainStatusRegistered}},
+			{Info: &persistence.DomainInfo{ID: "id-4", Name: "deprecated", Status: persistence.DomainStatusDeleted}},
+		},
+		NextPageToken: []byte("token"),
+	}, nil)
+	domainManager.EXPECT().ListDomains(gomock.Any(), &persistence.ListDomainsRequest{
+		PageSize:       10,
+		NextPageToken:  []byte("token"),
+		IncludeDeleted: true,
+	}).Return(&persistence.ListDomainsResponse{
+		Domains: []*persistence.GetDomainResponse{
+			{Info: &persistence.DomainInfo{ID: "id-3", Name: "deleted-2", Data: map[string]string{
+				common.DomainDataKeyForSoftDeletedTime: deletedAt.Add(time.Hour).Format(time.RFC3339Nano),
+			}}},
+		},
+	}, nil)
+
+	path := filepath.Join(s.T().TempDir(), "output.json")
+	err := s.app.Run([]string{"", "--format", "json", "--output_path", path, "admin", "domain", "list-deleted", "--ps", "10"})
+	s.NoError(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	var rows []DeletedDomainRow
+	s.NoError(json.Unmarshal(content, &rows))
+	s.Len(rows, 2)
+	s.Equal("deleted-1", rows[0].Name)
+	s.Equal("id-1", rows[0].DomainID)
+	s.True(deletedAt.Equal(rows[0].DeletedAt))
+	s.Equal("deleted-2", rows[1].Name)
+	s.True(deletedAt.Add(time.Hour).Equal(rows[1].DeletedAt))
+}
+
+func (s *cliAppSuite) mockShardManager(shardID int) {
+	shardManager := persistence.NewMockShardManager(s.mockCtrl)
+	factory := client.NewMockFactory(s.mockCtrl)
+	factory.EXPECT().NewShardManager().Return(shardManager, nil)
+	oldFactory := persistenceFactory
+	persistenceFactory = factory
+	s.T().Cleanup(func() { persistenceFactory = oldFactory })
+
+	shardManager.EXPECT().GetShard(gomock.Any(), &persistence.GetShardRequest{ShardID: shardID}).Return(&persistence.GetShardResponse{
+		ShardInfo: &persistence.ShardInfo{
+			ShardID:                 shardID,
+			Owner:                   "host-1",
+			RangeID:                 42,
+			TransferAckLevel:        100,
+			TimerAckLevel:           time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+			ClusterTransferAckLevel: map[string]int64{"cluster-a": 100, "cluster-b": 90},
+			ClusterReplicationLevel: map[string]int64{"cluster-b": 80},
+			ReplicationDLQAckLevel:  map[string]int64{"cluster-b": 70},
+			PendingFailoverMarkers:  []*types.FailoverMarkerAttributes{{DomainID: "d1"}, {DomainID: "d2"}},
+			TransferProcessingQueueStates: &types.ProcessingQueueStates{StatesByCluster: map[string][]*types.ProcessingQueueState{
+				"cluster-a": {{Level: common.Int32Ptr(0), AckLevel: common.Int64Ptr(100), MaxLevel: common.Int64Ptr(120)}},
+			}},
+			TimerProcessingQueueStates: &types.ProcessingQueueStates{StatesByCluster: map[string][]*types.ProcessingQueueState{
+				"cluster-a": {{Level: common.Int32Ptr(0), AckLevel: common.Int64Ptr(200), MaxLevel: common.Int64Ptr(220)}},
+			}},
+		},
+	}, nil)
+}
+
+func (s *cliAppSuite) TestAdminDescribeShard() {
+	s.mockShardManager(7)
+
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	err := s.app.Run([]string{"", "--output_path", path, "admin", "shard", "describe", "--shard_id", "7"})
+	s.NoError(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Contains(string(content), "RangeID: 42\n")
+	s.Contains(string(content), "TransferAckLevel: 100\n")
+	s.Contains(string(content), "ClusterTransferAckLevel: map[cluster-a:100 cluster-b:90]\n")
+	s.Contains(string(content), "ClusterReplicationLevel: map[cluster-b:80]\n")
+	s.Contains(string(content), "PendingFailoverMarkers: 2\n")
+	s.Contains(string(content), "ReplicationDLQAckLevel: map[cluster-b:70]\n")
+	s.Contains(string(content), `"ackLevel": 100`)
+	s.Contains(string(content), `"ackLevel": 200`)
+}
+
+func (s *cliAppSuite) TestAdminDescribeShard_JSON() {
+	s.mockShardManager(7)
+
+	path := filepath.Join(s.T().TempDir(), "output.json")
+	err := s.app.Run([]string{"", "--output_path", path, "admin", "shard", "describe", "--shard_id", "7", "--format", "json"})
+	s.NoError(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	var row ShardDescriptionRow
+	s.NoError(json.Unmarshal(content, &row))
+	s.Equal(7, row.ShardID)
+	s.Equal("host-1", row.Owner)
+	s.Equal(int64(42), row.RangeID)
+	s.Equal(int64(100), row.TransferAckLevel)
+	s.True(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC).Equal(row.TimerAckLevel))
+	s.Equal(map[string]int64{"cluster-a": 100, "cluster-b": 90}, row.ClusterTransferAckLevel)
+	s.Equal(map[string]int64{"cluster-b": 80}, row.ClusterReplicationLevel)
+	s.Equal(2, row.PendingFailoverMarkers)
+	s.Equal(map[string]int64{"cluster-b": 70}, row.ReplicationDLQAckLevel)
+	s.Equal(int64(120), row.TransferProcessingQueueStates.StatesByCluster["cluster-a"][0].GetMaxLevel())
+	s.Equal(int64(220), row.TimerProcessingQueueStates.StatesByCluster["cluster-a"][0].GetMaxLevel())
+}
+
 func (s *cliAppSuite) TestDomainDescribe_DomainNotExist() {
 	resp := describeDomainResponseServer
 	s.serverFrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).Return(resp, &types.EntityNotExistsError{})
@@ -314,6 +566,123 @@ func (s *cliAppSuite) TestShowHistory() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestShowHistory_HighlightNotTerminal() {
+	resp := getWorkflowExecutionHistoryResponse
+	s.serverFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(resp, nil)
+	describeResp := &types.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &types.WorkflowExecutionInfo{},
+	}
+	s.serverFrontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(describeResp, nil)
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "workflow", "show", "-w", "wid", "-pf", "--highlight"})
+	s.Nil(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.True(strings.HasPrefix(string(content), "WorkflowExecutionStarted {"), string(content))
+	s.NotContains(string(content), "\x1b[")
+}
+
+func (s *cliAppSuite) TestShowHistory_GlobalFormatJSON() {
+	resp := getWorkflowExecutionHistoryResponse
+	s.serverFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(resp, nil)
+	path := filepath.Join(s.T().TempDir(), "output.json")
+	err := s.app.Run([]string{"", "--do", domainName, "--format", "json", "--output_path", path, "workflow", "show", "-w", "wid"})
+	s.Nil(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	var events []*types.HistoryEvent
+	s.NoError(json.Unmarshal(content, &events))
+	s.Equal(resp.History.Events, events)
+}
+
+func (s *cliAppSuite) expectExportHistoryPages() {
+	scheduled := types.EventTypeDecisionTaskScheduled
+	started := types.EventTypeDecisionTaskStarted
+	first := &types.GetWorkflowExecutionHistoryResponse{
+		History: &types.History{
+			Events: []*types.HistoryEvent{
+				{
+					ID:        1,
+					Timestamp: common.Int64Ptr(1000),
+					EventType: &eventType,
+					WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{
+						WorkflowType: &types.WorkflowType{Name: "TestWorkflow"},
+						TaskList:     &types.TaskList{Name: "taskList"},
+						Input:        []byte(`"input"`),
+					},
+				},
+				{
+					ID:        2,
+					Timestamp: common.Int64Ptr(2000),
+					EventType: &scheduled,
+					DecisionTaskScheduledEventAttributes: &types.DecisionTaskScheduledEventAttributes{
+						TaskList: &types.TaskList{Name: "taskList"},
+					},
+				},
+			},
+		},
+		NextPageToken: []byte("token"),
+	}
+	second := &types.GetWorkflowExecutionHistoryResponse{
+		History: &types.History{
+			Events: []*types.HistoryEvent{
+				{
+					ID:        3,
+					Timestamp: common.Int64Ptr(3000),
+					EventType: &started,
+					DecisionTaskStartedEventAttributes: &types.DecisionTaskStartedEventAttributes{
+						ScheduledEventID: 2,
+						Identity:         "worker",
+					},
+				},
+			},
+		},
+	}
+	gomock.InOrder(
+		s.serverFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(first, nil),
+		s.serverFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *types.GetWorkflowExecutionHistoryRequest, _ ...yarpc.CallOption) (*types.GetWorkflowExecutionHistoryResponse, error) {
+				s.Equal([]byte("token"), req.NextPageToken)
+				return second, nil
+			}),
+	)
+}
+
+func (s *cliAppSuite) assertExportedHistory(content []byte) {
+	// decode with the client library types used by its workflow replayer
+	var events []*clientshared.HistoryEvent
+	s.NoError(json.Unmarshal(content, &events))
+	s.Len(events, 3)
+	s.Equal(int64(1), events[0].GetEventId())
+	s.Equal(clientshared.EventTypeWorkflowExecutionStarted, events[0].GetEventType())
+	s.Equal("TestWorkflow", events[0].WorkflowExecutionStartedEventAttributes.GetWorkflowType().GetName())
+	s.Equal([]byte(`"input"`), events[0].WorkflowExecutionStartedEventAttributes.GetInput())
+	s.Equal(int64(2000), events[1].GetTimestamp())
+	s.Equal(clientshared.EventTypeDecisionTaskScheduled, events[1].GetEventType())
+	s.Equal(clientshared.EventTypeDecisionTaskStarted, events[2].GetEventType())
+	s.Equal(int64(2), events[2].DecisionTaskStartedEventAttributes.GetScheduledEventId())
+}
+
+func (s *cliAppSuite) TestExportHistory_File() {
+	s.expectExportHistoryPages()
+	path := filepath.Join(s.T().TempDir(), "history.json")
+	err := s.app.Run([]string{"", "--do", domainName, "workflow", "export", "-w", "wid", "-r", "rid", "--of", path})
+	s.Nil(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.assertExportedHistory(content)
+}
+
+func (s *cliAppSuite) TestExportHistory_Stdout() {
+	s.expectExportHistoryPages()
+	path := filepath.Join(s.T().TempDir(), "output.json")
+	err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "workflow", "export", "-w", "wid"})
+	s.Nil(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.assertExportedHistory(content)
+}
+
 func (s *cliAppSuite) TestShowHistoryWithID() {
 	resp := getWorkflowExecutionHistoryResponse
 	s.serverFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(resp, nil)
@@ -325,6 +694,47 @@ func (s *cliAppSuite) TestShowHistoryWithID() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestDescribeWorkflow_ShowSearchAttributes() {
+	describeResp := &types.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &types.WorkflowExecutionInfo{
+			SearchAttributes: &types.SearchAttributes{
+				IndexedFields: map[string][]byte{
+					"CustomKeywordField": []byte(`"keyword"`),
+					"CustomIntField":     []byte(`10`),
+				},
+			},
+		},
+	}
+	s.serverFrontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(describeResp, nil)
+	searchAttrResp := &types.GetSearchAttributesResponse{
+		Keys: map[string]types.IndexedValueType{
+			"CustomKeywordField": types.IndexedValueTypeKeyword,
+			"CustomIntField":     types.IndexedValueTypeInt,
+		},
+	}
+	s.serverFrontendClient.EXPECT().GetSearchAttributes(gomock.Any()).Return(searchAttrResp, nil)
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "workflow", "describe", "-w", "wid", "--show-search-attributes"})
+	s.Nil(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	output := string(content)
+	s.Contains(output, "CustomKeywordField")
+	s.Contains(output, "keyword")
+	s.Contains(output, "CustomIntField")
+	s.Contains(output, "10")
+	s.Less(strings.Index(output, "CustomIntField"), strings.Index(output, "CustomKeywordField"))
+}
+
+func (s *cliAppSuite) TestDescribeWorkflow_ShowSearchAttributes_Empty() {
+	describeResp := &types.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &types.WorkflowExecutionInfo{},
+	}
+	s.serverFrontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(describeResp, nil)
+	err := s.app.Run([]string{"", "--do", domainName, "workflow", "describe", "-w", "wid", "--show-search-attributes"})
+	s.Nil(err)
+}
+
 func (s *cliAppSuite) TestShowHistory_PrintRawTime() {
 	resp := getWorkflowExecutionHistoryResponse
 	s.serverFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(resp, nil)
@@ -380,6 +790,56 @@ func (s *cliAppSuite) TestStartWorkflow_Failed() {
 	s.Equal(1, errorCode)
 }
 
+func (s *cliAppSuite) expectStartWorkflowWithInput(expectedInput string) {
+	resp := &types.StartWorkflowExecutionResponse{RunID: uuid.New()}
+	s.serverFrontendClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *types.StartWorkflowExecutionRequest, _ ...yarpc.CallOption) (*types.StartWorkflowExecutionResponse, error) {
+			s.Equal(expectedInput, string(req.Input))
+			return resp, nil
+		})
+}
+
+func (s *cliAppSuite) TestStartWorkflow_InputFile() {
+	input := `{"key":"value"} 1`
+	path := filepath.Join(s.T().TempDir(), "input.json")
+	s.NoError(os.WriteFile(path, []byte(input), 0644))
+	s.expectStartWorkflowWithInput(input)
+	err := s.app.Run([]string{"", "--do", domainName, "workflow", "start", "-tl", "testTaskList", "-wt", "testWorkflowType", "-et", "60", "--input-file", path})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestStartWorkflow_InputFileFromStdin() {
+	input := `{"key":"value"}`
+	path := filepath.Join(s.T().TempDir(), "stdin")
+	s.NoError(os.WriteFile(path, []byte(input), 0644))
+	stdin, err := os.Open(path)
+	s.NoError(err)
+	defer stdin.Close()
+	oldStdin := os.Stdin
+	os.Stdin = stdin
+	defer func() { os.Stdin = oldStdin }()
+
+	s.expectStartWorkflowWithInput(input)
+	err = s.app.Run([]string{"", "--do", domainName, "workflow", "start", "-tl", "testTaskList", "-wt", "testWorkflowType", "-et", "60", "--input-file", "-"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestStartWorkflow_InputFileInvalidJSON() {
+	path := filepath.Join(s.T().TempDir(), "input.json")
+	s.NoError(os.WriteFile(path, []byte(`{"key":`), 0644))
+	s.serverFrontendClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).Return(&types.StartWorkflowExecutionResponse{}, nil).AnyTimes()
+	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "workflow", "start", "-tl", "testTaskList", "-wt", "testWorkflowType", "-et", "60", "--input-file", path})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestStartWorkflow_InputAndInputFile() {
+	path := filepath.Join(s.T().TempDir(), "input.json")
+	s.NoError(os.WriteFile(path, []byte(`{"key":"value"}`), 0644))
+	s.serverFrontendClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).Return(&types.StartWorkflowExecutionResponse{}, nil).AnyTimes()
+	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "workflow", "start", "-tl", "testTaskList", "-wt", "testWorkflowType", "-et", "60", "-i", `"inline"`, "--input-file", path})
+	s.Equal(1, errorCode)
+}
+
 func (s *cliAppSuite) TestRunWorkflow() {
 	resp := &types.StartWorkflowExecutionResponse{RunID: uuid.New()}
 	history := getWorkflowExecutionHistoryResponse
@@ -415,6 +875,304 @@ func (s *cliAppSuite) TestTerminateWorkflow_Failed() {
 	s.Equal(1, errorCode)
 }
 
+func (s *cliAppSuite) TestTerminateWorkflow_IfRunning_Open() {
+	s.serverFrontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(&types.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &types.WorkflowExecutionInfo{
+			Execution: &types.WorkflowExecution{WorkflowID: "wid", RunID: "rid"},
+		},
+	}, nil)
+	s.serverFrontendClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *types.TerminateWorkflowExecutionRequest, _ ...yarpc.CallOption) error {
+			s.Equal("rid", req.WorkflowExecution.GetRunID())
+			return nil
+		})
+	err := s.app.Run([]string{"", "--do", domainName, "workflow", "terminate", "-w", "wid", "--if-running"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestTerminateWorkflow_IfRunning_Closed() {
+	s.serverFrontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(&types.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &types.WorkflowExecutionInfo{
+			Execution:   &types.WorkflowExecution{WorkflowID: "wid", RunID: "rid"},
+			CloseStatus: types.WorkflowExecutionCloseStatusCompleted.Ptr(),
+		},
+	}, nil)
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "workflow", "terminate", "-w", "wid", "--if-running"})
+	s.Nil(err)
+	out, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Contains(string(out), "skipping termination")
+}
+
+func batchTerminateListResponses() (*types.ListWorkflowExecutionsResponse, *types.ListWorkflowExecutionsResponse) {
+	first := &types.ListWorkflowExecutionsResponse{
+		Executions: []*types.WorkflowExecutionInfo{
+			{Execution: &types.WorkflowExecution{WorkflowID: "wid1", RunID: "rid1"}},
+			{Execution: &types.WorkflowExecution{WorkflowID: "wid2", RunID: "rid2"}},
+		},
+		NextPageToken: []byte("token"),
+	}
+	second := &types.ListWorkflowExecutionsResponse{
+		Executions: []*types.WorkflowExecutionInfo{
+			{Execution: &types.WorkflowExecution{WorkflowID: "wid3", RunID: "rid3"}},
+		},
+	}
+	return first, second
+}
+
+func (s *cliAppSuite) TestBatchTerminateWorkflows() {
+	first, second := batchTerminateListResponses()
+	gomock.InOrder(
+		s.serverFrontendClient.EXPECT().ListWorkflowExecutions(gomock.Any(), gomock.Any()).Return(first, nil),
+		s.serverFrontendClient.EXPECT().ListWorkflowExecutions(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *types.ListWorkflowExecutionsRequest, _ ...yarpc.CallOption) (*types.ListWorkflowExecutionsResponse, error) {
+				s.Equal([]byte("token"), req.NextPageToken)
+				s.Equal("WorkflowType='test'", req.Query)
+				return second, nil
+			}),
+	)
+	terminated := make(map[string]bool)
+	var mu sync.Mutex
+	s.serverFrontendClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *types.TerminateWorkflowExecutionRequest, _ ...yarpc.CallOption) error {
+			s.Equal("cleanup", req.Reason)
+			mu.Lock()
+			terminated[req.WorkflowExecution.GetWorkflowID()] = true
+			mu.Unlock()
+			return nil
+		}).Times(3)
+
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "workflow", "batch-terminate",
+		"--query", "WorkflowType='test'", "--reason", "cleanup", "--yes", "--concurrency", "2"})
+	s.Nil(err)
+	s.Equal(map[string]bool{"wid1": true, "wid2": true, "wid3": true}, terminated)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Contains(string(content), "Batch terminate finished: 3 succeeded, 0 failed.")
+}
+
+func (s *cliAppSuite) TestBatchTerminateWorkflows_PartialFailure() {
+	first, second := batchTerminateListResponses()
+	gomock.InOrder(
+		s.serverFrontendClient.EXPECT().ListWorkflowExecutions(gomock.Any(), gomock.Any()).Return(first, nil),
+		s.serverFrontendClient.EXPECT().ListWorkflowExecutions(gomock.Any(), gomock.Any()).Return(second, nil),
+	)
+	s.serverFrontendClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *types.TerminateWorkflowExecutionRequest, _ ...yarpc.CallOption) error {
+			if req.WorkflowExecution.GetWorkflowID() == "wid2" {
+				return &types.BadRequestError{Message: "faked error"}
+			}
+			return nil
+		}).Times(3)
+
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "--output_path", path, "workflow", "batch-terminate",
+		"--query", "WorkflowType='test'", "--yes"})
+	s.Equal(1, errorCode)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Contains(string(content), "Failed to terminate workflow wid2, run rid2")
+	s.Contains(string(content), "Batch terminate finished: 2 succeeded, 1 failed.")
+}
+
+func (s *cliAppSuite) TestBatchTerminateWorkflows_Prompt() {
+	defer func() { promptFn = prompt }()
+	var promptMsg string
+	promptFn = func(msg string) {
+		promptMsg = msg
+	}
+	s.serverFrontendClient.EXPECT().ListWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&types.ListWorkflowExecutionsResponse{}, nil)
+
+	err := s.app.Run([]string{"", "--do", domainName, "workflow", "batch-terminate", "--query", "WorkflowType='test'"})
+	s.Nil(err)
+	s.Equal(fmt.Sprintf("Are you sure to terminate all workflows in domain [%s] matching query [%s]? Y/N",
+		color.YellowString(domainName), color.YellowString("WorkflowType='test'")), promptMsg)
+
+	promptMsg = ""
+	s.serverFrontendClient.EXPECT().ListWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&types.ListWorkflowExecutionsResponse{}, nil)
+	err = s.app.Run([]string{"", "--do", domainName, "workflow", "batch-terminate", "--query", "WorkflowType='test'", "--yes"})
+	s.Nil(err)
+	s.Empty(promptMsg)
+}
+
+func (s *cliAppSuite) writeWorkflowIDFile() string {
+	path := filepath.Join(s.T().TempDir(), "workflows.txt")
+	s.NoError(os.WriteFile(path, []byte("wid1\trid1\n\nwid2\nwid3\trid3\n"), 0644))
+	return path
+}
+
+func (s *cliAppSuite) TestBatchSignalWorkflows_FromFile() {
+	signaled := make(map[string]string)
+	var mu sync.Mutex
+	s.serverFrontendClient.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *types.SignalWorkflowExecutionRequest, _ ...yarpc.CallOption) error {
+			s.Equal(domainName, req.Domain)
+			s.Equal("test-signal", req.SignalName)
+			s.Equal([]byte(`{"key":"value"}`), req.Input)
+			mu.Lock()
+			signaled[req.WorkflowExecution.GetWorkflowID()] = req.WorkflowExecution.GetRunID()
+			mu.Unlock()
+			return nil
+		}).Times(3)
+
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "workflow", "signal-batch",
+		"--workflow-id-file", s.writeWorkflowIDFile(), "--name", "test-signal", "--input", `{"key":"value"}`,
+		"--yes", "--concurrency", "2"})
+	s.Nil(err)
+	s.Equal(map[string]string{"wid1": "rid1", "wid2": "", "wid3": "rid3"}, signaled)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Contains(string(content), "Signaled workflow wid2, run \n")
+	s.Contains(string(content), "Batch signal finished: 3 succeeded, 0 failed.")
+}
+
+func (s *cliAppSuite) TestBatchSignalWorkflows_PartialFailure() {
+	first, second := batchTerminateListResponses()
+	gomock.InOrder(
+		s.serverFrontendClient.EXPECT().ListWorkflowExecutions(gomock.Any(), gomock.Any()).Return(first, nil),
+		s.serverFrontendClient.EXPECT().ListWorkflowExecutions(gomock.Any(), gomock.Any()).Return(second, nil),
+	)
+	s.serverFrontendClient.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *types.SignalWorkflowExecutionRequest, _ ...yarpc.CallOption) error {
+			if req.WorkflowExecution.GetWorkflowID() == "wid2" {
+				return &types.EntityNotExistsError{Message: "workflow not found"}
+			}
+			return nil
+		}).Times(3)
+
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "--output_path", path, "workflow", "signal-batch",
+		"--query", "WorkflowType='test'", "--name", "test-signal", "--yes"})
+	s.Equal(1, errorCode)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Contains(string(content), "Signaled workflow wid1, run rid1")
+	s.Contains(string(content), "Failed to signal workflow wid2, run rid2: workflow not found")
+	s.Contains(string(content), "Signaled workflow wid3, run rid3")
+	s.Contains(string(content), "Batch signal finished: 2 succeeded, 1 failed.")
+}
+
+func (s *cliAppSuite) TestBatchSignalWorkflows_DryRun() {
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "workflow", "signal-batch",
+		"--workflow-id-file", s.writeWorkflowIDFile(), "--name", "test-signal", "--dry-run"})
+	s.Nil(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Contains(string(content), "Would signal workflow wid1, run rid1")
+	s.Contains(string(content), "Batch signal dry run finished: 3 workflows would be signaled.")
+}
+
+func (s *cliAppSuite) TestBatchSignalWorkflows_RequiresSingleSource() {
+	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "workflow", "signal-batch", "--name", "test-signal", "--yes"})
+	s.Equal(1, errorCode)
+	errorCode = s.RunErrorExitCode([]string{"", "--do", domainName, "workflow", "signal-batch", "--name", "test-signal", "--yes",
+		"--query", "WorkflowType='test'", "--workflow-id-file", s.writeWorkflowIDFile()})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) expectResetBatchTargets() {
+	s.serverFrontendClient.EXPECT().ScanWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&types.ListWorkflowExecutionsResponse{
+		Executions: []*types.WorkflowExecutionInfo{
+			{Execution: &types.WorkflowExecution{WorkflowID: "wid1", RunID: "rid1"}},
+			{Execution: &types.WorkflowExecution{WorkflowID: "wid2", RunID: "rid2"}},
+			{Execution: &types.WorkflowExecution{WorkflowID: "wid3", RunID: "rid3"}},
+		},
+	}, nil)
+	s.serverFrontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *types.DescribeWorkflowExecutionRequest, _ ...yarpc.CallOption) (*types.DescribeWorkflowExecutionResponse, error) {
+			return &types.DescribeWorkflowExecutionResponse{
+				WorkflowExecutionInfo: &types.WorkflowExecutionInfo{
+					Execution: &types.WorkflowExecution{WorkflowID: req.Execution.GetWorkflowID(), RunID: "rid" + strings.TrimPrefix(req.Execution.GetWorkflowID(), "wid")},
+				},
+			}, nil
+		}).Times(3)
+	s.serverFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(&types.GetWorkflowExecutionHistoryResponse{
+		History: &types.History{Events: []*types.HistoryEvent{
+			{ID: 4, EventType: types.EventTypeDecisionTaskCompleted.Ptr()},
+		}},
+	}, nil).Times(3)
+}
+
+func (s *cliAppSuite) TestResetInBatch_DryRun() {
+	s.expectResetBatchTargets()
+
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "workflow", "reset-batch",
+		"--query", "WorkflowType='test'", "--reset_type", "LastDecisionCompleted", "--reason", "test", "--dry_run", "--input_parallelism", "3"})
+	s.Nil(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	for _, wid := range []string{"wid1", "wid2", "wid3"} {
+		s.Contains(string(content), fmt.Sprintf("dry run to reset wid: %s", wid))
+		s.Contains(string(content), fmt.Sprintf("would reset:  %s", wid))
+	}
+	s.NotContains(string(content), "succeeded processing")
+	s.Contains(string(content), "Batch reset dry run finished: 3 workflows would be reset, 0 skipped, 0 failed.")
+}
+
+func (s *cliAppSuite) TestResetInBatch_DryRun_Skipped() {
+	s.serverFrontendClient.EXPECT().ScanWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&types.ListWorkflowExecutionsResponse{
+		Executions: []*types.WorkflowExecutionInfo{
+			{Execution: &types.WorkflowExecution{WorkflowID: "wid1", RunID: "rid1"}},
+		},
+	}, nil)
+	s.serverFrontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(&types.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &types.WorkflowExecutionInfo{
+			Execution: &types.WorkflowExecution{WorkflowID: "wid1", RunID: "rid1"},
+		},
+	}, nil)
+
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "workflow", "reset-batch",
+		"--query", "WorkflowType='test'", "--reset_type", "LastDecisionCompleted", "--reason", "test", "--dry_run", "--skip_current_open"})
+	s.Nil(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Contains(string(content), "skipped processing:  wid1 rid1")
+	s.Contains(string(content), "Batch reset dry run finished: 0 workflows would be reset, 1 skipped, 0 failed.")
+}
+
+func (s *cliAppSuite) TestResetInBatch() {
+	s.expectResetBatchTargets()
+	s.serverFrontendClient.EXPECT().ResetWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *types.ResetWorkflowExecutionRequest, _ ...yarpc.CallOption) (*types.ResetWorkflowExecutionResponse, error) {
+			s.Equal(int64(4), req.DecisionFinishEventID)
+			return &types.ResetWorkflowExecutionResponse{RunID: uuid.New()}, nil
+		}).Times(3)
+
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "workflow", "reset-batch",
+		"--query", "WorkflowType='test'", "--reset_type", "LastDecisionCompleted", "--reason", "test", "--yes", "--input_parallelism", "3"})
+	s.Nil(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Contains(string(content), "Batch reset finished: 3 succeeded, 0 skipped, 0 failed.")
+}
+
+func (s *cliAppSuite) TestResetInBatch_PartialFailure() {
+	s.expectResetBatchTargets()
+	s.serverFrontendClient.EXPECT().ResetWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *types.ResetWorkflowExecutionRequest, _ ...yarpc.CallOption) (*types.ResetWorkflowExecutionResponse, error) {
+			if req.WorkflowExecution.GetWorkflowID() == "wid2" {
+				return nil, &types.BadRequestError{Message: "faked error"}
+			}
+			return &types.ResetWorkflowExecutionResponse{RunID: uuid.New()}, nil
+		}).Times(3)
+
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "--output_path", path, "workflow", "reset-batch",
+		"--query", "WorkflowType='test'", "--reset_type", "LastDecisionCompleted", "--reason", "test", "--yes", "--input_parallelism", "3"})
+	s.Equal(1, errorCode)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Contains(string(content), "[ERROR] failed processing:  wid2 rid2")
+	s.Contains(string(content), "Batch reset finished: 2 succeeded, 0 skipped, 1 failed.")
+}
+
 func (s *cliAppSuite) TestCancelWorkflow() {
 	s.serverFrontendClient.EXPECT().RequestCancelWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil)
 	err := s.app.Run([]string{"", "--do", domainName, "workflow", "cancel", "-w", "wid"})
@@ -457,6 +1215,19 @@ func (s *cliAppSuite) TestQueryWorkflowUsingStackTrace() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestQueryWorkflowUsingStackTrace_OutputFile() {
+	resp := &types.QueryWorkflowResponse{
+		QueryResult: []byte("goroutine 1 [running]:\nmain.main()"),
+	}
+	s.serverFrontendClient.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).Return(resp, nil)
+	path := filepath.Join(s.T().TempDir(), "stack.txt")
+	err := s.app.Run([]string{"", "--do", domainName, "workflow", "stack", "-w", "wid", "--of", path})
+	s.Nil(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Equal(resp.QueryResult, content)
+}
+
 func (s *cliAppSuite) TestQueryWorkflow_Failed() {
 	resp := &types.QueryWorkflowResponse{
 		QueryResult: []byte("query-result"),
@@ -514,6 +1285,88 @@ func (s *cliAppSuite) TestListWorkflow() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestListWorkflow_GlobalFormatJSON() {
+	resp := listClosedWorkflowExecutionsResponse
+	countWorkflowResp := &types.CountWorkflowExecutionsResponse{}
+	s.serverFrontendClient.EXPECT().CountWorkflowExecutions(gomock.Any(), gomock.Any()).Return(countWorkflowResp, nil)
+	s.serverFrontendClient.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any()).Return(resp, nil)
+	path := filepath.Join(s.T().TempDir(), "output.json")
+	err := s.app.Run([]string{"", "--do", domainName, "--format", "json", "--output_path", path, "workflow", "list"})
+	s.Nil(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	var rows []WorkflowRow
+	s.NoError(json.Unmarshal(content, &rows))
+	s.Len(rows, len(resp.Executions))
+	s.Equal(resp.Executions[0].Execution.WorkflowID, rows[0].WorkflowID)
+}
+
+func (s *cliAppSuite) TestListWorkflow_IncludeMemo() {
+	resp := &types.ListClosedWorkflowExecutionsResponse{
+		Executions: []*types.WorkflowExecutionInfo{
+			{
+				Execution: &types.WorkflowExecution{
+					WorkflowID: "test-list-workflow-id-memo",
+					RunID:      uuid.New(),
+				},
+				Type:          &types.WorkflowType{Name: "test-list-workflow-type"},
+				StartTime:     common.Int64Ptr(time.Now().UnixNano()),
+				CloseTime:     common.Int64Ptr(time.Now().Add(time.Hour).UnixNano()),
+				CloseStatus:   &closeStatus,
+				HistoryLength: 12,
+				Memo:          &types.Memo{Fields: map[string][]byte{"owner": []byte(`"team-a"`)}},
+			},
+			{
+				Execution: &types.WorkflowExecution{
+					WorkflowID: "test-list-workflow-id-no-memo",
+					RunID:      uuid.New(),
+				},
+				Type:          &types.WorkflowType{Name: "test-list-workflow-type"},
+				StartTime:     common.Int64Ptr(time.Now().UnixNano()),
+				CloseTime:     common.Int64Ptr(time.Now().Add(time.Hour).UnixNano()),
+				CloseStatus:   &closeStatus,
+				HistoryLength: 12,
+			},
+		},
+	}
+	countWorkflowResp := &types.CountWorkflowExecutionsResponse{}
+
+	for _, includeMemo := range []bool{true, false} {
+		s.serverFrontendClient.EXPECT().CountWorkflowExecutions(gomock.Any(), gomock.Any()).Return(countWorkflowResp, nil)
+		s.serverFrontendClient.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any()).Return(resp, nil)
+		path := filepath.Join(s.T().TempDir(), "output.txt")
+		args := []string{"", "--do", domainName, "--output_path", path, "workflow", "list"}
+		if includeMemo {
+			args = append(args, "--include-memo")
+		}
+		s.Nil(s.app.Run(args))
+		content, err := os.ReadFile(path)
+		s.NoError(err)
+		if includeMemo {
+			s.Contains(string(content), "DECODED MEMO")
+			s.Contains(string(content), `map{owner:"team-a"}`)
+		} else {
+			s.NotContains(string(content), "DECODED MEMO")
+			s.NotContains(string(content), "team-a")
+		}
+
+		s.serverFrontendClient.EXPECT().CountWorkflowExecutions(gomock.Any(), gomock.Any()).Return(countWorkflowResp, nil)
+		s.serverFrontendClient.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any()).Return(resp, nil)
+		s.Nil(s.app.Run(append(args, "--format", "json")))
+		content, err = os.ReadFile(path)
+		s.NoError(err)
+		var rows []map[string]interface{}
+		s.NoError(json.Unmarshal(content, &rows))
+		s.Len(rows, 2)
+		if includeMemo {
+			s.Equal(`map{owner:"team-a"}`, rows[0]["DecodedMemo"])
+		} else {
+			s.NotContains(rows[0], "DecodedMemo")
+		}
+		s.NotContains(rows[1], "DecodedMemo")
+	}
+}
+
 func (s *cliAppSuite) TestListWorkflow_WithWorkflowID() {
 	resp := &types.ListClosedWorkflowExecutionsResponse{}
 	countWorkflowResp := &types.CountWorkflowExecutionsResponse{}
@@ -622,6 +1475,131 @@ func (s *cliAppSuite) TestAdminDescribeWorkflow_Failed() {
 	s.Equal(1, errorCode)
 }
 
+func (s *cliAppSuite) TestAdminGetVersionHistories() {
+	branchToken := "WQsACgAAACQ2MzI5YzEzMi1mMGI0LTQwZmUtYWYxMS1hODVmMDA3MzAzODQLABQAAAAkOWM5OWI1MjItMGEyZi00NTdmLWEyNDgtMWU0OTA0ZDg4YzVhDwAeDAAAAAAA"
+	resp := &types.AdminDescribeWorkflowExecutionResponse{
+		ShardID:     "test-shard-id",
+		HistoryAddr: "ip:port",
+		MutableStateInDatabase: `{"VersionHistories":{"CurrentVersionHistoryIndex":1,"Histories":[` +
+			`{"BranchToken":"` + branchToken + `","Items":[{"EventID":5,"Version":1},{"EventID":7,"Version":11}]},` +
+			`{"BranchToken":"` + branchToken + `","Items":[{"EventID":5,"Version":1},{"EventID":9,"Version":21}]}]}}`,
+	}
+	s.serverAdminClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(resp, nil)
+	path := filepath.Join(s.T().TempDir(), "output.json")
+	err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "admin", "wf", "get-version-histories", "-w", "test-wf-id", "--format", "json"})
+	s.Nil(err)
+
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	var rows []VersionHistoryRow
+	s.NoError(json.Unmarshal(content, &rows))
+	treeID := "6329c132-f0b4-40fe-af11-a85f00730384"
+	branchID := "9c99b522-0a2f-457f-a248-1e4904d88c5a"
+	s.Equal([]VersionHistoryRow{
+		{Index: 0, Current: false, TreeID: treeID, BranchID: branchID, EventID: 5, Version: 1},
+		{Index: 0, Current: false, TreeID: treeID, BranchID: branchID, EventID: 7, Version: 11},
+		{Index: 1, Current: true, TreeID: treeID, BranchID: branchID, EventID: 5, Version: 1},
+		{Index: 1, Current: true, TreeID: treeID, BranchID: branchID, EventID: 9, Version: 21},
+	}, rows)
+}
+
+func (s *cliAppSuite) TestAdminDiffWorkflowAcrossClusters() {
+	s.serverAdminClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), &types.AdminDescribeWorkflowExecutionRequest{
+		Domain:    domainName,
+		Execution: &types.WorkflowExecution{WorkflowID: "test-wf-id"},
+	}).Return(&types.AdminDescribeWorkflowExecutionResponse{
+		MutableStateInDatabase: `{"ExecutionInfo":{"RunID":"test-run-id","NextEventID":12,"State":1},` +
+			`"VersionHistories":{"CurrentVersionHistoryIndex":0,"Histories":[{"Items":[{"EventID":11,"Version":2}]}]}}`,
+	}, nil)
+	s.serverAdminClientForMigration.EXPECT().DescribeWorkflowExecution(gomock.Any(), &types.AdminDescribeWorkflowExecutionRequest{
+		Domain:    domainName,
+		Execution: &types.WorkflowExecution{WorkflowID: "test-wf-id", RunID: "test-run-id"},
+	}).Return(&types.AdminDescribeWorkflowExecutionResponse{
+		MutableStateInDatabase: `{"ExecutionInfo":{"RunID":"test-run-id","NextEventID":10,"State":1},` +
+			`"VersionHistories":{"CurrentVersionHistoryIndex":0,"Histories":[{"Items":[{"EventID":9,"Version":1}]}]}}`,
+	}, nil)
+	path := filepath.Join(s.T().TempDir(), "output.json")
+	err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "admin", "wf", "diff-clusters", "-w", "test-wf-id",
+		"--destination_address", "remote:7833", "--format", "json"})
+	s.Nil(err)
+
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	var rows []MutableStateDiffRow
+	s.NoError(json.Unmarshal(content, &rows))
+	s.Equal([]MutableStateDiffRow{
+		{Field: "ExecutionInfo.NextEventID", Current: "12", Remote: "10"},
+		{Field: "VersionHistories.Histories[0].Items[0].EventID", Current: "11", Remote: "9"},
+		{Field: "VersionHistories.Histories[0].Items[0].Version", Current: "2", Remote: "1"},
+	}, rows)
+}
+
+func (s *cliAppSuite) TestAdminDiffWorkflowAcrossClusters_Identical() {
+	resp := &types.AdminDescribeWorkflowExecutionResponse{
+		MutableStateInDatabase: `{"ExecutionInfo":{"RunID":"test-run-id","NextEventID":12}}`,
+	}
+	s.serverAdminClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(resp, nil)
+	s.serverAdminClientForMigration.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(resp, nil)
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "admin", "wf", "diff-clusters", "-w", "test-wf-id",
+		"-r", "test-run-id", "--destination_address", "remote:7833"})
+	s.Nil(err)
+
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Equal("Mutable states are identical.\n", string(content))
+}
+
+func (s *cliAppSuite) TestAdminGetVersionHistories_NoVersionHistories() {
+	resp := &types.AdminDescribeWorkflowExecutionResponse{
+		MutableStateInDatabase: `{"ExecutionInfo":{}}`,
+	}
+	s.serverAdminClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(resp, nil)
+	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "admin", "wf", "get-version-histories", "-w", "test-wf-id"})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestAdminRereplicate() {
+	domainID := uuid.New()
+	s.serverAdminClient.EXPECT().ResendReplicationTasks(gomock.Any(), &types.ResendReplicationTasksRequest{
+		DomainID:      domainID,
+		WorkflowID:    "test-wf-id",
+		RunID:         "test-run-id",
+		RemoteCluster: "standby",
+		StartEventID:  common.Int64Ptr(9),
+		StartVersion:  common.Int64Ptr(1),
+		EndEventID:    common.Int64Ptr(21),
+		EndVersion:    common.Int64Ptr(2),
+	}).Return(nil)
+	err := s.app.Run([]string{"", "admin", "kafka", "rereplicate", "--domain_id", domainID, "-w", "test-wf-id", "-r", "test-run-id",
+		"--source_cluster", "standby", "--min_event_id", "10", "--start_event_version", "1", "--max_event_id", "20", "--end_event_version", "2"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminRereplicate_WholeHistory() {
+	domainID := uuid.New()
+	s.serverAdminClient.EXPECT().ResendReplicationTasks(gomock.Any(), &types.ResendReplicationTasksRequest{
+		DomainID:      domainID,
+		WorkflowID:    "test-wf-id",
+		RunID:         "test-run-id",
+		RemoteCluster: "standby",
+	}).Return(nil)
+	err := s.app.Run([]string{"", "admin", "ka", "rrp", "--domain_id", domainID, "-w", "test-wf-id", "-r", "test-run-id", "--source_cluster", "standby"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminRereplicate_MissingStartVersion() {
+	errorCode := s.RunErrorExitCode([]string{"", "admin", "ka", "rrp", "--domain_id", uuid.New(), "-w", "test-wf-id", "-r", "test-run-id",
+		"--source_cluster", "standby", "--min_event_id", "10"})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestAdminRereplicate_Failed() {
+	s.serverAdminClient.EXPECT().ResendReplicationTasks(gomock.Any(), gomock.Any()).Return(&types.BadRequestError{Message: "faked error"})
+	errorCode := s.RunErrorExitCode([]string{"", "admin", "ka", "rrp", "--domain_id", uuid.New(), "-w", "test-wf-id", "-r", "test-run-id", "--source_cluster", "standby"})
+	s.Equal(1, errorCode)
+}
+
 func (s *cliAppSuite) TestAdminAddSearchAttribute() {
 	var promptMsg string
 	promptFn = func(msg string) {
@@ -661,6 +1639,293 @@ func (s *cliAppSuite) TestDescribeTaskList_Activity() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestAdminDescribeTaskListPartition() {
+	tests := []struct {
+		name             string
+		args             []string
+		expectedTaskList string
+	}{
+		{
+			name:             "partition id",
+			args:             []string{"-tl", "test-taskList", "--partition", "3"},
+			expectedTaskList: "/__cadence_sys/test-taskList/3",
+		},
+		{
+			name:             "full partition name",
+			args:             []string{"-tl", "/__cadence_sys/test-taskList/2"},
+			expectedTaskList: "/__cadence_sys/test-taskList/2",
+		},
+		{
+			name:             "root partition",
+			args:             []string{"-tl", "test-taskList", "--partition", "0"},
+			expectedTaskList: "test-taskList",
+		},
+	}
+	resp := &types.DescribeTaskListResponse{
+		Pollers: describeTaskListResponse.Pollers,
+		TaskListStatus: &types.TaskListStatus{
+			BacklogCountHint: 4242,
+			ReadLevel:        10,
+			AckLevel:         5,
+			TaskIDBlock:      &types.TaskIDBlock{StartID: 1, EndID: 100},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.serverFrontendClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, request *types.DescribeTaskListRequest, _ ...interface{}) (*types.DescribeTaskListResponse, error) {
+					s.Equal(tt.expectedTaskList, request.GetTaskList().GetName())
+					s.True(request.GetIncludeTaskListStatus())
+					return resp, nil
+				})
+			path := filepath.Join(s.T().TempDir(), "output.txt")
+			args := append([]string{"", "--do", domainName, "--output_path", path, "admin", "tasklist", "describe-partition"}, tt.args...)
+			s.Nil(s.app.Run(args))
+			content, err := os.ReadFile(path)
+			s.NoError(err)
+			s.Contains(string(content), tt.expectedTaskList)
+			s.Contains(string(content), "4242")
+			s.Contains(string(content), "tester")
+		})
+	}
+}
+
+func (s *cliAppSuite) mockTaskManager() *persistence.MockTaskManager {
+	taskManager := persistence.NewMockTaskManager(s.mockCtrl)
+	factory := client.NewMockFactory(s.mockCtrl)
+	factory.EXPECT().NewTaskManager().Return(taskManager, nil)
+	oldFactory := persistenceFactory
+	persistenceFactory = factory
+	s.T().Cleanup(func() { persistenceFactory = oldFactory })
+	taskManager.EXPECT().Close()
+	return taskManager
+}
+
+func (s *cliAppSuite) expectDrainTaskListRange(storeName string, maxTaskID, size int64) *persistence.MockTaskManager {
+	s.serverFrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).Return(&types.DescribeDomainResponse{
+		DomainInfo: &types.DomainInfo{Name: domainName, UUID: "domain-id"},
+	}, nil)
+	taskManager := s.mockTaskManager()
+	taskManager.EXPECT().GetName().Return(storeName).AnyTimes()
+	taskManager.EXPECT().GetTaskIDRange(gomock.Any(), &persistence.GetTaskIDRangeRequest{
+		DomainID:     "domain-id",
+		TaskListName: "test-taskList",
+		TaskListType: int(types.TaskListTypeActivity),
+	}).Return(&persistence.GetTaskIDRangeResponse{MinTaskID: 1, MaxTaskID: maxTaskID}, nil)
+	if maxTaskID > 0 {
+		taskManager.EXPECT().GetTaskListSize(gomock.Any(), &persistence.GetTaskListSizeRequest{
+			DomainID:     "domain-id",
+			DomainName:   domainName,
+			TaskListName: "test-taskList",
+			TaskListType: int(types.TaskListTypeActivity),
+		}).Return(&persistence.GetTaskListSizeResponse{Size: size}, nil)
+	}
+	return taskManager
+}
+
+func (s *cliAppSuite) TestAdminDrainTaskList() {
+	tests := []struct {
+		name       string
+		storeName  string
+		maxCount   string
+		size       int64
+		exactCount bool
+		completed  []int
+		limits     []int
+		output     string
+	}{
+		{
+			name:      "stops at max count",
+			storeName: mysql.PluginName,
+			maxCount:  "5",
+			size:      10,
+			completed: []int{2, 2, 1},
+			limits:    []int{2, 2, 1},
+			output:    "Drained 5 tasks of tasklist test-taskList.",
+		},
+		{
+			name:      "stops when backlog is empty",
+			storeName: mysql.PluginName,
+			maxCount:  "5",
+			size:      6,
+			completed: []int{2, 1},
+			limits:    []int{2, 2},
+			output:    "Drained 3 tasks of tasklist test-taskList.",
+		},
+		{
+			name:       "backlog within max count",
+			storeName:  mysql.PluginName,
+			maxCount:   "10",
+			size:       7,
+			exactCount: true,
+			completed:  []int{7},
+			limits:     []int{2},
+			output:     "Drained 7 tasks of tasklist test-taskList.",
+		},
+		{
+			name:       "store without row count",
+			storeName:  "shardedNosql",
+			maxCount:   "10",
+			size:       7,
+			exactCount: true,
+			completed:  []int{persistence.UnknownNumRowsAffected},
+			limits:     []int{2},
+			output:     "Drained all tasks with ID up to 100 of tasklist test-taskList, the store does not report how many.",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			taskManager := s.expectDrainTaskListRange(tt.storeName, 100, tt.size)
+			var calls []*gomock.Call
+			for i, completed := range tt.completed {
+				calls = append(calls, taskManager.EXPECT().CompleteTasksLessThan(gomock.Any(), &persistence.CompleteTasksLessThanRequest{
+					DomainID:     "domain-id",
+					TaskListName: "test-taskList",
+					TaskType:     int(types.TaskListTypeActivity),
+					TaskID:       100,
+					Limit:        tt.limits[i],
+					DomainName:   domainName,
+					ExactCount:   tt.exactCount,
+				}).Return(&persistence.CompleteTasksLessThanResponse{TasksCompleted: completed}, nil))
+			}
+			gomock.InOrder(calls...)
+
+			path := filepath.Join(s.T().TempDir(), "output.txt")
+			err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "admin", "tasklist", "drain",
+				"-tl", "test-taskList", "-tlt", "activity", "--max_task_count", tt.maxCount, "--batch_size", "2", "--yes"})
+			s.NoError(err)
+			content, err := os.ReadFile(path)
+			s.NoError(err)
+			s.Contains(string(content), tt.output)
+		})
+	}
+}
+
+func (s *cliAppSuite) TestAdminDrainTaskList_NothingPersisted() {
+	s.expectDrainTaskListRange(mysql.PluginName, 0, 0)
+
+	path := filepath.Join(s.T().TempDir(), "output.txt")
+	err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "admin", "tasklist", "drain",
+		"-tl", "test-taskList", "-tlt", "activity", "--max_task_count", "10", "--yes"})
+	s.NoError(err)
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Contains(string(content), "Tasklist test-taskList has no persisted tasks, nothing to drain.")
+}
+
+func (s *cliAppSuite) TestAdminDrainTaskList_PromptWithoutLimitSupport() {
+	defer func() { promptFn = prompt }()
+	var promptMsg string
+	promptFn = func(msg string) {
+		promptMsg = msg
+		panic("declined")
+	}
+	s.expectDrainTaskListRange("shardedNosql", 100, 10)
+
+	s.Panics(func() {
+		s.app.Run([]string{"", "--do", domainName, "admin", "tasklist", "drain",
+			"-tl", "test-taskList", "-tlt", "activity", "--max_task_count", "10"})
+	})
+	s.Equal(fmt.Sprintf("The shardedNosql task store cannot limit deletes, max_task_count is ignored. Are you sure to delete ALL tasks with ID up to 100 of tasklist [%s] in domain [%s]? Y/N",
+		color.YellowString("test-taskList"), color.YellowString(domainName)), promptMsg)
+}
+
+func (s *cliAppSuite) TestAdminDrainTaskList_DryRun() {
+	tests := []struct {
+		name      string
+		storeName string
+		output    string
+	}{
+		{
+			name:      "limit supported",
+			storeName: mysql.PluginName,
+			output:    "Tasklist test-taskList has 4242 persisted tasks with ID up to 5000, 1000 of them would be drained.",
+		},
+		{
+			name:      "limit not supported",
+			storeName: "shardedNosql",
+			output:    "Tasklist test-taskList has 4242 persisted tasks with ID up to 5000, all of them would be drained as the shardedNosql task store cannot limit deletes.",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.expectDrainTaskListRange(tt.storeName, 5000, 4242)
+
+			path := filepath.Join(s.T().TempDir(), "output.txt")
+			err := s.app.Run([]string{"", "--do", domainName, "--output_path", path, "admin", "tasklist", "drain",
+				"-tl", "test-taskList", "-tlt", "activity", "--max_task_count", "1000", "--dry-run"})
+			s.NoError(err)
+			content, err := os.ReadFile(path)
+			s.NoError(err)
+			s.Contains(string(content), tt.output)
+		})
+	}
+}
+
+func (s *cliAppSuite) TestListTaskListPartitionConfig() {
+	tests := []struct {
+		name             string
+		args             []string
+		expectedTaskType string
+		notConfigured    bool
+		expectedRow      TaskListPartitionConfigRow
+	}{
+		{
+			name:             "decision",
+			args:             []string{},
+			expectedTaskType: "0",
+			expectedRow:      TaskListPartitionConfigRow{TaskListType: "Decision", ReadPartitions: 3, WritePartitions: 2},
+		},
+		{
+			name:             "activity",
+			args:             []string{"-tlt", "activity"},
+			expectedTaskType: "1",
+			expectedRow:      TaskListPartitionConfigRow{TaskListType: "Activity", ReadPartitions: 3, WritePartitions: 2},
+		},
+		{
+			name:             "not configured",
+			args:             []string{},
+			expectedTaskType: "0",
+			notConfigured:    true,
+			expectedRow:      TaskListPartitionConfigRow{TaskListType: "Decision", ReadPartitions: 1, WritePartitions: 1},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.serverAdminClient.EXPECT().GetDynamicConfig(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, req *types.GetDynamicConfigRequest, _ ...yarpc.CallOption) (*types.GetDynamicConfigResponse, error) {
+					filters := make(map[string]string)
+					for _, filter := range req.Filters {
+						filters[filter.Name] = string(filter.Value.GetData())
+					}
+					s.Equal(map[string]string{
+						"domainName":   `"` + domainName + `"`,
+						"taskListName": `"test-taskList"`,
+						"taskType":     tt.expectedTaskType,
+					}, filters)
+					if tt.notConfigured {
+						return nil, &types.EntityNotExistsError{Message: "unable to find key"}
+					}
+					value := []byte("2")
+					if req.ConfigName == "matching.numTasklistReadPartitions" {
+						value = []byte("3")
+					}
+					return &types.GetDynamicConfigResponse{Value: &types.DataBlob{Data: value}}, nil
+				}).Times(2)
+
+			path := filepath.Join(s.T().TempDir(), "output.json")
+			args := append([]string{"", "--do", domainName, "--output_path", path, "tasklist", "partition-config", "-tl", "test-taskList", "--format", "json"}, tt.args...)
+			err := s.app.Run(args)
+			s.Nil(err)
+			content, err := os.ReadFile(path)
+			s.NoError(err)
+			var rows []TaskListPartitionConfigRow
+			s.NoError(json.Unmarshal(content, &rows))
+			s.Equal([]TaskListPartitionConfigRow{tt.expectedRow}, rows)
+		})
+	}
+}
+
 func (s *cliAppSuite) TestObserveWorkflow() {
 	history := getWorkflowExecutionHistoryResponse
 	s.serverFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(history, nil).Times(2)
@@ -679,6 +1944,24 @@ func (s *cliAppSuite) TestObserveWorkflowWithID() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestObserveWorkflow_RetryTransientError() {
+	history := getWorkflowExecutionHistoryResponse
+	gomock.InOrder(
+		s.serverFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(nil, &types.ServiceBusyError{Message: "busy"}),
+		s.serverFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(history, nil),
+	)
+	err := s.app.Run([]string{"", "--do", domainName, "workflow", "observe", "-w", "wid", "--max-retries", "2", "--retry-interval", "10ms"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestIsObserveRetryableError() {
+	s.True(isObserveRetryableError(&types.ServiceBusyError{}))
+	s.True(isObserveRetryableError(&types.InternalServiceError{}))
+	s.True(isObserveRetryableError(context.DeadlineExceeded))
+	s.False(isObserveRetryableError(&types.EntityNotExistsError{}))
+	s.False(isObserveRetryableError(&types.BadRequestError{}))
+}
+
 // TestParseTime tests the parsing of date argument in UTC and UnixNano formats
 func (s *cliAppSuite) TestParseTime() {
 	s.Equal(int64(100), parseTime("", 100))
@@ -774,9 +2057,32 @@ func (s *cliAppSuite) TestParseTimeDateRange() {
 	for _, te := range tests {
 		s.True(te.expected <= parseTime(te.timeStr, te.defVal))
 		s.True(te.expected+delta >= parseTime(te.timeStr, te.defVal))
+
+		// admin timers --start_date/--end_date accept the same time ranges
+		ts, err := parseSingleTs(te.timeStr)
+		s.NoError(err)
+		s.True(te.expected <= ts.UnixNano())
+		s.True(te.expected+delta >= ts.UnixNano())
 	}
 }
 
+func (s *cliAppSuite) TestParseSingleTs() {
+	ts, err := parseSingleTs("2018-06-07T15:04:05+00:00")
+	s.NoError(err)
+	s.Equal(int64(1528383845000000000), ts.UnixNano())
+
+	ts, err = parseSingleTs("2018-06-07")
+	s.NoError(err)
+	s.Equal(time.Date(2018, 6, 7, 0, 0, 0, 0, time.UTC), ts)
+
+	ts, err = parseSingleTs("1528383845000000000")
+	s.NoError(err)
+	s.Equal(int64(1528383845000000000), ts.UnixNano())
+
+	_, err = parseSingleTs("not a time")
+	s.Error(err)
+}
+
 func (s *cliAppSuite) TestBreakLongWords() {
 	s.Equal("111 222 333 4", breakLongWords("1112223334", 3))
 	s.Equal("111 2 223", breakLongWords("1112 223", 3))
@@ -974,3 +2280,43 @@ func (s *cliAppSuite) TestConvertArray() {
 		s.Nil(res)
 	}
 }
+
+func (s *cliAppSuite) TestShowHistory_FollowReset() {
+	resetEvent := func(baseRunID, newRunID string) *types.HistoryEvent {
+		return &types.HistoryEvent{
+			ID:        3,
+			EventType: types.EventTypeDecisionTaskFailed.Ptr(),
+			DecisionTaskFailedEventAttributes: &types.DecisionTaskFailedEventAttributes{
+				Cause:     types.DecisionTaskFailedCauseResetWorkflow.Ptr(),
+				Reason:    common.StringPtr("reset " + baseRunID),
+				BaseRunID: baseRunID,
+				NewRunID:  newRunID,
+			},
+		}
+	}
+	historyByRunID := map[string][]*types.HistoryEvent{
+		// run3 was reset from run2, which itself was reset from run1, so run3 carries both reset events
+		"run3": {resetEvent("run1", "run2"), resetEvent("run2", "run3")},
+		"run2": {resetEvent("run1", "run2")},
+		"run1": {},
+	}
+	s.serverFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *types.GetWorkflowExecutionHistoryRequest, _ ...yarpc.CallOption) (*types.GetWorkflowExecutionHistoryResponse, error) {
+			events, ok := historyByRunID[request.Execution.GetRunID()]
+			s.True(ok)
+			return &types.GetWorkflowExecutionHistoryResponse{
+				History: &types.History{Events: append(getWorkflowExecutionHistoryResponse.History.Events, events...)},
+			}, nil
+		}).Times(5)
+
+	lineage, err := getResetLineage(context.Background(), s.serverFrontendClient, domainName, "wid", "run3", &types.History{Events: historyByRunID["run3"]})
+	s.NoError(err)
+	s.Equal([]resetLineageRow{
+		{RunID: "run1"},
+		{RunID: "run2", BaseRunID: "run1", Reason: "reset run1"},
+		{RunID: "run3", BaseRunID: "run2", Reason: "reset run2"},
+	}, lineage)
+
+	err = s.app.Run([]string{"", "--do", domainName, "workflow", "show", "-w", "wid", "-r", "run3", "--follow-reset"})
+	s.Nil(err)
+}
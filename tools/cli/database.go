@@ -176,6 +176,15 @@ func initializeShardManager(c *cli.Context) persistence.ShardManager {
 	return shardManager
 }
 
+func initializeTaskManager(c *cli.Context) persistence.TaskManager {
+	factory := getPersistenceFactory(c)
+	taskManager, err := factory.NewTaskManager()
+	if err != nil {
+		ErrorAndExit("Failed to initialize task manager", err)
+	}
+	return taskManager
+}
+
 func initializeDomainManager(c *cli.Context) persistence.DomainManager {
 	factory := getPersistenceFactory(c)
 	domainManager, err := factory.NewDomainManager()
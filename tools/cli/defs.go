@@ -60,11 +60,15 @@ const (
 	defaultContextTimeout                        = defaultContextTimeoutInSeconds * time.Second
 	defaultContextTimeoutForLongPoll             = 2 * time.Minute
 	defaultContextTimeoutForListArchivedWorkflow = 3 * time.Minute
-
-	defaultDecisionTimeoutInSeconds = 10
-	defaultPageSizeForList          = 500
-	defaultPageSizeForScan          = 2000
-	defaultWorkflowIDReusePolicy    = types.WorkflowIDReusePolicyAllowDuplicateFailedOnly
+	defaultGRPCKeepaliveTimeout                  = 20 * time.Second
+
+	defaultDecisionTimeoutInSeconds  = 10
+	defaultPageSizeForList           = 500
+	defaultPageSizeForScan           = 2000
+	defaultBatchTerminateConcurrency = 10
+	defaultBatchSignalConcurrency    = 10
+	defaultTaskListDrainBatchSize    = 1000
+	defaultWorkflowIDReusePolicy     = types.WorkflowIDReusePolicyAllowDuplicateFailedOnly
 
 	workflowStatusNotSet = -1
 	showErrorStackEnv    = `CADENCE_CLI_SHOW_STACKS`
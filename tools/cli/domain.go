@@ -83,6 +83,15 @@ func newDomainCommands() []cli.Command {
 				newDomainCLI(c, false).DescribeDomain(c)
 			},
 		},
+		{
+			Name:    "list",
+			Aliases: []string{"l"},
+			Usage:   "List all domains in the cluster",
+			Flags:   listDomainFlags,
+			Action: func(c *cli.Context) {
+				newDomainCLI(c, false).ListDomains(c)
+			},
+		},
 		{
 			Name:    "migration",
 			Aliases: []string{"mi"},
@@ -146,7 +146,7 @@ func (d *domainCLIImpl) RegisterDomain(c *cli.Context) {
 			ErrorAndExit(fmt.Sprintf("Domain %s already registered.", domainName), err)
 		}
 	} else {
-		fmt.Printf("Domain %s successfully registered.\n", domainName)
+		fmt.Fprintf(getOutputWriter(c), "Domain %s successfully registered.\n", domainName)
 	}
 }
 
@@ -160,7 +160,7 @@ func (d *domainCLIImpl) UpdateDomain(c *cli.Context) {
 
 	if c.IsSet(FlagActiveClusterName) {
 		activeCluster := c.String(FlagActiveClusterName)
-		fmt.Printf("Will set active cluster name to: %s, other flag will be omitted.\n", activeCluster)
+		fmt.Fprintf(getOutputWriter(c), "Will set active cluster name to: %s, other flag will be omitted.\n", activeCluster)
 
 		var failoverTimeout *int32
 		if c.String(FlagFailoverType) == gracefulFailoverType {
@@ -267,7 +267,7 @@ func (d *domainCLIImpl) UpdateDomain(c *cli.Context) {
 			ErrorAndExit(fmt.Sprintf("Domain %s does not exist.", domainName), err)
 		}
 	} else {
-		fmt.Printf("Domain %s successfully updated.\n", domainName)
+		fmt.Fprintf(getOutputWriter(c), "Domain %s successfully updated.\n", domainName)
 	}
 }
 
@@ -303,7 +303,7 @@ func (d *domainCLIImpl) DeprecateDomain(c *cli.Context) {
 			ErrorAndExit(fmt.Sprintf("Domain %s does not exist.", domainName), err)
 		}
 	} else {
-		fmt.Printf("Domain %s successfully deprecated.\n", domainName)
+		fmt.Fprintf(getOutputWriter(c), "Domain %s successfully deprecated.\n", domainName)
 	}
 }
 
@@ -332,13 +332,13 @@ func (d *domainCLIImpl) failoverDomains(c *cli.Context) ([]string, []string) {
 				printError(fmt.Sprintf("Failed failover domain: %s\n", domainName), err)
 				failedDomains = append(failedDomains, domainName)
 			} else {
-				fmt.Printf("Success failover domain: %s\n", domainName)
+				fmt.Fprintf(getOutputWriter(c), "Success failover domain: %s\n", domainName)
 				succeedDomains = append(succeedDomains, domainName)
 			}
 		}
 	}
-	fmt.Printf("Succeed %d: %v\n", len(succeedDomains), succeedDomains)
-	fmt.Printf("Failed  %d: %v\n", len(failedDomains), failedDomains)
+	fmt.Fprintf(getOutputWriter(c), "Succeed %d: %v\n", len(succeedDomains), succeedDomains)
+	fmt.Fprintf(getOutputWriter(c), "Failed  %d: %v\n", len(failedDomains), failedDomains)
 	return succeedDomains, failedDomains
 }
 
@@ -397,6 +397,7 @@ VisibilityArchivalURI: {{.}}{{end}}
 {{with .BadBinaries}}Bad binaries to reset:
 {{table .}}{{end}}
 {{with .FailoverInfo}}Graceful failover info:
+{{table .}}{{end}}{{with .AsyncWorkflowConfig}}Async workflow config:
 {{table .}}{{end}}`
 
 // DescribeDomain updates a domain
@@ -431,11 +432,15 @@ func (d *domainCLIImpl) DescribeDomain(c *cli.Context) {
 		if err != nil {
 			ErrorAndExit("Failed to encode domain response into JSON.", err)
 		}
-		fmt.Println(string(output))
+		fmt.Fprintln(getOutputWriter(c), string(output))
 		return
 	}
 
-	Render(c, newDomainRow(resp), RenderOptions{
+	row := newDomainRow(resp)
+	if c.Bool(FlagShowAsyncWorkflowConfig) {
+		row.AsyncWorkflowConfig = newAsyncWorkflowConfigRow(resp.Configuration.GetAsyncWorkflowConfiguration())
+	}
+	Render(c, row, RenderOptions{
 		DefaultTemplate: templateDomain,
 		Color:           true,
 		Border:          true,
@@ -458,6 +463,13 @@ type FailoverInfoRow struct {
 	PendingShard        []int32   `header:"Pending Shard"`
 }
 
+type AsyncWorkflowConfigRow struct {
+	Enabled             bool   `header:"Enabled"`
+	PredefinedQueueName string `header:"Predefined Queue Name"`
+	QueueType           string `header:"Queue Type"`
+	QueueConfig         string `header:"Queue Config"`
+}
+
 type DomainRow struct {
 	Name                     string `header:"Name"`
 	UUID                     string `header:"UUID"`
@@ -476,6 +488,7 @@ type DomainRow struct {
 	VisibilityArchivalURI    string               `header:"Visibility Archival URI"`
 	BadBinaries              []BadBinaryRow
 	FailoverInfo             *FailoverInfoRow
+	AsyncWorkflowConfig      *AsyncWorkflowConfigRow `json:",omitempty"`
 	LongRunningWorkFlowNum   *int
 }
 
@@ -536,6 +549,27 @@ func newFailoverInfoRow(info *types.FailoverInfo) *FailoverInfoRow {
 	}
 }
 
+func newAsyncWorkflowConfigRow(cfg types.AsyncWorkflowConfiguration) *AsyncWorkflowConfigRow {
+	return &AsyncWorkflowConfigRow{
+		Enabled:             cfg.Enabled,
+		PredefinedQueueName: cfg.PredefinedQueueName,
+		QueueType:           cfg.QueueType,
+		QueueConfig:         decodeAsyncWorkflowQueueConfig(cfg.QueueConfig),
+	}
+}
+
+// decodeAsyncWorkflowQueueConfig renders JSON encoded queue configs as is,
+// since other encodings are specific to the queue implementation
+func decodeAsyncWorkflowQueueConfig(blob *types.DataBlob) string {
+	if blob == nil || len(blob.Data) == 0 {
+		return ""
+	}
+	if blob.GetEncodingType() == types.EncodingTypeJSON {
+		return string(blob.Data)
+	}
+	return fmt.Sprintf("<%d bytes of %v encoded data>", len(blob.Data), blob.GetEncodingType())
+}
+
 func newBadBinaryRows(bb *types.BadBinaries) []BadBinaryRow {
 	if bb == nil {
 		return nil
@@ -577,6 +611,8 @@ func (d *domainCLIImpl) ListDomains(c *cli.Context) {
 	printAll := c.Bool(FlagAll)
 	printDeprecated := c.Bool(FlagDeprecated)
 	printJSON := c.Bool(FlagPrintJSON)
+	activeCluster := c.String(FlagActiveClusterFilter)
+	globalOnly := c.Bool(FlagGlobalOnly)
 
 	if printAll && printDeprecated {
 		ErrorAndExit(fmt.Sprintf("Cannot specify %s and %s flags at the same time.", FlagAll, FlagDeprecated), nil)
@@ -596,6 +632,27 @@ func (d *domainCLIImpl) ListDomains(c *cli.Context) {
 		domains = prefixDomains
 	}
 
+	// Only list domains that are active in the given cluster if it is provided
+	if len(activeCluster) > 0 {
+		var activeClusterDomains []*types.DescribeDomainResponse
+		for _, domain := range domains {
+			if domain.ReplicationConfiguration.GetActiveClusterName() == activeCluster {
+				activeClusterDomains = append(activeClusterDomains, domain)
+			}
+		}
+		domains = activeClusterDomains
+	}
+
+	if globalOnly {
+		var globalDomains []*types.DescribeDomainResponse
+		for _, domain := range domains {
+			if domain.IsGlobalDomain {
+				globalDomains = append(globalDomains, domain)
+			}
+		}
+		domains = globalDomains
+	}
+
 	if printAll {
 		filteredDomains = domains
 	} else {
@@ -614,7 +671,7 @@ func (d *domainCLIImpl) ListDomains(c *cli.Context) {
 		if err != nil {
 			ErrorAndExit("Failed to encode domain results into JSON.", err)
 		}
-		fmt.Println(string(output))
+		fmt.Fprintln(getOutputWriter(c), string(output))
 		return
 	}
 
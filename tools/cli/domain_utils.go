@@ -198,6 +198,48 @@ var (
 			Name:  FlagPrintJSONWithAlias,
 			Usage: "Print in raw JSON format",
 		},
+		cli.BoolFlag{
+			Name:  FlagShowAsyncWorkflowConfig,
+			Usage: "Show the async workflow queue configuration of the domain",
+		},
+		getFormatFlag(),
+	}
+
+	listDomainFlags = []cli.Flag{
+		cli.IntFlag{
+			Name:  FlagPageSizeWithAlias,
+			Value: 10,
+			Usage: "Result page size",
+		},
+		cli.BoolFlag{
+			Name:  FlagAllWithAlias,
+			Usage: "List all domains, by default only domains in REGISTERED status are listed",
+		},
+		cli.BoolFlag{
+			Name:  FlagDeprecatedWithAlias,
+			Usage: "List deprecated domains only, by default only domains in REGISTERED status are listed",
+		},
+		cli.StringFlag{
+			Name:  FlagPrefix,
+			Usage: "List domains that are matching to the given prefix",
+			Value: "",
+		},
+		cli.StringFlag{
+			Name:  FlagActiveClusterFilter,
+			Usage: "List domains that are active in the given cluster",
+		},
+		cli.BoolFlag{
+			Name:  FlagGlobalOnly,
+			Usage: "List global domains only",
+		},
+		cli.BoolFlag{
+			Name:  FlagPrintFullyDetailWithAlias,
+			Usage: "Print full domain detail",
+		},
+		cli.BoolFlag{
+			Name:  FlagPrintJSONWithAlias,
+			Usage: "Print in raw json format (DEPRECATED: instead use --format json)",
+		},
 		getFormatFlag(),
 	}
 
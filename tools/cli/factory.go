@@ -39,6 +39,7 @@ import (
 	"go.uber.org/yarpc/transport/tchannel"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 
 	serverAdmin "github.com/uber/cadence/.gen/go/admin/adminserviceclient"
 	serverFrontend "github.com/uber/cadence/.gen/go/cadence/workflowserviceclient"
@@ -202,7 +203,7 @@ func (b *clientFactory) newClientDispatcher(c *cli.Context, hostPortOverride str
 	var outbounds transport.Outbounds
 	if shouldUseGrpc {
 		grpcTransport := grpc.NewTransport()
-		outbounds = transport.Outbounds{Unary: grpc.NewTransport().NewSingleOutbound(hostPort)}
+		var dialOptions []grpc.DialOption
 
 		tlsCertificatePath := c.GlobalString(FlagTLSCertPath)
 		if tlsCertificatePath != "" {
@@ -218,9 +219,13 @@ func (b *clientFactory) newClientDispatcher(c *cli.Context, hostPortOverride str
 				RootCAs: caCertPool,
 			}
 			tlsCreds := credentials.NewTLS(&tlsConfig)
-			tlsChooser := peer.NewSingle(hostport.Identify(hostPort), grpcTransport.NewDialer(grpc.DialerCredentials(tlsCreds)))
-			outbounds = transport.Outbounds{Unary: grpc.NewTransport().NewOutbound(tlsChooser)}
+			dialOptions = append(dialOptions, grpc.DialerCredentials(tlsCreds))
 		}
+		if keepaliveParams, ok := getGRPCKeepaliveParams(c); ok {
+			dialOptions = append(dialOptions, grpc.KeepaliveParams(keepaliveParams))
+		}
+		chooser := peer.NewSingle(hostport.Identify(hostPort), grpcTransport.NewDialer(dialOptions...))
+		outbounds = transport.Outbounds{Unary: grpcTransport.NewOutbound(chooser)}
 	} else {
 		ch, err := tchannel.NewChannelTransport(tchannel.ServiceName(cadenceClientName), tchannel.ListenAddr("127.0.0.1:0"))
 		if err != nil {
@@ -244,6 +249,19 @@ func (b *clientFactory) newClientDispatcher(c *cli.Context, hostPortOverride str
 	return dispatcher
 }
 
+// getGRPCKeepaliveParams returns the client keepalive parameters, keepalive is only enabled if its time is set
+func getGRPCKeepaliveParams(c *cli.Context) (keepalive.ClientParameters, bool) {
+	keepaliveTime := c.GlobalDuration(FlagGRPCKeepaliveTime)
+	if keepaliveTime <= 0 {
+		return keepalive.ClientParameters{}, false
+	}
+	return keepalive.ClientParameters{
+		Time:                keepaliveTime,
+		Timeout:             c.GlobalDuration(FlagGRPCKeepaliveTimeout),
+		PermitWithoutStream: c.GlobalBool(FlagGRPCKeepalivePermitWithoutStream),
+	}, true
+}
+
 type versionMiddleware struct {
 }
 
@@ -0,0 +1,86 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2017-2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+	"google.golang.org/grpc/keepalive"
+)
+
+func newGlobalFlagsContext(t *testing.T, args ...string) *cli.Context {
+	app := NewCliApp()
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	for _, f := range app.Flags {
+		f.Apply(set)
+	}
+	require.NoError(t, set.Parse(args))
+	globalContext := cli.NewContext(app, set, nil)
+	return cli.NewContext(app, flag.NewFlagSet("command", flag.ContinueOnError), globalContext)
+}
+
+func TestGetGRPCKeepaliveParams(t *testing.T) {
+	tests := map[string]struct {
+		args     []string
+		expected keepalive.ClientParameters
+		enabled  bool
+	}{
+		"disabled by default": {},
+		"time set uses default timeout": {
+			args: []string{"--" + FlagGRPCKeepaliveTime, "5m"},
+			expected: keepalive.ClientParameters{
+				Time:    5 * time.Minute,
+				Timeout: defaultGRPCKeepaliveTimeout,
+			},
+			enabled: true,
+		},
+		"all set": {
+			args: []string{
+				"--" + FlagGRPCKeepaliveTime, "1m",
+				"--" + FlagGRPCKeepaliveTimeout, "10s",
+				"--" + FlagGRPCKeepalivePermitWithoutStream,
+			},
+			expected: keepalive.ClientParameters{
+				Time:                time.Minute,
+				Timeout:             10 * time.Second,
+				PermitWithoutStream: true,
+			},
+			enabled: true,
+		},
+		"timeout without time stays disabled": {
+			args: []string{"--" + FlagGRPCKeepaliveTimeout, "10s"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			params, enabled := getGRPCKeepaliveParams(newGlobalFlagsContext(t, tc.args...))
+			assert.Equal(t, tc.enabled, enabled)
+			assert.Equal(t, tc.expected, params)
+		})
+	}
+}
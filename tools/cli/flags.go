@@ -20,7 +20,11 @@
 
 package cli
 
-import "github.com/urfave/cli"
+import (
+	"time"
+
+	"github.com/urfave/cli"
+)
 
 // Flags used to specify cli command line arguments
 const (
@@ -64,11 +68,13 @@ const (
 	FlagSourceClusterWithAlias            = FlagSourceCluster + ", sc"
 	FlagMinEventID                        = "min_event_id"
 	FlagMaxEventID                        = "max_event_id"
+	FlagStartEventVersion                 = "start_event_version"
 	FlagEndEventVersion                   = "end_event_version"
 	FlagTaskList                          = "tasklist"
 	FlagTaskListWithAlias                 = FlagTaskList + ", tl"
 	FlagTaskListType                      = "tasklisttype"
 	FlagTaskListTypeWithAlias             = FlagTaskListType + ", tlt"
+	FlagTaskListPartition                 = "partition"
 	FlagWorkflowIDReusePolicy             = "workflowidreusepolicy"
 	FlagWorkflowIDReusePolicyAlias        = FlagWorkflowIDReusePolicy + ", wrp"
 	FlagCronSchedule                      = "cron"
@@ -93,6 +99,7 @@ const (
 	FlagSignalInputFile                   = "signal_input_file"
 	FlagSignalInputFileWithAlias          = FlagSignalInputFile + ", sif"
 	FlagExcludeFile                       = "exclude_file"
+	FlagWorkflowIDFile                    = "workflow_id_file"
 	FlagInputSeparator                    = "input_separator"
 	FlagParallelism                       = "input_parallelism"
 	FlagParallismDeprecated               = "input_parallism" // typo, replaced by FlagParallelism
@@ -125,6 +132,8 @@ const (
 	FlagAllWithAlias                      = FlagAll + ", a"
 	FlagDeprecated                        = "deprecated"
 	FlagDeprecatedWithAlias               = FlagDeprecated + ", dep"
+	FlagActiveClusterFilter               = "active-cluster"
+	FlagGlobalOnly                        = "global-only"
 	FlagForce                             = "force"
 	FlagPageID                            = "page_id"
 	FlagPageSize                          = "pagesize"
@@ -168,6 +177,7 @@ const (
 	FlagOutputFilename                    = "output_filename"
 	FlagOutputFilenameWithAlias           = FlagOutputFilename + ", of"
 	FlagOutputFormat                      = "output"
+	FlagOutputPath                        = "output_path"
 	FlagQueryType                         = "query_type"
 	FlagQueryTypeWithAlias                = FlagQueryType + ", qt"
 	FlagQueryRejectCondition              = "query_reject_condition"
@@ -215,6 +225,14 @@ const (
 	FlagResetType                         = "reset_type"
 	FlagDecisionOffset                    = "decision_offset"
 	FlagResetPointsOnly                   = "reset_points_only"
+	FlagFollowReset                       = "follow-reset"
+	FlagShowSearchAttributes              = "show-search-attributes"
+	FlagIncludeMemo                       = "include-memo"
+	FlagIfRunning                         = "if-running"
+	FlagShowAsyncWorkflowConfig           = "show-async-wf-config"
+	FlagHighlight                         = "highlight"
+	FlagObserveMaxRetries                 = "max-retries"
+	FlagObserveRetryInterval              = "retry-interval"
 	FlagResetBadBinaryChecksum            = "reset_bad_binary_checksum"
 	FlagSkipSignalReapply                 = "skip_signal_reapply"
 	FlagListQuery                         = "query"
@@ -250,6 +268,7 @@ const (
 	FlagDLQTypeWithAlias                  = FlagDLQType + ", dt"
 	FlagMaxMessageCount                   = "max_message_count"
 	FlagMaxMessageCountWithAlias          = FlagMaxMessageCount + ", mmc"
+	FlagMaxTaskCount                      = "max_task_count"
 	FlagLastMessageID                     = "last_message_id"
 	FlagLastMessageIDWithAlias            = FlagLastMessageID + ", lm"
 	FlagConcurrency                       = "concurrency"
@@ -297,6 +316,9 @@ const (
 	FlagDynamicConfigValue                = "value"
 	FlagTransport                         = "transport"
 	FlagTransportWithAlias                = FlagTransport + ", t"
+	FlagGRPCKeepaliveTime                 = "grpc-keepalive-time"
+	FlagGRPCKeepaliveTimeout              = "grpc-keepalive-timeout"
+	FlagGRPCKeepalivePermitWithoutStream  = "grpc-keepalive-permit-without-stream"
 	FlagFormat                            = "format"
 	FlagJSON                              = "json"
 	FlagIsolationGroupSetDrains           = "set-drains"
@@ -326,6 +348,15 @@ var flagsOfExecutionForShow = []cli.Flag{
 	},
 }
 
+func getFlagsForExport() []cli.Flag {
+	return append(flagsOfExecutionForShow,
+		cli.StringFlag{
+			Name:  FlagOutputFilenameWithAlias,
+			Usage: "File to write the history to, stdout if not set",
+		},
+	)
+}
+
 func getFlagsForShow() []cli.Flag {
 	return append(flagsOfExecutionForShow, getFlagsForShowID()...)
 }
@@ -365,6 +396,14 @@ func getFlagsForShowID() []cli.Flag {
 			Name:  FlagResetPointsOnly,
 			Usage: "Only show events that are eligible for reset",
 		},
+		cli.BoolFlag{
+			Name:  FlagFollowReset,
+			Usage: "Walk the reset lineage of the workflow and print the chain of runs instead of history",
+		},
+		cli.BoolFlag{
+			Name:  FlagHighlight,
+			Usage: "Color event types by severity when printing to a terminal: red for failures and timeouts, yellow for retries, green for completions",
+		},
 	}
 }
 
@@ -412,9 +451,9 @@ func getFlagsForStart() []cli.Flag {
 			Usage: "Optional input for the workflow, in JSON format. If there are multiple parameters, concatenate them and separate by space.",
 		},
 		cli.StringFlag{
-			Name: FlagInputFileWithAlias,
-			Usage: "Optional input for the workflow from JSON file. If there are multiple JSON, concatenate them and separate by space or newline. " +
-				"Input from file will be overwrite by input from command line",
+			Name: FlagInputFileWithAlias + ", input-file",
+			Usage: "Optional input for the workflow from JSON file, or - to read from stdin. If there are multiple JSON, concatenate them and separate by space or newline. " +
+				"Cannot be used together with --" + FlagInput,
 		},
 		cli.StringFlag{
 			Name:  FlagMemoKey,
@@ -545,10 +584,91 @@ func getFlagsForSignalWithStart() []cli.Flag {
 }
 
 func getFlagsForTerminate() []cli.Flag {
-	return append(flagsForExecution, cli.StringFlag{
-		Name:  FlagReasonWithAlias,
-		Usage: "The reason you want to terminate the workflow",
-	})
+	return append(flagsForExecution,
+		cli.StringFlag{
+			Name:  FlagReasonWithAlias,
+			Usage: "The reason you want to terminate the workflow",
+		},
+		cli.BoolFlag{
+			Name:  FlagIfRunning,
+			Usage: "Only terminate the workflow if it is still running, and succeed without doing anything if it is already closed",
+		},
+	)
+}
+
+func getFlagsForBatchTerminate() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  FlagListQueryWithAlias,
+			Usage: "Visibility query selecting the workflows to terminate. Required",
+		},
+		cli.StringFlag{
+			Name:  FlagReasonWithAlias,
+			Usage: "The reason you want to terminate the workflows",
+		},
+		cli.BoolFlag{
+			Name:  FlagYes,
+			Usage: "Optional flag to disable confirmation prompt",
+		},
+		cli.IntFlag{
+			Name:  FlagConcurrency,
+			Value: defaultBatchTerminateConcurrency,
+			Usage: "Number of workflows terminated in parallel",
+		},
+		cli.IntFlag{
+			Name:  FlagPageSizeWithAlias,
+			Value: defaultPageSizeForList,
+			Usage: "Page size used when listing workflows",
+		},
+	}
+}
+
+func getFlagsForBatchSignal() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  FlagListQueryWithAlias,
+			Usage: "Visibility query selecting the workflows to signal. Cannot be used together with --" + FlagWorkflowIDFile,
+		},
+		cli.StringFlag{
+			Name:  FlagWorkflowIDFile + ", workflow-id-file",
+			Usage: "File with one workflow per line of WorkflowID and RunID. RunID is optional, default to current run if not specified",
+		},
+		cli.StringFlag{
+			Name:  FlagInputSeparator,
+			Value: "\t",
+			Usage: "Separator for the workflow ID file (default to tab)",
+		},
+		cli.StringFlag{
+			Name:  FlagNameWithAlias,
+			Usage: "SignalName",
+		},
+		cli.StringFlag{
+			Name:  FlagInputWithAlias,
+			Usage: "Input for the signal, in JSON format.",
+		},
+		cli.StringFlag{
+			Name:  FlagInputFileWithAlias + ", input-file",
+			Usage: "Input for the signal from JSON file.",
+		},
+		cli.BoolFlag{
+			Name:  FlagDryRun + ", dry-run",
+			Usage: "Only print the workflows that would be signaled",
+		},
+		cli.BoolFlag{
+			Name:  FlagYes,
+			Usage: "Optional flag to disable confirmation prompt",
+		},
+		cli.IntFlag{
+			Name:  FlagConcurrency,
+			Value: defaultBatchSignalConcurrency,
+			Usage: "Number of workflows signaled in parallel",
+		},
+		cli.IntFlag{
+			Name:  FlagPageSizeWithAlias,
+			Value: defaultPageSizeForList,
+			Usage: "Page size used when listing workflows",
+		},
+	}
 }
 
 func getFlagsForCancel() []cli.Flag {
@@ -606,6 +726,10 @@ func getFlagsForList() []cli.Flag {
 			Value: 10,
 			Usage: "Result page size",
 		},
+		cli.BoolFlag{
+			Name:  FlagIncludeMemo,
+			Usage: "Include a column with the decoded memo of each workflow",
+		},
 	}
 	flagsForList = append(getFlagsForListAll(), flagsForList...)
 	return flagsForList
@@ -746,6 +870,13 @@ func getFlagsForStack() []cli.Flag {
 	return flags
 }
 
+func getFlagsForStackTrace() []cli.Flag {
+	return append(getFlagsForStack(), cli.StringFlag{
+		Name:  FlagOutputFilenameWithAlias,
+		Usage: "Optional file to write the stack trace to instead of printing it",
+	})
+}
+
 func getFlagsForDescribe() []cli.Flag {
 	return append(flagsForExecution, getFlagsForDescribeID()...)
 }
@@ -760,6 +891,10 @@ func getFlagsForDescribeID() []cli.Flag {
 			Name:  FlagResetPointsOnly,
 			Usage: "Only show auto-reset points",
 		},
+		cli.BoolFlag{
+			Name:  FlagShowSearchAttributes,
+			Usage: "Only show decoded search attributes",
+		},
 	}
 }
 
@@ -777,5 +912,14 @@ func getFlagsForObserveID() []cli.Flag {
 			Name:  FlagMaxFieldLengthWithAlias,
 			Usage: "Optional maximum length for each attribute field when show details",
 		},
+		cli.IntFlag{
+			Name:  FlagObserveMaxRetries,
+			Usage: "Optional maximum number of retries on transient errors while fetching history, default to no retry",
+		},
+		cli.DurationFlag{
+			Name:  FlagObserveRetryInterval,
+			Value: time.Second,
+			Usage: "Optional initial interval between retries, which grows exponentially",
+		},
 	}
 }
@@ -46,9 +46,9 @@ func AdminGetGlobalIsolationGroups(c *cli.Context) {
 	format := c.String(FlagFormat)
 	switch format {
 	case "json":
-		prettyPrintJSONObject(igs.IsolationGroups.ToPartitionList())
+		prettyPrintJSONObject(getOutputWriter(c), igs.IsolationGroups.ToPartitionList())
 	default:
-		fmt.Print(renderIsolationGroups(igs.IsolationGroups))
+		fmt.Fprint(getOutputWriter(c), renderIsolationGroups(igs.IsolationGroups))
 	}
 }
 
@@ -105,9 +105,9 @@ func AdminGetDomainIsolationGroups(c *cli.Context) {
 	format := c.String(FlagFormat)
 	switch format {
 	case "json":
-		prettyPrintJSONObject(igs.IsolationGroups.ToPartitionList())
+		prettyPrintJSONObject(getOutputWriter(c), igs.IsolationGroups.ToPartitionList())
 	default:
-		fmt.Print(renderIsolationGroups(igs.IsolationGroups))
+		fmt.Fprint(getOutputWriter(c), renderIsolationGroups(igs.IsolationGroups))
 	}
 }
 
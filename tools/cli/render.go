@@ -32,16 +32,20 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
 )
 
 const (
 	formatTable = "table"
 	formatJSON  = "json"
+	formatYAML  = "yaml"
 
 	templateTable = "{{table .}}\n"
 	templateJSON  = "{{json .}}\n"
+	templateYAML  = "{{yaml .}}"
 
 	defaultSliceSeparator   = ", "
 	defaultMapSeparator     = ", "
@@ -81,15 +85,16 @@ func Render(c *cli.Context, data interface{}, opts RenderOptions) (err error) {
 		}
 	}()
 
-	// For now always output to stdout
-	w := os.Stdout
+	w := getOutputWriter(c)
 
 	template := opts.DefaultTemplate
 
 	// Handle template shorthands
-	switch format := c.String(FlagFormat); format {
+	switch format := getFormat(c); format {
 	case formatJSON:
 		template = templateJSON
+	case formatYAML:
+		template = templateYAML
 	case formatTable:
 		template = templateTable
 	default:
@@ -108,6 +113,22 @@ func Render(c *cli.Context, data interface{}, opts RenderOptions) (err error) {
 	return RenderTemplate(w, data, template, opts)
 }
 
+// getOutputWriter returns the writer command results are written to, which is stdout unless the output path flag is given
+func getOutputWriter(c *cli.Context) io.Writer {
+	if c.App == nil || c.App.Writer == nil {
+		return os.Stdout
+	}
+	return c.App.Writer
+}
+
+// getFormat returns the command level --format flag, falling back to the global one
+func getFormat(c *cli.Context) string {
+	if format := c.String(FlagFormat); format != "" {
+		return format
+	}
+	return c.GlobalString(FlagFormat)
+}
+
 // RenderTemplate uses golang text/template format to render data with user provided template
 func RenderTemplate(w io.Writer, data interface{}, tmpl string, opts RenderOptions) error {
 	fns := map[string]interface{}{
@@ -122,6 +143,10 @@ func RenderTemplate(w io.Writer, data interface{}, tmpl string, opts RenderOptio
 			encoded, err := json.MarshalIndent(data, "", "  ")
 			return string(encoded), err
 		},
+		"yaml": func(data interface{}) (string, error) {
+			encoded, err := yaml.Marshal(data)
+			return string(encoded), err
+		},
 	}
 
 	t, err := template.New("").Funcs(fns).Parse(tmpl)
@@ -195,7 +220,8 @@ func RenderTable(w io.Writer, data interface{}, opts RenderOptions) error {
 		}
 		if r == 0 {
 			table.SetHeader(headers)
-			if opts.Color {
+			// only a terminal can show colors, files would get the escape sequences
+			if opts.Color && w == io.Writer(os.Stdout) && !color.NoColor {
 				table.SetHeaderColor(colors...)
 			}
 		}
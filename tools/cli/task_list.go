@@ -53,5 +53,23 @@ func newTaskListCommands() []cli.Command {
 			},
 			Action: ListTaskListPartitions,
 		},
+		{
+			Name:    "partition-config",
+			Aliases: []string{"pc"},
+			Usage:   "Show the number of read and write partitions configured for a tasklist.",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskListWithAlias,
+					Usage: "TaskList description",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskListTypeWithAlias,
+					Value: "decision",
+					Usage: "Optional TaskList type [decision|activity]",
+				},
+				getFormatFlag(),
+			},
+			Action: ListTaskListPartitionConfig,
+		},
 	}
 }
@@ -21,11 +21,13 @@
 package cli
 
 import (
-	"os"
+	"encoding/json"
+	"io"
 	"time"
 
 	"github.com/urfave/cli"
 
+	"github.com/uber/cadence/common/dynamicconfig"
 	"github.com/uber/cadence/common/types"
 )
 
@@ -40,6 +42,11 @@ type (
 		DecisionPartition string `header:"Decision Task List Partition"`
 		Host              string `header:"Host"`
 	}
+	TaskListPartitionConfigRow struct {
+		TaskListType    string `header:"Task List Type"`
+		ReadPartitions  int    `header:"Read Partitions"`
+		WritePartitions int    `header:"Write Partitions"`
+	}
 )
 
 // DescribeTaskList show pollers info of a given tasklist
@@ -69,7 +76,7 @@ func DescribeTaskList(c *cli.Context) {
 		ErrorAndExit(colorMagenta("No poller for tasklist: "+taskList), nil)
 	}
 
-	printTaskListPollers(pollers, taskListType)
+	printTaskListPollers(getOutputWriter(c), pollers, taskListType)
 }
 
 // ListTaskListPartitions gets all the tasklist partition and host information.
@@ -90,14 +97,55 @@ func ListTaskListPartitions(c *cli.Context) {
 		ErrorAndExit("Operation ListTaskListPartitions failed.", err)
 	}
 	if len(response.DecisionTaskListPartitions) > 0 {
-		printTaskListPartitions("Decision", response.DecisionTaskListPartitions)
+		printTaskListPartitions(getOutputWriter(c), "Decision", response.DecisionTaskListPartitions)
 	}
 	if len(response.ActivityTaskListPartitions) > 0 {
-		printTaskListPartitions("Activity", response.ActivityTaskListPartitions)
+		printTaskListPartitions(getOutputWriter(c), "Activity", response.ActivityTaskListPartitions)
 	}
 }
 
-func printTaskListPollers(pollers []*types.PollerInfo, taskListType types.TaskListType) {
+// ListTaskListPartitionConfig shows the number of read and write partitions configured for a tasklist.
+func ListTaskListPartitionConfig(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	taskList := getRequiredOption(c, FlagTaskList)
+	taskListType := strToTaskListType(c.String(FlagTaskListType)) // default type is decision
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	filters := []dynamicconfig.FilterOption{
+		dynamicconfig.DomainFilter(domain),
+		dynamicconfig.TaskListFilter(taskList),
+		dynamicconfig.TaskTypeFilter(int(taskListType)),
+	}
+	getPartitions := func(key dynamicconfig.IntKey) int {
+		request := dynamicconfig.ToGetDynamicConfigFilterRequest(key.String(), filters)
+		response, err := adminClient.GetDynamicConfig(ctx, request)
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			// no value is configured for the tasklist, so the default is in effect
+			return key.DefaultInt()
+		}
+		if err != nil {
+			ErrorAndExit("Operation GetDynamicConfig failed.", err)
+			return 0
+		}
+		var partitions int
+		if err := json.Unmarshal(response.Value.GetData(), &partitions); err != nil {
+			ErrorAndExit("Failed to decode number of partitions.", err)
+		}
+		return partitions
+	}
+
+	table := []TaskListPartitionConfigRow{{
+		TaskListType:    taskListType.String(),
+		ReadPartitions:  getPartitions(dynamicconfig.MatchingNumTasklistReadPartitions),
+		WritePartitions: getPartitions(dynamicconfig.MatchingNumTasklistWritePartitions),
+	}}
+	Render(c, table, RenderOptions{Color: true, Border: true})
+}
+
+func printTaskListPollers(w io.Writer, pollers []*types.PollerInfo, taskListType types.TaskListType) {
 	table := []TaskListPollerRow{}
 	for _, poller := range pollers {
 		table = append(table, TaskListPollerRow{
@@ -105,13 +153,13 @@ func printTaskListPollers(pollers []*types.PollerInfo, taskListType types.TaskLi
 			DecisionIdentity: poller.GetIdentity(),
 			LastAccessTime:   time.Unix(0, poller.GetLastAccessTime())})
 	}
-	RenderTable(os.Stdout, table, RenderOptions{Color: true, PrintDateTime: true, OptionalColumns: map[string]bool{
+	RenderTable(w, table, RenderOptions{Color: true, PrintDateTime: true, OptionalColumns: map[string]bool{
 		"Activity Poller Identity": taskListType == types.TaskListTypeActivity,
 		"Decision Poller Identity": taskListType == types.TaskListTypeDecision,
 	}})
 }
 
-func printTaskListPartitions(taskListType string, partitions []*types.TaskListPartitionMetadata) {
+func printTaskListPartitions(w io.Writer, taskListType string, partitions []*types.TaskListPartitionMetadata) {
 	table := []TaskListPartitionRow{}
 	for _, partition := range partitions {
 		table = append(table, TaskListPartitionRow{
@@ -120,7 +168,7 @@ func printTaskListPartitions(taskListType string, partitions []*types.TaskListPa
 			Host:              partition.GetOwnerHostName(),
 		})
 	}
-	RenderTable(os.Stdout, table, RenderOptions{Color: true, OptionalColumns: map[string]bool{
+	RenderTable(w, table, RenderOptions{Color: true, OptionalColumns: map[string]bool{
 		"Activity Task List Partition": taskListType == "Activity",
 		"Decision Task List Partition": taskListType == "Decision",
 	}})
@@ -47,6 +47,7 @@ import (
 	"github.com/uber/cadence/client/frontend"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/authorization"
+	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/pagination"
 	"github.com/uber/cadence/common/types"
 )
@@ -95,10 +96,22 @@ func GetWorkflowHistoryIterator(
 	isLongPoll bool,
 	filterType *types.HistoryEventFilterType,
 ) (pagination.Iterator, error) {
-	paginate := func(ctx context.Context, pageToken pagination.PageToken) (pagination.Page, error) {
-		tcCtx, cancel := context.WithTimeout(ctx, 25*time.Second)
-		defer cancel()
+	return getWorkflowHistoryIteratorWithRetry(ctx, workflowClient, domain, workflowID, runID, isLongPoll, filterType, nil)
+}
 
+// getWorkflowHistoryIteratorWithRetry returns a HistoryEvent iterator which retries fetching a page of history
+// with the given throttleRetry, if it is not nil
+func getWorkflowHistoryIteratorWithRetry(
+	ctx context.Context,
+	workflowClient frontend.Client,
+	domain,
+	workflowID,
+	runID string,
+	isLongPoll bool,
+	filterType *types.HistoryEventFilterType,
+	throttleRetry *backoff.ThrottleRetry,
+) (pagination.Iterator, error) {
+	paginate := func(ctx context.Context, pageToken pagination.PageToken) (pagination.Page, error) {
 		var nextPageToken []byte
 		if pageToken != nil {
 			nextPageToken, _ = pageToken.([]byte)
@@ -117,9 +130,21 @@ func GetWorkflowHistoryIterator(
 
 		var resp *types.GetWorkflowExecutionHistoryResponse
 		var err error
+		getHistory := func() error {
+			tcCtx, cancel := context.WithTimeout(ctx, 25*time.Second)
+			defer cancel()
+
+			var err error
+			resp, err = workflowClient.GetWorkflowExecutionHistory(tcCtx, request)
+			return err
+		}
 	Loop:
 		for {
-			resp, err = workflowClient.GetWorkflowExecutionHistory(tcCtx, request)
+			if throttleRetry != nil {
+				err = throttleRetry.Do(ctx, getHistory)
+			} else {
+				err = getHistory()
+			}
 			if err != nil {
 				return pagination.Page{}, err
 			}
@@ -333,6 +358,37 @@ func EventColorFunction(eventType types.EventType) func(format string, a ...inte
 	return colorFunc
 }
 
+// highlightEvent returns the event type of e colored by severity: red for failures and timeouts,
+// yellow for retries and green for completions. Colors are dropped when stdout is not a terminal.
+func highlightEvent(e *types.HistoryEvent) string {
+	eventType := e.GetEventType().String()
+	switch {
+	case strings.HasSuffix(eventType, "Failed"), strings.HasSuffix(eventType, "TimedOut"):
+		return color.RedString(eventType)
+	case isRetryEvent(e):
+		return color.YellowString(eventType)
+	case strings.HasSuffix(eventType, "Completed"):
+		return color.GreenString(eventType)
+	default:
+		return eventType
+	}
+}
+
+func isRetryEvent(e *types.HistoryEvent) bool {
+	switch e.GetEventType() {
+	case types.EventTypeWorkflowExecutionStarted:
+		return e.WorkflowExecutionStartedEventAttributes.GetAttempt() > 0
+	case types.EventTypeDecisionTaskScheduled:
+		return e.DecisionTaskScheduledEventAttributes.GetAttempt() > 0
+	case types.EventTypeActivityTaskStarted:
+		return e.ActivityTaskStartedEventAttributes != nil && e.ActivityTaskStartedEventAttributes.Attempt > 0
+	case types.EventTypeWorkflowExecutionContinuedAsNew:
+		return e.WorkflowExecutionContinuedAsNewEventAttributes.GetInitiator() == types.ContinueAsNewInitiatorRetryPolicy
+	default:
+		return false
+	}
+}
+
 func getEventAttributes(e *types.HistoryEvent) interface{} {
 	var data interface{}
 	switch e.GetEventType() {
@@ -480,14 +536,14 @@ func getCurrentUserFromEnv() string {
 	return "unknown"
 }
 
-func prettyPrintJSONObject(o interface{}) {
+func prettyPrintJSONObject(w io.Writer, o interface{}) {
 	b, err := json.MarshalIndent(o, "", "  ")
 	if err != nil {
 		fmt.Printf("Error when try to print pretty: %v\n", err)
-		fmt.Println(o)
+		fmt.Fprintln(w, o)
 	}
-	os.Stdout.Write(b)
-	fmt.Println()
+	w.Write(b)
+	fmt.Fprintln(w)
 }
 
 func mapKeysToArray(m map[string]string) []string {
@@ -663,6 +719,8 @@ func parseTimeRange(timeRange string) (time.Time, error) {
 	return res, nil
 }
 
+// parseSingleTs parses a timestamp in one of the supported date formats, raw UnixNano
+// or time range format (N<duration>), the same way parseTime does
 func parseSingleTs(ts string) (time.Time, error) {
 	var tsOut time.Time
 	var err error
@@ -672,6 +730,16 @@ func parseSingleTs(ts string) (time.Time, error) {
 			return tsOut, err
 		}
 	}
+
+	// treat as raw time
+	if unixNano, parseErr := strconv.ParseInt(ts, 10, 64); parseErr == nil {
+		return time.Unix(0, unixNano), nil
+	}
+
+	// treat as time range format
+	if tsOut, parseErr := parseTimeRange(ts); parseErr == nil {
+		return tsOut, nil
+	}
 	return tsOut, err
 }
 
@@ -806,9 +874,15 @@ func processJSONInputHelper(c *cli.Context, jType jsonType) string {
 		input = c.String(flagNameOfRawInput)
 	} else if c.IsSet(flagNameOfInputFileName) {
 		inputFile := c.String(flagNameOfInputFileName)
-		// This method is purely used to parse input from the CLI. The input comes from a trusted user
-		// #nosec
-		data, err := ioutil.ReadFile(inputFile)
+		var data []byte
+		var err error
+		if inputFile == "-" {
+			data, err = ioutil.ReadAll(os.Stdin)
+		} else {
+			// This method is purely used to parse input from the CLI. The input comes from a trusted user
+			// #nosec
+			data, err = ioutil.ReadFile(inputFile)
+		}
 		if err != nil {
 			ErrorAndExit("Error reading input file", err)
 		}
@@ -856,7 +930,8 @@ func validateJSONs(str string) error {
 	input := []byte(str)
 	dec := json.NewDecoder(bytes.NewReader(input))
 	for {
-		_, err := dec.Token()
+		var v json.RawMessage
+		err := dec.Decode(&v)
 		if err == io.EOF {
 			return nil // End of input, valid JSON
 		}
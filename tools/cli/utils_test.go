@@ -24,7 +24,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common/types"
 )
 
 func Test_ParseIntMultiRange(t *testing.T) {
@@ -124,3 +127,56 @@ func Test_anyToString(t *testing.T) {
 	res := anyToString(info, false, 100)
 	assert.Equal(t, "{Name:Joel, Number:1234, Time:2019-01-15 14:30:45 +0000 UTC}", res)
 }
+
+func Test_highlightEvent(t *testing.T) {
+	originalNoColor := color.NoColor
+	defer func() { color.NoColor = originalNoColor }()
+
+	event := func(eventType types.EventType) *types.HistoryEvent {
+		return &types.HistoryEvent{EventType: eventType.Ptr()}
+	}
+	tests := []struct {
+		name   string
+		event  *types.HistoryEvent
+		expect string
+	}{
+		{
+			name:   "failure",
+			event:  event(types.EventTypeActivityTaskFailed),
+			expect: "\x1b[31mActivityTaskFailed\x1b[0m",
+		},
+		{
+			name:   "timeout",
+			event:  event(types.EventTypeDecisionTaskTimedOut),
+			expect: "\x1b[31mDecisionTaskTimedOut\x1b[0m",
+		},
+		{
+			name: "retry",
+			event: &types.HistoryEvent{
+				EventType:                          types.EventTypeActivityTaskStarted.Ptr(),
+				ActivityTaskStartedEventAttributes: &types.ActivityTaskStartedEventAttributes{Attempt: 2},
+			},
+			expect: "\x1b[33mActivityTaskStarted\x1b[0m",
+		},
+		{
+			name:   "first attempt",
+			event:  event(types.EventTypeActivityTaskStarted),
+			expect: "ActivityTaskStarted",
+		},
+		{
+			name:   "completion",
+			event:  event(types.EventTypeWorkflowExecutionCompleted),
+			expect: "\x1b[32mWorkflowExecutionCompleted\x1b[0m",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			color.NoColor = false
+			assert.Equal(t, tt.expect, highlightEvent(tt.event))
+
+			// not a terminal
+			color.NoColor = true
+			assert.Equal(t, tt.event.GetEventType().String(), highlightEvent(tt.event))
+		})
+	}
+}
@@ -50,6 +50,12 @@ func newWorkflowCommands() []cli.Command {
 			Flags:  getFlagsForShow(),
 			Action: ShowHistory,
 		},
+		{
+			Name:   "export",
+			Usage:  "export workflow history as a JSON file which the Go client workflow replayer can load",
+			Flags:  getFlagsForExport(),
+			Action: ExportHistory,
+		},
 		{
 			Name:        "showid",
 			Usage:       "show workflow history with given workflow_id and run_id (a shortcut of `show -w <wid> -r <rid>`). run_id is only required for archived history",
@@ -89,6 +95,12 @@ func newWorkflowCommands() []cli.Command {
 			Flags:  getFlagsForSignalWithStart(),
 			Action: SignalWithStartWorkflowExecution,
 		},
+		{
+			Name:   "signal-batch",
+			Usage:  "signal all workflow executions matching a list query or listed in a file",
+			Flags:  getFlagsForBatchSignal(),
+			Action: BatchSignalWorkflows,
+		},
 		{
 			Name:    "terminate",
 			Aliases: []string{"term"},
@@ -96,6 +108,12 @@ func newWorkflowCommands() []cli.Command {
 			Flags:   getFlagsForTerminate(),
 			Action:  TerminateWorkflow,
 		},
+		{
+			Name:   "batch-terminate",
+			Usage:  "terminate all workflow executions matching a list query",
+			Flags:  getFlagsForBatchTerminate(),
+			Action: BatchTerminateWorkflows,
+		},
 		{
 			Name:        "list",
 			Aliases:     []string{"l"},
@@ -148,7 +166,7 @@ func newWorkflowCommands() []cli.Command {
 		{
 			Name:   "stack",
 			Usage:  "query workflow execution with __stack_trace as query type",
-			Flags:  getFlagsForStack(),
+			Flags:  getFlagsForStackTrace(),
 			Action: QueryWorkflowUsingStackTrace,
 		},
 		{
@@ -296,6 +314,10 @@ func newWorkflowCommands() []cli.Command {
 					Name:  FlagDryRun,
 					Usage: "Not do real action of reset(just logging in STDOUT)",
 				},
+				cli.BoolFlag{
+					Name:  FlagYes,
+					Usage: "Optional flag to disable confirmation prompt",
+				},
 				cli.StringFlag{
 					Name:  FlagResetType,
 					Usage: "where to reset. Support one of these: " + strings.Join(mapKeysToArray(resetTypesMap), ","),
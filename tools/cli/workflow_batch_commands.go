@@ -62,7 +62,7 @@ func TerminateBatchJob(c *cli.Context) {
 	output := map[string]interface{}{
 		"msg": "batch job is terminated",
 	}
-	prettyPrintJSONObject(output)
+	prettyPrintJSONObject(getOutputWriter(c), output)
 }
 
 // DescribeBatchJob describe the status of the batch job
@@ -106,7 +106,7 @@ func DescribeBatchJob(c *cli.Context) {
 			output["progress"] = hbd
 		}
 	}
-	prettyPrintJSONObject(output)
+	prettyPrintJSONObject(getOutputWriter(c), output)
 }
 
 // ListBatchJobs list the started batch jobs
@@ -147,7 +147,7 @@ func ListBatchJobs(c *cli.Context) {
 
 		output = append(output, job)
 	}
-	prettyPrintJSONObject(output)
+	prettyPrintJSONObject(getOutputWriter(c), output)
 }
 
 // StartBatchJob starts a batch job
@@ -191,11 +191,11 @@ func StartBatchJob(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("Failed to count impacting workflows for starting a batch job", err)
 	}
-	fmt.Printf("This batch job will be operating on %v workflows.\n", resp.GetCount())
+	fmt.Fprintf(getOutputWriter(c), "This batch job will be operating on %v workflows.\n", resp.GetCount())
 	if !c.Bool(FlagYes) {
 		reader := bufio.NewReader(os.Stdin)
 		for {
-			fmt.Print("Please confirm[Yes/No]:")
+			fmt.Fprint(getOutputWriter(c), "Please confirm[Yes/No]:")
 			text, err := reader.ReadString('\n')
 			if err != nil {
 				ErrorAndExit("Failed to  get confirmation for starting a batch job", err)
@@ -203,7 +203,7 @@ func StartBatchJob(c *cli.Context) {
 			if strings.EqualFold(strings.TrimSpace(text), "yes") {
 				break
 			} else {
-				fmt.Println("Batch job is not started")
+				fmt.Fprintln(getOutputWriter(c), "Batch job is not started")
 				return
 			}
 		}
@@ -269,7 +269,7 @@ func StartBatchJob(c *cli.Context) {
 		"msg":   "batch job is started",
 		"jobID": workflowID,
 	}
-	prettyPrintJSONObject(output)
+	prettyPrintJSONObject(getOutputWriter(c), output)
 }
 
 func validateBatchType(bt string) bool {
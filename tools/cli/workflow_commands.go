@@ -27,24 +27,30 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math"
 	"math/rand"
 	"os"
+	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
 	"github.com/pborman/uuid"
 	"github.com/urfave/cli"
 
 	"github.com/uber/cadence/client/frontend"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/types/mapper/thrift"
 	"github.com/uber/cadence/service/history/execution"
 )
 
@@ -72,7 +78,7 @@ func RestartWorkflow(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("Restart workflow failed.", err)
 	} else {
-		fmt.Printf("Restarted Workflow Id: %s, run Id: %s\n", wid, resp.GetRunID())
+		fmt.Fprintf(getOutputWriter(c), "Restarted Workflow Id: %s, run Id: %s\n", wid, resp.GetRunID())
 	}
 }
 
@@ -110,6 +116,14 @@ func showHistoryHelper(c *cli.Context, wid, rid string) {
 		maxFieldLength = c.Int(FlagMaxFieldLength)
 	}
 	resetPointsOnly := c.Bool(FlagResetPointsOnly)
+	highlight := c.Bool(FlagHighlight)
+	eventDetail := func(e *types.HistoryEvent, maxFieldLength int) string {
+		detail := anyToString(e, true, maxFieldLength)
+		if highlight {
+			return highlightEvent(e) + " " + detail
+		}
+		return detail
+	}
 
 	ctx, cancel := newContext(c)
 	defer cancel()
@@ -118,8 +132,21 @@ func showHistoryHelper(c *cli.Context, wid, rid string) {
 		ErrorAndExit(fmt.Sprintf("Failed to get history on workflow id: %s, run id: %s.", wid, rid), err)
 	}
 
+	if c.Bool(FlagFollowReset) {
+		lineage, err := getResetLineage(ctx, wfClient, domain, wid, rid, history)
+		if err != nil {
+			ErrorAndExit(fmt.Sprintf("Failed to get reset lineage on workflow id: %s, run id: %s.", wid, rid), err)
+		}
+		RenderTable(getOutputWriter(c), lineage, RenderOptions{Color: true, Border: true})
+		return
+	}
+
+	format := getFormat(c)
+	machineReadable := format == formatJSON || format == formatYAML
 	prevEvent := types.HistoryEvent{}
-	if printFully { // dump everything
+	if machineReadable { // render the typed events, table formatting flags do not apply
+		Render(c, history.Events, RenderOptions{})
+	} else if printFully { // dump everything
 		for _, e := range history.Events {
 			if resetPointsOnly {
 				if prevEvent.GetEventType() != types.EventTypeDecisionTaskStarted {
@@ -128,7 +155,7 @@ func showHistoryHelper(c *cli.Context, wid, rid string) {
 				}
 				prevEvent = *e
 			}
-			fmt.Println(anyToString(e, true, maxFieldLength))
+			fmt.Fprintln(getOutputWriter(c), eventDetail(e, maxFieldLength))
 		}
 	} else if c.IsSet(FlagEventID) { // only dump that event
 		eventID := c.Int(FlagEventID)
@@ -136,9 +163,9 @@ func showHistoryHelper(c *cli.Context, wid, rid string) {
 			ErrorAndExit("EventId out of range.", fmt.Errorf("number should be 1 - %d inclusive", len(history.Events)))
 		}
 		e := history.Events[eventID-1]
-		fmt.Println(anyToString(e, true, 0))
+		fmt.Fprintln(getOutputWriter(c), eventDetail(e, 0))
 	} else { // use table to pretty output, will trim long text
-		table := tablewriter.NewWriter(os.Stdout)
+		table := tablewriter.NewWriter(getOutputWriter(c))
 		table.SetBorder(false)
 		table.SetColumnSeparator("")
 		for _, e := range history.Events {
@@ -162,7 +189,11 @@ func showHistoryHelper(c *cli.Context, wid, rid string) {
 				columns = append(columns, fmt.Sprintf("(Version: %v)", e.Version))
 			}
 
-			columns = append(columns, ColorEvent(e), HistoryEventToString(e, false, maxFieldLength))
+			eventType := ColorEvent(e)
+			if highlight {
+				eventType = highlightEvent(e)
+			}
+			columns = append(columns, eventType, HistoryEventToString(e, false, maxFieldLength))
 			table.Append(columns)
 		}
 		table.Render()
@@ -178,6 +209,9 @@ func showHistoryHelper(c *cli.Context, wid, rid string) {
 			ErrorAndExit("Failed to export history data file.", err)
 		}
 	}
+	if machineReadable {
+		return
+	}
 
 	// finally append activities with retry
 	frontendClient := cFactory.ServerFrontendClient(c)
@@ -190,20 +224,112 @@ func showHistoryHelper(c *cli.Context, wid, rid string) {
 	})
 	if err != nil {
 		if _, ok := err.(*types.EntityNotExistsError); ok {
-			fmt.Printf("%s %s\n", colorRed("Error:"), err)
+			fmt.Fprintf(getOutputWriter(c), "%s %s\n", colorRed("Error:"), err)
 			return
 		}
 		ErrorAndExit("Describe workflow execution failed, cannot get information of pending activities", err)
 	}
-	fmt.Println("History Source: Default Storage")
+	fmt.Fprintln(getOutputWriter(c), "History Source: Default Storage")
 
 	descOutput := convertDescribeWorkflowExecutionResponse(resp, frontendClient, c)
 	if len(descOutput.PendingActivities) > 0 {
-		fmt.Println("============Workflow Pending activities============")
-		prettyPrintJSONObject(descOutput.PendingActivities)
-		fmt.Println("NOTE: ActivityStartedEvent with retry policy will be written into history when the activity is finished.")
+		fmt.Fprintln(getOutputWriter(c), "============Workflow Pending activities============")
+		prettyPrintJSONObject(getOutputWriter(c), descOutput.PendingActivities)
+		fmt.Fprintln(getOutputWriter(c), "NOTE: ActivityStartedEvent with retry policy will be written into history when the activity is finished.")
+	}
+
+}
+
+type resetLineageRow struct {
+	RunID     string `header:"Run ID"`
+	BaseRunID string `header:"Reset From Run ID"`
+	Reason    string `header:"Reset Reason"`
+}
+
+// getResetLineage walks back from the given run through the reset base run IDs recorded in history
+// and returns the chain of runs, oldest first.
+func getResetLineage(
+	ctx context.Context,
+	wfClient frontend.Client,
+	domain string,
+	wid string,
+	rid string,
+	history *types.History,
+) ([]resetLineageRow, error) {
+	var lineage []resetLineageRow
+	visited := make(map[string]struct{})
+	for {
+		attr := getLastResetAttributes(history)
+		if attr == nil {
+			lineage = append(lineage, resetLineageRow{RunID: rid})
+			break
+		}
+		if _, ok := visited[attr.BaseRunID]; ok {
+			return nil, fmt.Errorf("reset lineage contains a cycle at run id: %s", attr.BaseRunID)
+		}
+		visited[attr.NewRunID] = struct{}{}
+		lineage = append(lineage, resetLineageRow{
+			RunID:     attr.NewRunID,
+			BaseRunID: attr.BaseRunID,
+			Reason:    common.StringDefault(attr.Reason),
+		})
+
+		rid = attr.BaseRunID
+		var err error
+		history, err = GetHistory(ctx, wfClient, domain, wid, rid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i, j := 0, len(lineage)-1; i < j; i, j = i+1, j-1 {
+		lineage[i], lineage[j] = lineage[j], lineage[i]
+	}
+	return lineage, nil
+}
+
+// getLastResetAttributes returns the attributes of the reset event that created the run owning the history.
+// A reset run copies history up to the reset point, so earlier reset events may belong to ancestor runs.
+func getLastResetAttributes(history *types.History) *types.DecisionTaskFailedEventAttributes {
+	for i := len(history.GetEvents()) - 1; i >= 0; i-- {
+		attr := history.Events[i].DecisionTaskFailedEventAttributes
+		if attr != nil && attr.GetCause() == types.DecisionTaskFailedCauseResetWorkflow {
+			return attr
+		}
+	}
+	return nil
+}
+
+// ExportHistory writes the full history of a workflow execution in the JSON format of the Go client replayer
+func ExportHistory(c *cli.Context) {
+	wfClient := getWorkflowClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	history, err := GetHistory(ctx, wfClient, domain, wid, rid)
+	if err != nil {
+		ErrorAndExit(fmt.Sprintf("Failed to get history on workflow id: %s, run id: %s.", wid, rid), err)
+	}
+
+	// the replayer decodes a JSON array of thrift history events
+	data, err := json.MarshalIndent(thrift.FromHistoryEventArray(history.Events), "", "  ")
+	if err != nil {
+		ErrorAndExit("Failed to serialize history data.", err)
 	}
 
+	outputFileName := c.String(FlagOutputFilename)
+	if outputFileName == "" {
+		fmt.Fprintln(getOutputWriter(c), string(data))
+		return
+	}
+	if err := ioutil.WriteFile(outputFileName, data, 0666); err != nil {
+		ErrorAndExit("Failed to export history data file.", err)
+	}
+	fmt.Fprintf(getOutputWriter(c), "Exported %d events to %s.\n", len(history.Events), outputFileName)
 }
 
 // StartWorkflow starts a new workflow execution
@@ -234,7 +360,7 @@ func startWorkflowHelper(c *cli.Context, shouldPrintProgress bool) {
 		if err != nil {
 			ErrorAndExit("Failed to create workflow.", err)
 		} else {
-			fmt.Printf("Started Workflow Id: %s, run Id: %s\n", wid, resp.GetRunID())
+			fmt.Fprintf(getOutputWriter(c), "Started Workflow Id: %s, run Id: %s\n", wid, resp.GetRunID())
 		}
 	}
 
@@ -248,8 +374,8 @@ func startWorkflowHelper(c *cli.Context, shouldPrintProgress bool) {
 		}
 
 		// print execution summary
-		fmt.Println(colorMagenta("Running execution:"))
-		table := tablewriter.NewWriter(os.Stdout)
+		fmt.Fprintln(getOutputWriter(c), colorMagenta("Running execution:"))
+		table := tablewriter.NewWriter(getOutputWriter(c))
 		executionData := [][]string{
 			{"Workflow Id", wid},
 			{"Run Id", resp.GetRunID()},
@@ -291,6 +417,9 @@ func constructStartWorkflowRequest(c *cli.Context) *types.StartWorkflowExecution
 		reusePolicy = getWorkflowIDReusePolicy(c.Int(FlagWorkflowIDReusePolicy))
 	}
 
+	if c.IsSet(FlagInput) && c.IsSet(FlagInputFile) {
+		ErrorAndExit(fmt.Sprintf("Only one of %s and %s can be specified.", FlagInput, FlagInputFile), nil)
+	}
 	input := processJSONInput(c)
 	startRequest := &types.StartWorkflowExecutionRequest{
 		RequestID:  uuid.New(),
@@ -412,7 +541,7 @@ func processMemo(c *cli.Context) map[string][]byte {
 
 // helper function to print workflow progress with time refresh every second
 func printWorkflowProgress(c *cli.Context, domain, wid, rid string) {
-	fmt.Println(colorMagenta("Progress:"))
+	fmt.Fprintln(getOutputWriter(c), colorMagenta("Progress:"))
 
 	wfClient := getWorkflowClient(c)
 	timeElapse := 1
@@ -430,25 +559,38 @@ func printWorkflowProgress(c *cli.Context, domain, wid, rid string) {
 		maxFieldLength = c.Int(FlagMaxFieldLength)
 	}
 
+	var throttleRetry *backoff.ThrottleRetry
+	if maxRetries := c.Int(FlagObserveMaxRetries); maxRetries > 0 {
+		retryPolicy := backoff.NewExponentialRetryPolicy(c.Duration(FlagObserveRetryInterval))
+		retryPolicy.SetMaximumAttempts(maxRetries)
+		retryPolicy.SetExpirationInterval(backoff.NoInterval)
+		throttleRetry = backoff.NewThrottleRetry(
+			backoff.WithRetryPolicy(retryPolicy),
+			backoff.WithThrottlePolicy(retryPolicy),
+			backoff.WithRetryableError(isObserveRetryableError),
+		)
+	}
+
 	go func() {
-		iterator, err := GetWorkflowHistoryIterator(tcCtx, wfClient, domain, wid, rid, true, types.HistoryEventFilterTypeAllEvent.Ptr())
+		iterator, err := getWorkflowHistoryIteratorWithRetry(tcCtx, wfClient, domain, wid, rid, true, types.HistoryEventFilterTypeAllEvent.Ptr(), throttleRetry)
 		if err != nil {
 			ErrorAndExit("Unable to get history events.", err)
 		}
 		for iterator.HasNext() {
 			entity, err := iterator.Next()
-			event := entity.(*types.HistoryEvent)
 			if err != nil {
 				ErrorAndExit("Unable to read event.", err)
+				return
 			}
+			event := entity.(*types.HistoryEvent)
 			if isTimeElapseExist {
 				removePrevious2LinesFromTerminal()
 				isTimeElapseExist = false
 			}
 			if showDetails {
-				fmt.Printf("  %d, %s, %s, %s\n", event.ID, convertTime(event.GetTimestamp(), false), ColorEvent(event), HistoryEventToString(event, true, maxFieldLength))
+				fmt.Fprintf(getOutputWriter(c), "  %d, %s, %s, %s\n", event.ID, convertTime(event.GetTimestamp(), false), ColorEvent(event), HistoryEventToString(event, true, maxFieldLength))
 			} else {
-				fmt.Printf("  %d, %s, %s\n", event.ID, convertTime(event.GetTimestamp(), false), ColorEvent(event))
+				fmt.Fprintf(getOutputWriter(c), "  %d, %s, %s\n", event.ID, convertTime(event.GetTimestamp(), false), ColorEvent(event))
 			}
 			lastEvent = event
 		}
@@ -461,18 +603,22 @@ func printWorkflowProgress(c *cli.Context, domain, wid, rid string) {
 			if isTimeElapseExist {
 				removePrevious2LinesFromTerminal()
 			}
-			fmt.Printf("\nTime elapse: %ds\n", timeElapse)
+			fmt.Fprintf(getOutputWriter(c), "\nTime elapse: %ds\n", timeElapse)
 			isTimeElapseExist = true
 			timeElapse++
 		case <-doneChan: // print result of this run
-			fmt.Println(colorMagenta("\nResult:"))
-			fmt.Printf("  Run Time: %d seconds\n", timeElapse)
-			printRunStatus(lastEvent)
+			fmt.Fprintln(getOutputWriter(c), colorMagenta("\nResult:"))
+			fmt.Fprintf(getOutputWriter(c), "  Run Time: %d seconds\n", timeElapse)
+			printRunStatus(getOutputWriter(c), lastEvent)
 			return
 		}
 	}
 }
 
+func isObserveRetryableError(err error) bool {
+	return common.IsServiceTransientError(err) || common.IsContextTimeoutError(err)
+}
+
 // TerminateWorkflow terminates a workflow execution
 func TerminateWorkflow(c *cli.Context) {
 	wfClient := getWorkflowClient(c)
@@ -481,9 +627,30 @@ func TerminateWorkflow(c *cli.Context) {
 	wid := getRequiredOption(c, FlagWorkflowID)
 	rid := c.String(FlagRunID)
 	reason := c.String(FlagReason)
+	ifRunning := c.Bool(FlagIfRunning)
 
 	ctx, cancel := newContext(c)
 	defer cancel()
+
+	if ifRunning {
+		resp, err := wfClient.DescribeWorkflowExecution(ctx, &types.DescribeWorkflowExecutionRequest{
+			Domain: domain,
+			Execution: &types.WorkflowExecution{
+				WorkflowID: wid,
+				RunID:      rid,
+			},
+		})
+		if err != nil {
+			ErrorAndExit("Describe workflow execution failed", err)
+		}
+		if resp.WorkflowExecutionInfo.CloseStatus != nil {
+			fmt.Fprintf(getOutputWriter(c), "Workflow is already closed with status %v, skipping termination.\n", resp.WorkflowExecutionInfo.GetCloseStatus())
+			return
+		}
+		// pin the run so that a newer run started in the meantime is not terminated
+		rid = resp.WorkflowExecutionInfo.Execution.GetRunID()
+	}
+
 	err := wfClient.TerminateWorkflowExecution(
 		ctx,
 		&types.TerminateWorkflowExecutionRequest{
@@ -496,10 +663,64 @@ func TerminateWorkflow(c *cli.Context) {
 		},
 	)
 
+	var alreadyCompletedErr *types.WorkflowExecutionAlreadyCompletedError
+	if ifRunning && errors.As(err, &alreadyCompletedErr) {
+		fmt.Fprintln(getOutputWriter(c), "Workflow closed before it could be terminated, skipping termination.")
+		return
+	}
 	if err != nil {
 		ErrorAndExit("Terminate workflow failed.", err)
 	} else {
-		fmt.Println("Terminate workflow succeeded.")
+		fmt.Fprintln(getOutputWriter(c), "Terminate workflow succeeded.")
+	}
+}
+
+// BatchTerminateWorkflows terminates all workflow executions matching a list query
+func BatchTerminateWorkflows(c *cli.Context) {
+	wfClient := getWorkflowClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	query := getRequiredOption(c, FlagListQuery)
+	reason := c.String(FlagReason)
+	concurrency := c.Int(FlagConcurrency)
+	if concurrency <= 0 {
+		ErrorAndExit(fmt.Sprintf("Option %s must be positive.", FlagConcurrency), nil)
+		return
+	}
+	pageSize := c.Int(FlagPageSize)
+	if pageSize <= 0 {
+		pageSize = defaultPageSizeForList
+	}
+
+	if !c.Bool(FlagYes) {
+		promptFn(fmt.Sprintf("Are you sure to terminate all workflows in domain [%s] matching query [%s]? Y/N",
+			color.YellowString(domain), color.YellowString(query)))
+	}
+
+	succeeded, failed := runBatch(
+		concurrency,
+		listedWorkflowExecutions(c, wfClient, pageSize, domain, query),
+		func(execution *types.WorkflowExecution) error {
+			ctx, cancel := newContext(c)
+			defer cancel()
+			return wfClient.TerminateWorkflowExecution(ctx, &types.TerminateWorkflowExecutionRequest{
+				Domain:            domain,
+				WorkflowExecution: execution,
+				Reason:            reason,
+				Identity:          getCliIdentity(),
+			})
+		},
+		func(execution *types.WorkflowExecution, err error, succeeded, failed int) {
+			if err != nil {
+				fmt.Fprintf(getOutputWriter(c), "Failed to terminate workflow %s, run %s: %v\n", execution.GetWorkflowID(), execution.GetRunID(), err)
+			}
+			fmt.Fprintf(getOutputWriter(c), "Processed %d workflows (%d succeeded, %d failed)\n", succeeded+failed, succeeded, failed)
+		},
+	)
+
+	fmt.Fprintf(getOutputWriter(c), "Batch terminate finished: %d succeeded, %d failed.\n", succeeded, failed)
+	if failed > 0 {
+		ErrorAndExit(fmt.Sprintf("Failed to terminate %d workflows.", failed), nil)
 	}
 }
 
@@ -531,7 +752,7 @@ func CancelWorkflow(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("Cancel workflow failed.", err)
 	} else {
-		fmt.Println("Cancel workflow succeeded.")
+		fmt.Fprintln(getOutputWriter(c), "Cancel workflow succeeded.")
 	}
 }
 
@@ -565,10 +786,156 @@ func SignalWorkflow(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("Signal workflow failed.", err)
 	} else {
-		fmt.Println("Signal workflow succeeded.")
+		fmt.Fprintln(getOutputWriter(c), "Signal workflow succeeded.")
+	}
+}
+
+// BatchSignalWorkflows signals all workflows matching a list query or listed in a file
+func BatchSignalWorkflows(c *cli.Context) {
+	wfClient := getWorkflowClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	query := c.String(FlagListQuery)
+	idFileName := c.String(FlagWorkflowIDFile)
+	if (query == "") == (idFileName == "") {
+		ErrorAndExit(fmt.Sprintf("Exactly one of %s and %s must be specified.", FlagListQuery, FlagWorkflowIDFile), nil)
+		return
+	}
+	name := getRequiredOption(c, FlagName)
+	input := processJSONInput(c)
+	dryRun := c.Bool(FlagDryRun)
+	concurrency := c.Int(FlagConcurrency)
+	if concurrency <= 0 {
+		ErrorAndExit(fmt.Sprintf("Option %s must be positive.", FlagConcurrency), nil)
+		return
+	}
+	pageSize := c.Int(FlagPageSize)
+	if pageSize <= 0 {
+		pageSize = defaultPageSizeForList
+	}
+
+	var fromFile []*types.WorkflowExecution
+	if idFileName != "" {
+		fromFile = loadWorkflowExecutionsFromFile(idFileName, c.String(FlagInputSeparator))
+	}
+
+	if !dryRun && !c.Bool(FlagYes) {
+		promptFn(fmt.Sprintf("Are you sure to send signal [%s] to all target workflows in domain [%s]? Y/N",
+			color.YellowString(name), color.YellowString(domain)))
+	}
+
+	source := listedWorkflowExecutions(c, wfClient, pageSize, domain, query)
+	if idFileName != "" {
+		source = fileWorkflowExecutions(fromFile)
+	}
+	succeeded, failed := runBatch(
+		concurrency,
+		source,
+		func(execution *types.WorkflowExecution) error {
+			if dryRun {
+				return nil
+			}
+			ctx, cancel := newContext(c)
+			defer cancel()
+			return wfClient.SignalWorkflowExecution(ctx, &types.SignalWorkflowExecutionRequest{
+				Domain:            domain,
+				WorkflowExecution: execution,
+				SignalName:        name,
+				Input:             []byte(input),
+				Identity:          getCliIdentity(),
+				RequestID:         uuid.New(),
+			})
+		},
+		func(execution *types.WorkflowExecution, err error, _, _ int) {
+			switch {
+			case dryRun:
+				fmt.Fprintf(getOutputWriter(c), "Would signal workflow %s, run %s\n", execution.GetWorkflowID(), execution.GetRunID())
+			case err != nil:
+				fmt.Fprintf(getOutputWriter(c), "Failed to signal workflow %s, run %s: %v\n", execution.GetWorkflowID(), execution.GetRunID(), err)
+			default:
+				fmt.Fprintf(getOutputWriter(c), "Signaled workflow %s, run %s\n", execution.GetWorkflowID(), execution.GetRunID())
+			}
+		},
+	)
+
+	if dryRun {
+		fmt.Fprintf(getOutputWriter(c), "Batch signal dry run finished: %d workflows would be signaled.\n", succeeded)
+		return
+	}
+	fmt.Fprintf(getOutputWriter(c), "Batch signal finished: %d succeeded, %d failed.\n", succeeded, failed)
+	if failed > 0 {
+		ErrorAndExit(fmt.Sprintf("Failed to signal %d workflows.", failed), nil)
+	}
+}
+
+// batchExecutionSource sends the executions a batch command operates on to the given channel
+type batchExecutionSource func(executions chan<- *types.WorkflowExecution)
+
+// listedWorkflowExecutions is a batchExecutionSource of the executions matching a list query
+func listedWorkflowExecutions(c *cli.Context, wfClient frontend.Client, pageSize int, domain, query string) batchExecutionSource {
+	return func(executions chan<- *types.WorkflowExecution) {
+		listFn := listWorkflowExecutions(wfClient, pageSize, domain, query, c)
+		var nextPageToken []byte
+		for {
+			var infos []*types.WorkflowExecutionInfo
+			infos, nextPageToken = listFn(nextPageToken)
+			for _, info := range infos {
+				executions <- info.GetExecution()
+			}
+			if len(nextPageToken) == 0 {
+				return
+			}
+		}
+	}
+}
+
+// fileWorkflowExecutions is a batchExecutionSource of executions loaded from a file
+func fileWorkflowExecutions(fromFile []*types.WorkflowExecution) batchExecutionSource {
+	return func(executions chan<- *types.WorkflowExecution) {
+		for _, execution := range fromFile {
+			executions <- execution
+		}
 	}
 }
 
+// runBatch calls processFn for every execution of source using concurrency workers.
+// reportFn is called serially after each execution with its outcome and the counts so far.
+func runBatch(
+	concurrency int,
+	source batchExecutionSource,
+	processFn func(execution *types.WorkflowExecution) error,
+	reportFn func(execution *types.WorkflowExecution, err error, succeeded, failed int),
+) (succeeded, failed int) {
+	executions := make(chan *types.WorkflowExecution, concurrency)
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for execution := range executions {
+				err := processFn(execution)
+
+				mu.Lock()
+				if err != nil {
+					failed++
+				} else {
+					succeeded++
+				}
+				reportFn(execution, err, succeeded, failed)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	source(executions)
+	close(executions)
+	wg.Wait()
+	return succeeded, failed
+}
+
 // SignalWithStartWorkflowExecution starts a workflow execution if not already exists and signals it
 func SignalWithStartWorkflowExecution(c *cli.Context) {
 	serviceClient := cFactory.ServerFrontendClient(c)
@@ -582,7 +949,7 @@ func SignalWithStartWorkflowExecution(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("SignalWithStart workflow failed.", err)
 	} else {
-		fmt.Printf("SignalWithStart workflow succeeded. Workflow Id: %s, run Id: %s\n", signalWithStartRequest.GetWorkflowID(), resp.GetRunID())
+		fmt.Fprintf(getOutputWriter(c), "SignalWithStart workflow succeeded. Workflow Id: %s, run Id: %s\n", signalWithStartRequest.GetWorkflowID(), resp.GetRunID())
 	}
 }
 
@@ -689,10 +1056,15 @@ func queryWorkflowHelper(c *cli.Context, queryType string) {
 	}
 
 	if queryResponse.QueryRejected != nil {
-		fmt.Printf("Query was rejected, workflow is in state: %v\n", *queryResponse.QueryRejected.CloseStatus)
+		fmt.Fprintf(getOutputWriter(c), "Query was rejected, workflow is in state: %v\n", *queryResponse.QueryRejected.CloseStatus)
+	} else if outputFile := c.String(FlagOutputFilename); outputFile != "" {
+		if err := ioutil.WriteFile(outputFile, queryResponse.QueryResult, 0666); err != nil {
+			ErrorAndExit("Failed to write query result to file.", err)
+		}
+		fmt.Fprintf(getOutputWriter(c), "Query result written to %s\n", outputFile)
 	} else {
 		// assume it is json encoded
-		fmt.Print(string(queryResponse.QueryResult))
+		fmt.Fprint(getOutputWriter(c), string(queryResponse.QueryResult))
 	}
 }
 
@@ -735,7 +1107,7 @@ func CountWorkflow(c *cli.Context) {
 		ErrorAndExit("Failed to count workflow.", err)
 	}
 
-	fmt.Println(response.GetCount())
+	fmt.Fprintln(getOutputWriter(c), response.GetCount())
 }
 
 // ListArchivedWorkflow lists archived workflow executions based on filters
@@ -792,7 +1164,12 @@ func describeWorkflowHelper(c *cli.Context, wid, rid string) {
 	}
 
 	if printResetPointsOnly {
-		printAutoResetPoints(resp)
+		printAutoResetPoints(getOutputWriter(c), resp)
+		return
+	}
+
+	if c.Bool(FlagShowSearchAttributes) {
+		printSearchAttributes(resp, frontendClient, c)
 		return
 	}
 
@@ -803,7 +1180,7 @@ func describeWorkflowHelper(c *cli.Context, wid, rid string) {
 		o = convertDescribeWorkflowExecutionResponse(resp, frontendClient, c)
 	}
 
-	prettyPrintJSONObject(o)
+	prettyPrintJSONObject(getOutputWriter(c), o)
 }
 
 type AutoResetPointRow struct {
@@ -813,8 +1190,8 @@ type AutoResetPointRow struct {
 	EventID        int64     `header:"EventID"`
 }
 
-func printAutoResetPoints(resp *types.DescribeWorkflowExecutionResponse) {
-	fmt.Println("Auto Reset Points:")
+func printAutoResetPoints(w io.Writer, resp *types.DescribeWorkflowExecutionResponse) {
+	fmt.Fprintln(w, "Auto Reset Points:")
 	table := []AutoResetPointRow{}
 	if resp.WorkflowExecutionInfo.AutoResetPoints == nil || len(resp.WorkflowExecutionInfo.AutoResetPoints.Points) == 0 {
 		return
@@ -827,7 +1204,33 @@ func printAutoResetPoints(resp *types.DescribeWorkflowExecutionResponse) {
 			EventID:        pt.GetFirstDecisionCompletedID(),
 		})
 	}
-	RenderTable(os.Stdout, table, RenderOptions{Color: true, Border: true, PrintDateTime: true})
+	RenderTable(w, table, RenderOptions{Color: true, Border: true, PrintDateTime: true})
+}
+
+type SearchAttributeRow struct {
+	Key   string `header:"Key"`
+	Value string `header:"Value"`
+}
+
+func printSearchAttributes(resp *types.DescribeWorkflowExecutionResponse, wfClient frontend.Client, c *cli.Context) {
+	fmt.Fprintln(getOutputWriter(c), "Search Attributes:")
+	searchAttributes := convertSearchAttributesToMapOfInterface(resp.WorkflowExecutionInfo.SearchAttributes, wfClient, c)
+	if len(searchAttributes) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(searchAttributes))
+	for k := range searchAttributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	table := make([]SearchAttributeRow, 0, len(keys))
+	for _, k := range keys {
+		table = append(table, SearchAttributeRow{
+			Key:   k,
+			Value: fmt.Sprintf("%v", searchAttributes[k]),
+		})
+	}
+	RenderTable(getOutputWriter(c), table, RenderOptions{Color: true, Border: true})
 }
 
 // describeWorkflowExecutionResponse is used to print datetime instead of print raw time
@@ -1002,21 +1405,21 @@ func getAllWorkflowIDsByQuery(c *cli.Context, query string) map[string]bool {
 	return result
 }
 
-func printRunStatus(event *types.HistoryEvent) {
+func printRunStatus(w io.Writer, event *types.HistoryEvent) {
 	switch event.GetEventType() {
 	case types.EventTypeWorkflowExecutionCompleted:
-		fmt.Printf("  Status: %s\n", colorGreen("COMPLETED"))
-		fmt.Printf("  Output: %s\n", string(event.WorkflowExecutionCompletedEventAttributes.Result))
+		fmt.Fprintf(w, "  Status: %s\n", colorGreen("COMPLETED"))
+		fmt.Fprintf(w, "  Output: %s\n", string(event.WorkflowExecutionCompletedEventAttributes.Result))
 	case types.EventTypeWorkflowExecutionFailed:
-		fmt.Printf("  Status: %s\n", colorRed("FAILED"))
-		fmt.Printf("  Reason: %s\n", event.WorkflowExecutionFailedEventAttributes.GetReason())
-		fmt.Printf("  Detail: %s\n", string(event.WorkflowExecutionFailedEventAttributes.Details))
+		fmt.Fprintf(w, "  Status: %s\n", colorRed("FAILED"))
+		fmt.Fprintf(w, "  Reason: %s\n", event.WorkflowExecutionFailedEventAttributes.GetReason())
+		fmt.Fprintf(w, "  Detail: %s\n", string(event.WorkflowExecutionFailedEventAttributes.Details))
 	case types.EventTypeWorkflowExecutionTimedOut:
-		fmt.Printf("  Status: %s\n", colorRed("TIMEOUT"))
-		fmt.Printf("  Timeout Type: %s\n", event.WorkflowExecutionTimedOutEventAttributes.GetTimeoutType())
+		fmt.Fprintf(w, "  Status: %s\n", colorRed("TIMEOUT"))
+		fmt.Fprintf(w, "  Timeout Type: %s\n", event.WorkflowExecutionTimedOutEventAttributes.GetTimeoutType())
 	case types.EventTypeWorkflowExecutionCanceled:
-		fmt.Printf("  Status: %s\n", colorRed("CANCELED"))
-		fmt.Printf("  Detail: %s\n", string(event.WorkflowExecutionCanceledEventAttributes.Details))
+		fmt.Fprintf(w, "  Status: %s\n", colorRed("CANCELED"))
+		fmt.Fprintf(w, "  Detail: %s\n", string(event.WorkflowExecutionCanceledEventAttributes.Details))
 	}
 }
 
@@ -1034,6 +1437,7 @@ type WorkflowRow struct {
 	HistoryLength    int64                  `header:"History Length"`
 	UpdateTime       time.Time              `header:"Update Time"`
 	Memo             map[string]string      `header:"Memo"`
+	DecodedMemo      string                 `header:"Decoded Memo" json:",omitempty"`
 	SearchAttributes map[string]interface{} `header:"Search Attributes"`
 }
 
@@ -1067,6 +1471,14 @@ func newWorkflowRow(workflow *types.WorkflowExecutionInfo) WorkflowRow {
 	}
 }
 
+// decodeMemo renders memo fields the same way they are printed in decoded history events
+func decodeMemo(memo *types.Memo) string {
+	if len(memo.GetFields()) == 0 {
+		return ""
+	}
+	return valueToString(reflect.ValueOf(memo.GetFields()), true, 0)
+}
+
 func workflowTableOptions(c *cli.Context) RenderOptions {
 	isScanQueryOpen := isQueryOpen(c.String(FlagListQuery))
 
@@ -1078,6 +1490,7 @@ func workflowTableOptions(c *cli.Context) RenderOptions {
 		OptionalColumns: map[string]bool{
 			"End Time":          !(c.Bool(FlagOpen) || isScanQueryOpen),
 			"Memo":              c.Bool(FlagPrintMemo),
+			"Decoded Memo":      c.Bool(FlagIncludeMemo),
 			"Search Attributes": c.Bool(FlagPrintSearchAttr),
 		},
 	}
@@ -1103,7 +1516,7 @@ func filterExcludedWorkflows(c *cli.Context, getWorkflowPage getWorkflowPageFn)
 	if c.IsSet(FlagListQuery) && c.IsSet(FlagExcludeWorkflowIDByQuery) {
 		excludeQuery := c.String(FlagExcludeWorkflowIDByQuery)
 		excludeWIDs = getAllWorkflowIDsByQuery(c, excludeQuery)
-		fmt.Printf("found %d workflowIDs to exclude\n", len(excludeWIDs))
+		fmt.Fprintf(getOutputWriter(c), "found %d workflowIDs to exclude\n", len(excludeWIDs))
 	}
 
 	return func(nextPageToken []byte) ([]*types.WorkflowExecutionInfo, []byte) {
@@ -1147,14 +1560,19 @@ func displayWorkflows(c *cli.Context, workflows []*types.WorkflowExecutionInfo)
 	printJSON := c.Bool(FlagPrintJSON)
 	printDecodedRaw := c.Bool(FlagPrintFullyDetail)
 	if printJSON || printDecodedRaw {
-		fmt.Println("[")
-		printListResults(workflows, printJSON, false)
-		fmt.Println("]")
+		fmt.Fprintln(getOutputWriter(c), "[")
+		printListResults(getOutputWriter(c), workflows, printJSON, false)
+		fmt.Fprintln(getOutputWriter(c), "]")
 	} else {
 		tableOptions := workflowTableOptions(c)
+		includeMemo := c.Bool(FlagIncludeMemo)
 		var table []WorkflowRow
 		for _, workflow := range workflows {
-			table = append(table, newWorkflowRow(workflow))
+			row := newWorkflowRow(workflow)
+			if includeMemo {
+				row.DecodedMemo = decodeMemo(workflow.Memo)
+			}
+			table = append(table, row)
 		}
 		Render(c, table, tableOptions)
 	}
@@ -1377,20 +1795,20 @@ func getWorkflowIDReusePolicy(value int) *types.WorkflowIDReusePolicy {
 }
 
 // default will print decoded raw
-func printListResults(executions []*types.WorkflowExecutionInfo, inJSON bool, more bool) {
+func printListResults(w io.Writer, executions []*types.WorkflowExecutionInfo, inJSON bool, more bool) {
 	for i, execution := range executions {
 		if inJSON {
 			j, _ := json.Marshal(execution)
 			if more || i < len(executions)-1 {
-				fmt.Println(string(j) + ",")
+				fmt.Fprintln(w, string(j)+",")
 			} else {
-				fmt.Println(string(j))
+				fmt.Fprintln(w, string(j))
 			}
 		} else {
 			if more || i < len(executions)-1 {
-				fmt.Println(anyToString(execution, true, 0) + ",")
+				fmt.Fprintln(w, anyToString(execution, true, 0)+",")
 			} else {
-				fmt.Println(anyToString(execution, true, 0))
+				fmt.Fprintln(w, anyToString(execution, true, 0))
 			}
 		}
 	}
@@ -1463,32 +1881,31 @@ func ResetWorkflow(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("reset failed", err)
 	}
-	prettyPrintJSONObject(resp)
+	prettyPrintJSONObject(getOutputWriter(c), resp)
 }
 
-func processResets(c *cli.Context, domain string, wes chan types.WorkflowExecution, done chan bool, wg *sync.WaitGroup, params batchResetParamsType) {
+func processResets(c *cli.Context, domain string, wes chan types.WorkflowExecution, done chan bool, wg *sync.WaitGroup, params batchResetParamsType, report *batchResetReport) {
 	for {
 		select {
 		case we := <-wes:
-			fmt.Println("received: ", we.GetWorkflowID(), we.GetRunID())
+			fmt.Fprintln(getOutputWriter(c), "received: ", we.GetWorkflowID(), we.GetRunID())
 			wid := we.GetWorkflowID()
 			rid := we.GetRunID()
+			var reset bool
 			var err error
 			for i := 0; i < 3; i++ {
-				err = doReset(c, domain, wid, rid, params)
+				reset, err = doReset(c, domain, wid, rid, params)
 				if err == nil {
 					break
 				}
 				if _, ok := err.(*types.BadRequestError); ok {
 					break
 				}
-				fmt.Println("failed and retry...: ", wid, rid, err)
+				fmt.Fprintln(getOutputWriter(c), "failed and retry...: ", wid, rid, err)
 				time.Sleep(time.Millisecond * time.Duration(rand.Intn(2000)))
 			}
 			time.Sleep(time.Millisecond * time.Duration(rand.Intn(1000)))
-			if err != nil {
-				fmt.Println("[ERROR] failed processing: ", wid, rid, err.Error())
-			}
+			report.record(wid, rid, reset, err)
 		case <-done:
 			wg.Done()
 			return
@@ -1496,6 +1913,36 @@ func processResets(c *cli.Context, domain string, wes chan types.WorkflowExecuti
 	}
 }
 
+// batchResetReport tracks the outcome of each workflow processed by a batch reset
+type batchResetReport struct {
+	sync.Mutex
+	output     io.Writer
+	dryRun     bool
+	succeeded  int
+	wouldReset int
+	skipped    int
+	failed     int
+}
+
+func (r *batchResetReport) record(wid, rid string, reset bool, err error) {
+	r.Lock()
+	defer r.Unlock()
+	switch {
+	case err != nil:
+		r.failed++
+		fmt.Fprintln(r.output, "[ERROR] failed processing: ", wid, rid, err.Error())
+	case !reset:
+		r.skipped++
+		fmt.Fprintln(r.output, "skipped processing: ", wid, rid)
+	case r.dryRun:
+		r.wouldReset++
+		fmt.Fprintln(r.output, "would reset: ", wid, rid)
+	default:
+		r.succeeded++
+		fmt.Fprintln(r.output, "succeeded processing: ", wid, rid)
+	}
+}
+
 type batchResetParamsType struct {
 	reason               string
 	skipCurrentOpen      bool
@@ -1554,13 +2001,19 @@ func ResetInBatch(c *cli.Context) {
 		ErrorAndExit("Must provide input file or list query to get target workflows to reset", nil)
 	}
 
+	if !batchResetParams.dryRun && !c.Bool(FlagYes) {
+		promptFn(fmt.Sprintf("Are you sure to reset all target workflows in domain [%s] by reset type [%s]? Y/N",
+			color.YellowString(domain), color.YellowString(resetType)))
+	}
+
 	wg := &sync.WaitGroup{}
 
 	wes := make(chan types.WorkflowExecution)
 	done := make(chan bool)
+	report := &batchResetReport{output: getOutputWriter(c), dryRun: batchResetParams.dryRun}
 	for i := 0; i < parallel; i++ {
 		wg.Add(1)
-		go processResets(c, domain, wes, done, wg, batchResetParams)
+		go processResets(c, domain, wes, done, wg, batchResetParams, report)
 	}
 
 	// read excluded workflowIDs
@@ -1572,7 +2025,7 @@ func ResetInBatch(c *cli.Context) {
 		excludeWIDs = getAllWorkflowIDsByQuery(c, excludeQuery)
 	}
 
-	fmt.Println("num of excluded WorkflowIDs:", len(excludeWIDs))
+	fmt.Fprintln(getOutputWriter(c), "num of excluded WorkflowIDs:", len(excludeWIDs))
 
 	if len(inFileName) > 0 {
 		inFile, err := os.Open(inFileName)
@@ -1586,14 +2039,14 @@ func ResetInBatch(c *cli.Context) {
 			idx++
 			line := strings.TrimSpace(scanner.Text())
 			if len(line) == 0 {
-				fmt.Printf("line %v is empty, skipped\n", idx)
+				fmt.Fprintf(getOutputWriter(c), "line %v is empty, skipped\n", idx)
 				continue
 			}
 			cols := strings.Split(line, separator)
 			if len(cols) < 1 {
 				ErrorAndExit("Split failed", fmt.Errorf("line %v has less than 1 cols separated by comma, only %v ", idx, len(cols)))
 			}
-			fmt.Printf("Start processing line %v ...\n", idx)
+			fmt.Fprintf(getOutputWriter(c), "Start processing line %v ...\n", idx)
 			wid := strings.TrimSpace(cols[0])
 			rid := ""
 			if len(cols) > 1 {
@@ -1601,7 +2054,7 @@ func ResetInBatch(c *cli.Context) {
 			}
 
 			if excludeWIDs[wid] {
-				fmt.Println("skip by exclude file: ", wid, rid)
+				fmt.Fprintln(getOutputWriter(c), "skip by exclude file: ", wid, rid)
 				continue
 			}
 
@@ -1621,7 +2074,7 @@ func ResetInBatch(c *cli.Context) {
 				wid := we.Execution.GetWorkflowID()
 				rid := we.Execution.GetRunID()
 				if excludeWIDs[wid] {
-					fmt.Println("skip by exclude file: ", wid, rid)
+					fmt.Fprintln(getOutputWriter(c), "skip by exclude file: ", wid, rid)
 					continue
 				}
 
@@ -1638,8 +2091,18 @@ func ResetInBatch(c *cli.Context) {
 	}
 
 	close(done)
-	fmt.Println("wait for all goroutines...")
+	fmt.Fprintln(getOutputWriter(c), "wait for all goroutines...")
 	wg.Wait()
+
+	if batchResetParams.dryRun {
+		fmt.Fprintf(getOutputWriter(c), "Batch reset dry run finished: %d workflows would be reset, %d skipped, %d failed.\n",
+			report.wouldReset, report.skipped, report.failed)
+	} else {
+		fmt.Fprintf(getOutputWriter(c), "Batch reset finished: %d succeeded, %d skipped, %d failed.\n", report.succeeded, report.skipped, report.failed)
+	}
+	if report.failed > 0 {
+		ErrorAndExit(fmt.Sprintf("Failed to reset %d workflows.", report.failed), nil)
+	}
 }
 
 func loadWorkflowIDsFromFile(excludeFileName, separator string) map[string]bool {
@@ -1672,12 +2135,43 @@ func loadWorkflowIDsFromFile(excludeFileName, separator string) map[string]bool
 	return excludeWIDs
 }
 
+// loadWorkflowExecutionsFromFile reads one workflow per line of WorkflowID and an optional RunID
+func loadWorkflowExecutionsFromFile(fileName, separator string) []*types.WorkflowExecution {
+	// This code is only used in the CLI. The input provided is from a trusted user.
+	// #nosec
+	file, err := os.Open(fileName)
+	if err != nil {
+		ErrorAndExit("Open failed", err)
+	}
+	defer file.Close()
+
+	var executions []*types.WorkflowExecution
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+		cols := strings.Split(line, separator)
+		execution := &types.WorkflowExecution{WorkflowID: strings.TrimSpace(cols[0])}
+		if len(cols) > 1 {
+			execution.RunID = strings.TrimSpace(cols[1])
+		}
+		executions = append(executions, execution)
+	}
+	if err := scanner.Err(); err != nil {
+		ErrorAndExit("Read failed", err)
+	}
+	return executions
+}
+
 func printErrorAndReturn(msg string, err error) error {
 	fmt.Println(msg)
 	return err
 }
 
-func doReset(c *cli.Context, domain, wid, rid string, params batchResetParamsType) error {
+// doReset resets the workflow, or only prints the reset in a dry run. It returns false if the workflow is skipped.
+func doReset(c *cli.Context, domain, wid, rid string, params batchResetParamsType) (bool, error) {
 	ctx, cancel := newContext(c)
 	defer cancel()
 
@@ -1689,13 +2183,13 @@ func doReset(c *cli.Context, domain, wid, rid string, params batchResetParamsTyp
 		},
 	})
 	if err != nil {
-		return printErrorAndReturn("DescribeWorkflowExecution failed", err)
+		return false, printErrorAndReturn("DescribeWorkflowExecution failed", err)
 	}
 
 	currentRunID := resp.WorkflowExecutionInfo.Execution.GetRunID()
 	if currentRunID != rid && params.skipBaseNotCurrent {
-		fmt.Println("skip because base run is different from current run: ", wid, rid, currentRunID)
-		return nil
+		fmt.Fprintln(getOutputWriter(c), "skip because base run is different from current run: ", wid, rid, currentRunID)
+		return false, nil
 	}
 	if rid == "" {
 		rid = currentRunID
@@ -1703,37 +2197,37 @@ func doReset(c *cli.Context, domain, wid, rid string, params batchResetParamsTyp
 
 	if resp.WorkflowExecutionInfo.CloseStatus == nil || resp.WorkflowExecutionInfo.CloseTime == nil {
 		if params.skipCurrentOpen {
-			fmt.Println("skip because current run is open: ", wid, rid, currentRunID)
-			return nil
+			fmt.Fprintln(getOutputWriter(c), "skip because current run is open: ", wid, rid, currentRunID)
+			return false, nil
 		}
 	}
 
 	if resp.WorkflowExecutionInfo.GetCloseStatus() == types.WorkflowExecutionCloseStatusCompleted {
 		if params.skipCurrentCompleted {
-			fmt.Println("skip because current run is completed: ", wid, rid, currentRunID)
-			return nil
+			fmt.Fprintln(getOutputWriter(c), "skip because current run is completed: ", wid, rid, currentRunID)
+			return false, nil
 		}
 	}
 
 	if params.nonDeterministicOnly {
 		isLDN, err := isLastEventDecisionTaskFailedWithNonDeterminism(ctx, domain, wid, rid, frontendClient)
 		if err != nil {
-			return printErrorAndReturn("check isLastEventDecisionTaskFailedWithNonDeterminism failed", err)
+			return false, printErrorAndReturn("check isLastEventDecisionTaskFailedWithNonDeterminism failed", err)
 		}
 		if !isLDN {
-			fmt.Println("skip because last event is not DecisionTaskFailedWithNonDeterminism")
-			return nil
+			fmt.Fprintln(getOutputWriter(c), "skip because last event is not DecisionTaskFailedWithNonDeterminism")
+			return false, nil
 		}
 	}
 
 	resetBaseRunID, decisionFinishID, err := getResetEventIDByType(ctx, c, params.resetType, params.decisionOffset, domain, wid, rid, frontendClient)
 	if err != nil {
-		return printErrorAndReturn("getResetEventIDByType failed", err)
+		return false, printErrorAndReturn("getResetEventIDByType failed", err)
 	}
-	fmt.Println("DecisionFinishEventId for reset:", wid, rid, resetBaseRunID, decisionFinishID)
+	fmt.Fprintln(getOutputWriter(c), "DecisionFinishEventId for reset:", wid, rid, resetBaseRunID, decisionFinishID)
 
 	if params.dryRun {
-		fmt.Printf("dry run to reset wid: %v, rid:%v to baseRunID:%v, eventID:%v \n", wid, rid, resetBaseRunID, decisionFinishID)
+		fmt.Fprintf(getOutputWriter(c), "dry run to reset wid: %v, rid:%v to baseRunID:%v, eventID:%v \n", wid, rid, resetBaseRunID, decisionFinishID)
 	} else {
 		resp2, err := frontendClient.ResetWorkflowExecution(ctx, &types.ResetWorkflowExecutionRequest{
 			Domain: domain,
@@ -1748,12 +2242,12 @@ func doReset(c *cli.Context, domain, wid, rid string, params batchResetParamsTyp
 		})
 
 		if err != nil {
-			return printErrorAndReturn("ResetWorkflowExecution failed", err)
+			return false, printErrorAndReturn("ResetWorkflowExecution failed", err)
 		}
-		fmt.Println("new runID for wid/rid is ,", wid, rid, resp2.GetRunID())
+		fmt.Fprintln(getOutputWriter(c), "new runID for wid/rid is ,", wid, rid, resp2.GetRunID())
 	}
 
-	return nil
+	return true, nil
 }
 
 func isLastEventDecisionTaskFailedWithNonDeterminism(ctx context.Context, domain, wid, rid string, frontendClient frontend.Client) (bool, error) {
@@ -1812,7 +2306,7 @@ func getResetEventIDByType(
 	// default to the same runID
 	resetBaseRunID = rid
 
-	fmt.Println("resetType:", resetType)
+	fmt.Fprintln(getOutputWriter(c), "resetType:", resetType)
 	switch resetType {
 	case resetTypeLastDecisionCompleted:
 		decisionFinishID, err = getLastDecisionTaskByType(ctx, domain, wid, rid, frontendClient, types.EventTypeDecisionTaskCompleted, decisionOffset)
@@ -2076,7 +2570,7 @@ func CompleteActivity(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("Completing activity failed", err)
 	} else {
-		fmt.Println("Complete activity successfully.")
+		fmt.Fprintln(getOutputWriter(c), "Complete activity successfully.")
 	}
 }
 
@@ -2108,7 +2602,7 @@ func FailActivity(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("Failing activity failed", err)
 	} else {
-		fmt.Println("Fail activity successfully.")
+		fmt.Fprintln(getOutputWriter(c), "Fail activity successfully.")
 	}
 }
 